@@ -0,0 +1,159 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/host"
+	hostgame "github.com/cretz/one-left/oneleft/host/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/transcript"
+	"google.golang.org/grpc"
+)
+
+const playerCountPollInterval = 500 * time.Millisecond
+
+func runHost(args []string) error {
+	flags := flag.NewFlagSet("host", flag.ExitOnError)
+	addr := flags.String("addr", "127.0.0.1:7755", "The address to listen on")
+	playerCount := flags.Int("players", 2, "The number of joined players to wait for before starting the game")
+	maxPlayers := flags.Int("max-players", host.DefaultMaxPlayers, "The maximum number of players that can join")
+	rpcTimeout := flags.Duration("rpc-timeout", host.DefaultMaxClientRPCWait,
+		"How long to wait for a player to respond to a request before failing the game")
+	turnTimeout := flags.Duration("turn-timeout", host.DefaultTurnTimeout,
+		"How long to wait for a player to decide in their turn before drawing and passing or taking another default")
+	reconnectTimeout := flags.Duration("reconnect-timeout", host.DefaultReconnectTimeout,
+		"How long to wait for a player that disconnected during a game to reconnect before ending the game")
+	transcriptFile := flags.String("transcript", "",
+		"The file to append a transcript of every game to, for verifying later")
+	primeBits := flags.Int("prime-bits", hostgame.DefaultSharedPrimeBits,
+		"The bit size of the safe primes cards are encrypted with, raised to the minimum of any player")
+	maxPrimeBits := flags.Int("max-prime-bits", host.DefaultMaxSharedPrimeBits,
+		"The largest minimum prime size a player can join with")
+	primePoolSize := flags.Int("prime-pool-size", 2,
+		"How many safe primes to generate ahead of time, 0 to generate each when its hand starts")
+	cardCipher := flags.String("card-cipher", pb.CardCipher_SRA.String(),
+		"The cipher cards are encrypted with if every player accepts it, SRA otherwise. Either SRA, which is quickest "+
+			"with the default prime size, or EC_P256, which has the security of 3072-bit primes at a fraction of "+
+			"their cost.")
+	rules := newRulesFlags(flags)
+	flags.Parse(args)
+	if err := rules.apply(); err != nil {
+		return err
+	}
+	cardCipherValue, ok := pb.CardCipher_value[*cardCipher]
+	if !ok {
+		return fmt.Errorf("Unrecognized card cipher %v", *cardCipher)
+	}
+	if *playerCount < 2 {
+		return fmt.Errorf("Must have at least 2 players")
+	} else if *playerCount > *maxPlayers {
+		return fmt.Errorf("Player count greater than max players")
+	} else if err := rules.Validate(*playerCount); err != nil {
+		return fmt.Errorf("Invalid rules: %v", err)
+	}
+	// Start the server
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("Failed listening: %v", err)
+	}
+	conf := &host.Config{
+		MaxClientRPCWait:   *rpcTimeout,
+		TurnTimeout:        *turnTimeout,
+		ReconnectTimeout:   *reconnectTimeout,
+		MaxPlayers:         *maxPlayers,
+		Rules:              &rules.Rules,
+		SharedPrimeBits:    *primeBits,
+		MaxSharedPrimeBits: *maxPrimeBits,
+		CardCipher:         pb.CardCipher(cardCipherValue),
+	}
+	if *primePoolSize > 0 {
+		conf.SharedPrimePool = sra.NewSafePrimePool(rand.Reader, *primePoolSize)
+		defer conf.SharedPrimePool.Close()
+		// Start generating while players join
+		conf.SharedPrimePool.Prepare(*primeBits)
+	}
+	if *transcriptFile != "" {
+		if conf.Transcript, err = transcript.Create(*transcriptFile, nil); err != nil {
+			return fmt.Errorf("Failed opening transcript: %v", err)
+		}
+		defer conf.Transcript.Close()
+	}
+	h := host.New(conf)
+	server := grpc.NewServer()
+	pb.RegisterHostServer(server, h)
+	serveErrCh := make(chan error, 1)
+	go func() { serveErrCh <- server.Serve(lis) }()
+	defer server.Stop()
+	log.Printf("Listening on %v, waiting for %v players to join", lis.Addr(), *playerCount)
+	// Wait for enough players
+	for h.PlayerCount() < *playerCount {
+		select {
+		case err := <-serveErrCh:
+			return fmt.Errorf("Server failed: %v", err)
+		case <-time.After(playerCountPollInterval):
+		}
+	}
+	// Play the game
+	log.Printf("Starting game")
+	if err := h.PlayGame(); err != nil {
+		return fmt.Errorf("Game failed: %v", err)
+	}
+	log.Printf("Game complete")
+	return nil
+}
+
+// rulesFlags are the flags for the rules of a game, shared by the commands that play one
+type rulesFlags struct {
+	game.Rules
+	reshuffleFirstWildDrawFour *bool
+	penaltyScoring             *bool
+	fixedHands                 *int
+}
+
+func newRulesFlags(flags *flag.FlagSet) *rulesFlags {
+	rules := &rulesFlags{Rules: game.DefaultRules()}
+	flags.IntVar(&rules.HandSize, "hand-size", rules.HandSize, "The number of cards dealt to each player")
+	flags.IntVar(&rules.TargetScore, "target-score", rules.TargetScore, "The score that ends the game")
+	flags.BoolVar(&rules.SingleHand, "single-hand", rules.SingleHand, "End the game after a single hand")
+	flags.BoolVar(&rules.DrawUntilPlayable, "draw-until-playable", rules.DrawUntilPlayable,
+		"Keep drawing until a card is played instead of drawing once")
+	flags.BoolVar(&rules.PlayDrawnCard, "play-drawn-card", rules.PlayDrawnCard,
+		"Allow playing the card just drawn instead of ending the turn")
+	flags.BoolVar(&rules.StackDrawCards, "stack-draw-cards", rules.StackDrawCards,
+		"Allow stacking draw cards on draw cards, with whoever can't stack drawing the total")
+	flags.BoolVar(&rules.JumpIn, "jump-in", rules.JumpIn,
+		"Allow playing a card identical to the top discard out of turn, cancelling the current player's play")
+	flags.BoolVar(&rules.SevenO, "seven-o", rules.SevenO,
+		"Swap hands with a chosen player when playing a 7 and pass all hands along when playing a 0")
+	flags.BoolVar(&rules.Partners, "partners", rules.Partners,
+		"Play in teams of players sitting opposite each other that win hands and score together")
+	rules.reshuffleFirstWildDrawFour = flags.Bool("reshuffle-first-wild-draw-four", false,
+		"Reshuffle and deal again when the first discard is a wild draw four instead of putting another on top")
+	rules.penaltyScoring = flags.Bool("penalty-scoring", false,
+		"Score players the cards left in their own hand, with the lowest score winning once one reaches the target")
+	rules.fixedHands = flags.Int("fixed-hands", 0,
+		"If set, end the game after this many hands with the highest score winning instead of playing to the target")
+	return rules
+}
+
+// apply sets the rules from the flags that don't map directly to a rule, and must be called after parsing
+func (r *rulesFlags) apply() error {
+	if *r.reshuffleFirstWildDrawFour {
+		r.FirstWildDrawFour = game.FirstWildDrawFourReshuffle
+	}
+	if *r.penaltyScoring && *r.fixedHands > 0 {
+		return fmt.Errorf("Cannot use both penalty scoring and fixed hands")
+	} else if *r.penaltyScoring {
+		r.Scoring = game.ScoringPenalty
+	} else if *r.fixedHands > 0 {
+		r.Scoring, r.HandCount = game.ScoringFixedHands, *r.fixedHands
+	}
+	return nil
+}
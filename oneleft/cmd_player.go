@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/player"
+	"github.com/cretz/one-left/oneleft/player/bot"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
+	"google.golang.org/grpc"
+)
+
+type playerFlags struct {
+	flags          *flag.FlagSet
+	addr           *string
+	name           *string
+	keyFile        *string
+	uiTimeout      *time.Duration
+	connectTimeout *time.Duration
+	transcriptFile *string
+	stateFile      *string
+	minPrimeBits   *int
+	sraOnly        *bool
+}
+
+func newPlayerFlags(command string, defaultName string) *playerFlags {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	return &playerFlags{
+		flags: flags,
+		addr:  flags.String("addr", "127.0.0.1:7755", "The host address to connect to"),
+		name:  flags.String("name", defaultName, "The player name"),
+		keyFile: flags.String("key-file", "",
+			"The file holding the hex-encoded ed25519 private key. Created if it doesn't exist. "+
+				"If unset, a new identity is used."),
+		uiTimeout: flags.Duration("ui-timeout", player.DefaultMaxIfaceHandleTime,
+			"How long to give the player to make a decision"),
+		connectTimeout: flags.Duration("connect-timeout", 30*time.Second, "How long to wait to connect to the host"),
+		transcriptFile: flags.String("transcript", "",
+			"The file to append a transcript of every game played to, for verifying later"),
+		stateFile: flags.String("state-file", "",
+			"The file to keep the state of the game being played in, to resume it if restarted with the same key"),
+		minPrimeBits: flags.Int("min-prime-bits", player.DefaultMinSharedPrimeBits,
+			"The smallest safe prime size to accept cards encrypted with"),
+		sraOnly: flags.Bool("sra-only", false, "Only accept cards encrypted with SRA, not with elliptic curves"),
+	}
+}
+
+func (p *playerFlags) parse(args []string) (ed25519.KeyPair, error) {
+	p.flags.Parse(args)
+	if *p.name == "" {
+		return nil, fmt.Errorf("Name required")
+	}
+	return loadOrCreateKeyPair(*p.keyFile)
+}
+
+func (p *playerFlags) connectAndRun(keyPair ed25519.KeyPair, ui iface.Interface) (player.Player, func() error, error) {
+	var transcriptWriter *transcript.Writer
+	if *p.transcriptFile != "" {
+		var err error
+		if transcriptWriter, err = transcript.Create(*p.transcriptFile, keyPair.PublicKey()); err != nil {
+			return nil, nil, fmt.Errorf("Failed opening transcript: %v", err)
+		}
+	}
+	// Connect
+	ctx, cancelFn := context.WithTimeout(context.Background(), *p.connectTimeout)
+	defer cancelFn()
+	conn, err := grpc.DialContext(ctx, *p.addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		closeTranscript(transcriptWriter)
+		return nil, nil, fmt.Errorf("Failed connecting to host: %v", err)
+	}
+	stream, err := pb.NewHostClient(conn).Stream(context.Background())
+	if err != nil {
+		conn.Close()
+		closeTranscript(transcriptWriter)
+		return nil, nil, fmt.Errorf("Failed opening stream: %v", err)
+	}
+	log.Printf("Connected to %v as %v", *p.addr, *p.name)
+	ret := player.New(stream, &player.Config{
+		KeyPair:            keyPair,
+		Name:               *p.name,
+		UI:                 ui,
+		JoinOnWelcome:      true,
+		MaxIfaceHandleTime: *p.uiTimeout,
+		Transcript:         transcriptWriter,
+		StateFile:          *p.stateFile,
+		MinSharedPrimeBits: *p.minPrimeBits,
+		SRAOnly:            *p.sraOnly,
+	})
+	return ret, func() error {
+		defer closeTranscript(transcriptWriter)
+		defer conn.Close()
+		return ret.Run()
+	}, nil
+}
+
+func closeTranscript(w *transcript.Writer) {
+	if w != nil {
+		w.Close()
+	}
+}
+
+func runPlay(args []string) error {
+	flags := newPlayerFlags("play", "")
+	keyPair, err := flags.parse(args)
+	if err != nil {
+		return err
+	}
+	ui := newConsoleUI(os.Stdin, os.Stdout, keyPair.PublicKey())
+	p, run, err := flags.connectAndRun(keyPair, ui)
+	if err != nil {
+		return err
+	}
+	ui.setPlayer(p)
+	return run()
+}
+
+func runBot(args []string) error {
+	flags := newPlayerFlags("bot", "bot")
+	difficultyName := flags.flags.String("difficulty", bot.Medium.String(), "How well to play: easy, medium, or hard")
+	keyPair, err := flags.parse(args)
+	if err != nil {
+		return err
+	}
+	difficulty, err := bot.ParseDifficulty(*difficultyName)
+	if err != nil {
+		return err
+	}
+	ui := bot.New(&bot.Config{ID: keyPair.PublicKey(), Difficulty: difficulty})
+	p, run, err := flags.connectAndRun(keyPair, ui)
+	if err != nil {
+		return err
+	}
+	ui.SetPlayer(p)
+	return run()
+}
+
+func loadOrCreateKeyPair(keyFile string) (ed25519.KeyPair, error) {
+	if keyFile == "" {
+		return ed25519.GenerateKey(nil)
+	}
+	// Load it if it's there
+	if byts, err := ioutil.ReadFile(keyFile); err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(byts)))
+		if err != nil || len(key) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("Invalid key in %v", keyFile)
+		}
+		return ed25519.PrivateKey(key).KeyPair(), nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Failed reading key file: %v", err)
+	}
+	// Otherwise, create and save
+	keyPair, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(keyFile, []byte(hex.EncodeToString(keyPair.PrivateKey())), 0600); err != nil {
+		return nil, fmt.Errorf("Failed writing key file: %v", err)
+	}
+	return keyPair, nil
+}
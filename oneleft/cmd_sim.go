@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cretz/one-left/oneleft/player/bot"
+	"github.com/cretz/one-left/oneleft/sim"
+)
+
+func runSim(args []string) error {
+	flags := flag.NewFlagSet("sim", flag.ExitOnError)
+	games := flags.Int("games", sim.DefaultGames, "The number of games to play")
+	seed := flags.Int64("seed", 0,
+		"The seed of the first game, with each game after adding one. If 0, the current time is used.")
+	parallelism := flags.Int("parallel", 0, "The number of games to play at once. If 0, the number of CPUs is used.")
+	seats := flags.String("bots", "medium,medium,medium,medium",
+		"Comma-separated difficulty of the bot in each seat: easy, medium, or hard")
+	rules := newRulesFlags(flags)
+	flags.Parse(args)
+	if err := rules.apply(); err != nil {
+		return err
+	}
+	conf := &sim.Config{Rules: rules.Rules, Games: *games, Seed: *seed, Parallelism: *parallelism}
+	for _, name := range strings.Split(*seats, ",") {
+		difficulty, err := bot.ParseDifficulty(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		conf.Seats = append(conf.Seats, difficulty)
+	}
+	if conf.Seed == 0 {
+		conf.Seed = time.Now().UnixNano()
+	}
+	fmt.Printf("Playing %v games from seed %v\n", *games, conf.Seed)
+	start := time.Now()
+	stats, err := sim.Run(conf)
+	if err != nil {
+		return err
+	}
+	printSimStats(stats, time.Since(start))
+	return nil
+}
+
+func printSimStats(stats *sim.Stats, took time.Duration) {
+	fmt.Printf("Played %v games with %v hands in %v\n\n", stats.Games, stats.Hands, took.Round(time.Millisecond))
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "Seat\tBot\tWins\tWin rate\tFinal score (min/median/p90/max)")
+	for i, difficulty := range stats.Seats {
+		scores := stats.SeatScores[i]
+		fmt.Fprintf(w, "%v\t%v\t%.1f\t%.1f%%\t%v/%v/%v/%v\n", i, difficulty, stats.SeatWins[i],
+			stats.SeatWinRate(i)*100, scores.Min, scores.Median, scores.P90, scores.Max)
+	}
+	w.Flush()
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, difficulty := range []bot.Difficulty{bot.Easy, bot.Medium, bot.Hard} {
+		for _, seatDifficulty := range stats.Seats {
+			if seatDifficulty == difficulty {
+				fmt.Fprintf(w, "Win rate per %v bot\t%.1f%%\n", difficulty, stats.DifficultyWinRate(difficulty)*100)
+				break
+			}
+		}
+	}
+	fmt.Fprintf(w, "Hands per game\t%.2f\n", stats.AverageGameHands())
+	fmt.Fprintf(w, "Turns per hand\t%.2f\n", stats.AverageHandTurns())
+	fmt.Fprintf(w, "Reshuffles per hand\t%.3f\n", stats.HandReshuffleRate())
+	fmt.Fprintf(w, "Hand score (mean/median/p90/max)\t%.1f/%v/%v/%v\n", stats.HandScores.Mean,
+		stats.HandScores.Median, stats.HandScores.P90, stats.HandScores.Max)
+	fmt.Fprintf(w, "Wild draw fours unchallenged\t%v\n", stats.Unchallenged)
+	fmt.Fprintf(w, "Challenges succeeded\t%v\n", stats.ChallengesSucceeded)
+	fmt.Fprintf(w, "Challenges failed\t%v\n", stats.ChallengesFailed)
+	w.Flush()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cretz/one-left/oneleft/player"
+)
+
+func runVerify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: oneleft verify TRANSCRIPT_FILE...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		return fmt.Errorf("At least one transcript file required")
+	}
+	failed := 0
+	for _, path := range flags.Args() {
+		if err := verifyTranscriptFile(path); err != nil {
+			fmt.Printf("%v: FAILED: %v\n", path, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v of %v transcripts failed verification", failed, flags.NArg())
+	}
+	return nil
+}
+
+func verifyTranscriptFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	result, err := player.VerifyTranscript(f)
+	if err != nil {
+		return err
+	}
+	writer := "host"
+	if len(result.WriterID) > 0 {
+		writer = "player " + hex.EncodeToString(result.WriterID)
+	}
+	fmt.Printf("%v: written by %v, %v games\n", path, writer, len(result.Games))
+	for _, g := range result.Games {
+		status := "complete"
+		if !g.Complete {
+			status = "incomplete"
+		}
+		fmt.Printf("  Game %v (%v): %v players, %v hands, %v events, %v signatures, %v cards decrypted, scores %v\n",
+			g.ID, status, len(g.Players), g.Hands, g.Events, g.Signatures, g.CardsDecrypted, g.PlayerScores)
+	}
+	return nil
+}
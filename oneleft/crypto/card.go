@@ -0,0 +1,28 @@
+package crypto
+
+import (
+	"math/big"
+
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+)
+
+// deckSize is the number of cards in the deck, each of which is encoded
+const deckSize = 108
+
+// NewCardEncoding derives the encoding of the cards in a hand from the hand's card cipher and encoding seed
+func NewCardEncoding(cipher CardCipher, seed []byte) (*sra.CardEncoding, error) {
+	return sra.NewCardEncoding(cipher, seed, deckSize)
+}
+
+// CardToInt converts the card to the int that is encrypted for it in the encoding
+func CardToInt(encoding *sra.CardEncoding, card game.Card) *big.Int {
+	return encoding.Encode(int(card))
+}
+
+// IntToCard converts an int created via CardToInt with the same encoding back into a card, or returns false if it is
+// not a valid card
+func IntToCard(encoding *sra.CardEncoding, v *big.Int) (game.Card, bool) {
+	index, ok := encoding.Decode(v)
+	return game.Card(index), ok
+}
@@ -0,0 +1,116 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/cretz/one-left/oneleft/crypto/ecph"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/pb"
+)
+
+// KeyPair is a commutative key pair that cards are encrypted with, such as a *sra.KeyPair or *ecph.KeyPair
+type KeyPair interface {
+	EncryptInt(v *big.Int) *big.Int
+	DecryptInt(v *big.Int) *big.Int
+	EncryptionKey() *big.Int
+	DecryptionKey() *big.Int
+}
+
+// CardCipher is the commutative cipher cards are encrypted with during a hand. Cards are encoded as elements of its
+// group, which the shuffle proofs and key commitments are over.
+type CardCipher interface {
+	sra.Group
+	// Type returns the cipher's type as negotiated in the hand start.
+	Type() pb.CardCipher
+	// GenerateKeyPair generates a new random key pair to encrypt cards with.
+	GenerateKeyPair() (KeyPair, error)
+	// KeyPair returns the key pair for the keys, such as one that was saved.
+	KeyPair(enc *big.Int, dec *big.Int) KeyPair
+	// DecryptInt returns v decrypted with another player's decryption key.
+	DecryptInt(decKey *big.Int, v *big.Int) *big.Int
+	// GenerateMaskPair generates a new random SRA key pair to mask decryption keys with when they are moved to another
+	// player. Its prime is larger than any decryption key, and its exponents are full size so the host can't recover
+	// them from the keys it sees masked one and two times.
+	GenerateMaskPair() (*sra.KeyPair, error)
+}
+
+// NewCardCipher creates the cipher of the type, which needs the hand's shared prime for SRA and no prime otherwise.
+// This does not check that the shared prime is a safe one.
+func NewCardCipher(typ pb.CardCipher, prime *big.Int) (CardCipher, error) {
+	switch typ {
+	case pb.CardCipher_SRA:
+		if prime == nil || prime.Sign() == 0 {
+			return nil, fmt.Errorf("Missing shared prime")
+		}
+		return &sraCardCipher{sra.NewResidueGroup(prime)}, nil
+	case pb.CardCipher_EC_P256:
+		if prime != nil && prime.Sign() != 0 {
+			return nil, fmt.Errorf("Shared prime only used with SRA")
+		}
+		return &ecCardCipher{ecph.P256}, nil
+	default:
+		return nil, fmt.Errorf("Unrecognized card cipher %v", typ)
+	}
+}
+
+type sraCardCipher struct{ *sra.ResidueGroup }
+
+func (*sraCardCipher) Type() pb.CardCipher { return pb.CardCipher_SRA }
+
+func (s *sraCardCipher) GenerateKeyPair() (KeyPair, error) {
+	// Don't return a nil pair as a non-nil interface
+	pair, err := sra.GenerateKeyPair(rand.Reader, s.Prime())
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+func (s *sraCardCipher) KeyPair(enc *big.Int, dec *big.Int) KeyPair {
+	return &sra.KeyPair{Prime: s.Prime(), Enc: enc, Dec: dec}
+}
+
+func (s *sraCardCipher) DecryptInt(decKey *big.Int, v *big.Int) *big.Int {
+	return sra.DecryptInt(s.Prime(), decKey, v)
+}
+
+func (s *sraCardCipher) GenerateMaskPair() (*sra.KeyPair, error) {
+	// Decryption keys are less than the shared prime
+	return sra.GenerateKeyPair(rand.Reader, s.Prime())
+}
+
+type ecCardCipher struct{ *ecph.Group }
+
+func (*ecCardCipher) Type() pb.CardCipher { return pb.CardCipher_EC_P256 }
+
+func (e *ecCardCipher) GenerateKeyPair() (KeyPair, error) {
+	pair, err := ecph.GenerateKeyPair(rand.Reader, e.Group)
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+func (e *ecCardCipher) KeyPair(enc *big.Int, dec *big.Int) KeyPair {
+	return &ecph.KeyPair{Group: e.Group, Enc: enc, Dec: dec}
+}
+
+func (e *ecCardCipher) DecryptInt(decKey *big.Int, v *big.Int) *big.Int { return e.Exp(v, decKey) }
+
+func (e *ecCardCipher) GenerateMaskPair() (*sra.KeyPair, error) {
+	return sra.GenerateKeyPair(rand.Reader, ecMaskPrime)
+}
+
+// ecMaskPrime is the 2048-bit safe prime of RFC 3526 that EC decryption keys are masked over, since there is no shared
+// prime and generating one is slow. Keys are less than the curve's order so they all fit.
+var ecMaskPrime, _ = new(big.Int).SetString(
+	"FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B139B22514A08798E3404DD"+
+		"EF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED"+
+		"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F"+
+		"83655D23DCA3AD961C62F356208552BB9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B"+
+		"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF6955817183995497CEA956AE515D2261898FA0510"+
+		"15728E5A8AACAA68FFFFFFFFFFFFFFFF",
+	16,
+)
@@ -0,0 +1,82 @@
+package crypto_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCardCipher(t *testing.T) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	// SRA needs the shared prime and nothing else takes one
+	_, err = crypto.NewCardCipher(pb.CardCipher_SRA, nil)
+	require.EqualError(t, err, "Missing shared prime")
+	_, err = crypto.NewCardCipher(pb.CardCipher_SRA, new(big.Int))
+	require.EqualError(t, err, "Missing shared prime")
+	_, err = crypto.NewCardCipher(pb.CardCipher_EC_P256, prime)
+	require.EqualError(t, err, "Shared prime only used with SRA")
+	_, err = crypto.NewCardCipher(pb.CardCipher(5), nil)
+	require.EqualError(t, err, "Unrecognized card cipher 5")
+
+	sraCipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	require.Equal(t, pb.CardCipher_SRA, sraCipher.Type())
+	ecCipher, err := crypto.NewCardCipher(pb.CardCipher_EC_P256, nil)
+	require.NoError(t, err)
+	require.Equal(t, pb.CardCipher_EC_P256, ecCipher.Type())
+	ecCipher, err = crypto.NewCardCipher(pb.CardCipher_EC_P256, new(big.Int))
+	require.NoError(t, err)
+
+	for _, cipher := range []crypto.CardCipher{sraCipher, ecCipher} {
+		encoding, err := crypto.NewCardEncoding(cipher, make([]byte, 32))
+		require.NoError(t, err)
+		v := encoding.Encode(17)
+		pair, err := cipher.GenerateKeyPair()
+		require.NoError(t, err)
+		encrypted := pair.EncryptInt(v)
+		require.True(t, cipher.Contains(encrypted), cipher.Type())
+		require.NotEqual(t, v, encrypted, cipher.Type())
+		// Decrypting with just the key or a pair rebuilt from saved keys is the same
+		require.Equal(t, v, pair.DecryptInt(encrypted), cipher.Type())
+		require.Equal(t, v, cipher.DecryptInt(pair.DecryptionKey(), encrypted), cipher.Type())
+		saved := cipher.KeyPair(pair.EncryptionKey(), pair.DecryptionKey())
+		require.Equal(t, encrypted, saved.EncryptInt(v), cipher.Type())
+		// Released keys match the key commitment
+		require.NoError(t, sra.VerifyDecryptionKey(
+			cipher, sra.KeyCommitment(cipher, pair.EncryptionKey()), pair.DecryptionKey()), cipher.Type())
+		// Mask pairs can mask any decryption key
+		mask, err := cipher.GenerateMaskPair()
+		require.NoError(t, err)
+		require.True(t, mask.Prime.Cmp(cipher.Order()) > 0, cipher.Type())
+		require.Equal(t, pair.DecryptionKey(), mask.DecryptInt(mask.EncryptInt(pair.DecryptionKey())), cipher.Type())
+	}
+}
+
+func TestECShuffleResponses(t *testing.T) {
+	cipher, err := crypto.NewCardCipher(pb.CardCipher_EC_P256, nil)
+	require.NoError(t, err)
+	s := newTestShuffle(t, cipher, 2)
+	playerIndex, err := s.verify(func(*pb.ShuffleRequest) {})
+	require.NoError(t, err)
+	require.Equal(t, -1, playerIndex)
+	// The proofs are bound to the cipher's group, so they don't verify as SRA
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	s.cipher, err = crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	_, err = s.verify(func(*pb.ShuffleRequest) {})
+	require.Error(t, err)
+	s.cipher = cipher
+	// A substituted card is caught the same way
+	playerIndex, err = s.verify(func(req *pb.ShuffleRequest) {
+		req.Responses[1].WorkingCardSet[0] = s.encoding.Encode(3).Bytes()
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, playerIndex)
+}
@@ -0,0 +1,122 @@
+package ecph_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/crypto/ecph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommutative(t *testing.T) {
+	group := ecph.P256
+	alice, err := ecph.GenerateKeyPair(rand.Reader, group)
+	require.NoError(t, err)
+	bob, err := ecph.GenerateKeyPair(rand.Reader, group)
+	require.NoError(t, err)
+	ted, err := ecph.GenerateKeyPair(rand.Reader, group)
+	require.NoError(t, err)
+	peoplePerms := [][]*ecph.KeyPair{
+		{alice, bob, ted},
+		{alice, ted, bob},
+		{bob, alice, ted},
+		{bob, ted, alice},
+		{ted, alice, bob},
+		{ted, bob, alice},
+	}
+	// Go over each set of people in any order and make sure the decrypted value always comes out right
+	for _, encPeople := range peoplePerms {
+		orig := randomPoint(t)
+		encrypted := orig
+		for _, person := range encPeople {
+			encrypted = person.EncryptInt(encrypted)
+			require.True(t, group.Contains(encrypted))
+			require.NotEqual(t, orig, encrypted)
+		}
+		for _, decPeople := range peoplePerms {
+			decrypted := encrypted
+			for _, person := range decPeople {
+				decrypted = person.DecryptInt(decrypted)
+			}
+			require.Equal(t, orig, decrypted)
+		}
+	}
+}
+
+func TestExpAndMul(t *testing.T) {
+	group := ecph.P256
+	v := randomPoint(t)
+	// The generator is multiplied a faster way that must agree
+	require.Equal(t, group.Exp(group.Generator(), big.NewInt(3)),
+		group.Mul(group.Generator(), group.Mul(group.Generator(), group.Generator())))
+	require.Equal(t, group.Exp(v, big.NewInt(2)), group.Mul(v, v))
+	// Scalars are mod the order
+	require.Equal(t, v, group.Exp(v, new(big.Int).Add(group.Order(), big.NewInt(1))))
+	// Multiplying by the order gives the identity, and adding the identity changes nothing
+	identity := group.Exp(v, group.Order())
+	require.Zero(t, identity.Sign())
+	require.Equal(t, v, group.Mul(v, identity))
+	require.Zero(t, group.Exp(identity, big.NewInt(5)).Sign())
+	// Anything that isn't a point gives the identity
+	require.Zero(t, group.Exp(big.NewInt(5), big.NewInt(2)).Sign())
+	require.Zero(t, group.Mul(v, big.NewInt(5)).Sign())
+	// Results don't change the inputs, even when their decoded points are cached
+	before := new(big.Int).Set(v)
+	group.Exp(v, big.NewInt(7))
+	group.Mul(v, v)
+	require.Equal(t, before, v)
+	require.Equal(t, group.Exp(v, big.NewInt(7)), group.Exp(before, big.NewInt(7)))
+}
+
+func TestContains(t *testing.T) {
+	group := ecph.P256
+	require.True(t, group.Contains(group.Generator()))
+	v := randomPoint(t)
+	require.True(t, group.Contains(v))
+	require.Len(t, v.Bytes(), group.ElementLen())
+	// The identity, nil, and values that aren't compressed points are not elements
+	require.False(t, group.Contains(nil))
+	require.False(t, group.Contains(new(big.Int)))
+	require.False(t, group.Contains(big.NewInt(5)))
+	require.False(t, group.Contains(new(big.Int).Lsh(v, 8)))
+	// Same x, but not a valid compressed point prefix
+	b := v.Bytes()
+	b[0] = 4
+	require.False(t, group.Contains(new(big.Int).SetBytes(b)))
+	// An x that isn't on the curve
+	x := notOnCurve()
+	b = append([]byte{2}, x.FillBytes(make([]byte, group.ElementLen()-1))...)
+	require.False(t, group.Contains(new(big.Int).SetBytes(b)))
+}
+
+func TestHashToElement(t *testing.T) {
+	group := ecph.P256
+	v := randomPoint(t)
+	// The same bytes always give the same point with an even y
+	require.Equal(t, v, group.HashToElement(v.Bytes()[1:]))
+	require.Equal(t, byte(2), v.Bytes()[0])
+	// Bytes whose x isn't on the curve give nil
+	require.Nil(t, group.HashToElement(notOnCurve().Bytes()))
+}
+
+// randomPoint returns a random point from hashing random bytes
+func randomPoint(t *testing.T) *big.Int {
+	for {
+		b := make([]byte, 32)
+		_, err := rand.Read(b)
+		require.NoError(t, err)
+		if v := ecph.P256.HashToElement(b); v != nil {
+			return v
+		}
+	}
+}
+
+// notOnCurve returns an x that no point on the curve has, which is about half of them
+func notOnCurve() *big.Int {
+	for i := int64(1); ; i++ {
+		if ecph.P256.HashToElement(big.NewInt(i).Bytes()) == nil {
+			return big.NewInt(i)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/google/uuid"
+)
+
+// shuffleProofContext is what a shuffle proof is bound to, so a proof for one hand, stage, and player can't be used for
+// another
+func shuffleProofContext(handID uuid.UUID, stage int, playerIndex int) []byte {
+	return []byte(fmt.Sprintf("oneleft-shuffle:%v:%v:%v", handID, stage, playerIndex))
+}
+
+// ProveShuffle proves the stage 0 shuffle where out[i] is in[perm[i]] encrypted with the key
+func ProveShuffle(
+	cipher CardCipher, handID uuid.UUID, playerIndex int, in []*big.Int, out []*big.Int, perm []int, key KeyPair,
+) (*pb.ShuffleProof, error) {
+	context := shuffleProofContext(handID, 0, playerIndex)
+	proof, err := sra.ProveShuffle(rand.Reader, cipher, context, in, out, perm, key.EncryptionKey())
+	if err != nil {
+		return nil, err
+	}
+	ret := &pb.ShuffleProof{
+		Challenge:     proof.Challenge,
+		KeyCommitment: proof.KeyCommitment.Bytes(),
+		Rounds:        make([]*pb.ShuffleProof_Round, len(proof.Rounds)),
+	}
+	for i, round := range proof.Rounds {
+		ret.Rounds[i] = &pb.ShuffleProof_Round{
+			Exponent:    round.Exponent.Bytes(),
+			Permutation: make([]uint32, len(round.Permutation)),
+		}
+		for j, index := range round.Permutation {
+			ret.Rounds[i].Permutation[j] = uint32(index)
+		}
+	}
+	return ret, nil
+}
+
+// ProveRekey proves the stage 1 shuffle where out[i] is in[i] raised to exponents[i], committing to each exponent
+// against the commitment to the stage 0 key
+func ProveRekey(
+	cipher CardCipher, handID uuid.UUID, playerIndex int, stage0Key KeyPair,
+	in []*big.Int, out []*big.Int, exponents []*big.Int,
+) (*pb.ShuffleProof, error) {
+	base := sra.KeyCommitment(cipher, stage0Key.EncryptionKey())
+	context := shuffleProofContext(handID, 1, playerIndex)
+	proof, err := sra.ProveRekey(rand.Reader, cipher, context, base, in, out, exponents)
+	if err != nil {
+		return nil, err
+	}
+	ret := &pb.ShuffleProof{
+		Challenge:                proof.Challenge,
+		RekeyResponses:           make([][]byte, len(proof.Responses)),
+		DecryptionKeyCommitments: make([][]byte, len(proof.KeyCommitments)),
+	}
+	for i, response := range proof.Responses {
+		ret.RekeyResponses[i] = response.Bytes()
+	}
+	for i, commitment := range proof.KeyCommitments {
+		ret.DecryptionKeyCommitments[i] = commitment.Bytes()
+	}
+	return ret, nil
+}
+
+// VerifyShuffleResponse checks the proof in a player's response to a stage 0 or 1 shuffle request with the working
+// card set in. On stage 1, stage0Resp must be the same player's already verified stage 0 response.
+func VerifyShuffleResponse(
+	cipher CardCipher, handID uuid.UUID, stage int, playerIndex int, in [][]byte,
+	resp *pb.ShuffleResponse, stage0Resp *pb.ShuffleResponse,
+) error {
+	inInts, outInts := bytesToInts(in), bytesToInts(resp.WorkingCardSet)
+	context := shuffleProofContext(handID, stage, playerIndex)
+	if resp.Proof == nil {
+		return fmt.Errorf("Missing proof")
+	}
+	switch stage {
+	case 0:
+		proof := &sra.ShuffleProof{
+			Challenge:     resp.Proof.Challenge,
+			KeyCommitment: new(big.Int).SetBytes(resp.Proof.KeyCommitment),
+			Rounds:        make([]*sra.ShuffleProofRound, len(resp.Proof.Rounds)),
+		}
+		for i, round := range resp.Proof.Rounds {
+			proof.Rounds[i] = &sra.ShuffleProofRound{
+				Exponent:    new(big.Int).SetBytes(round.Exponent),
+				Permutation: make([]int, len(round.Permutation)),
+			}
+			for j, index := range round.Permutation {
+				proof.Rounds[i].Permutation[j] = int(index)
+			}
+		}
+		return sra.VerifyShuffle(cipher, context, inInts, outInts, proof)
+	case 1:
+		if stage0Resp == nil || stage0Resp.Proof == nil {
+			return fmt.Errorf("Missing stage 0 proof")
+		}
+		proof := &sra.RekeyProof{
+			Challenge:      resp.Proof.Challenge,
+			KeyCommitments: bytesToInts(resp.Proof.DecryptionKeyCommitments),
+			Responses:      bytesToInts(resp.Proof.RekeyResponses),
+		}
+		base := new(big.Int).SetBytes(stage0Resp.Proof.KeyCommitment)
+		return sra.VerifyRekey(cipher, context, base, inInts, outInts, proof)
+	default:
+		return fmt.Errorf("No proof on stage %v", stage)
+	}
+}
+
+// VerifyShuffleResponses checks the proofs of every response in a stage 2 shuffle request, following the cards from
+// the encoded start cards to the final working card set. On failure, it returns the index of the player whose response
+// was invalid.
+func VerifyShuffleResponses(
+	cipher CardCipher, encoding *sra.CardEncoding, handID uuid.UUID, playerCount int, req *pb.ShuffleRequest,
+) (int, error) {
+	if len(req.Responses) != playerCount*2 {
+		return -1, fmt.Errorf("Expected %v shuffle responses, got %v", playerCount*2, len(req.Responses))
+	}
+	in := make([][]byte, len(req.UnencryptedStartCards))
+	for i, card := range req.UnencryptedStartCards {
+		if !game.Card(card).Valid() {
+			return -1, fmt.Errorf("Invalid card to shuffle")
+		}
+		in[i] = CardToInt(encoding, game.Card(card)).Bytes()
+	}
+	for i, resp := range req.Responses {
+		stage, playerIndex := i/playerCount, i%playerCount
+		var stage0Resp *pb.ShuffleResponse
+		if stage == 1 {
+			stage0Resp = req.Responses[playerIndex]
+		}
+		if err := VerifyShuffleResponse(cipher, handID, stage, playerIndex, in, resp, stage0Resp); err != nil {
+			return playerIndex, fmt.Errorf("Invalid stage %v shuffle: %v", stage, err)
+		}
+		in = resp.WorkingCardSet
+	}
+	if len(in) != len(req.WorkingCardSet) {
+		return -1, fmt.Errorf("Final working card set not the last response's")
+	}
+	for i, card := range in {
+		if new(big.Int).SetBytes(card).Cmp(new(big.Int).SetBytes(req.WorkingCardSet[i])) != 0 {
+			return -1, fmt.Errorf("Final working card set not the last response's")
+		}
+	}
+	return -1, nil
+}
+
+// DecryptionKeyCommitments returns every player's decryption key commitment for each card in the final working card set
+// of a stage 2 shuffle request whose responses are verified, keyed by the card's big int string. The cards are in the
+// same order as in every stage 1 response since stage 1 doesn't shuffle.
+func DecryptionKeyCommitments(playerCount int, req *pb.ShuffleRequest) map[string][]*big.Int {
+	ret := make(map[string][]*big.Int, len(req.WorkingCardSet))
+	for i, card := range req.WorkingCardSet {
+		commitments := make([]*big.Int, playerCount)
+		for playerIndex, resp := range req.Responses[playerCount:] {
+			commitments[playerIndex] = new(big.Int).SetBytes(resp.Proof.DecryptionKeyCommitments[i])
+		}
+		ret[new(big.Int).SetBytes(card).String()] = commitments
+	}
+	return ret
+}
+
+func bytesToInts(v [][]byte) []*big.Int {
+	ret := make([]*big.Int, len(v))
+	for i, b := range v {
+		ret[i] = new(big.Int).SetBytes(b)
+	}
+	return ret
+}
@@ -0,0 +1,166 @@
+package crypto_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testShuffle is a stage 2 shuffle request of a few cards made the way players make them
+type testShuffle struct {
+	cipher   crypto.CardCipher
+	encoding *sra.CardEncoding
+	handID   uuid.UUID
+	players  int
+	req      *pb.ShuffleRequest
+}
+
+func newTestShuffle(t *testing.T, cipher crypto.CardCipher, players int) *testShuffle {
+	encoding, err := crypto.NewCardEncoding(cipher, make([]byte, 32))
+	require.NoError(t, err)
+	ret := &testShuffle{
+		cipher:   cipher,
+		encoding: encoding,
+		handID:   uuid.New(),
+		players:  players,
+		req:      &pb.ShuffleRequest{Stage: 2, UnencryptedStartCards: []uint32{0, 14, 27, 53, 100, 107}},
+	}
+	working := make([]*big.Int, len(ret.req.UnencryptedStartCards))
+	for i, card := range ret.req.UnencryptedStartCards {
+		working[i] = encoding.Encode(int(card))
+	}
+	stage0Pairs := make([]crypto.KeyPair, players)
+	for playerIndex := range stage0Pairs {
+		stage0Pairs[playerIndex], err = cipher.GenerateKeyPair()
+		require.NoError(t, err)
+		perm := crypto.NewCryptoRand().Perm(len(working))
+		out := make([]*big.Int, len(working))
+		for i, inIndex := range perm {
+			out[i] = stage0Pairs[playerIndex].EncryptInt(working[inIndex])
+		}
+		resp := &pb.ShuffleResponse{WorkingCardSet: intsToBytes(out)}
+		resp.Proof, err = crypto.ProveShuffle(
+			cipher, ret.handID, playerIndex, working, out, perm, stage0Pairs[playerIndex])
+		require.NoError(t, err)
+		ret.req.Responses = append(ret.req.Responses, resp)
+		working = out
+	}
+	for playerIndex, stage0Pair := range stage0Pairs {
+		out := make([]*big.Int, len(working))
+		exponents := make([]*big.Int, len(working))
+		for i, v := range working {
+			pair, err := cipher.GenerateKeyPair()
+			require.NoError(t, err)
+			out[i] = pair.EncryptInt(stage0Pair.DecryptInt(v))
+			exponents[i] = new(big.Int).Mul(stage0Pair.DecryptionKey(), pair.EncryptionKey())
+		}
+		resp := &pb.ShuffleResponse{WorkingCardSet: intsToBytes(out)}
+		resp.Proof, err = crypto.ProveRekey(cipher, ret.handID, playerIndex, stage0Pair, working, out, exponents)
+		require.NoError(t, err)
+		ret.req.Responses = append(ret.req.Responses, resp)
+		working = out
+	}
+	ret.req.WorkingCardSet = intsToBytes(working)
+	return ret
+}
+
+// verify checks a copy of the request after it's tampered with
+func (s *testShuffle) verify(tamper func(req *pb.ShuffleRequest)) (int, error) {
+	req := proto.Clone(s.req).(*pb.ShuffleRequest)
+	tamper(req)
+	return crypto.VerifyShuffleResponses(s.cipher, s.encoding, s.handID, s.players, req)
+}
+
+func intsToBytes(ints []*big.Int) [][]byte {
+	ret := make([][]byte, len(ints))
+	for i, v := range ints {
+		ret[i] = v.Bytes()
+	}
+	return ret
+}
+
+func TestShuffleResponses(t *testing.T) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	cipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	s := newTestShuffle(t, cipher, 3)
+
+	// Every response verifies on its own and together
+	in := make([][]byte, len(s.req.UnencryptedStartCards))
+	for i, card := range s.req.UnencryptedStartCards {
+		in[i] = s.encoding.Encode(int(card)).Bytes()
+	}
+	for i, resp := range s.req.Responses {
+		stage, playerIndex := i/s.players, i%s.players
+		var stage0Resp *pb.ShuffleResponse
+		if stage == 1 {
+			stage0Resp = s.req.Responses[playerIndex]
+		}
+		require.NoError(t, crypto.VerifyShuffleResponse(cipher, s.handID, stage, playerIndex, in, resp, stage0Resp))
+		// Not as another player or stage
+		require.Error(t, crypto.VerifyShuffleResponse(
+			cipher, s.handID, stage, (playerIndex+1)%s.players, in, resp, stage0Resp))
+		in = resp.WorkingCardSet
+	}
+	playerIndex, err := s.verify(func(*pb.ShuffleRequest) {})
+	require.NoError(t, err)
+	require.Equal(t, -1, playerIndex)
+
+	// Each tamper is blamed on the player whose response it changes
+	other, err := cipher.GenerateKeyPair()
+	require.NoError(t, err)
+	tests := []struct {
+		name        string
+		playerIndex int
+		tamper      func(req *pb.ShuffleRequest)
+	}{
+		{"dropped card", 1, func(req *pb.ShuffleRequest) {
+			resp := req.Responses[1]
+			resp.WorkingCardSet = resp.WorkingCardSet[1:]
+		}},
+		{"duplicated card", 1, func(req *pb.ShuffleRequest) {
+			resp := req.Responses[1]
+			resp.WorkingCardSet[1] = resp.WorkingCardSet[0]
+		}},
+		{"substituted card", 2, func(req *pb.ShuffleRequest) {
+			resp := req.Responses[2]
+			resp.WorkingCardSet[0] = other.EncryptInt(s.encoding.Encode(5)).Bytes()
+		}},
+		{"substituted card in rekey", 0, func(req *pb.ShuffleRequest) {
+			resp := req.Responses[s.players]
+			resp.WorkingCardSet[0] = other.EncryptInt(new(big.Int).SetBytes(resp.WorkingCardSet[0])).Bytes()
+		}},
+		{"wrong key commitment", 1, func(req *pb.ShuffleRequest) {
+			req.Responses[1].Proof.KeyCommitment = sra.KeyCommitment(cipher, other.EncryptionKey()).Bytes()
+		}},
+		{"wrong decryption key commitment", 2, func(req *pb.ShuffleRequest) {
+			req.Responses[s.players+2].Proof.DecryptionKeyCommitments[3] =
+				sra.KeyCommitment(cipher, other.EncryptionKey()).Bytes()
+		}},
+		{"missing proof", 0, func(req *pb.ShuffleRequest) {
+			req.Responses[0].Proof = nil
+		}},
+		{"unknown card", -1, func(req *pb.ShuffleRequest) {
+			req.UnencryptedStartCards[0] = 108
+		}},
+		{"missing response", -1, func(req *pb.ShuffleRequest) {
+			req.Responses = req.Responses[1:]
+		}},
+		{"final set not the last response's", -1, func(req *pb.ShuffleRequest) {
+			req.WorkingCardSet[0], req.WorkingCardSet[1] = req.WorkingCardSet[1], req.WorkingCardSet[0]
+		}},
+	}
+	for _, test := range tests {
+		playerIndex, err := s.verify(test.tamper)
+		require.Error(t, err, test.name)
+		require.Equal(t, test.playerIndex, playerIndex, test.name)
+	}
+}
@@ -0,0 +1,72 @@
+package sra
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// MinCardEncodingSeedLen is the minimum length of the seed a card encoding is derived from.
+const MinCardEncodingSeedLen = 32
+
+// CardEncoding maps card indexes to random elements of a group, such as quadratic residues mod a safe prime.
+// Encrypting and decrypting with odd exponents keeps whether a value is a residue, so encoding cards as small ints
+// leaks which encrypted values are which cards to anyone checking residues. When every card is in the group, that check
+// reveals nothing.
+type CardEncoding struct {
+	values []*big.Int
+	// Key is the value string
+	indexes map[string]int
+}
+
+// NewCardEncoding derives the encoding of count cards in the group from the seed. Everyone given the same group and
+// seed derives the same encoding, and since the values are hashed from the seed nobody can choose them.
+func NewCardEncoding(group Group, seed []byte, count int) (*CardEncoding, error) {
+	if len(seed) < MinCardEncodingSeedLen {
+		return nil, fmt.Errorf("Seed must be at least %v bytes", MinCardEncodingSeedLen)
+	} else if group.Order().BitLen() < 63 {
+		return nil, fmt.Errorf("Group too small")
+	}
+	enc := &CardEncoding{values: make([]*big.Int, 0, count), indexes: make(map[string]int, count)}
+	// The extra bytes over the element length make the reduction close enough to uniform
+	hashLen := group.ElementLen() + 16
+	for counter := uint32(0); len(enc.values) < count; counter++ {
+		v := group.HashToElement(expandSeed(seed, counter, hashLen))
+		if v == nil {
+			continue
+		} else if _, exists := enc.indexes[v.String()]; exists {
+			continue
+		}
+		enc.indexes[v.String()] = len(enc.values)
+		enc.values = append(enc.values, v)
+	}
+	return enc, nil
+}
+
+// expandSeed hashes the seed and counter into n bytes
+func expandSeed(seed []byte, counter uint32, n int) []byte {
+	ret := make([]byte, 0, n+sha512.Size)
+	var block [8]byte
+	binary.BigEndian.PutUint32(block[:4], counter)
+	for i := uint32(0); len(ret) < n; i++ {
+		binary.BigEndian.PutUint32(block[4:], i)
+		h := sha512.New()
+		h.Write(seed)
+		h.Write(block[:])
+		ret = h.Sum(ret)
+	}
+	return ret[:n]
+}
+
+// Len returns the number of cards in the encoding.
+func (c *CardEncoding) Len() int { return len(c.values) }
+
+// Encode returns the value for the card index, which must be less than Len.
+func (c *CardEncoding) Encode(index int) *big.Int { return new(big.Int).Set(c.values[index]) }
+
+// Decode returns the card index for the value, or false if the value isn't one in the encoding.
+func (c *CardEncoding) Decode(v *big.Int) (int, bool) {
+	index, ok := c.indexes[v.String()]
+	return index, ok
+}
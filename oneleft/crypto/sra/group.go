@@ -0,0 +1,72 @@
+package sra
+
+import (
+	"math/big"
+)
+
+// Group is a group of prime order whose elements are encrypted by raising them to a key, which commutes. The proofs,
+// card encoding, and key commitments work in any such group, with elements represented as big ints. Keys are only
+// meaningful mod the order.
+type Group interface {
+	// Order returns the prime order of the group.
+	Order() *big.Int
+	// Exp returns v raised to k. It's only meaningful when v is an element, so callers check that first.
+	Exp(v *big.Int, k *big.Int) *big.Int
+	// Mul returns the group operation on a and b. Like Exp, it's only meaningful when both are elements.
+	Mul(a *big.Int, b *big.Int) *big.Int
+	// Generator returns the element keys are committed against.
+	Generator() *big.Int
+	// Contains checks that v is an element of the group other than the identity.
+	Contains(v *big.Int) bool
+	// HashToElement maps hashed bytes to an element whose discrete log nobody knows, or returns nil if the bytes don't
+	// map to one and others need to be tried.
+	HashToElement(b []byte) *big.Int
+	// ElementLen returns the most bytes an element takes.
+	ElementLen() int
+}
+
+// ResidueGroup is the group of quadratic residues mod a safe prime, which has the prime order (p-1)/2. SRA encryption
+// keeps residues as residues, and values outside of it would leak which cards are which.
+type ResidueGroup struct {
+	prime *big.Int
+	order *big.Int
+}
+
+// NewResidueGroup creates the group of quadratic residues mod the safe prime.
+func NewResidueGroup(prime *big.Int) *ResidueGroup {
+	return &ResidueGroup{prime: prime, order: new(big.Int).Rsh(prime, 1)}
+}
+
+// residueGenerator is the generator of every residue group. Every quadratic residue other than 1 generates the whole
+// group since its order is prime.
+var residueGenerator = big.NewInt(4)
+
+// Prime returns the safe prime of the group.
+func (r *ResidueGroup) Prime() *big.Int { return r.prime }
+
+func (r *ResidueGroup) Order() *big.Int { return r.order }
+
+func (r *ResidueGroup) Exp(v *big.Int, k *big.Int) *big.Int { return new(big.Int).Exp(v, k, r.prime) }
+
+func (r *ResidueGroup) Mul(a *big.Int, b *big.Int) *big.Int {
+	ret := new(big.Int).Mul(a, b)
+	return ret.Mod(ret, r.prime)
+}
+
+func (r *ResidueGroup) Generator() *big.Int { return residueGenerator }
+
+func (r *ResidueGroup) Contains(v *big.Int) bool {
+	return v != nil && v.Cmp(bigOne) > 0 && v.Cmp(r.prime) < 0 && big.Jacobi(v, r.prime) == 1
+}
+
+func (r *ResidueGroup) HashToElement(b []byte) *big.Int {
+	// Squaring any value other than 0 gives a residue, and only 1 and p-1 square to 1
+	v := new(big.Int).SetBytes(b)
+	v.Mod(v, r.prime)
+	if v.Sign() == 0 || v.Cmp(bigOne) == 0 || v.Cmp(new(big.Int).Sub(r.prime, bigOne)) == 0 {
+		return nil
+	}
+	return v.Exp(v, big.NewInt(2), r.prime)
+}
+
+func (r *ResidueGroup) ElementLen() int { return (r.prime.BitLen() + 7) / 8 }
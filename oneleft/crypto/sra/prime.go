@@ -0,0 +1,116 @@
+package sra
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// GenerateSafePrime generates a prime p of the given bit size where (p-1)/2 is also prime. Without that, p-1 can have
+// small factors that let encrypted values be placed in small subgroups, leaking information about the plaintexts.
+func GenerateSafePrime(rnd io.Reader, bits int) (*big.Int, error) {
+	if bits < 3 {
+		return nil, fmt.Errorf("Safe prime size must be at least 3 bits")
+	}
+	p := new(big.Int)
+	for {
+		q, err := rand.Prime(rnd, bits-1)
+		if err != nil {
+			return nil, err
+		}
+		// The top bit of q is set, so p = 2q + 1 always has the requested size
+		p.Lsh(q, 1).Add(p, bigOne)
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// IsSafePrime checks that p is prime and so is (p-1)/2, with the same certainty as GenerateSafePrime.
+func IsSafePrime(p *big.Int) bool {
+	if p.Sign() <= 0 || !p.ProbablyPrime(20) {
+		return false
+	}
+	return new(big.Int).Rsh(p, 1).ProbablyPrime(20)
+}
+
+// SafePrimePool generates safe primes in the background so they are ready when needed, since generating them can take
+// a while. It keeps a number of primes ready for each bit size asked for.
+type SafePrimePool struct {
+	rnd  io.Reader
+	size int
+
+	lock sync.Mutex
+	// Key is bit size
+	primes map[int]chan *big.Int
+	// Closed when the pool is closed
+	closedCh chan struct{}
+}
+
+// NewSafePrimePool creates a pool that keeps size primes ready of each bit size. It must be closed to stop generating.
+func NewSafePrimePool(rnd io.Reader, size int) *SafePrimePool {
+	return &SafePrimePool{rnd: rnd, size: size, primes: map[int]chan *big.Int{}, closedCh: make(chan struct{})}
+}
+
+// Prepare starts generating primes of the bit size if they aren't already being generated.
+func (s *SafePrimePool) Prepare(bits int) {
+	s.primesCh(bits)
+}
+
+// Get takes a prime of the bit size from the pool, waiting for one to be generated if none are ready.
+func (s *SafePrimePool) Get(ctx context.Context, bits int) (*big.Int, error) {
+	ch := s.primesCh(bits)
+	select {
+	case p, ok := <-ch:
+		if !ok {
+			return nil, fmt.Errorf("Failed generating safe prime")
+		}
+		return p, nil
+	case <-s.closedCh:
+		return nil, fmt.Errorf("Pool closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops generating primes.
+func (s *SafePrimePool) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	select {
+	case <-s.closedCh:
+	default:
+		close(s.closedCh)
+	}
+}
+
+func (s *SafePrimePool) primesCh(bits int) chan *big.Int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ch := s.primes[bits]
+	if ch == nil {
+		ch = make(chan *big.Int, s.size)
+		s.primes[bits] = ch
+		go s.generate(bits, ch)
+	}
+	return ch
+}
+
+func (s *SafePrimePool) generate(bits int, ch chan *big.Int) {
+	// The channel is closed if primes can't be generated, which only happens if the random source fails
+	defer close(ch)
+	for {
+		p, err := GenerateSafePrime(s.rnd, bits)
+		if err != nil {
+			return
+		}
+		select {
+		case ch <- p:
+		case <-s.closedCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package sra_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSafePrime(t *testing.T) {
+	for _, bits := range []int{3, 64, 128} {
+		prime, err := sra.GenerateSafePrime(rand.Reader, bits)
+		require.NoError(t, err)
+		require.Equal(t, bits, prime.BitLen())
+		require.True(t, sra.IsSafePrime(prime))
+	}
+	_, err := sra.GenerateSafePrime(rand.Reader, 2)
+	require.EqualError(t, err, "Safe prime size must be at least 3 bits")
+}
+
+func TestIsSafePrime(t *testing.T) {
+	for _, v := range []int64{5, 7, 11, 23, 47, 59, 83, 107, 2039} {
+		require.True(t, sra.IsSafePrime(big.NewInt(v)), "%v", v)
+	}
+	// Composites, non-positives, and primes whose (p-1)/2 isn't prime
+	for _, v := range []int64{-7, 0, 1, 4, 9, 15, 13, 29, 31, 41, 2029} {
+		require.False(t, sra.IsSafePrime(big.NewInt(v)), "%v", v)
+	}
+	// A random prime of this size is almost never safe
+	for {
+		prime, err := rand.Prime(rand.Reader, 128)
+		require.NoError(t, err)
+		if !sra.IsSafePrime(prime) {
+			break
+		}
+	}
+}
+
+func TestSafePrimePool(t *testing.T) {
+	pool := sra.NewSafePrimePool(rand.Reader, 2)
+	pool.Prepare(64)
+	for _, bits := range []int{64, 64, 64, 96} {
+		prime, err := pool.Get(context.Background(), bits)
+		require.NoError(t, err)
+		require.Equal(t, bits, prime.BitLen())
+		require.True(t, sra.IsSafePrime(prime))
+	}
+	pool.Close()
+	// Closing again is fine
+	pool.Close()
+
+	// Failing to generate fails the gets of that size
+	failPool := sra.NewSafePrimePool(failingReader{}, 2)
+	defer failPool.Close()
+	_, err := failPool.Get(context.Background(), 64)
+	require.EqualError(t, err, "Failed generating safe prime")
+
+	// Gets waiting for a prime stop when the context is done or the pool is closed
+	r, w := io.Pipe()
+	defer w.Close()
+	blockedPool := sra.NewSafePrimePool(r, 2)
+	ctx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+	_, err = blockedPool.Get(ctx, 64)
+	require.Equal(t, context.Canceled, err)
+	blockedPool.Close()
+	_, err = blockedPool.Get(context.Background(), 64)
+	require.EqualError(t, err, "Pool closed")
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("Read failed") }
@@ -0,0 +1,337 @@
+package sra
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// ShuffleProofRounds is the number of cut-and-choose rounds in a shuffle proof. A cheater passes each round with half
+// probability, and since the challenge is a hash they can try as many as they like offline, so it takes 128 rounds for
+// 128 bits of security. The challenge has a bit for each.
+const ShuffleProofRounds = 128
+
+// ShuffleProof proves a set of values was made by permuting another set and encrypting each with the same key, without
+// revealing the permutation or the key. For each round, the prover commits to a shadow set made by permuting and
+// encrypting the inputs with random ones, then depending on the challenge reveals either how the shadow set was made
+// from the inputs or how the outputs are made from the shadow set. The challenge is a hash of the commitments, so the
+// verifier recreates the shadow sets from the revealed values and checks they hash to it.
+//
+// The proof also carries a commitment to the key, KeyCommitment of it. Each round's shadow set exponent is
+// committed to the same way and revealed with the rest of the round, so the commitment is proven to be of the exponent
+// the outputs were encrypted with.
+//
+// All values must be elements of a group of prime order, which the card encoding and encryption keep them as. The
+// exponents are then only meaningful mod that order.
+type ShuffleProof struct {
+	Challenge     []byte
+	KeyCommitment *big.Int
+	Rounds        []*ShuffleProofRound
+}
+
+// ShuffleProofRound is the revealed part of a round of a shuffle proof.
+type ShuffleProofRound struct {
+	// The exponent the inputs were encrypted with for the shadow set if the challenge bit is 0, or the shadow set was
+	// encrypted with for the outputs if 1
+	Exponent *big.Int
+	// The index of the input each shadow value came from if the challenge bit is 0, or the index of the shadow value
+	// each output came from if 1
+	Permutation []int
+}
+
+// ProveShuffle proves out[i] is in[perm[i]] encrypted with the key. The context is hashed into the challenge so the
+// proof can't be used for anything else.
+func ProveShuffle(
+	rnd io.Reader, group Group, context []byte, in []*big.Int, out []*big.Int, perm []int, key *big.Int,
+) (*ShuffleProof, error) {
+	if len(in) != len(out) || len(in) != len(perm) {
+		return nil, fmt.Errorf("Set sizes differ")
+	}
+	order := group.Order()
+	enc := new(big.Int).Mod(key, order)
+	shadowPerms := make([][]int, ShuffleProofRounds)
+	shadowExps := make([]*big.Int, ShuffleProofRounds)
+	shadowCommitments := make([]*big.Int, ShuffleProofRounds)
+	shadows := make([][]*big.Int, ShuffleProofRounds)
+	var err error
+	for round := range shadows {
+		if shadowPerms[round], err = randomPermutation(rnd, len(in)); err != nil {
+			return nil, err
+		} else if shadowExps[round], err = randomExponent(rnd, order); err != nil {
+			return nil, err
+		}
+		shadowCommitments[round] = KeyCommitment(group, shadowExps[round])
+		shadows[round] = make([]*big.Int, len(in))
+		for i, inIndex := range shadowPerms[round] {
+			shadows[round][i] = group.Exp(in[inIndex], shadowExps[round])
+		}
+	}
+	keyCommitment := KeyCommitment(group, enc)
+	proof := &ShuffleProof{
+		Challenge: proofChallenge(group, context,
+			append([][]*big.Int{in, out, {keyCommitment}, shadowCommitments}, shadows...)...),
+		KeyCommitment: keyCommitment,
+		Rounds:        make([]*ShuffleProofRound, ShuffleProofRounds),
+	}
+	for round := range proof.Rounds {
+		if !challengeBit(proof.Challenge, round) {
+			proof.Rounds[round] = &ShuffleProofRound{Exponent: shadowExps[round], Permutation: shadowPerms[round]}
+			continue
+		}
+		// out[i] = in[perm[i]]^enc and shadow[j] = in[shadowPerm[j]]^exp, so out[i] = shadow[j]^(enc/exp) for the j
+		// with shadowPerm[j] = perm[i]. The key commitment is the shadow commitment to the same exponent.
+		shadowIndexes := make([]int, len(in))
+		for j, inIndex := range shadowPerms[round] {
+			shadowIndexes[inIndex] = j
+		}
+		revealed := &ShuffleProofRound{
+			Exponent:    new(big.Int).ModInverse(shadowExps[round], order),
+			Permutation: make([]int, len(out)),
+		}
+		revealed.Exponent.Mul(revealed.Exponent, enc).Mod(revealed.Exponent, order)
+		for i, inIndex := range perm {
+			revealed.Permutation[i] = shadowIndexes[inIndex]
+		}
+		proof.Rounds[round] = revealed
+	}
+	return proof, nil
+}
+
+// VerifyShuffle checks the proof that out is in permuted and encrypted with the same key.
+func VerifyShuffle(group Group, context []byte, in []*big.Int, out []*big.Int, proof *ShuffleProof) error {
+	if len(in) != len(out) {
+		return fmt.Errorf("Set sizes differ")
+	} else if err := checkElements(group, in); err != nil {
+		return fmt.Errorf("Invalid input: %v", err)
+	} else if err := checkElements(group, out); err != nil {
+		return fmt.Errorf("Invalid output: %v", err)
+	} else if proof == nil || len(proof.Rounds) != ShuffleProofRounds || len(proof.Challenge) != sha512.Size {
+		return fmt.Errorf("Invalid proof size")
+	} else if !group.Contains(proof.KeyCommitment) {
+		return fmt.Errorf("Invalid key commitment")
+	}
+	order := group.Order()
+	shadowCommitments := make([]*big.Int, ShuffleProofRounds)
+	shadows := make([][]*big.Int, ShuffleProofRounds)
+	for round, revealed := range proof.Rounds {
+		if revealed == nil || !validExponent(revealed.Exponent, order) {
+			return fmt.Errorf("Invalid exponent in round %v", round)
+		} else if !validPermutation(revealed.Permutation, len(in)) {
+			return fmt.Errorf("Invalid permutation in round %v", round)
+		}
+		shadows[round] = make([]*big.Int, len(in))
+		if !challengeBit(proof.Challenge, round) {
+			shadowCommitments[round] = KeyCommitment(group, revealed.Exponent)
+			for i, inIndex := range revealed.Permutation {
+				shadows[round][i] = group.Exp(in[inIndex], revealed.Exponent)
+			}
+		} else {
+			inverse := new(big.Int).ModInverse(revealed.Exponent, order)
+			shadowCommitments[round] = group.Exp(proof.KeyCommitment, inverse)
+			for i, shadowIndex := range revealed.Permutation {
+				shadows[round][shadowIndex] = group.Exp(out[i], inverse)
+			}
+		}
+	}
+	expected := proofChallenge(group, context,
+		append([][]*big.Int{in, out, {proof.KeyCommitment}, shadowCommitments}, shadows...)...)
+	if !bytes.Equal(expected, proof.Challenge) {
+		return fmt.Errorf("Proof doesn't match")
+	}
+	return nil
+}
+
+// RekeyProof proves each of a set of values was made by raising the value at the same index of another set to an
+// exponent the prover knows, without revealing the exponents. Each exponent is also committed to by raising a base to
+// it, so the exponents can be tied to a key commitment used as the base. It's a Chaum-Pedersen proof for each value
+// that both were raised to the same exponent, with one challenge hashed from every commitment. Like ShuffleProof, the
+// values must be elements of a group of prime order.
+type RekeyProof struct {
+	Challenge []byte
+	// The base raised to each exponent
+	KeyCommitments []*big.Int
+	Responses      []*big.Int
+}
+
+// ProveRekey proves out[i] is in[i] raised to exponents[i], the same exponent the base is raised to for the key
+// commitment. The context is hashed into the challenge so the proof can't be used for anything else.
+func ProveRekey(
+	rnd io.Reader, group Group, context []byte, base *big.Int, in []*big.Int, out []*big.Int, exponents []*big.Int,
+) (*RekeyProof, error) {
+	if len(in) != len(out) || len(in) != len(exponents) {
+		return nil, fmt.Errorf("Set sizes differ")
+	}
+	order := group.Order()
+	keyCommitments := make([]*big.Int, len(in))
+	nonces := make([]*big.Int, len(in))
+	commitments := make([]*big.Int, len(in))
+	baseCommitments := make([]*big.Int, len(in))
+	var err error
+	for i, v := range in {
+		if nonces[i], err = randomExponent(rnd, order); err != nil {
+			return nil, err
+		}
+		keyCommitments[i] = group.Exp(base, exponents[i])
+		commitments[i] = group.Exp(v, nonces[i])
+		baseCommitments[i] = group.Exp(base, nonces[i])
+	}
+	proof := &RekeyProof{
+		Challenge: proofChallenge(
+			group, context, in, out, []*big.Int{base}, keyCommitments, commitments, baseCommitments),
+		KeyCommitments: keyCommitments,
+		Responses:      make([]*big.Int, len(in)),
+	}
+	challenge := new(big.Int).SetBytes(proof.Challenge)
+	challenge.Mod(challenge, order)
+	for i, exponent := range exponents {
+		proof.Responses[i] = new(big.Int).Mul(challenge, exponent)
+		proof.Responses[i].Add(proof.Responses[i], nonces[i]).Mod(proof.Responses[i], order)
+	}
+	return proof, nil
+}
+
+// VerifyRekey checks the proof that each out value is the in value at the same index raised to a known exponent, the
+// same one the base is raised to for the key commitment at that index.
+func VerifyRekey(
+	group Group, context []byte, base *big.Int, in []*big.Int, out []*big.Int, proof *RekeyProof,
+) error {
+	if len(in) != len(out) {
+		return fmt.Errorf("Set sizes differ")
+	} else if err := checkElements(group, in); err != nil {
+		return fmt.Errorf("Invalid input: %v", err)
+	} else if err := checkElements(group, out); err != nil {
+		return fmt.Errorf("Invalid output: %v", err)
+	} else if !group.Contains(base) {
+		return fmt.Errorf("Invalid base")
+	} else if proof == nil || len(proof.Responses) != len(in) || len(proof.KeyCommitments) != len(in) ||
+		len(proof.Challenge) != sha512.Size {
+		return fmt.Errorf("Invalid proof size")
+	} else if err := checkElements(group, proof.KeyCommitments); err != nil {
+		return fmt.Errorf("Invalid key commitment: %v", err)
+	}
+	order := group.Order()
+	challenge := new(big.Int).SetBytes(proof.Challenge)
+	challenge.Mod(challenge, order)
+	// Values have the group's order, so raising to order - challenge divides by the value to the challenge
+	negChallenge := new(big.Int).Sub(order, challenge)
+	commitments := make([]*big.Int, len(in))
+	baseCommitments := make([]*big.Int, len(in))
+	for i, response := range proof.Responses {
+		if response == nil || response.Sign() < 0 || response.Cmp(order) >= 0 {
+			return fmt.Errorf("Invalid response %v", i)
+		}
+		commitments[i] = group.Mul(group.Exp(in[i], response), group.Exp(out[i], negChallenge))
+		baseCommitments[i] = group.Mul(group.Exp(base, response), group.Exp(proof.KeyCommitments[i], negChallenge))
+	}
+	expected := proofChallenge(
+		group, context, in, out, []*big.Int{base}, proof.KeyCommitments, commitments, baseCommitments)
+	if !bytes.Equal(expected, proof.Challenge) {
+		return fmt.Errorf("Proof doesn't match")
+	}
+	return nil
+}
+
+// KeyCommitment returns the commitment to the key, the group's generator raised to it, which reveals nothing about the
+// key itself.
+func KeyCommitment(group Group, key *big.Int) *big.Int {
+	return group.Exp(group.Generator(), key)
+}
+
+// VerifyDecryptionKey checks the decryption key undoes the encryption with the exponent the commitment is to. For a
+// card encrypted with a key in a shuffle and then re-encrypted per card, the commitment is the per-card key commitment
+// from the RekeyProof with the ShuffleProof key commitment as its base, which commits to the product of the two
+// exponents.
+func VerifyDecryptionKey(group Group, commitment *big.Int, key *big.Int) error {
+	if !group.Contains(commitment) || key == nil || key.Sign() <= 0 {
+		return fmt.Errorf("Missing decryption key or commitment")
+	} else if group.Exp(commitment, key).Cmp(group.Generator()) != 0 {
+		return fmt.Errorf("Decryption key doesn't match commitment")
+	}
+	return nil
+}
+
+// checkElements makes sure every value is an element of the group, since the proofs only hold in it. For residues,
+// values outside of it could pass a round by flipping signs.
+func checkElements(group Group, values []*big.Int) error {
+	for i, v := range values {
+		if !group.Contains(v) {
+			return fmt.Errorf("Value %v not in the group", i)
+		}
+	}
+	return nil
+}
+
+func validExponent(v *big.Int, order *big.Int) bool {
+	return v != nil && v.Sign() > 0 && v.Cmp(order) < 0
+}
+
+func validPermutation(perm []int, n int) bool {
+	if len(perm) != n {
+		return false
+	}
+	seen := make([]bool, n)
+	for _, index := range perm {
+		if index < 0 || index >= n || seen[index] {
+			return false
+		}
+		seen[index] = true
+	}
+	return true
+}
+
+// randomExponent returns a random exponent in [1, order)
+func randomExponent(rnd io.Reader, order *big.Int) (*big.Int, error) {
+	v, err := rand.Int(rnd, new(big.Int).Sub(order, bigOne))
+	if err != nil {
+		return nil, err
+	}
+	return v.Add(v, bigOne), nil
+}
+
+func randomPermutation(rnd io.Reader, n int) ([]int, error) {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j, err := rand.Int(rnd, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		perm[i], perm[j.Int64()] = perm[j.Int64()], perm[i]
+	}
+	return perm, nil
+}
+
+// proofChallenge hashes the context, the group's order, and sets of values, each value at the group's element length
+func proofChallenge(group Group, context []byte, sets ...[]*big.Int) []byte {
+	h := sha512.New()
+	writeLen(h, len(context))
+	h.Write(context)
+	orderBytes := group.Order().Bytes()
+	writeLen(h, len(orderBytes))
+	h.Write(orderBytes)
+	buf := make([]byte, group.ElementLen())
+	for _, set := range sets {
+		writeLen(h, len(set))
+		for _, v := range set {
+			h.Write(v.FillBytes(buf))
+		}
+	}
+	return h.Sum(nil)
+}
+
+func writeLen(h hash.Hash, n int) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(n))
+	h.Write(b[:])
+}
+
+func challengeBit(challenge []byte, index int) bool {
+	return challenge[index/8]&(1<<uint(index%8)) != 0
+}
@@ -18,25 +18,21 @@ type KeyPair struct {
 
 var bigOne = big.NewInt(1)
 
-// GenerateKeyPair generates a SRA key pair for the given prime and numBits.
-func GenerateKeyPair(rnd io.Reader, prime *big.Int, numBits int) (kp *KeyPair, err error) {
+// GenerateKeyPair generates a SRA key pair for the given prime. The encryption key is drawn uniformly from the
+// exponents coprime to prime-1, so with a safe prime it is uniform mod the order of the quadratic residues and can't be
+// recovered from a commitment to it any faster than a discrete log in that group.
+func GenerateKeyPair(rnd io.Reader, prime *big.Int) (kp *KeyPair, err error) {
 	kp = &KeyPair{Prime: prime}
 	phiP := new(big.Int).Sub(prime, bigOne)
-	// TODO: max tries?
 	for {
-		if kp.Enc, err = rand.Prime(rnd, numBits); err != nil {
+		if kp.Enc, err = rand.Int(rnd, phiP); err != nil {
 			return nil, err
 		}
-		if new(big.Int).GCD(nil, nil, kp.Enc, phiP).Cmp(bigOne) == 0 {
+		if kp.Enc.Cmp(bigOne) > 0 && new(big.Int).GCD(nil, nil, kp.Enc, phiP).Cmp(bigOne) == 0 {
 			break
 		}
 	}
 	kp.Dec = new(big.Int).ModInverse(kp.Enc, phiP)
-	// TODO: since direction doesn't matter, I could switch Enc and Dec here.
-	// Should I swap em randomly as shown below?
-	// if v, err := rand.Int(rand.Reader, big.NewInt(2)); err == nil && v.Cmp(bigOne) == 0 {
-	// 	kp.Enc, kp.Dec = kp.Dec, kp.Enc
-	// }
 	return
 }
 
@@ -50,6 +46,12 @@ func (k *KeyPair) DecryptInt(v *big.Int) *big.Int {
 	return DecryptInt(k.Prime, k.Dec, v)
 }
 
+// EncryptionKey returns Enc.
+func (k *KeyPair) EncryptionKey() *big.Int { return k.Enc }
+
+// DecryptionKey returns Dec.
+func (k *KeyPair) DecryptionKey() *big.Int { return k.Dec }
+
 func EncryptInt(prime *big.Int, encKey *big.Int, v *big.Int) *big.Int {
 	return new(big.Int).Exp(v, encKey, prime)
 }
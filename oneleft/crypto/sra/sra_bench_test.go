@@ -6,63 +6,34 @@ import (
 	"math/big"
 	"testing"
 
-	"github.com/cretz/go-mental-poker/sra"
+	"github.com/cretz/one-left/oneleft/crypto/ecph"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
 )
 
-// Testing has shown prime size doesn't matter that much
+// Keys are as large as the prime, so its size is what matters
 var smallPrime = genPrime(64)
 var mediumPrime = genPrime(256)
 var largePrime = genPrime(1024)
 
-func BenchmarkGenerateKeyPairSmallPrime32Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, smallPrime, 32)
-}
-func BenchmarkGenerateKeyPairSmallPrime64Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, smallPrime, 64)
-}
-func BenchmarkGenerateKeyPairSmallPrime128Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, smallPrime, 128)
+func BenchmarkGenerateKeyPairSmallPrime(b *testing.B) {
+	benchmarkGenerateKeyPair(b, smallPrime)
 }
 
-func BenchmarkGenerateKeyPairMediumPrime32Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, mediumPrime, 32)
-}
-func BenchmarkGenerateKeyPairMediumPrime64Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, mediumPrime, 64)
-}
-func BenchmarkGenerateKeyPairMediumPrime128Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, mediumPrime, 128)
+func BenchmarkGenerateKeyPairMediumPrime(b *testing.B) {
+	benchmarkGenerateKeyPair(b, mediumPrime)
 }
 
-func BenchmarkGenerateKeyPairLargePrime32Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 32)
-}
-func BenchmarkGenerateKeyPairLargePrime64Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 64)
-}
-func BenchmarkGenerateKeyPairLargePrime128Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 128)
-}
-func BenchmarkGenerateKeyPairLargePrime256Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 256)
-}
-func BenchmarkGenerateKeyPairLargePrime512Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 512)
-}
-func BenchmarkGenerateKeyPairLargePrime1024Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 1024)
-}
-func BenchmarkGenerateKeyPairLargePrime2048Bit(b *testing.B) {
-	benchmarkGenerateKeyPair(b, largePrime, 2048)
+func BenchmarkGenerateKeyPairLargePrime(b *testing.B) {
+	benchmarkGenerateKeyPair(b, largePrime)
 }
 
 var resultKp *sra.KeyPair
 
-func benchmarkGenerateKeyPair(b *testing.B, prime *big.Int, bits int) {
+func benchmarkGenerateKeyPair(b *testing.B, prime *big.Int) {
 	var kp *sra.KeyPair
 	var err error
 	for i := 0; i < b.N; i++ {
-		kp, err = sra.GenerateKeyPair(rand.Reader, prime, bits)
+		kp, err = sra.GenerateKeyPair(rand.Reader, prime)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -77,3 +48,64 @@ func genPrime(bits int) *big.Int {
 	}
 	return ret
 }
+
+// rfc3526Prime3072 is the 3072-bit safe prime of RFC 3526, which gives SRA about the 128-bit security of P-256
+var rfc3526Prime3072, _ = new(big.Int).SetString(
+	"FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B139B22514A08798E3404DD"+
+		"EF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED"+
+		"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F"+
+		"83655D23DCA3AD961C62F356208552BB9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B"+
+		"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF6955817183995497CEA956AE515D2261898FA0510"+
+		"15728E5A8AAAC42DAD33170D04507A33A85521ABDF1CBA64ECFB850458DBEF0A8AEA71575D060C7DB3970F85A6E1E4C7"+
+		"ABF5AE8CDB0933D71E8C94E04A25619DCEE3D2261AD2EE6BF12FFA06D98A0864D87602733EC86A64521F2B18177B200C"+
+		"BBE117577A615D6C770988C0BAD946E208E24FA074E5AB3143DB5BFCE0FD108E4B82D120A93AD2CAFFFFFFFFFFFFFFFF",
+	16,
+)
+
+// The round trip benchmarks encrypt and decrypt a card the way a hand does, to compare the card ciphers. P-256 is
+// compared to SRA with 3072-bit primes, which has the same security. SRA with the default 256-bit primes is quicker
+// than both but much weaker.
+func BenchmarkRoundTripSRA256Bit(b *testing.B) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 256)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkRoundTrip(b, sra.NewResidueGroup(prime), func() (roundTripKeyPair, error) {
+		return sra.GenerateKeyPair(rand.Reader, prime)
+	})
+}
+
+func BenchmarkRoundTripSRA3072Bit(b *testing.B) {
+	benchmarkRoundTrip(b, sra.NewResidueGroup(rfc3526Prime3072), func() (roundTripKeyPair, error) {
+		return sra.GenerateKeyPair(rand.Reader, rfc3526Prime3072)
+	})
+}
+
+func BenchmarkRoundTripECP256(b *testing.B) {
+	benchmarkRoundTrip(b, ecph.P256, func() (roundTripKeyPair, error) {
+		return ecph.GenerateKeyPair(rand.Reader, ecph.P256)
+	})
+}
+
+type roundTripKeyPair interface {
+	EncryptInt(v *big.Int) *big.Int
+	DecryptInt(v *big.Int) *big.Int
+}
+
+func benchmarkRoundTrip(b *testing.B, group sra.Group, generateKeyPair func() (roundTripKeyPair, error)) {
+	encoding, err := sra.NewCardEncoding(group, make([]byte, 32), 108)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pair, err := generateKeyPair()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		card := encoding.Encode(i % encoding.Len())
+		if pair.DecryptInt(pair.EncryptInt(card)).Cmp(card) != 0 {
+			b.Fatal("Round trip changed the card")
+		}
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"math/big"
 	"testing"
 
-	"github.com/cretz/go-mental-poker/sra"
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,11 +17,11 @@ func TestSRACommutative(t *testing.T) {
 	prime, err := rand.Prime(rand.Reader, 256)
 	require.NoError(t, err)
 	// Gen key pairs for alice, bob, and ted
-	alice, err := sra.GenerateKeyPair(rand.Reader, prime, 32)
+	alice, err := sra.GenerateKeyPair(rand.Reader, prime)
 	require.NoError(t, err)
-	bob, err := sra.GenerateKeyPair(rand.Reader, prime, 32)
+	bob, err := sra.GenerateKeyPair(rand.Reader, prime)
 	require.NoError(t, err)
-	ted, err := sra.GenerateKeyPair(rand.Reader, prime, 32)
+	ted, err := sra.GenerateKeyPair(rand.Reader, prime)
 	require.NoError(t, err)
 
 	// Make sure it can be encrypted by all the people in any order, and decrypted
@@ -76,3 +79,247 @@ func newSuperSecretInt(t *testing.T, maxBits int) *big.Int {
 		}
 	}
 }
+
+// testPrime is a safe prime shared by the tests, since generating one takes a bit
+var testPrime = mustGenerateSafePrime(256)
+
+func mustGenerateSafePrime(bits int) *big.Int {
+	prime, err := sra.GenerateSafePrime(rand.Reader, bits)
+	if err != nil {
+		panic(err)
+	}
+	return prime
+}
+
+func TestGenerateKeyPairFullSize(t *testing.T) {
+	phiP := new(big.Int).Sub(testPrime, big.NewInt(1))
+	for i := 0; i < 20; i++ {
+		pair, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+		require.NoError(t, err)
+		// Small keys could be recovered from their commitments, and a random full-size one is this small with a
+		// negligible chance
+		require.Greater(t, pair.Enc.BitLen(), 128)
+		require.Equal(t, int64(1), new(big.Int).GCD(nil, nil, pair.Enc, phiP).Int64())
+		require.Equal(t, int64(1), new(big.Int).Mod(new(big.Int).Mul(pair.Enc, pair.Dec), phiP).Int64())
+	}
+}
+
+func TestVerifyDecryptionKey(t *testing.T) {
+	group := sra.NewResidueGroup(testPrime)
+	pair, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+	require.NoError(t, err)
+	other, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+	require.NoError(t, err)
+	commitment := sra.KeyCommitment(group, pair.Enc)
+	require.NoError(t, sra.VerifyDecryptionKey(group, commitment, pair.Dec))
+	require.Error(t, sra.VerifyDecryptionKey(group, commitment, other.Dec))
+	require.Error(t, sra.VerifyDecryptionKey(group, commitment, pair.Enc))
+	require.Error(t, sra.VerifyDecryptionKey(group, commitment, nil))
+	require.Error(t, sra.VerifyDecryptionKey(group, commitment, new(big.Int)))
+	require.Error(t, sra.VerifyDecryptionKey(group, nil, pair.Dec))
+	// Not a residue
+	require.Error(t, sra.VerifyDecryptionKey(group, new(big.Int).Sub(testPrime, big.NewInt(1)), pair.Dec))
+}
+
+func TestRekeyProof(t *testing.T) {
+	group := sra.NewResidueGroup(testPrime)
+	context := []byte("rekey test")
+	shuffleKey, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+	require.NoError(t, err)
+	base := sra.KeyCommitment(group, shuffleKey.Enc)
+	in := testElements(t, group, 10)
+	exponents := make([]*big.Int, len(in))
+	decKeys := make([]*big.Int, len(in))
+	out := make([]*big.Int, len(in))
+	for i, v := range in {
+		pair, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+		require.NoError(t, err)
+		exponents[i] = pair.Enc
+		out[i] = group.Exp(v, pair.Enc)
+		// Undoes both the shuffle key and the card key
+		decKeys[i] = new(big.Int).Mul(shuffleKey.Dec, pair.Dec)
+	}
+	proof, err := sra.ProveRekey(rand.Reader, group, context, base, in, out, exponents)
+	require.NoError(t, err)
+	require.NoError(t, sra.VerifyRekey(group, context, base, in, out, proof))
+	// The key commitments are to both keys, so the combined decryption key verifies against them
+	for i, decKey := range decKeys {
+		require.NoError(t, sra.VerifyDecryptionKey(group, proof.KeyCommitments[i], decKey))
+		require.Error(t, sra.VerifyDecryptionKey(group, proof.KeyCommitments[i], decKeys[(i+1)%len(decKeys)]))
+	}
+
+	// Other context
+	require.Error(t, sra.VerifyRekey(group, []byte("other"), base, in, out, proof))
+	// Other base
+	require.Error(t, sra.VerifyRekey(group, context, group.Generator(), in, out, proof))
+	// Swapped outputs
+	swapped := append([]*big.Int{}, out...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	require.Error(t, sra.VerifyRekey(group, context, base, in, swapped, proof))
+	// Output encrypted with another exponent
+	substituted := append([]*big.Int{}, out...)
+	substituted[2] = group.Exp(in[2], exponents[3])
+	require.Error(t, sra.VerifyRekey(group, context, base, in, substituted, proof))
+	// Wrong key commitment
+	badCommitments := *proof
+	badCommitments.KeyCommitments = append([]*big.Int{}, proof.KeyCommitments...)
+	badCommitments.KeyCommitments[4] = group.Exp(base, exponents[5])
+	require.Error(t, sra.VerifyRekey(group, context, base, in, out, &badCommitments))
+	// Wrong response
+	badResponses := *proof
+	badResponses.Responses = append([]*big.Int{}, proof.Responses...)
+	badResponses.Responses[6] = new(big.Int).Add(badResponses.Responses[6], big.NewInt(1))
+	require.Error(t, sra.VerifyRekey(group, context, base, in, out, &badResponses))
+	// Missing response
+	short := *proof
+	short.Responses = proof.Responses[1:]
+	require.Error(t, sra.VerifyRekey(group, context, base, in, out, &short))
+}
+
+// testElements returns count random elements of the group
+func testElements(t *testing.T, group sra.Group, count int) []*big.Int {
+	ret := make([]*big.Int, 0, count)
+	for len(ret) < count {
+		b := make([]byte, group.ElementLen())
+		_, err := rand.Read(b)
+		require.NoError(t, err)
+		if v := group.HashToElement(b); v != nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func TestShuffleProof(t *testing.T) {
+	group := sra.NewResidueGroup(testPrime)
+	context := []byte("shuffle test")
+	key, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+	require.NoError(t, err)
+	in := testElements(t, group, 6)
+	perm := []int{3, 0, 5, 1, 4, 2}
+	out := make([]*big.Int, len(in))
+	for i, inIndex := range perm {
+		out[i] = group.Exp(in[inIndex], key.Enc)
+	}
+	proof, err := sra.ProveShuffle(rand.Reader, group, context, in, out, perm, key.Enc)
+	require.NoError(t, err)
+	require.Len(t, proof.Rounds, sra.ShuffleProofRounds)
+	require.NoError(t, sra.VerifyShuffle(group, context, in, out, proof))
+	// The key commitment is to the key the outputs were encrypted with
+	require.NoError(t, sra.VerifyDecryptionKey(group, proof.KeyCommitment, key.Dec))
+
+	// Other context
+	require.Error(t, sra.VerifyShuffle(group, []byte("other"), in, out, proof))
+	// Dropped card
+	require.Error(t, sra.VerifyShuffle(group, context, in, out[1:], proof))
+	// Duplicated card
+	duplicated := append([]*big.Int{}, out...)
+	duplicated[1] = duplicated[0]
+	require.Error(t, sra.VerifyShuffle(group, context, in, duplicated, proof))
+	// Substituted card, encrypted with the same key
+	substituted := append([]*big.Int{}, out...)
+	substituted[2] = group.Exp(testElements(t, group, 1)[0], key.Enc)
+	require.Error(t, sra.VerifyShuffle(group, context, in, substituted, proof))
+	// Not in the group
+	outside := append([]*big.Int{}, out...)
+	outside[3] = new(big.Int).Sub(testPrime, outside[3])
+	require.Error(t, sra.VerifyShuffle(group, context, in, outside, proof))
+	// Wrong key commitment
+	other, err := sra.GenerateKeyPair(rand.Reader, testPrime)
+	require.NoError(t, err)
+	badCommitment := *proof
+	badCommitment.KeyCommitment = sra.KeyCommitment(group, other.Enc)
+	require.Error(t, sra.VerifyShuffle(group, context, in, out, &badCommitment))
+	// Missing round
+	short := *proof
+	short.Rounds = proof.Rounds[1:]
+	require.Error(t, sra.VerifyShuffle(group, context, in, out, &short))
+	// Proving a wrong shuffle doesn't verify
+	wrongProof, err := sra.ProveShuffle(rand.Reader, group, context, in, substituted, perm, key.Enc)
+	require.NoError(t, err)
+	require.Error(t, sra.VerifyShuffle(group, context, in, substituted, wrongProof))
+}
+
+func TestCardEncoding(t *testing.T) {
+	cipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, testPrime)
+	require.NoError(t, err)
+	seed := make([]byte, sra.MinCardEncodingSeedLen)
+	_, err = rand.Read(seed)
+	require.NoError(t, err)
+	encoding, err := crypto.NewCardEncoding(cipher, seed)
+	require.NoError(t, err)
+	require.Equal(t, 108, encoding.Len())
+	// Every card round trips through a distinct element of the group
+	seen := map[string]bool{}
+	for card := game.Card(0); card < 108; card++ {
+		v := crypto.CardToInt(encoding, card)
+		require.True(t, cipher.Contains(v), "card %v", card)
+		require.False(t, seen[v.String()], "card %v", card)
+		seen[v.String()] = true
+		decoded, ok := crypto.IntToCard(encoding, v)
+		require.True(t, ok)
+		require.Equal(t, card, decoded)
+	}
+	// The same seed gives the same encoding
+	again, err := crypto.NewCardEncoding(cipher, seed)
+	require.NoError(t, err)
+	for card := game.Card(0); card < 108; card++ {
+		require.Equal(t, crypto.CardToInt(encoding, card), crypto.CardToInt(again, card))
+	}
+	// Tampered values and the values of another seed's encoding aren't cards
+	seed[0]++
+	other, err := crypto.NewCardEncoding(cipher, seed)
+	require.NoError(t, err)
+	for card := game.Card(0); card < 108; card++ {
+		v := crypto.CardToInt(encoding, card)
+		_, ok := crypto.IntToCard(encoding, v.Add(v, big.NewInt(1)))
+		require.False(t, ok)
+		_, ok = crypto.IntToCard(encoding, crypto.CardToInt(other, card))
+		require.False(t, ok)
+	}
+	for _, v := range []int64{-1, 0, 1, 107, 108} {
+		_, ok := crypto.IntToCard(encoding, big.NewInt(v))
+		require.False(t, ok, "%v", v)
+	}
+
+	// Short seeds and small groups can't be used
+	_, err = crypto.NewCardEncoding(cipher, seed[:sra.MinCardEncodingSeedLen-1])
+	require.EqualError(t, err, "Seed must be at least 32 bytes")
+	_, err = sra.NewCardEncoding(sra.NewResidueGroup(big.NewInt(2039)), seed, 108)
+	require.EqualError(t, err, "Group too small")
+}
+
+// repeatingGroup hashes every other value to the previous element and fails to hash every third one
+type repeatingGroup struct {
+	sra.Group
+	hashes int
+	last   *big.Int
+}
+
+func (r *repeatingGroup) HashToElement(b []byte) *big.Int {
+	r.hashes++
+	switch {
+	case r.hashes%3 == 0:
+		return nil
+	case r.hashes%2 == 0 && r.last != nil:
+		return new(big.Int).Set(r.last)
+	}
+	r.last = r.Group.HashToElement(b)
+	return r.last
+}
+
+func TestCardEncodingSkipsDuplicates(t *testing.T) {
+	group := &repeatingGroup{Group: sra.NewResidueGroup(testPrime)}
+	encoding, err := sra.NewCardEncoding(group, make([]byte, sra.MinCardEncodingSeedLen), 108)
+	require.NoError(t, err)
+	require.Equal(t, 108, encoding.Len())
+	seen := map[string]bool{}
+	for i := 0; i < encoding.Len(); i++ {
+		v := encoding.Encode(i)
+		require.False(t, seen[v.String()], "index %v", i)
+		seen[v.String()] = true
+		decoded, ok := encoding.Decode(v)
+		require.True(t, ok)
+		require.Equal(t, i, decoded)
+	}
+}
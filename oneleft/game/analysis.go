@@ -0,0 +1,64 @@
+package game
+
+// CurrentColor returns the color that must be matched to play on the top card, which is the chosen color if the top
+// card is wild
+func CurrentColor(top Card, lastWildColor CardColor) CardColor {
+	if top.Wild() {
+		return lastWildColor
+	}
+	return top.Color()
+}
+
+// LegalPlays returns every play that can be made from the cards on the top card in the order of the cards. A wild has
+// a play for each color it can be given. When there is a draw penalty, only the cards that can be stacked are
+// included.
+func LegalPlays(cards []Card, top Card, lastWildColor CardColor, drawPenalty int) []PlayerPlay {
+	plays := []PlayerPlay{}
+	for _, card := range cards {
+		if !card.CanPlayOn(top, lastWildColor) || (drawPenalty > 0 && !card.CanStackOn(top)) {
+			continue
+		} else if !card.Wild() {
+			plays = append(plays, PlayerPlay{Card: card})
+			continue
+		}
+		for color := ColorRed; color <= ColorBlue; color++ {
+			plays = append(plays, PlayerPlay{Card: card, WildColor: color})
+		}
+	}
+	return plays
+}
+
+// HasColor is true if any of the cards are of the given color. Wilds have no color.
+func HasColor(cards []Card, color CardColor) bool {
+	if !color.Valid() {
+		return false
+	}
+	for _, card := range cards {
+		if card.Color() == color {
+			return true
+		}
+	}
+	return false
+}
+
+// WildDrawFourChallengeable is true if a wild draw four played from the cards on the top card would lose a challenge,
+// which is when the cards include one of the current color
+func WildDrawFourChallengeable(cards []Card, top Card, lastWildColor CardColor) bool {
+	return HasColor(cards, CurrentColor(top, lastWildColor))
+}
+
+// MostCommonColor returns the color most of the cards have, with ties going to the first color in red, yellow, green,
+// blue order. Red is returned if there are only wilds.
+func MostCommonColor(cards []Card) CardColor {
+	counts := map[CardColor]int{}
+	for _, card := range cards {
+		counts[card.Color()]++
+	}
+	mostCommon := ColorRed
+	for color := ColorYellow; color <= ColorBlue; color++ {
+		if counts[color] > counts[mostCommon] {
+			mostCommon = color
+		}
+	}
+	return mostCommon
+}
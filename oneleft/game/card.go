@@ -92,6 +92,11 @@ func (c Card) CanPlayOn(other Card, lastWildColor CardColor) bool {
 		(other.Wild() && lastWildColor == c.Color())
 }
 
+// Identical is true if the card has the same color and value as the other, e.g. for jumping in
+func (c Card) Identical(other Card) bool {
+	return c.Color() == other.Color() && c.Value() == other.Value()
+}
+
 func (c Card) Score() int {
 	switch v := c.Value(); v {
 	case Wild, WildDrawFour:
@@ -115,12 +120,29 @@ func (c Card) Valid() bool {
 	return c >= 0 && c <= 107
 }
 
+// CanStackOn is true if this can be stacked on the given draw card when draw cards are stacked. Any draw card can be
+// stacked on a draw two, but only a wild draw four can be stacked on a wild draw four.
+func (c Card) CanStackOn(top Card) bool {
+	switch top.Value() {
+	case DrawTwo:
+		return c.Value() == DrawTwo || c.Value() == WildDrawFour
+	case WildDrawFour:
+		return c.Value() == WildDrawFour
+	default:
+		return false
+	}
+}
+
 type CardDeck interface {
 	CardsRemaining() int
+	// Nil means all cards, taking back any held by players
 	Shuffle([]Card) error
 	DealTo(playerIndex int) error
 	PopForFirstDiscard() (Card, error)
 	CompleteHand() (CardDeckHandCompleteReveal, error)
+	// Each player is given the hand held by the player at their index in fromIndexes, which is a permutation of the
+	// player indexes
+	MoveHands(fromIndexes []int) error
 }
 
 type CardDeckHandCompleteReveal interface {
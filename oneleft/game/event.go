@@ -22,6 +22,20 @@ const (
 	EventHandPlayerCalledOneLeft
 	EventHandPlayerOneLeftPenaltyDrewTwo
 	EventHandEnd
+	// Only when draw cards are stacked, the player added to the draw penalty
+	EventHandPlayerPenaltyStacked
+	// Only when draw cards are stacked, the player couldn't stack and drew the whole draw penalty
+	EventHandPlayerPenaltyAbsorbed
+	// Only when jumping in is allowed, the player discarded out of turn in place of the current player
+	EventHandPlayerJumpedIn
+	// Only when playing Seven-O, the player that played a 7 swapped hands with the swap target
+	EventHandPlayerSwappedHands
+	// Only when playing Seven-O, the player played a 0 and every hand was passed to the next player
+	EventHandHandsRotated
+	// Only with two players, the reverse skipped the other player instead of changing direction
+	EventHandPlayerReverseSkipped
+	// The player took too long to decide and the default was taken for them, which has its own events if needed
+	EventHandPlayerTimedOut
 )
 
 var eventTypeNames = map[EventType]string{
@@ -43,6 +57,13 @@ var eventTypeNames = map[EventType]string{
 	EventHandPlayerCalledOneLeft:            "HandPlayerCalledOneLeft",
 	EventHandPlayerOneLeftPenaltyDrewTwo:    "HandPlayerOneLeftPenaltyDrewTwo",
 	EventHandEnd:                            "HandEnd",
+	EventHandPlayerPenaltyStacked:           "HandPlayerPenaltyStacked",
+	EventHandPlayerPenaltyAbsorbed:          "HandPlayerPenaltyAbsorbed",
+	EventHandPlayerJumpedIn:                 "HandPlayerJumpedIn",
+	EventHandPlayerSwappedHands:             "HandPlayerSwappedHands",
+	EventHandHandsRotated:                   "HandHandsRotated",
+	EventHandPlayerReverseSkipped:           "HandPlayerReverseSkipped",
+	EventHandPlayerTimedOut:                 "HandPlayerTimedOut",
 }
 
 func (e EventType) String() string { return eventTypeNames[e] }
@@ -63,4 +84,8 @@ type EventHand struct {
 	LastDiscardWildColor CardColor
 	Forward              bool
 	OneLeftTarget        int
+	// The amount the next player has to draw if they don't stack
+	DrawPenalty int
+	// The player whose hand was swapped with, -1 if none
+	SwapTarget int
 }
@@ -4,12 +4,16 @@ import "fmt"
 
 type Game struct {
 	players []Player
+	rules   Rules
 	newDeck func() (CardDeck, error)
 	eventCb func(*Event) error
 
-	dealerIndex  int
-	hand         *hand
-	playerScores []int
+	scoring         ScoringPolicy
+	snapshotCb      func(*Snapshot) error
+	dealerIndex     int
+	hand            *hand
+	playerScores    []int
+	handScoreDeltas [][]int
 }
 
 type GameError struct {
@@ -22,40 +26,109 @@ func (h *GameError) Error() string { return h.Message }
 
 type GameComplete struct {
 	PlayerScores []int
+	// The score change of every player for each hand played
+	HandScoreDeltas [][]int
+	// The players with the best score according to the scoring policy
+	WinnerIndexes []int
 }
 
-func New(players []Player, newDeck func() (CardDeck, error), eventCb func(*Event) error) *Game {
-	return &Game{players: players, newDeck: newDeck, eventCb: eventCb}
+func New(players []Player, rules Rules, newDeck func() (CardDeck, error), eventCb func(*Event) error) *Game {
+	return &Game{players: players, rules: rules, newDeck: newDeck, eventCb: eventCb}
+}
+
+// SetScoringPolicy replaces the built-in scoring policy of the rules. Players that validate scores with the rules will
+// not accept scores from other policies.
+func (g *Game) SetScoringPolicy(scoring ScoringPolicy) {
+	g.scoring = scoring
+}
+
+// SetSnapshotCallback sets the callback given a snapshot each time the game reaches a point it can be resumed from,
+// which is before each hand and before each turn, not after every event. For the snapshot to have the cards needed to
+// resume a hand, the decks must be SnapshotDecks.
+func (g *Game) SetSnapshotCallback(snapshotCb func(*Snapshot) error) {
+	g.snapshotCb = snapshotCb
 }
 
 func (g *Game) Play(initialDealerIndex int) (*GameComplete, *GameError) {
+	if err := g.rules.Validate(len(g.players)); err != nil {
+		return nil, Errorf("Invalid rules: %v", err)
+	}
 	g.dealerIndex = initialDealerIndex
 	g.playerScores = make([]int, len(g.players))
+	g.handScoreDeltas = nil
 	if err := g.sendEvent(EventGameStart, nil, nil); err != nil {
 		return nil, err
 	}
-	// Play until someone gets 500
+	return g.playHands(nil)
+}
+
+// Resume continues a game from the snapshot instead of starting one. The game must have the same rules and number of
+// players as the snapshot. No game start event is sent, and a hand in progress continues with the turn of the player
+// the snapshot was taken before.
+func (g *Game) Resume(snapshot *Snapshot) (*GameComplete, *GameError) {
+	if snapshot.Version != SnapshotVersion {
+		return nil, Errorf("Unsupported snapshot version %v, expected %v", snapshot.Version, SnapshotVersion)
+	} else if snapshot.Rules != g.rules {
+		return nil, Errorf("Snapshot rules don't match game rules")
+	} else if len(snapshot.PlayerScores) != len(g.players) {
+		return nil, Errorf("Snapshot has %v players, game has %v", len(snapshot.PlayerScores), len(g.players))
+	} else if err := g.rules.Validate(len(g.players)); err != nil {
+		return nil, Errorf("Invalid rules: %v", err)
+	}
+	g.dealerIndex = snapshot.DealerIndex
+	g.playerScores = append([]int{}, snapshot.PlayerScores...)
+	g.handScoreDeltas = make([][]int, len(snapshot.HandScoreDeltas))
+	for i, deltas := range snapshot.HandScoreDeltas {
+		g.handScoreDeltas[i] = append([]int{}, deltas...)
+	}
+	return g.playHands(snapshot.Hand)
+}
+
+// playHands plays until the scoring policy says the game is over, starting with the hand in the optional snapshot
+func (g *Game) playHands(resumeHand *HandSnapshot) (*GameComplete, *GameError) {
+	if g.scoring == nil {
+		g.scoring = g.rules.ScoringPolicy(len(g.players))
+	}
 	for {
-		// Create the hand
-		deck, err := g.newDeck()
-		if err != nil {
-			return nil, Errorf("Failed creating deck: %v", err)
+		// Create the hand and play it, or continue the one being resumed
+		var handComplete *HandComplete
+		var gameErr *GameError
+		if resumeHand != nil {
+			if g.hand, gameErr = g.resumeHand(resumeHand); gameErr != nil {
+				return nil, gameErr
+			}
+			handComplete, gameErr = g.hand.playTurns(resumeHand.OneLeftTarget)
+			resumeHand = nil
+		} else {
+			if err := g.sendSnapshot(nil, -1); err != nil {
+				return nil, err
+			}
+			deck, err := g.newDeck()
+			if err != nil {
+				return nil, Errorf("Failed creating deck: %v", err)
+			}
+			g.hand = &hand{
+				game:        g,
+				deck:        deck,
+				playerIndex: g.dealerIndex,
+				forward:     true,
+			}
+			handComplete, gameErr = g.hand.play()
 		}
-		hand := &hand{
-			game:        g,
-			deck:        deck,
-			playerIndex: g.dealerIndex,
-			forward:     true,
-		}
-		// Play it
-		handComplete, gameErr := hand.play()
 		if gameErr != nil {
 			return nil, gameErr
 		}
-		// Add the score to the winning player and check if 500 reached
-		g.playerScores[handComplete.WinnerIndex] += handComplete.Score
-		g.sendEvent(EventHandEnd, hand.eventState(), handComplete)
-		if g.playerScores[handComplete.WinnerIndex] >= 500 {
+		// Apply the score changes and check if the game is over
+		if len(handComplete.ScoreDeltas) != len(g.players) {
+			return nil, Errorf("Scoring policy gave %v score deltas for %v players",
+				len(handComplete.ScoreDeltas), len(g.players))
+		}
+		for i, delta := range handComplete.ScoreDeltas {
+			g.playerScores[i] += delta
+		}
+		g.handScoreDeltas = append(g.handScoreDeltas, handComplete.ScoreDeltas)
+		g.sendEvent(EventHandEnd, g.hand.eventState(), handComplete)
+		if g.rules.SingleHand || g.scoring.GameOver(g.playerScores, len(g.handScoreDeltas)) {
 			break
 		}
 		// Next player becomes dealer
@@ -64,10 +137,15 @@ func (g *Game) Play(initialDealerIndex int) (*GameComplete, *GameError) {
 			g.dealerIndex = 0
 		}
 	}
+	g.hand = nil
 	if err := g.sendEvent(EventGameEnd, nil, nil); err != nil {
 		return nil, err
 	}
-	return &GameComplete{PlayerScores: g.playerScores}, nil
+	return &GameComplete{
+		PlayerScores:    g.playerScores,
+		HandScoreDeltas: g.handScoreDeltas,
+		WinnerIndexes:   g.scoring.GameWinners(g.playerScores),
+	}, nil
 }
 
 // if last param is err, it is cause
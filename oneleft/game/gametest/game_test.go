@@ -1,60 +1,653 @@
 package gametest
 
 import (
+	"fmt"
 	"log"
-	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cretz/one-left/oneleft/game"
 )
 
-var benchmarkSomeGamesGlobalCounter int64
-
 func BenchmarkSomeGames(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		// seed := time.Now().UnixNano()
-		benchmarkSomeGamesGlobalCounter++
-		seed := benchmarkSomeGamesGlobalCounter
-		rand.Seed(seed)
-		if err := runGame(5); err != nil {
-			b.Fatalf("Failure with seed %v: %v", seed, err)
+		if err := runGame(int64(i), 5, game.DefaultRules()); err != nil {
+			b.Fatal(err)
 		}
 	}
 }
 
 func TestGame(t *testing.T) {
-	rand.Seed(0)
-	// rand.Seed(1529995356611101700)
-	// rand.Seed(time.Now().UnixNano())
-	if err := runGame(5); err != nil {
+	if err := runGame(0, 5, game.DefaultRules()); err != nil {
 		t.Fatal(err)
 	}
 }
 
-func runGame(playerCount int) error {
-	// Build deck and players
-	players := make([]game.Player, playerCount)
-	for i := 0; i < len(players); i++ {
-		players[i] = &PracticalPlayer{Index: i, AllPlayers: players}
+func TestSeededGame(t *testing.T) {
+	r := game.DefaultRules()
+	r.JumpIn, r.SevenO, r.StackDrawCards = true, true, true
+	// Every event of the same seed must match, even the ones decided by timing like one-left calls and jump-ins
+	playEvents := func(seed int64) []string {
+		events := []string{}
+		err := runGameWithEvents(seed, 4, r, func(event *game.Event) error {
+			events = append(events, fmt.Sprintf("%v %v %v", event.Type, event.PlayerScores, event.Hand))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events
 	}
-	newDeck := func() (game.CardDeck, error) {
-		handState := &HandState{}
-		for _, player := range players {
-			player.(*PracticalPlayer).HandState = handState
+	for seed := int64(0); seed < 5; seed++ {
+		if !reflect.DeepEqual(playEvents(seed), playEvents(seed)) {
+			t.Fatalf("Game with seed %v played differently the second time", seed)
 		}
-		return &SimpleDeck{HandState: handState, AllPlayers: players}, nil
 	}
-	// Log events
-	logEventCb := func(event *game.Event) error {
-		debugf("Event: %v - Hand: %v", event, event.Hand)
+	if reflect.DeepEqual(playEvents(0), playEvents(1)) {
+		t.Fatal("Expected different seeds to play different games")
+	}
+}
+
+func TestOneLeftCallsBeforePlay(t *testing.T) {
+	r := game.DefaultRules()
+	r.JumpIn = true
+	for seed := int64(0); seed < 10; seed++ {
+		// Practical players call one-left on themselves right away, so the call always comes before the next play
+		calls, pendingIndex := 0, -1
+		err := runGameWithEvents(seed, 4, r, func(event *game.Event) error {
+			switch event.Type {
+			case game.EventHandPlayerDiscarded, game.EventHandPlayerJumpedIn:
+				if pendingIndex >= 0 {
+					return fmt.Errorf("Player %v played before the one-left call on %v", event.Hand.PlayerIndex,
+						pendingIndex)
+				} else if event.Hand.PlayerCardsRemaining[event.Hand.PlayerIndex] == 1 {
+					pendingIndex = event.Hand.PlayerIndex
+				}
+			case game.EventHandPlayerDrewOne, game.EventHandPlayerPlayedNothing:
+				if pendingIndex >= 0 {
+					return fmt.Errorf("Player %v played before the one-left call on %v", event.Hand.PlayerIndex,
+						pendingIndex)
+				}
+			case game.EventHandPlayerCalledOneLeft:
+				if event.Hand.OneLeftTarget != pendingIndex {
+					return fmt.Errorf("Expected one-left call on %v, got %v", pendingIndex, event.Hand.OneLeftTarget)
+				}
+				calls, pendingIndex = calls+1, -1
+			case game.EventHandEnd:
+				pendingIndex = -1
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		} else if calls == 0 {
+			t.Fatalf("Game with seed %v had no one-left calls", seed)
+		}
+	}
+}
+
+func TestSnapshotResume(t *testing.T) {
+	r := game.DefaultRules()
+	r.JumpIn, r.SevenO, r.TargetScore = true, true, 200
+	eventString := func(event *game.Event) string {
+		return fmt.Sprintf("%v %v %v", event.Type, event.PlayerScores, event.Hand)
+	}
+	// Play a game keeping every snapshot and the index of the event it was taken after
+	events := []string{}
+	snapshots := []*game.Snapshot{}
+	snapshotEventIndexes := []int{}
+	_, err := RunWithSnapshots(0, 4, r, func(event *game.Event) error {
+		events = append(events, eventString(event))
+		return nil
+	}, func(snapshot *game.Snapshot) error {
+		// Make sure it survives serialization
+		b, err := snapshot.Marshal()
+		if err != nil {
+			return err
+		} else if unmarshaled, err := game.UnmarshalSnapshot(b); err != nil {
+			return err
+		} else if !reflect.DeepEqual(snapshot, unmarshaled) {
+			return fmt.Errorf("Snapshot changed after serialization")
+		}
+		snapshots = append(snapshots, snapshot)
+		snapshotEventIndexes = append(snapshotEventIndexes, len(events))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(snapshots) < 10 {
+		t.Fatalf("Expected many snapshots, got %v", len(snapshots))
+	}
+	// Resuming from any point must play the same as the original until the deck is shuffled, and finish the game
+	for i := 0; i < len(snapshots); i += 7 {
+		resumedEvents := []string{}
+		_, err := Resume(0, snapshots[i], func(event *game.Event) error {
+			resumedEvents = append(resumedEvents, eventString(event))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed resuming snapshot %v: %v", i, err)
+		}
+		for j, event := range resumedEvents {
+			original := events[snapshotEventIndexes[i]+j]
+			if strings.HasPrefix(original, game.EventHandStartShuffled.String()) ||
+				strings.HasPrefix(original, game.EventHandReshuffled.String()) {
+				break
+			} else if event != original {
+				t.Fatalf("Resuming snapshot %v, expected event %v, got %v", i, original, event)
+			}
+		}
+	}
+	// Other versions can't be resumed
+	snapshot := *snapshots[len(snapshots)/2]
+	snapshot.Version++
+	if _, err := Resume(0, &snapshot, nil); err == nil {
+		t.Fatal("Expected version error")
+	}
+}
+
+func TestSnapshotResumeInsideTurn(t *testing.T) {
+	r := game.DefaultRules()
+	r.TargetScore = 200
+	eventString := func(event *game.Event) string {
+		return fmt.Sprintf("%v %v %v", event.Type, event.PlayerScores, event.Hand)
+	}
+	// Stop the game at the third draw of one, which is inside a turn that may play the drawn card
+	events := []string{}
+	draws := 0
+	var lastSnapshot *game.Snapshot
+	lastSnapshotEventIndex := 0
+	_, err := RunWithSnapshots(0, 4, r, func(event *game.Event) error {
+		events = append(events, eventString(event))
+		if event.Type == game.EventHandPlayerDrewOne {
+			if draws++; draws == 3 {
+				return fmt.Errorf("Stopped")
+			}
+		}
+		return nil
+	}, func(snapshot *game.Snapshot) error {
+		lastSnapshot, lastSnapshotEventIndex = snapshot, len(events)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "Stopped") {
+		t.Fatalf("Expected stopped game, got %v", err)
+	}
+	// Resuming plays the turn again from its start, including the draw it stopped at
+	lost := events[lastSnapshotEventIndex:]
+	if len(lost) == 0 {
+		t.Fatal("Expected events since the last snapshot")
+	}
+	resumedEvents := []string{}
+	if _, err = Resume(0, lastSnapshot, func(event *game.Event) error {
+		resumedEvents = append(resumedEvents, eventString(event))
 		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if len(resumedEvents) < len(lost) || !reflect.DeepEqual(lost, resumedEvents[:len(lost)]) {
+		t.Fatalf("Expected resumed game to start with %v, got %v", lost, resumedEvents)
+	}
+}
+
+func TestGameRules(t *testing.T) {
+	rules := map[string]func(*game.Rules){
+		"small hands":         func(r *game.Rules) { r.HandSize = 3 },
+		"large hands":         func(r *game.Rules) { r.HandSize = 20 },
+		"low target":          func(r *game.Rules) { r.TargetScore = 50 },
+		"single hand":         func(r *game.Rules) { r.SingleHand = true },
+		"draw until playable": func(r *game.Rules) { r.DrawUntilPlayable = true },
+		"no drawn card play":  func(r *game.Rules) { r.PlayDrawnCard = false },
+		"reshuffle first wd4": func(r *game.Rules) { r.FirstWildDrawFour = game.FirstWildDrawFourReshuffle },
+		"stack draw cards":    func(r *game.Rules) { r.StackDrawCards = true },
+		"jump in":             func(r *game.Rules) { r.JumpIn = true },
+		"seven o":             func(r *game.Rules) { r.SevenO = true },
+		"penalty scoring":     func(r *game.Rules) { r.Scoring = game.ScoringPenalty },
+		"fixed hands":         func(r *game.Rules) { r.Scoring, r.HandCount = game.ScoringFixedHands, 3 },
+	}
+	for name, applyRules := range rules {
+		t.Run(name, func(t *testing.T) {
+			r := game.DefaultRules()
+			applyRules(&r)
+			if err := runGame(0, 5, r); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestTwoPlayerGame(t *testing.T) {
+	rules := map[string]func(*game.Rules){
+		"default":          func(r *game.Rules) {},
+		"stack draw cards": func(r *game.Rules) { r.StackDrawCards = true },
+		"seven o":          func(r *game.Rules) { r.SevenO = true },
+	}
+	for name, applyRules := range rules {
+		t.Run(name, func(t *testing.T) {
+			r := game.DefaultRules()
+			applyRules(&r)
+			// Every skip, reverse, and draw skips the other player, so whoever played it must take the next turn
+			expectedTurnIndex, reverseSkips := -1, 0
+			err := runGameWithEvents(0, 2, r, func(event *game.Event) error {
+				switch event.Type {
+				case game.EventHandStartShuffled:
+					expectedTurnIndex = -1
+				case game.EventHandPlayReversed:
+					return fmt.Errorf("Reversed direction with two players")
+				case game.EventHandPlayerReverseSkipped:
+					reverseSkips++
+					expectedTurnIndex = 1 - event.Hand.PlayerIndex
+				case game.EventHandPlayerSkipped, game.EventHandPlayerDrewTwo, game.EventHandPlayerNoChallengeDrewFour,
+					game.EventHandPlayerChallengeFailedDrewSix, game.EventHandPlayerPenaltyAbsorbed:
+					expectedTurnIndex = 1 - event.Hand.PlayerIndex
+				case game.EventHandPlayerDiscarded, game.EventHandPlayerDrewOne, game.EventHandPlayerPlayedNothing:
+					if expectedTurnIndex != -1 && event.Hand.PlayerIndex != expectedTurnIndex {
+						return fmt.Errorf("Expected player %v to take the turn, got %v", expectedTurnIndex,
+							event.Hand.PlayerIndex)
+					}
+					expectedTurnIndex = -1
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			} else if reverseSkips == 0 {
+				t.Fatal("Expected at least one reverse to skip")
+			}
+		})
+	}
+}
+
+func TestPartners(t *testing.T) {
+	for _, playerCount := range []int{4, 6} {
+		t.Run(fmt.Sprintf("%v players", playerCount), func(t *testing.T) {
+			r := game.DefaultRules()
+			r.Partners = true
+			err := runGameWithEvents(0, playerCount, r, func(event *game.Event) error {
+				if event.Type != game.EventHandEnd {
+					return nil
+				}
+				// Only cards held by the other team count
+				complete := event.HandComplete
+				score := 0
+				for i, cards := range complete.DeckReveal.PlayerCards() {
+					if (i-complete.WinnerIndex)%(playerCount/2) != 0 {
+						for _, card := range cards {
+							score += card.Score()
+						}
+					}
+				}
+				if score != complete.Score {
+					return fmt.Errorf("Expected score %v, got %v", score, complete.Score)
+				}
+				// Partners sit opposite each other and share the score
+				for i := 0; i < playerCount/2; i++ {
+					if event.PlayerScores[i] != event.PlayerScores[i+playerCount/2] {
+						return fmt.Errorf("Partners %v and %v have different scores", i, i+playerCount/2)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// timingOutPlayer is a practical player that times out on every third decision
+type timingOutPlayer struct {
+	*PracticalPlayer
+	decisions int
+}
+
+func (p *timingOutPlayer) timeOut() bool {
+	p.decisions++
+	return p.decisions%3 == 0
+}
+
+func (p *timingOutPlayer) ChooseColorSinceFirstCardIsWild() (game.CardColor, error) {
+	if p.timeOut() {
+		return 0, game.ErrTurnTimedOut
+	}
+	return p.PracticalPlayer.ChooseColorSinceFirstCardIsWild()
+}
+
+func (p *timingOutPlayer) Play() (*game.PlayerPlay, error) {
+	if p.timeOut() {
+		return nil, game.ErrTurnTimedOut
+	}
+	return p.PracticalPlayer.Play()
+}
+
+func (p *timingOutPlayer) ShouldChallengeWildDrawFour() (bool, error) {
+	if p.timeOut() {
+		return false, game.ErrTurnTimedOut
+	}
+	return p.PracticalPlayer.ShouldChallengeWildDrawFour()
+}
+
+func (p *timingOutPlayer) JumpIn() (*game.PlayerPlay, error) {
+	if p.timeOut() {
+		return nil, game.ErrTurnTimedOut
+	}
+	return p.PracticalPlayer.JumpIn()
+}
+
+func (p *timingOutPlayer) ChooseSwapTarget() (int, error) {
+	if p.timeOut() {
+		return 0, game.ErrTurnTimedOut
+	}
+	return p.PracticalPlayer.ChooseSwapTarget()
+}
+
+func TestTurnTimeouts(t *testing.T) {
+	drawUntilPlayable := game.DefaultRules()
+	drawUntilPlayable.DrawUntilPlayable = true
+	sevenOJumpIn := game.DefaultRules()
+	sevenOJumpIn.SevenO = true
+	sevenOJumpIn.JumpIn = true
+	allRules := map[string]game.Rules{
+		"default":             game.DefaultRules(),
+		"draw until playable": drawUntilPlayable,
+		"seven-o and jump-in": sevenOJumpIn,
+	}
+	for name, rules := range allRules {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(0); seed < 5; seed++ {
+				// A timed out play draws once unless the player already drew this turn
+				timeouts, timedOutIndex, drawsAllowed, drawerIndex, draws := 0, -1, 0, -1, 0
+				g, _ := newPracticalGame(seed, 4, rules, func(event *game.Event) error {
+					switch event.Type {
+					case game.EventHandPlayerTimedOut:
+						timeouts++
+						timedOutIndex, drawsAllowed = event.Hand.PlayerIndex, 1
+						if drawerIndex == timedOutIndex && draws > 0 {
+							drawsAllowed = 0
+						}
+					case game.EventHandPlayerDrewOne:
+						if event.Hand.PlayerIndex != drawerIndex {
+							drawerIndex, draws = event.Hand.PlayerIndex, 0
+						}
+						draws++
+						if drawerIndex == timedOutIndex {
+							if drawsAllowed--; drawsAllowed < 0 {
+								return fmt.Errorf("Player %v drew again after timing out", timedOutIndex)
+							}
+						}
+					default:
+						timedOutIndex, drawerIndex = -1, -1
+					}
+					return nil
+				}, func(p *PracticalPlayer) game.Player {
+					if p.Index == 0 {
+						return &timingOutPlayer{PracticalPlayer: p}
+					}
+					return p
+				})
+				if _, err := g.Play(0); err != nil {
+					t.Fatalf("Game with seed %v failed: %v", seed, err)
+				}
+				if timeouts == 0 {
+					t.Fatalf("Game with seed %v had no timeouts", seed)
+				}
+			}
+		})
+	}
+}
+
+// slowPlayer is a practical player that, when another player can jump in, doesn't play until its play is cancelled
+type slowPlayer struct {
+	*PracticalPlayer
+	cancelCh chan struct{}
+	// Set when the last play was cancelled
+	cancelled          bool
+	cardsWhenCancelled int
+}
+
+func (p *slowPlayer) Play() (*game.PlayerPlay, error) {
+	p.cancelled = false
+	if p.otherCanJumpIn() {
+		// A claim made before the play was started wins right away, but claims already taken in an earlier turn
+		// don't come again
+		select {
+		case <-p.cancelCh:
+			p.cancelled, p.cardsWhenCancelled = true, len(p.Cards)
+			return nil, game.ErrPlayCancelled
+		case <-time.After(100 * time.Millisecond):
+		}
 	}
-	// Begin
-	debugf("------- New game -------")
-	gameComplete, gameError := game.New(players, newDeck, logEventCb).Play(0)
-	if gameError != nil {
-		debugf("ERR: %v", gameError)
-		return gameError
+	return p.PracticalPlayer.Play()
+}
+
+func (p *slowPlayer) CancelPlay() {
+	select {
+	case p.cancelCh <- struct{}{}:
+	default:
+	}
+}
+
+func (p *slowPlayer) otherCanJumpIn() bool {
+	if p.TopDiscard.Wild() || p.DrawPenalty > 0 {
+		return false
+	}
+	for _, player := range p.AllPlayers {
+		if other, ok := player.(*PracticalPlayer); ok && other.identicalCardIndex() >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestJumpInCancelsPlay(t *testing.T) {
+	r := game.DefaultRules()
+	r.JumpIn, r.PlayDrawnCard = true, false
+	cancels := 0
+	for seed := int64(0); seed < 5; seed++ {
+		var slow *slowPlayer
+		// After a jump-in over a cancelled play, the next turn is the one after the jumper
+		expectedNextIndex := -1
+		g, _ := newPracticalGame(seed, 4, r, func(event *game.Event) error {
+			switch event.Type {
+			case game.EventHandPlayerDiscarded, game.EventHandPlayerDrewOne, game.EventHandPlayerPlayedNothing:
+				if slow.cancelled {
+					return fmt.Errorf("Cancelled player took their turn anyway")
+				} else if expectedNextIndex >= 0 && event.Hand.PlayerIndex != expectedNextIndex {
+					return fmt.Errorf("Expected player %v after jump-in, got %v", expectedNextIndex,
+						event.Hand.PlayerIndex)
+				}
+				expectedNextIndex = -1
+			case game.EventHandPlayerJumpedIn:
+				expectedNextIndex = -1
+				if !slow.cancelled {
+					break
+				}
+				slow.cancelled = false
+				cancels++
+				if event.Hand.PlayerIndex == slow.Index {
+					return fmt.Errorf("Cancelled player jumped in")
+				} else if event.Hand.PlayerCardsRemaining[slow.Index] != slow.cardsWhenCancelled {
+					return fmt.Errorf("Cancelled player lost a card")
+				}
+				// Only number cards leave the next player alone
+				if topCard := event.Hand.DiscardStack[len(event.Hand.DiscardStack)-1]; topCard.Value() <= 9 {
+					expectedNextIndex = (event.Hand.PlayerIndex + 1) % 4
+					if !event.Hand.Forward {
+						expectedNextIndex = (event.Hand.PlayerIndex + 3) % 4
+					}
+				}
+			case game.EventHandEnd:
+				expectedNextIndex = -1
+			}
+			return nil
+		}, func(p *PracticalPlayer) game.Player {
+			if p.Index == 0 {
+				slow = &slowPlayer{PracticalPlayer: p, cancelCh: make(chan struct{}, 1)}
+				return slow
+			}
+			return p
+		})
+		if _, err := g.Play(0); err != nil {
+			t.Fatalf("Game with seed %v failed: %v", seed, err)
+		}
+	}
+	if cancels == 0 {
+		t.Fatal("Expected a jump-in to cancel a play")
+	}
+}
+
+func TestScoring(t *testing.T) {
+	tests := map[string]struct {
+		applyRules func(*game.Rules)
+		// Given the winner, the player, and the player's cards, the expected score delta for the player's cards
+		cardDelta func(winnerIndex, playerIndex, cardsIndex int, cardsScore int) int
+		// Given the hand count and the final scores, whether the game ended properly
+		gameOver func(hands int, scores []int) bool
+	}{
+		"standard": {
+			applyRules: func(r *game.Rules) {},
+			cardDelta: func(winnerIndex, playerIndex, cardsIndex int, cardsScore int) int {
+				if playerIndex == winnerIndex {
+					return cardsScore
+				}
+				return 0
+			},
+			gameOver: func(hands int, scores []int) bool { return maxScore(scores) >= 500 },
+		},
+		"penalty": {
+			applyRules: func(r *game.Rules) { r.TargetScore, r.Scoring = 200, game.ScoringPenalty },
+			cardDelta: func(winnerIndex, playerIndex, cardsIndex int, cardsScore int) int {
+				if playerIndex == cardsIndex {
+					return cardsScore
+				}
+				return 0
+			},
+			gameOver: func(hands int, scores []int) bool { return maxScore(scores) >= 200 },
+		},
+		"fixed hands": {
+			applyRules: func(r *game.Rules) { r.TargetScore, r.Scoring, r.HandCount = 1, game.ScoringFixedHands, 4 },
+			cardDelta: func(winnerIndex, playerIndex, cardsIndex int, cardsScore int) int {
+				if playerIndex == winnerIndex {
+					return cardsScore
+				}
+				return 0
+			},
+			gameOver: func(hands int, scores []int) bool { return hands == 4 },
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := game.DefaultRules()
+			test.applyRules(&r)
+			hands := 0
+			err := runGameWithEvents(0, 4, r, func(event *game.Event) error {
+				switch event.Type {
+				case game.EventHandEnd:
+					hands++
+					complete := event.HandComplete
+					for i, delta := range complete.ScoreDeltas {
+						expected := 0
+						for j, cards := range complete.DeckReveal.PlayerCards() {
+							cardsScore := 0
+							for _, card := range cards {
+								cardsScore += card.Score()
+							}
+							expected += test.cardDelta(complete.WinnerIndex, i, j, cardsScore)
+						}
+						if delta != expected {
+							return fmt.Errorf("Expected player %v delta of %v, got %v", i, expected, delta)
+						}
+					}
+				case game.EventGameEnd:
+					if !test.gameOver(hands, event.PlayerScores) {
+						return fmt.Errorf("Game ended early after %v hands with scores %v", hands, event.PlayerScores)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	// Penalty scoring has the lowest score win, ties included
+	r := game.Rules{Scoring: game.ScoringPenalty}
+	if winners := r.ScoringPolicy(4).GameWinners([]int{30, 10, 250, 10}); fmt.Sprint(winners) != "[1 3]" {
+		t.Fatalf("Expected winners [1 3], got %v", winners)
+	}
+}
+
+func maxScore(scores []int) int {
+	max := 0
+	for _, score := range scores {
+		if score > max {
+			max = score
+		}
+	}
+	return max
+}
+
+func TestHandAnalysis(t *testing.T) {
+	// Red 3, yellow 3, green 1, wild, and wild draw four
+	cards := []game.Card{3, 28, 51, 100, 104}
+	green3, greenDrawTwo := game.Card(53), game.Card(73)
+	if plays := game.LegalPlays(cards, green3, game.ColorUnknown, 0); len(plays) != 11 {
+		t.Fatalf("Expected 3 plays and 4 colors for each wild, got %v", plays)
+	}
+	// Only a wild draw four can be stacked on a draw two from these
+	stacks := game.LegalPlays(cards, greenDrawTwo, game.ColorUnknown, 2)
+	if len(stacks) != 4 {
+		t.Fatalf("Expected wild draw four in 4 colors, got %v", stacks)
+	}
+	for _, play := range stacks {
+		if play.Card != 104 || !play.WildColor.Valid() {
+			t.Fatalf("Unexpected stack %v", play)
+		}
+	}
+	// Challengeable when holding the current color, including the color chosen for a wild
+	if !game.WildDrawFourChallengeable(cards, green3, game.ColorUnknown) {
+		t.Fatal("Expected challengeable with a green card")
+	} else if game.WildDrawFourChallengeable(cards, 100, game.ColorBlue) {
+		t.Fatal("Expected not challengeable without a blue card")
+	} else if !game.WildDrawFourChallengeable(cards, 100, game.ColorYellow) {
+		t.Fatal("Expected challengeable with a yellow card")
+	}
+	if color := game.MostCommonColor([]game.Card{3, 28, 29, 100}); color != game.ColorYellow {
+		t.Fatalf("Expected yellow, got %v", color)
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	r := game.DefaultRules()
+	r.DrawUntilPlayable, r.PlayDrawnCard = true, false
+	if _, err := game.New(nil, r, nil, nil).Play(0); err == nil {
+		t.Fatal("Expected invalid rules error")
+	}
+	// Partners need an even number of players
+	r = game.DefaultRules()
+	r.Partners = true
+	if _, err := game.New(make([]game.Player, 5), r, nil, nil).Play(0); err == nil {
+		t.Fatal("Expected invalid partners error")
+	}
+}
+
+func runGame(seed int64, playerCount int, rules game.Rules) error {
+	return runGameWithEvents(seed, playerCount, rules, nil)
+}
+
+// runGameWithEvents runs a game, logging every event and passing it to the optional eventCb which can fail the game
+func runGameWithEvents(seed int64, playerCount int, rules game.Rules, eventCb func(*game.Event) error) error {
+	debugf("------- New game with seed %v -------", seed)
+	gameComplete, err := Run(seed, playerCount, rules, func(event *game.Event) error {
+		debugf("Event: %v - Hand: %v", event, event.Hand)
+		if eventCb != nil {
+			return eventCb(event)
+		}
+		return nil
+	})
+	if err != nil {
+		debugf("ERR: %v", err)
+		return err
 	}
 	debugf("END: %v", gameComplete)
 	return nil
@@ -2,7 +2,6 @@ package gametest
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/cretz/one-left/oneleft/game"
 )
@@ -10,13 +9,15 @@ import (
 type HandState struct {
 	TopDiscard          game.Card
 	TopDiscardWildColor game.CardColor
+	// Updated from events
+	DrawPenalty int
 }
 
 type PracticalPlayer struct {
 	*HandState
 	Index      int
 	AllPlayers []game.Player
-	// Randomized
+	// In the order dealt
 	Cards []game.Card
 }
 
@@ -24,45 +25,53 @@ func (p *PracticalPlayer) CardsRemaining() int {
 	return len(p.Cards)
 }
 
-func (p *PracticalPlayer) mostPopularColor() game.CardColor {
-	countsByColor := map[game.CardColor]int{}
-	maxCountSoFar := 0
-	maxColorSoFar := game.ColorRed
-	for _, card := range p.Cards {
-		if color := card.Color(); color != game.ColorUnknown {
-			countsByColor[color]++
-			if count := countsByColor[color]; count > maxCountSoFar {
-				maxColorSoFar = color
-			}
-		}
-	}
-	return maxColorSoFar
+func (p *PracticalPlayer) HeldCards() []game.Card {
+	return p.Cards
+}
+
+func (p *PracticalPlayer) ReceiveCard(card game.Card) error {
+	p.Cards = append(p.Cards, card)
+	return nil
+}
+
+func (p *PracticalPlayer) ReceiveHand(fromIndex int, cards []game.Card) error {
+	p.Cards = cards
+	return nil
 }
 
 func (p *PracticalPlayer) ChooseColorSinceFirstCardIsWild() (game.CardColor, error) {
-	p.TopDiscardWildColor = p.mostPopularColor()
+	p.TopDiscardWildColor = game.MostCommonColor(p.Cards)
 	return p.TopDiscardWildColor, nil
 }
 
 func (p *PracticalPlayer) Play() (*game.PlayerPlay, error) {
-	// Try same color or symbol, then try any wild, then draw
-	for cardIndex, card := range p.Cards {
-		if !card.Wild() && card.CanPlayOn(p.TopDiscard, p.TopDiscardWildColor) {
-			p.TopDiscard = card
-			p.TopDiscardWildColor = game.ColorUnknown
-			p.Cards = append(p.Cards[:cardIndex], p.Cards[cardIndex+1:]...)
-			return &game.PlayerPlay{Card: card}, nil
+	// Try same color or symbol, then try any wild as the most common color, then draw
+	wildColor := game.MostCommonColor(p.Cards)
+	var chosen *game.PlayerPlay
+	for _, play := range game.LegalPlays(p.Cards, p.TopDiscard, p.TopDiscardWildColor, p.DrawPenalty) {
+		play := play
+		if !play.Card.Wild() {
+			chosen = &play
+			break
+		} else if chosen == nil && play.WildColor == wildColor {
+			chosen = &play
 		}
 	}
+	if chosen == nil {
+		return &game.PlayerPlay{Card: game.NoCard}, nil
+	}
+	p.TopDiscard = chosen.Card
+	p.TopDiscardWildColor = game.ColorUnknown
+	if chosen.Card.Wild() {
+		p.TopDiscardWildColor = chosen.WildColor
+	}
 	for cardIndex, card := range p.Cards {
-		if card.Wild() {
-			p.TopDiscard = card
-			p.TopDiscardWildColor = p.mostPopularColor()
+		if card == chosen.Card {
 			p.Cards = append(p.Cards[:cardIndex], p.Cards[cardIndex+1:]...)
-			return &game.PlayerPlay{Card: card, WildColor: p.TopDiscardWildColor}, nil
+			break
 		}
 	}
-	return &game.PlayerPlay{Card: game.NoCard}, nil
+	return chosen, nil
 }
 
 func (p *PracticalPlayer) ShouldChallengeWildDrawFour() (bool, error) {
@@ -77,10 +86,50 @@ func (p *PracticalPlayer) ChallengedWildDrawFour(challengerIndex int) (bool, err
 }
 
 func (p *PracticalPlayer) SetOneLeftCallback(justGotOneLeftIndex int, callOneLeft func(target int)) {
-	// If it's me do it now, otherwise wait 3 seconds
+	// Only call it on myself, since a call on someone else could come before or after their next play
 	if justGotOneLeftIndex == p.Index {
 		callOneLeft(justGotOneLeftIndex)
-	} else {
-		time.AfterFunc(3*time.Second, func() { callOneLeft(justGotOneLeftIndex) })
 	}
 }
+
+func (p *PracticalPlayer) SetJumpInCallback(jumpIn func()) {
+	// Claim right away if there is a card to jump in with
+	if p.identicalCardIndex() >= 0 {
+		jumpIn()
+	}
+}
+
+func (p *PracticalPlayer) JumpIn() (*game.PlayerPlay, error) {
+	cardIndex := p.identicalCardIndex()
+	if cardIndex == -1 {
+		return &game.PlayerPlay{Card: game.NoCard}, nil
+	}
+	card := p.Cards[cardIndex]
+	p.TopDiscard = card
+	p.TopDiscardWildColor = game.ColorUnknown
+	p.Cards = append(p.Cards[:cardIndex], p.Cards[cardIndex+1:]...)
+	return &game.PlayerPlay{Card: card}, nil
+}
+
+func (p *PracticalPlayer) identicalCardIndex() int {
+	for cardIndex, card := range p.Cards {
+		if !card.Wild() && card.Identical(p.TopDiscard) {
+			return cardIndex
+		}
+	}
+	return -1
+}
+
+// CancelPlay does nothing since plays are decided right away
+func (p *PracticalPlayer) CancelPlay() {}
+
+func (p *PracticalPlayer) ChooseSwapTarget() (int, error) {
+	// Take the smallest hand
+	target := -1
+	for i, player := range p.AllPlayers {
+		if i != p.Index && (target == -1 || player.CardsRemaining() < p.AllPlayers[target].CardsRemaining()) {
+			target = i
+		}
+	}
+	return target, nil
+}
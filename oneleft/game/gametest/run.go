@@ -0,0 +1,89 @@
+package gametest
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cretz/one-left/oneleft/game"
+)
+
+// Run plays a game between practical players with every deck shuffled from the seed, so the same seed, player count,
+// and rules always play the same game. Every event is passed to the optional eventCb, which can fail the game. The
+// error includes the seed to play a failed game again with.
+func Run(seed int64, playerCount int, rules game.Rules, eventCb func(*game.Event) error) (*game.GameComplete, error) {
+	return RunWithSnapshots(seed, playerCount, rules, eventCb, nil)
+}
+
+// RunWithSnapshots is Run with every snapshot of the game passed to the optional snapshotCb, which can fail the game
+func RunWithSnapshots(
+	seed int64,
+	playerCount int,
+	rules game.Rules,
+	eventCb func(*game.Event) error,
+	snapshotCb func(*game.Snapshot) error,
+) (*game.GameComplete, error) {
+	g, _ := newPracticalGame(seed, playerCount, rules, eventCb, nil)
+	if snapshotCb != nil {
+		g.SetSnapshotCallback(snapshotCb)
+	}
+	complete, gameErr := g.Play(0)
+	if gameErr != nil {
+		return nil, fmt.Errorf("Game with seed %v failed: %v", seed, gameErr)
+	}
+	return complete, nil
+}
+
+// Resume continues the game in the snapshot between practical players with every new deck shuffled from the seed.
+// The error includes the seed to resume a failed game again with.
+func Resume(seed int64, snapshot *game.Snapshot, eventCb func(*game.Event) error) (*game.GameComplete, error) {
+	g, handState := newPracticalGame(seed, len(snapshot.PlayerScores), snapshot.Rules, eventCb, nil)
+	// The players only learn the discard from events, so catch them up
+	if hand := snapshot.Hand; hand != nil && len(hand.Discard) > 0 {
+		handState.TopDiscard = hand.Discard[len(hand.Discard)-1]
+		handState.TopDiscardWildColor = hand.LastWildColor
+		handState.DrawPenalty = hand.DrawPenalty
+	}
+	complete, gameErr := g.Resume(snapshot)
+	if gameErr != nil {
+		return nil, fmt.Errorf("Game resumed with seed %v failed: %v", seed, gameErr)
+	}
+	return complete, nil
+}
+
+// newPracticalGame creates a game between practical players, each passed to the optional wrapPlayer to change how
+// they play
+func newPracticalGame(
+	seed int64,
+	playerCount int,
+	rules game.Rules,
+	eventCb func(*game.Event) error,
+	wrapPlayer func(*PracticalPlayer) game.Player,
+) (*game.Game, *HandState) {
+	r := rand.New(rand.NewSource(seed))
+	handState := &HandState{}
+	players := make([]game.Player, playerCount)
+	holders := make([]game.CardHolder, playerCount)
+	for i := range players {
+		player := &PracticalPlayer{HandState: handState, Index: i, AllPlayers: players}
+		players[i], holders[i] = player, player
+		if wrapPlayer != nil {
+			players[i] = wrapPlayer(player)
+		}
+	}
+	newDeck := func() (game.CardDeck, error) { return game.NewMemoryDeck(r, holders), nil }
+	// Keep the players up to date with the top of the discard, which is set before any play in a new hand
+	stateEventCb := func(event *game.Event) error {
+		if hand := event.Hand; hand != nil {
+			handState.DrawPenalty = hand.DrawPenalty
+			if len(hand.DiscardStack) > 0 {
+				handState.TopDiscard = hand.DiscardStack[len(hand.DiscardStack)-1]
+				handState.TopDiscardWildColor = hand.LastDiscardWildColor
+			}
+		}
+		if eventCb != nil {
+			return eventCb(event)
+		}
+		return nil
+	}
+	return game.New(players, rules, newDeck, stateEventCb), handState
+}
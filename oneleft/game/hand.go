@@ -7,6 +7,12 @@ type hand struct {
 	discard       []Card
 	lastWildColor CardColor
 	forward       bool
+	// Only used when draw cards are stacked
+	drawPenalty int
+	// While a play is outstanding, the player may remove the card from its count before it reaches the discard, so
+	// events sent in the meantime use the count from before the play
+	playOutstanding bool
+	cardsBeforePlay int
 }
 
 type oneLeftCall struct {
@@ -16,41 +22,88 @@ type oneLeftCall struct {
 
 type HandComplete struct {
 	WinnerIndex int
-	Score       int
+	// The points of the cards left in the hands of players not on the winner's team
+	Score int
+	// How much the hand changed each player's score according to the scoring policy
+	ScoreDeltas []int
 	DeckReveal  CardDeckHandCompleteReveal
 }
 
 func (h *hand) play() (*HandComplete, *GameError) {
-	// Shuffle and go around table dealing cards
-	if err := h.shuffleAndDeal(); err != nil {
-		return nil, err
-	}
-	// Dealer was dealt last, move to next
-	h.moveNextPlayer()
-	// Discard top
-	if err := h.createDiscardWithFirstCard(); err != nil {
-		return nil, err
+	for {
+		// Shuffle and go around table dealing cards
+		if err := h.shuffleAndDeal(); err != nil {
+			return nil, err
+		}
+		// Dealer was dealt last, move to next
+		h.moveNextPlayer()
+		// Discard top, which may require dealing again
+		if redeal, err := h.createDiscardWithFirstCard(); err != nil {
+			return nil, err
+		} else if !redeal {
+			break
+		}
+		h.playerIndex = h.game.dealerIndex
+		h.discard = nil
 	}
-	playerIndexJustGotOneLeft := -1
-	oneLeftCallbackChan := h.resetOneLeftCallbacks(-1)
+	return h.playTurns(-1)
+}
+
+// playTurns plays until the hand is complete, starting with the given player open to a one-left call if not -1
+func (h *hand) playTurns(playerIndexJustGotOneLeft int) (*HandComplete, *GameError) {
+	oneLeftCallbackChan := h.resetOneLeftCallbacks(playerIndexJustGotOneLeft)
+	jumpInChan := h.resetJumpInCallbacks()
 	// Main game loop
 	for {
-		// Do play or one-left call, whichever first
+		// Each turn starts at a point the game can be resumed from
+		if err := h.game.sendSnapshot(h, playerIndexJustGotOneLeft); err != nil {
+			return nil, err
+		}
+		// Do play, one-left call, or jump-in claim, whichever first
+		jumpInIndex := -1
 		playCh := make(chan *PlayerPlay, 1)
 		errCh := make(chan error, 1)
-		go func() {
-			if play, err := h.currentPlayer().Play(); err != nil {
-				errCh <- err
-			} else {
-				playCh <- play
-			}
-		}()
+		startPlay := func() {
+			h.playOutstanding, h.cardsBeforePlay = true, h.currentPlayer().CardsRemaining()
+			go func() {
+				if play, err := h.currentPlayer().Play(); err != nil {
+					errCh <- err
+				} else {
+					playCh <- play
+				}
+			}()
+		}
 		var play *PlayerPlay
+		var call *oneLeftCall
+		// A timed out player draws once and passes
+		timedOut := false
+		// Calls already made are handled before asking for the play so the result doesn't depend on how fast the
+		// player plays. Players can call right in the callback, like in-memory players do, and a seeded game must play
+		// the same every time.
 		select {
-		case call := <-oneLeftCallbackChan:
+		case c := <-oneLeftCallbackChan:
+			call = &c
+		default:
+			startPlay()
+			select {
+			case c := <-oneLeftCallbackChan:
+				call = &c
+			case jumpInIndex = <-jumpInChan:
+				// Cancels the play if it can be jumped in on, checked below
+			case play = <-playCh:
+				// All good, do nothing
+			case err := <-errCh:
+				var gameErr *GameError
+				if play, gameErr = h.timedOutPlay(err); gameErr != nil {
+					return nil, gameErr
+				}
+				timedOut = true
+			}
+		}
+		if call != nil {
 			// If it's called on a pending one-left, check it
 			if call.targetIndex == playerIndexJustGotOneLeft {
-				if err := h.sendCalledOneLeftEvent(call); err != nil {
+				if err := h.sendCalledOneLeftEvent(*call); err != nil {
 					return nil, err
 				}
 				// If it wasn't the one with one left, it's a penalty
@@ -64,7 +117,7 @@ func (h *hand) play() (*HandComplete, *GameError) {
 				}
 			} else if call.callerIndex == call.targetIndex && h.game.players[call.callerIndex].CardsRemaining() != 1 {
 				// It was called for myself out of turn when I didn't have one left
-				if err := h.sendCalledOneLeftEvent(call); err != nil {
+				if err := h.sendCalledOneLeftEvent(*call); err != nil {
 					return nil, err
 				}
 				if err := h.playerDraw(2, call.callerIndex); err != nil {
@@ -74,37 +127,88 @@ func (h *hand) play() (*HandComplete, *GameError) {
 					return nil, err
 				}
 			}
-		case play = <-playCh:
-			// All good, do nothing
-		case err := <-errCh:
-			return nil, h.playerErrorf("Failure to play: %v", err)
 		}
 		// Reset one left if there was a player with it
 		if playerIndexJustGotOneLeft >= 0 {
 			playerIndexJustGotOneLeft = -1
 			oneLeftCallbackChan = h.resetOneLeftCallbacks(playerIndexJustGotOneLeft)
 		}
-		// If play was not set because one-left was called, wait for it
-		if play == nil {
+		// If play was not set because one-left was called or a jump-in was claimed, wait for it. A jump-in claimed
+		// while the play is outstanding wins unless the player already played a card.
+		jumpedIn := false
+		for play == nil {
+			if jumpInIndex >= 0 && h.canJumpIn(jumpInIndex) {
+				var gameErr *GameError
+				play, jumpedIn, timedOut, gameErr = h.jumpInOverPlay(jumpInIndex, playCh, errCh)
+				if gameErr != nil {
+					return nil, gameErr
+				}
+				jumpInIndex = -1
+				continue
+			}
+			if !h.playOutstanding {
+				startPlay()
+			}
 			select {
+			case jumpInIndex = <-jumpInChan:
+				// Checked above
 			case play = <-playCh:
 				// All good, do nothing
 			case err := <-errCh:
-				return nil, h.playerErrorf("Failure to play: %v", err)
+				var gameErr *GameError
+				if play, gameErr = h.timedOutPlay(err); gameErr != nil {
+					return nil, gameErr
+				}
+				timedOut = true
+			}
+		}
+		h.playOutstanding = false
+		// If the player isn't playing a card, someone that claimed a jump-in plays instead
+		if !jumpedIn && play.Card == NoCard && jumpInChan != nil {
+			if jumpInIndex == -1 {
+				select {
+				case jumpInIndex = <-jumpInChan:
+				default:
+				}
+			}
+			if jumpInIndex >= 0 {
+				if jumpInPlay, err := h.jumpIn(jumpInIndex); err != nil {
+					return nil, err
+				} else if jumpInPlay != nil {
+					h.playerIndex, play, jumpedIn = jumpInIndex, jumpInPlay, true
+				}
+			}
+		}
+		// With a draw penalty, the player takes it all instead of drawing if not stacking on it
+		if h.drawPenalty > 0 && play.Card == NoCard {
+			if err := h.absorbDrawPenalty(); err != nil {
+				return nil, err
 			}
+			h.moveNextPlayer()
+			continue
 		}
-		// Draw if necessary
-		if play.Card == NoCard {
+		// Draw if necessary, once or until something is played depending on the rules
+		for drew := false; play.Card == NoCard && h.canDraw(); drew = true {
+			if drew && (!h.game.rules.DrawUntilPlayable || timedOut) {
+				break
+			}
 			if err := h.draw(1); err != nil {
 				return nil, err
 			}
 			if err := h.sendEvent(EventHandPlayerDrewOne); err != nil {
 				return nil, err
 			}
-			// Let the player try again to play it
+			// Let the player try again to play if allowed
+			if !h.game.rules.PlayDrawnCard || timedOut {
+				break
+			}
 			var err error
 			if play, err = h.currentPlayer().Play(); err != nil {
-				return nil, h.playerErrorf("Failure to play: %v", err)
+				var gameErr *GameError
+				if play, gameErr = h.timedOutPlay(err); gameErr != nil {
+					return nil, gameErr
+				}
+				timedOut = true
 			}
 		}
 		if err := play.AssertValid(); err != nil {
@@ -115,6 +219,8 @@ func (h *hand) play() (*HandComplete, *GameError) {
 			}
 		} else if !play.Card.CanPlayOn(h.topCard(), h.lastWildColor) {
 			return nil, h.playerErrorf("Invalid card, tried to play %v on %v", play.Card, h.topCard())
+		} else if h.drawPenalty > 0 && !play.Card.CanStackOn(h.topCard()) {
+			return nil, h.playerErrorf("Invalid card, tried to stack %v on %v", play.Card, h.topCard())
 		} else {
 			// Otherwise, handle discard
 			h.discard = append(h.discard, play.Card)
@@ -124,9 +230,15 @@ func (h *hand) play() (*HandComplete, *GameError) {
 				playerIndexJustGotOneLeft = h.playerIndex
 				oneLeftCallbackChan = h.resetOneLeftCallbacks(playerIndexJustGotOneLeft)
 			}
-			if err := h.sendEvent(EventHandPlayerDiscarded); err != nil {
+			discardEvent := EventHandPlayerDiscarded
+			if jumpedIn {
+				discardEvent = EventHandPlayerJumpedIn
+			}
+			if err := h.sendEvent(discardEvent); err != nil {
 				return nil, err
 			}
+			// A new top card means new jump-in claims
+			jumpInChan = h.resetJumpInCallbacks()
 			// Handle play
 			switch play.Card.Value() {
 			case Skip:
@@ -135,11 +247,25 @@ func (h *hand) play() (*HandComplete, *GameError) {
 					return nil, err
 				}
 			case Reverse:
+				// With two players, reverse is a skip so the player goes again
+				if h.twoPlayer() {
+					h.moveNextPlayer()
+					if err := h.sendEvent(EventHandPlayerReverseSkipped); err != nil {
+						return nil, err
+					}
+					break
+				}
 				h.forward = !h.forward
 				if err := h.sendEvent(EventHandPlayReversed); err != nil {
 					return nil, err
 				}
 			case DrawTwo:
+				if h.game.rules.StackDrawCards {
+					if err := h.stackDrawPenalty(2); err != nil {
+						return nil, err
+					}
+					break
+				}
 				h.moveNextPlayer()
 				if err := h.draw(2); err != nil {
 					return nil, err
@@ -148,11 +274,24 @@ func (h *hand) play() (*HandComplete, *GameError) {
 					return nil, err
 				}
 			case WildDrawFour:
-				// Before moving, we need to see if they want to challenge
-				if challenge, err := h.peekNextPlayer().ShouldChallengeWildDrawFour(); err != nil {
+				if h.game.rules.StackDrawCards {
+					if err := h.stackDrawPenalty(4); err != nil {
+						return nil, err
+					}
+					break
+				}
+				// Before moving, we need to see if they want to challenge, which they don't if they time out
+				challenge, err := h.peekNextPlayer().ShouldChallengeWildDrawFour()
+				if err == ErrTurnTimedOut {
+					if err := h.sendPlayerEvent(EventHandPlayerTimedOut, h.peekNextPlayerIndex()); err != nil {
+						return nil, err
+					}
+					challenge = false
+				} else if err != nil {
 					h.moveNextPlayer()
 					return nil, h.playerErrorf("Failed checking draw four challenge: %v", err)
-				} else if !challenge {
+				}
+				if !challenge {
 					h.moveNextPlayer()
 					if err := h.draw(4); err != nil {
 						return nil, err
@@ -184,6 +323,19 @@ func (h *hand) play() (*HandComplete, *GameError) {
 						return nil, err
 					}
 				}
+			case 7, 0:
+				if fromIndexes, err := h.moveHands(play.Card.Value()); err != nil {
+					return nil, err
+				} else if fromIndexes != nil && playerIndexJustGotOneLeft >= 0 {
+					// The one left call is now on whoever got the hand
+					for i, fromIndex := range fromIndexes {
+						if fromIndex == playerIndexJustGotOneLeft {
+							playerIndexJustGotOneLeft = i
+							break
+						}
+					}
+					oneLeftCallbackChan = h.resetOneLeftCallbacks(playerIndexJustGotOneLeft)
+				}
 			}
 		}
 		h.moveNextPlayer()
@@ -203,7 +355,7 @@ func (h *hand) shuffleAndDeal() *GameError {
 		return err
 	}
 	// Deal to all players
-	for i := 0; i < 7; i++ {
+	for i := 0; i < h.game.rules.HandSize; i++ {
 		for j := 0; j < len(h.game.players); j++ {
 			h.moveNextPlayer()
 			if err := h.draw(1); err != nil {
@@ -217,69 +369,94 @@ func (h *hand) shuffleAndDeal() *GameError {
 	return nil
 }
 
-func (h *hand) createDiscardWithFirstCard() *GameError {
+// createDiscardWithFirstCard returns true if the cards need to be dealt again
+func (h *hand) createDiscardWithFirstCard() (bool, *GameError) {
 	// Put top card of deck on discard
 	for {
 		topCard, err := h.deck.PopForFirstDiscard()
 		if err != nil {
-			return Errorf("Unable to put top deck card on discard pile: %v", err)
+			return false, Errorf("Unable to put top deck card on discard pile: %v", err)
 		}
 		h.discard = append(h.discard, topCard)
 		// Action cards have effects at the beginning
 		switch v := topCard.Value(); v {
 		case Skip:
 			if err := h.sendEvent(EventHandStartTopCardAddedToDiscard); err != nil {
-				return err
+				return false, err
 			}
 			if err := h.sendEvent(EventHandPlayerSkipped); err != nil {
-				return err
+				return false, err
 			}
 			h.moveNextPlayer()
 		case DrawTwo:
 			if err := h.sendEvent(EventHandStartTopCardAddedToDiscard); err != nil {
-				return err
+				return false, err
 			}
 			if err := h.draw(2); err != nil {
-				return err
+				return false, err
 			}
 			if err := h.sendEvent(EventHandPlayerDrewTwo); err != nil {
-				return err
+				return false, err
 			}
 			h.moveNextPlayer()
 		case Reverse:
 			if err := h.sendEvent(EventHandStartTopCardAddedToDiscard); err != nil {
-				return err
+				return false, err
+			}
+			// With two players, reverse is a skip so the dealer goes first
+			if h.twoPlayer() {
+				if err := h.sendEvent(EventHandPlayerReverseSkipped); err != nil {
+					return false, err
+				}
+				h.moveNextPlayer()
+				break
 			}
 			h.forward = !h.forward
 			if err := h.sendEvent(EventHandPlayReversed); err != nil {
-				return err
+				return false, err
 			}
 		case Wild:
-			// Wild means first player gets to choose
-			if h.lastWildColor, err = h.currentPlayer().ChooseColorSinceFirstCardIsWild(); err != nil {
-				return h.playerErrorf("Failure to get color for first wild: %v", err)
+			// Wild means first player gets to choose, and it's red if they take too long
+			timedOut := false
+			if h.lastWildColor, err = h.currentPlayer().ChooseColorSinceFirstCardIsWild(); err == ErrTurnTimedOut {
+				h.lastWildColor, timedOut = ColorRed, true
+			} else if err != nil {
+				return false, h.playerErrorf("Failure to get color for first wild: %v", err)
 			} else if !h.lastWildColor.Valid() {
-				return h.playerErrorf("Invalid color value %v for first wild: %v", h.lastWildColor, err)
+				return false, h.playerErrorf("Invalid color value %v for first wild: %v", h.lastWildColor, err)
 			}
-			// Do this after the color is selected
+			// Do this after the color is selected, with the timeout after so it has the color too
 			if err := h.sendEvent(EventHandStartTopCardAddedToDiscard); err != nil {
-				return err
+				return false, err
+			} else if timedOut {
+				if err := h.sendEvent(EventHandPlayerTimedOut); err != nil {
+					return false, err
+				}
 			}
 		case WildDrawFour:
-			// Can't be wild draw four
+			// Can't be wild draw four, so either put another on top or deal again
 			if err := h.sendEvent(EventHandStartTopCardAddedToDiscard); err != nil {
-				return err
+				return false, err
+			}
+			if h.game.rules.FirstWildDrawFour == FirstWildDrawFourReshuffle {
+				return true, nil
 			}
 			continue
 		default:
 			if err := h.sendEvent(EventHandStartTopCardAddedToDiscard); err != nil {
-				return err
+				return false, err
 			}
 		}
-		return nil
+		return false, nil
 	}
 }
 
+// twoPlayer is true when the heads-up rules apply. Skips, draw twos, and wild draw fours already give the player that
+// played them another turn since the other player is skipped, but reverses need to be made skips.
+func (h *hand) twoPlayer() bool {
+	return len(h.game.players) == 2
+}
+
 func (h *hand) peekNextPlayerIndex() int {
 	if h.forward {
 		if h.playerIndex == len(h.game.players)-1 {
@@ -317,6 +494,34 @@ func (h *hand) currentPlayer() Player {
 	return h.game.players[h.playerIndex]
 }
 
+// stackDrawPenalty adds to the penalty the next player has to stack on or draw
+func (h *hand) stackDrawPenalty(amount int) *GameError {
+	h.drawPenalty += amount
+	if err := h.sendEvent(EventHandPlayerPenaltyStacked); err != nil {
+		return err
+	}
+	// If that was the last card, nobody can stack anymore so the next player takes it now
+	if h.currentPlayer().CardsRemaining() == 0 {
+		h.moveNextPlayer()
+		return h.absorbDrawPenalty()
+	}
+	return nil
+}
+
+// absorbDrawPenalty makes the current player draw the entire penalty
+func (h *hand) absorbDrawPenalty() *GameError {
+	if err := h.draw(h.drawPenalty); err != nil {
+		return err
+	}
+	h.drawPenalty = 0
+	return h.sendEvent(EventHandPlayerPenaltyAbsorbed)
+}
+
+// canDraw is false if there are no cards in the deck and none to reshuffle into it
+func (h *hand) canDraw() bool {
+	return h.deck.CardsRemaining() > 0 || len(h.discard) > 1
+}
+
 func (h *hand) draw(amount int) *GameError {
 	return h.playerDraw(amount, h.playerIndex)
 }
@@ -337,6 +542,9 @@ func (h *hand) playerDraw(amount int, playerIndex int) *GameError {
 		if err := h.deck.DealTo(playerIndex); err != nil {
 			return h.playerErrorf("Failed dealing: %v", err)
 		}
+		if h.playOutstanding && playerIndex == h.playerIndex {
+			h.cardsBeforePlay++
+		}
 	}
 	return nil
 }
@@ -360,11 +568,16 @@ func (h *hand) checkComplete() (*HandComplete, *GameError) {
 	if complete.DeckReveal, err = h.deck.CompleteHand(); err != nil {
 		return nil, Errorf("Failed revealing deck: %v", err)
 	}
-	for _, cards := range complete.DeckReveal.PlayerCards() {
+	// Partners of the winner don't count against their own team
+	for index, cards := range complete.DeckReveal.PlayerCards() {
+		if h.game.rules.Teammates(index, complete.WinnerIndex, len(h.game.players)) {
+			continue
+		}
 		for _, card := range cards {
 			complete.Score += card.Score()
 		}
 	}
+	complete.ScoreDeltas = h.game.scoring.HandScoreDeltas(complete.WinnerIndex, complete.DeckReveal.PlayerCards())
 	return complete, nil
 }
 
@@ -379,6 +592,147 @@ func (h *hand) resetOneLeftCallbacks(hasOneLeftIndex int) chan oneLeftCall {
 	return ret
 }
 
+// resetJumpInCallbacks lets every player claim a jump-in on the current top card. The channel is nil when jumping in is
+// not allowed.
+func (h *hand) resetJumpInCallbacks() chan int {
+	if !h.game.rules.JumpIn {
+		return nil
+	}
+	ret := make(chan int, len(h.game.players))
+	for i, player := range h.game.players {
+		playerIndex := i
+		player.SetJumpInCallback(func() {
+			// Only the first claim from each player can matter, so don't block on others
+			select {
+			case ret <- playerIndex:
+			default:
+			}
+		})
+	}
+	return ret
+}
+
+// jumpInOverPlay cancels the current player's outstanding play for the jump-in, unless they already played a card. It
+// returns a nil play if the jump-in was withdrawn after cancelling the play, so the current player needs to play again.
+func (h *hand) jumpInOverPlay(
+	playerIndex int, playCh chan *PlayerPlay, errCh chan error,
+) (play *PlayerPlay, jumpedIn bool, timedOut bool, gameErr *GameError) {
+	h.currentPlayer().CancelPlay()
+	cancelled := false
+	select {
+	case play = <-playCh:
+		// Played or chose to draw before the cancel
+	case err := <-errCh:
+		if err == ErrPlayCancelled {
+			play, cancelled = &PlayerPlay{Card: NoCard}, true
+		} else if play, gameErr = h.timedOutPlay(err); gameErr != nil {
+			return nil, false, false, gameErr
+		} else {
+			timedOut = true
+		}
+	}
+	h.playOutstanding = false
+	if play.Card != NoCard {
+		return play, false, timedOut, nil
+	} else if jumpInPlay, gameErr := h.jumpIn(playerIndex); gameErr != nil {
+		return nil, false, false, gameErr
+	} else if jumpInPlay != nil {
+		h.playerIndex = playerIndex
+		return jumpInPlay, true, false, nil
+	} else if cancelled {
+		return nil, false, false, nil
+	}
+	return play, false, timedOut, nil
+}
+
+// canJumpIn is true if the player can jump in on the top discard. The current player just plays normally, and there is
+// nothing to jump in with on wilds or draw penalties.
+func (h *hand) canJumpIn(playerIndex int) bool {
+	return playerIndex != h.playerIndex && !h.topCard().Wild() && h.drawPenalty == 0
+}
+
+// jumpIn asks the player that claimed a jump-in for their card. The play is nil if the jump-in doesn't happen.
+func (h *hand) jumpIn(playerIndex int) (*PlayerPlay, *GameError) {
+	if !h.canJumpIn(playerIndex) {
+		return nil, nil
+	}
+	play, err := h.game.players[playerIndex].JumpIn()
+	if err == ErrTurnTimedOut {
+		// Taking too long withdraws the jump-in
+		return nil, h.sendPlayerEvent(EventHandPlayerTimedOut, playerIndex)
+	} else if err != nil {
+		return nil, PlayerErrorf(playerIndex, "Failure to jump in: %v", err)
+	} else if play.Card == NoCard {
+		return nil, nil
+	} else if err := play.AssertValid(); err != nil {
+		return nil, PlayerErrorf(playerIndex, "Invalid jump in: %v", err)
+	} else if !play.Card.Identical(h.topCard()) {
+		return nil, PlayerErrorf(playerIndex, "Invalid card, tried to jump in with %v on %v", play.Card, h.topCard())
+	}
+	return play, nil
+}
+
+// moveHands swaps hands on a 7 or rotates them on a 0 when playing Seven-O. The result has the index each player's
+// new hand came from, or is nil if no hands moved.
+func (h *hand) moveHands(v CardValue) ([]int, *GameError) {
+	// Nothing moves when the player just went out
+	if !h.game.rules.SevenO || h.currentPlayer().CardsRemaining() == 0 {
+		return nil, nil
+	}
+	playerCount := len(h.game.players)
+	fromIndexes := make([]int, playerCount)
+	for i := range fromIndexes {
+		fromIndexes[i] = i
+	}
+	swapTarget := -1
+	if v == 7 {
+		var err error
+		if swapTarget, err = h.currentPlayer().ChooseSwapTarget(); err == ErrTurnTimedOut {
+			// Taking too long swaps with the next player
+			swapTarget = h.peekNextPlayerIndex()
+			if err := h.sendEvent(EventHandPlayerTimedOut); err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, h.playerErrorf("Failure to choose swap target: %v", err)
+		} else if swapTarget < 0 || swapTarget >= playerCount || swapTarget == h.playerIndex {
+			return nil, h.playerErrorf("Invalid swap target %v", swapTarget)
+		}
+		fromIndexes[h.playerIndex], fromIndexes[swapTarget] = swapTarget, h.playerIndex
+	} else {
+		// Each hand goes to the player after the one holding it
+		for i := range fromIndexes {
+			if h.forward {
+				fromIndexes[(i+1)%playerCount] = i
+			} else {
+				fromIndexes[(i-1+playerCount)%playerCount] = i
+			}
+		}
+	}
+	if err := h.deck.MoveHands(fromIndexes); err != nil {
+		return nil, Errorf("Failed moving hands: %v", err)
+	}
+	if v == 7 {
+		if err := h.sendSwappedHandsEvent(swapTarget); err != nil {
+			return nil, err
+		}
+	} else if err := h.sendEvent(EventHandHandsRotated); err != nil {
+		return nil, err
+	}
+	return fromIndexes, nil
+}
+
+// timedOutPlay is the play for the current player when their play failed. A timed out turn has the timed out event
+// sent and nothing played so the player draws and passes, while any other failure ends the game.
+func (h *hand) timedOutPlay(err error) (*PlayerPlay, *GameError) {
+	if err != ErrTurnTimedOut {
+		return nil, h.playerErrorf("Failure to play: %v", err)
+	} else if err := h.sendEvent(EventHandPlayerTimedOut); err != nil {
+		return nil, err
+	}
+	return &PlayerPlay{Card: NoCard}, nil
+}
+
 // if last param is err, it is cause
 func (h *hand) playerErrorf(format string, args ...interface{}) *GameError {
 	err := Errorf(format, args...)
@@ -412,6 +766,15 @@ func (h *hand) sendCalledOneLeftEvent(call oneLeftCall) *GameError {
 	return h.game.sendEvent(EventHandPlayerCalledOneLeft, state, nil)
 }
 
+func (h *hand) sendSwappedHandsEvent(swapTarget int) *GameError {
+	if h.game.eventCb == nil {
+		return nil
+	}
+	state := h.eventState()
+	state.SwapTarget = swapTarget
+	return h.game.sendEvent(EventHandPlayerSwappedHands, state, nil)
+}
+
 func (h *hand) eventState() *EventHand {
 	hand := &EventHand{
 		PlayerIndex:          h.playerIndex,
@@ -421,9 +784,15 @@ func (h *hand) eventState() *EventHand {
 		LastDiscardWildColor: h.lastWildColor,
 		Forward:              h.forward,
 		OneLeftTarget:        -1,
+		DrawPenalty:          h.drawPenalty,
+		SwapTarget:           -1,
 	}
 	for i, player := range h.game.players {
-		hand.PlayerCardsRemaining[i] = player.CardsRemaining()
+		if h.playOutstanding && i == h.playerIndex {
+			hand.PlayerCardsRemaining[i] = h.cardsBeforePlay
+		} else {
+			hand.PlayerCardsRemaining[i] = player.CardsRemaining()
+		}
 	}
 	copy(hand.DiscardStack, h.discard)
 	return hand
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// CardHolder is a player that holds its cards in memory for a MemoryDeck to deal to
+type CardHolder interface {
+	HeldCards() []Card
+	// ReceiveCard adds a card dealt to the player
+	ReceiveCard(card Card) error
+	// ReceiveHand replaces the player's cards with the hand of the player at fromIndex. When the cards are taken back
+	// for a new shuffle, fromIndex is -1 and there are no cards.
+	ReceiveHand(fromIndex int, cards []Card) error
+}
+
+// MemoryDeck is an unencrypted CardDeck for players that can see each other's cards, such as tests and bots. It only
+// shuffles with the given RNG, so a game with every deck sharing an RNG from a seed can be played again from the seed.
+type MemoryDeck struct {
+	rnd     *rand.Rand
+	holders []CardHolder
+	cards   []Card
+}
+
+type memoryDeckComplete struct {
+	playerCards [][]Card
+}
+
+func (m *memoryDeckComplete) PlayerCards() [][]Card { return m.playerCards }
+
+// NewMemoryDeck creates a deck that deals to the holders, which are the players in game order
+func NewMemoryDeck(rnd *rand.Rand, holders []CardHolder) *MemoryDeck {
+	return &MemoryDeck{rnd: rnd, holders: holders}
+}
+
+func (m *MemoryDeck) CardsRemaining() int { return len(m.cards) }
+
+func (m *MemoryDeck) Shuffle(cards []Card) error {
+	m.cards = cards
+	if m.cards == nil {
+		m.cards = make([]Card, 108)
+		for i := range m.cards {
+			m.cards[i] = Card(i)
+		}
+		// Take back any cards the players have
+		for _, holder := range m.holders {
+			if err := holder.ReceiveHand(-1, nil); err != nil {
+				return err
+			}
+		}
+	}
+	m.rnd.Shuffle(len(m.cards), func(i, j int) { m.cards[i], m.cards[j] = m.cards[j], m.cards[i] })
+	return nil
+}
+
+func (m *MemoryDeck) DealTo(playerIndex int) error {
+	card, err := m.pop()
+	if err != nil {
+		return err
+	}
+	return m.holders[playerIndex].ReceiveCard(card)
+}
+
+func (m *MemoryDeck) PopForFirstDiscard() (Card, error) {
+	return m.pop()
+}
+
+func (m *MemoryDeck) CompleteHand() (CardDeckHandCompleteReveal, error) {
+	return &memoryDeckComplete{playerCards: m.heldCards()}, nil
+}
+
+func (m *MemoryDeck) MoveHands(fromIndexes []int) error {
+	hands := m.heldCards()
+	for i, fromIndex := range fromIndexes {
+		if err := m.holders[i].ReceiveHand(fromIndex, hands[fromIndex]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MemoryDeck) DeckSnapshot() (*DeckSnapshot, error) {
+	return &DeckSnapshot{Cards: append([]Card{}, m.cards...), PlayerCards: m.heldCards()}, nil
+}
+
+// RestoreDeckSnapshot replaces the deck's cards and gives every player their hand from the snapshot as if received
+// from themselves
+func (m *MemoryDeck) RestoreDeckSnapshot(snapshot *DeckSnapshot) error {
+	if len(snapshot.PlayerCards) != len(m.holders) {
+		return fmt.Errorf("Snapshot has %v hands, deck has %v players", len(snapshot.PlayerCards), len(m.holders))
+	}
+	m.cards = append([]Card{}, snapshot.Cards...)
+	for i, cards := range snapshot.PlayerCards {
+		if err := m.holders[i].ReceiveHand(i, append([]Card{}, cards...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MemoryDeck) pop() (Card, error) {
+	if len(m.cards) == 0 {
+		return NoCard, fmt.Errorf("No cards left")
+	}
+	card := m.cards[len(m.cards)-1]
+	m.cards = m.cards[:len(m.cards)-1]
+	return card, nil
+}
+
+// heldCards returns a copy of every player's hand
+func (m *MemoryDeck) heldCards() [][]Card {
+	ret := make([][]Card, len(m.holders))
+	for i, holder := range m.holders {
+		ret[i] = append([]Card{}, holder.HeldCards()...)
+	}
+	return ret
+}
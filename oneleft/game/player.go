@@ -1,6 +1,18 @@
 package game
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTurnTimedOut is returned from a player's turn decision when they took too long. The default is taken for them
+// instead of ending the game: drawing and passing for a play, not challenging, red for the first wild, swapping with
+// the next player, and withdrawing a jump-in.
+var ErrTurnTimedOut = errors.New("Turn timed out")
+
+// ErrPlayCancelled is returned from a player's play when it was cancelled before they played a card, because another
+// player jumped in.
+var ErrPlayCancelled = errors.New("Play cancelled")
 
 type Player interface {
 	CardsRemaining() int
@@ -10,6 +22,16 @@ type Player interface {
 	ShouldChallengeWildDrawFour() (bool, error)
 	ChallengedWildDrawFour(challengerIndex int) (bool, error)
 	SetOneLeftCallback(justGotOneLeftIndex int, callOneLeft func(target int))
+	// Only called when jumping in is allowed, each time the top discard changes. Calling the callback claims a jump-in.
+	SetJumpInCallback(jumpIn func())
+	// Called after a jump-in claim is accepted. Card must be identical to the top discard, or NoCard to withdraw.
+	JumpIn() (*PlayerPlay, error)
+	// Only called when jumping in is allowed, while Play is outstanding and another player claimed a jump-in. Play must
+	// then return soon, with ErrPlayCancelled if no card was played yet. A card played before the cancel still counts.
+	CancelPlay()
+	// Only called when playing Seven-O, after the player played a 7. Returns the index of another player to swap
+	// hands with.
+	ChooseSwapTarget() (int, error)
 }
 
 type PlayerPlay struct {
@@ -0,0 +1,96 @@
+package game
+
+import "fmt"
+
+// Rules are the house rules a game is played with. Every player must agree on them.
+type Rules struct {
+	// Cards dealt to each player at the start of a hand
+	HandSize int
+	// The game ends once a player reaches this score unless scoring is for a fixed number of hands
+	TargetScore int
+	// If true, the game ends after the first hand regardless of score
+	SingleHand bool
+	// If true, a player that doesn't play keeps drawing until they do instead of drawing once
+	DrawUntilPlayable bool
+	// If true, a player may play the card they just drew instead of their turn ending
+	PlayDrawnCard bool
+	// What happens when the first card on the discard is a wild draw four
+	FirstWildDrawFour FirstWildDrawFourRule
+	// If true, a player can stack a draw card on the draw card played on them instead of drawing, and whoever can't
+	// stack draws the total. Wild draw fours cannot be challenged with this on.
+	StackDrawCards bool
+	// If true, a player holding a card identical to the top non-wild discard can claim a jump-in at any time, out of
+	// turn. A claim made before the current player plays a card cancels their play, and the claiming player plays
+	// theirs instead with play continuing from them. A card the current player played first still stands.
+	JumpIn bool
+	// If true, a player that plays a 7 swaps hands with a player of their choosing and a player that plays a 0 has
+	// everyone pass their hand to the next player in the current direction. Going out with either still wins the hand.
+	SevenO bool
+	// If true, players sitting opposite each other are partners. A team wins the hand when either partner goes out,
+	// only scores the cards held by other teams, and shares its score toward the target. Requires an even number of at
+	// least 4 players.
+	Partners bool
+	// How hands are scored and when the game ends
+	Scoring Scoring
+	// The number of hands played with fixed hands scoring
+	HandCount int
+}
+
+type FirstWildDrawFourRule int
+
+// Another card is put on top of it
+const FirstWildDrawFourRedraw FirstWildDrawFourRule = 0
+
+// All cards are taken back, reshuffled, and dealt again
+const FirstWildDrawFourReshuffle FirstWildDrawFourRule = 1
+
+// DefaultRules returns the standard rules
+func DefaultRules() Rules {
+	return Rules{HandSize: 7, TargetScore: 500, PlayDrawnCard: true}
+}
+
+// Validate returns an error if the rules cannot be used to play with the given number of players
+func (r *Rules) Validate(playerCount int) error {
+	if r.HandSize < 1 {
+		return fmt.Errorf("Hand size must be at least 1")
+	} else if r.HandSize*playerCount >= 108 {
+		// Need at least one card for the discard
+		return fmt.Errorf("Hand size of %v too large for %v players", r.HandSize, playerCount)
+	} else if r.TargetScore < 1 {
+		return fmt.Errorf("Target score must be at least 1")
+	} else if r.DrawUntilPlayable && !r.PlayDrawnCard {
+		return fmt.Errorf("Cannot draw until playable without being able to play drawn card")
+	} else if r.FirstWildDrawFour != FirstWildDrawFourRedraw && r.FirstWildDrawFour != FirstWildDrawFourReshuffle {
+		return fmt.Errorf("Unknown first wild draw four rule %v", r.FirstWildDrawFour)
+	} else if _, ok := scoringNames[r.Scoring]; !ok {
+		return fmt.Errorf("Unknown scoring %v", int(r.Scoring))
+	} else if r.Scoring == ScoringFixedHands && r.HandCount < 1 {
+		return fmt.Errorf("Hand count must be at least 1 with fixed hands scoring")
+	} else if r.Partners && (playerCount < 4 || playerCount%2 != 0) {
+		return fmt.Errorf("Partners need an even number of at least 4 players, got %v", playerCount)
+	}
+	return nil
+}
+
+// TeamIndex returns the team of the player at the given index. With partners, players sitting opposite each other
+// share a team. Otherwise, every player is their own team and the team index is the player index.
+func (r *Rules) TeamIndex(playerIndex int, playerCount int) int {
+	if r.Partners {
+		return playerIndex % (playerCount / 2)
+	}
+	return playerIndex
+}
+
+// PlayerTeams returns the team index of every player
+func (r *Rules) PlayerTeams(playerCount int) []int {
+	teams := make([]int, playerCount)
+	for i := range teams {
+		teams[i] = r.TeamIndex(i, playerCount)
+	}
+	return teams
+}
+
+// Teammates is true if both players are on the same team, which includes a player being their own teammate
+func (r *Rules) Teammates(playerIndex int, otherIndex int, playerCount int) bool {
+	return r.TeamIndex(playerIndex, playerCount) == r.TeamIndex(otherIndex, playerCount)
+}
@@ -0,0 +1,93 @@
+package game
+
+// ScoringPolicy decides how each hand changes the scores and when the game is over
+type ScoringPolicy interface {
+	// HandScoreDeltas returns how much the hand changes the score of each player given the winner and the cards left
+	// in every player's hand
+	HandScoreDeltas(winnerIndex int, playerCards [][]Card) []int
+	// GameOver is true if no more hands should be played with the given scores after the given number of hands
+	GameOver(playerScores []int, handsPlayed int) bool
+	// GameWinners returns the indexes of the players that won a game that ended with the given scores
+	GameWinners(playerScores []int) []int
+}
+
+// Scoring is one of the built-in scoring policies
+type Scoring int
+
+// The winner collects the points of the cards left in the other players' hands. The first to the target score wins.
+const ScoringStandard Scoring = 0
+
+// Every player collects the points of the cards left in their own hand. Once a player reaches the target score, the
+// lowest score wins.
+const ScoringPenalty Scoring = 1
+
+// Hands are scored like standard, but the game ends after the rules' hand count and the highest score wins
+const ScoringFixedHands Scoring = 2
+
+var scoringNames = map[Scoring]string{
+	ScoringStandard:   "Standard",
+	ScoringPenalty:    "Penalty",
+	ScoringFixedHands: "FixedHands",
+}
+
+func (s Scoring) String() string { return scoringNames[s] }
+
+// ScoringPolicy returns the built-in policy for the rules' scoring. With partners, the team shares every score change.
+func (r *Rules) ScoringPolicy(playerCount int) ScoringPolicy {
+	return &rulesScoringPolicy{rules: *r, playerCount: playerCount}
+}
+
+type rulesScoringPolicy struct {
+	rules       Rules
+	playerCount int
+}
+
+func (r *rulesScoringPolicy) HandScoreDeltas(winnerIndex int, playerCards [][]Card) []int {
+	deltas := make([]int, r.playerCount)
+	for i := range deltas {
+		for j, cards := range playerCards {
+			// Penalties are for your own team's cards, otherwise the winner's team gets the other teams' cards
+			var scored bool
+			if r.rules.Scoring == ScoringPenalty {
+				scored = r.rules.Teammates(i, j, r.playerCount)
+			} else {
+				scored = r.rules.Teammates(i, winnerIndex, r.playerCount) &&
+					!r.rules.Teammates(j, winnerIndex, r.playerCount)
+			}
+			if scored {
+				for _, card := range cards {
+					deltas[i] += card.Score()
+				}
+			}
+		}
+	}
+	return deltas
+}
+
+func (r *rulesScoringPolicy) GameOver(playerScores []int, handsPlayed int) bool {
+	if r.rules.Scoring == ScoringFixedHands {
+		return handsPlayed >= r.rules.HandCount
+	}
+	for _, score := range playerScores {
+		if score >= r.rules.TargetScore {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rulesScoringPolicy) GameWinners(playerScores []int) []int {
+	winners := []int{}
+	for i, score := range playerScores {
+		if len(winners) > 0 {
+			best := playerScores[winners[0]]
+			if (r.rules.Scoring == ScoringPenalty && score > best) || (r.rules.Scoring != ScoringPenalty && score < best) {
+				continue
+			} else if score != best {
+				winners = winners[:0]
+			}
+		}
+		winners = append(winners, i)
+	}
+	return winners
+}
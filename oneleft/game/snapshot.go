@@ -0,0 +1,138 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SnapshotVersion is the version of snapshots created by this package. Only snapshots of this version can be resumed.
+const SnapshotVersion = 1
+
+// Snapshot is the state of a game at a point it can be resumed from, which is before each hand and each turn. Points
+// inside a turn, such as between a draw and the play after it or during a challenge, can't be resumed from since the
+// player's decisions are still outstanding. A game stopped inside a turn resumes from the start of that turn, and the
+// restored deck deals the same cards again. It serializes to JSON.
+type Snapshot struct {
+	Version         int
+	Rules           Rules
+	DealerIndex     int
+	PlayerScores    []int
+	HandScoreDeltas [][]int
+	// Nil before a hand starts
+	Hand *HandSnapshot
+}
+
+// HandSnapshot is the state of a hand before a player's turn
+type HandSnapshot struct {
+	PlayerIndex   int
+	Discard       []Card
+	LastWildColor CardColor
+	Forward       bool
+	DrawPenalty   int
+	// The player that just got one left and can still be called on, -1 if none
+	OneLeftTarget int
+	// Nil if the deck doesn't support snapshots, in which case the hand can't be resumed
+	Deck *DeckSnapshot
+}
+
+// DeckSnapshot is every card not on the discard
+type DeckSnapshot struct {
+	Cards       []Card
+	PlayerCards [][]Card
+}
+
+// SnapshotDeck is a CardDeck that can save its cards in a snapshot and restore them to resume a hand
+type SnapshotDeck interface {
+	CardDeck
+	DeckSnapshot() (*DeckSnapshot, error)
+	RestoreDeckSnapshot(snapshot *DeckSnapshot) error
+}
+
+// Marshal serializes the snapshot to JSON
+func (s *Snapshot) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// UnmarshalSnapshot deserializes a snapshot serialized with Marshal, failing if it's not of the current version
+func UnmarshalSnapshot(b []byte) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, err
+	} else if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("Unsupported snapshot version %v, expected %v", snapshot.Version, SnapshotVersion)
+	}
+	return snapshot, nil
+}
+
+// snapshot returns the state of the game and the optional hand, with the given player open to a one-left call. The
+// slices are copied so the snapshot doesn't change as the game goes on.
+func (g *Game) snapshot(h *hand, oneLeftTarget int) (*Snapshot, *GameError) {
+	snapshot := &Snapshot{
+		Version:         SnapshotVersion,
+		Rules:           g.rules,
+		DealerIndex:     g.dealerIndex,
+		PlayerScores:    append([]int{}, g.playerScores...),
+		HandScoreDeltas: make([][]int, len(g.handScoreDeltas)),
+	}
+	for i, deltas := range g.handScoreDeltas {
+		snapshot.HandScoreDeltas[i] = append([]int{}, deltas...)
+	}
+	if h != nil {
+		snapshot.Hand = &HandSnapshot{
+			PlayerIndex:   h.playerIndex,
+			Discard:       append([]Card{}, h.discard...),
+			LastWildColor: h.lastWildColor,
+			Forward:       h.forward,
+			DrawPenalty:   h.drawPenalty,
+			OneLeftTarget: oneLeftTarget,
+		}
+		if deck, ok := h.deck.(SnapshotDeck); ok {
+			var err error
+			if snapshot.Hand.Deck, err = deck.DeckSnapshot(); err != nil {
+				return nil, Errorf("Failed snapshotting deck: %v", err)
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func (g *Game) sendSnapshot(h *hand, oneLeftTarget int) *GameError {
+	if g.snapshotCb == nil {
+		return nil
+	}
+	snapshot, gameErr := g.snapshot(h, oneLeftTarget)
+	if gameErr != nil {
+		return gameErr
+	} else if err := g.snapshotCb(snapshot); err != nil {
+		return Errorf("Failed sending snapshot: %v", err)
+	}
+	return nil
+}
+
+// resumeHand creates a hand from the snapshot with a new deck that has the snapshot's cards
+func (g *Game) resumeHand(snapshot *HandSnapshot) (*hand, *GameError) {
+	if snapshot.Deck == nil {
+		return nil, Errorf("Snapshot has no deck to resume the hand with")
+	} else if len(snapshot.Discard) == 0 {
+		return nil, Errorf("Snapshot has no discard")
+	}
+	deck, err := g.newDeck()
+	if err != nil {
+		return nil, Errorf("Failed creating deck: %v", err)
+	}
+	snapshotDeck, ok := deck.(SnapshotDeck)
+	if !ok {
+		return nil, Errorf("Deck can't be restored from a snapshot")
+	} else if err := snapshotDeck.RestoreDeckSnapshot(snapshot.Deck); err != nil {
+		return nil, Errorf("Failed restoring deck: %v", err)
+	}
+	return &hand{
+		game:          g,
+		deck:          deck,
+		playerIndex:   snapshot.PlayerIndex,
+		discard:       append([]Card{}, snapshot.Discard...),
+		lastWildColor: snapshot.LastWildColor,
+		forward:       snapshot.Forward,
+		drawPenalty:   snapshot.DrawPenalty,
+	}, nil
+}
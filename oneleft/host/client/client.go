@@ -24,19 +24,33 @@ type client struct {
 	stream         pb.Host_StreamServer
 	maxRPCWaitTime time.Duration
 
-	chLock           sync.RWMutex
-	sendCh           chan *pb.HostMessage
+	chLock sync.RWMutex
+	// Only signals that sendQueue has messages, the queue keeps them in order
+	sendCh           chan struct{}
 	terminatingErrCh chan error
+	// Set once the stream has ended
+	stopped bool
+
+	sendQueueLock sync.Mutex
+	sendQueue     []*pb.HostMessage
+
+	// Held for an entire RPC since responses can only be matched to the single outstanding request
+	rpcLock sync.Mutex
 
 	reqRespLock       sync.Mutex
 	receivedRespValCh chan<- *pb.ClientMessage_PlayerResponse
 	receivedRespErrCh chan<- error
+	// Requests that were given up on but still get a response. Players respond in order, so the next responses are for
+	// these and are dropped.
+	abandonedResps int
 }
 
 type RequestHandler interface {
 	OnRun(Client)
 	OnChatMessage(Client, *pb.ChatMessage)
 	OnStartJoin(Client)
+	OnCallOneLeft(c Client, targetIndex uint32)
+	OnJumpIn(Client)
 	OnStop(Client)
 }
 
@@ -67,7 +81,7 @@ func (c *client) Num() uint64 { return c.num }
 func (c *client) Running() bool {
 	c.chLock.RLock()
 	defer c.chLock.RUnlock()
-	return c.sendCh != nil
+	return c.sendCh != nil && !c.stopped
 }
 
 func (c *client) Run() error {
@@ -77,19 +91,19 @@ func (c *client) Run() error {
 		c.chLock.Unlock()
 		return fmt.Errorf("Already running or have run")
 	}
-	c.sendCh = make(chan *pb.HostMessage)
+	c.sendCh = make(chan struct{}, 1)
 	c.terminatingErrCh = make(chan error)
 	c.chLock.Unlock()
 	recvMsgCh := make(chan *pb.ClientMessage)
-	recvErrCh := make(chan error)
+	recvErrCh := make(chan error, 1)
+	// Closed when done so the receiver stops
+	recvDoneCh := make(chan struct{})
 	// Close the chans when done
 	defer func() {
 		c.chLock.Lock()
 		defer c.chLock.Unlock()
-		close(c.sendCh)
 		close(c.terminatingErrCh)
-		close(recvMsgCh)
-		close(recvErrCh)
+		close(recvDoneCh)
 	}()
 	// Receive messages asynchronously
 	go func() {
@@ -98,7 +112,11 @@ func (c *client) Run() error {
 				recvErrCh <- err
 				break
 			} else {
-				recvMsgCh <- msg
+				select {
+				case recvMsgCh <- msg:
+				case <-recvDoneCh:
+					return
+				}
 			}
 		}
 	}()
@@ -109,9 +127,11 @@ func (c *client) Run() error {
 MainLoop:
 	for {
 		select {
-		case sendMsg := <-c.sendCh:
-			if err = c.stream.Send(sendMsg); err != nil {
-				break MainLoop
+		case <-c.sendCh:
+			for _, sendMsg := range c.takeSendQueue() {
+				if err = c.stream.Send(sendMsg); err != nil {
+					break MainLoop
+				}
 			}
 		case recvMsg := <-recvMsgCh:
 			switch recvMsg := recvMsg.Message.(type) {
@@ -119,8 +139,17 @@ MainLoop:
 				go c.handler.OnChatMessage(c, recvMsg.ChatMessage)
 			case *pb.ClientMessage_StartJoin:
 				go c.handler.OnStartJoin(c)
+			case *pb.ClientMessage_CallOneLeft:
+				go c.handler.OnCallOneLeft(c, recvMsg.CallOneLeft)
+			case *pb.ClientMessage_JumpIn:
+				go c.handler.OnJumpIn(c)
 			case *pb.ClientMessage_PlayerResponse_:
 				c.reqRespLock.Lock()
+				if c.abandonedResps > 0 {
+					c.abandonedResps--
+					c.reqRespLock.Unlock()
+					continue
+				}
 				rcpRespCh := c.receivedRespValCh
 				c.receivedRespValCh = nil
 				c.receivedRespErrCh = nil
@@ -140,6 +169,10 @@ MainLoop:
 			break MainLoop
 		}
 	}
+	// Mark stopped before failing the outstanding request so it's known to have failed from the stream ending
+	c.chLock.Lock()
+	c.stopped = true
+	c.chLock.Unlock()
 	c.reqRespLock.Lock()
 	rcpRespCh := c.receivedRespErrCh
 	c.receivedRespErrCh = nil
@@ -153,17 +186,32 @@ MainLoop:
 func (c *client) SendNonBlocking(msg *pb.HostMessage) error {
 	c.chLock.RLock()
 	defer c.chLock.RUnlock()
-	if c.sendCh == nil {
+	if c.sendCh == nil || c.stopped {
 		return fmt.Errorf("Not running")
 	}
-	go func(ch chan *pb.HostMessage) { ch <- msg }(c.sendCh)
+	c.sendQueueLock.Lock()
+	c.sendQueue = append(c.sendQueue, msg)
+	c.sendQueueLock.Unlock()
+	// Signal, but if there is already a signal pending it will pick this up too
+	select {
+	case c.sendCh <- struct{}{}:
+	default:
+	}
 	return nil
 }
 
+func (c *client) takeSendQueue() []*pb.HostMessage {
+	c.sendQueueLock.Lock()
+	defer c.sendQueueLock.Unlock()
+	ret := c.sendQueue
+	c.sendQueue = nil
+	return ret
+}
+
 func (c *client) FailNonBlocking(err error) error {
 	c.chLock.RLock()
 	defer c.chLock.RUnlock()
-	if c.terminatingErrCh == nil {
+	if c.terminatingErrCh == nil || c.stopped {
 		return fmt.Errorf("Not running")
 	}
 	go func(ch chan error) { ch <- err }(c.terminatingErrCh)
@@ -7,6 +7,20 @@ import (
 	"github.com/cretz/one-left/oneleft/pb"
 )
 
+type requestSeqKey struct{}
+
+// WithRequestSeq returns a context that gives requests made with it the sequence number. Requests sent again after a
+// player reconnects must have the same number as the first time.
+func WithRequestSeq(ctx context.Context, seq uint64) context.Context {
+	return context.WithValue(ctx, requestSeqKey{}, seq)
+}
+
+// RequestSeq returns the sequence number set with WithRequestSeq, or 0 if none
+func RequestSeq(ctx context.Context) uint64 {
+	seq, _ := ctx.Value(requestSeqKey{}).(uint64)
+	return seq
+}
+
 func (c *client) doRPC(ctx context.Context, req interface{}) (interface{}, error) {
 	if !c.Running() {
 		return nil, fmt.Errorf("Client not running")
@@ -15,6 +29,10 @@ func (c *client) doRPC(ctx context.Context, req interface{}) (interface{}, error
 	if err != nil {
 		return nil, err
 	}
+	sendMsg.Seq = RequestSeq(ctx)
+	// Requests can come concurrently (e.g. a draw during a play), but only one can be outstanding
+	c.rpcLock.Lock()
+	defer c.rpcLock.Unlock()
 	respValCh := make(chan *pb.ClientMessage_PlayerResponse, 1)
 	respErrCh := make(chan error, 1)
 	// Set them here, but they are nil'd out in the stream loop
@@ -31,6 +49,14 @@ func (c *client) doRPC(ctx context.Context, req interface{}) (interface{}, error
 	defer cancelFn()
 	select {
 	case <-ctx.Done():
+		// Unless the response was just received, it still has to be dropped when it comes
+		c.reqRespLock.Lock()
+		if c.receivedRespValCh == respValCh {
+			c.receivedRespValCh = nil
+			c.receivedRespErrCh = nil
+			c.abandonedResps++
+		}
+		c.reqRespLock.Unlock()
 		return nil, ctx.Err()
 	case err := <-respErrCh:
 		return nil, err
@@ -155,14 +181,44 @@ func (c *client) RevealedCardsForChallenge(
 	return resp.(*pb.RevealedCardsForChallengeResponse), nil
 }
 
+func (c *client) JumpIn(ctx context.Context, req *pb.JumpInRequest) (*pb.JumpInResponse, error) {
+	resp, err := c.doRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.JumpInResponse), nil
+}
+
+func (c *client) ChooseSwapTarget(
+	ctx context.Context, req *pb.ChooseSwapTargetRequest,
+) (*pb.ChooseSwapTargetResponse, error) {
+	resp, err := c.doRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ChooseSwapTargetResponse), nil
+}
+
+func (c *client) MoveHand(ctx context.Context, req *pb.MoveHandRequest) (*pb.MoveHandResponse, error) {
+	resp, err := c.doRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.MoveHandResponse), nil
+}
+
 func hostMessageFromPlayerRequest(req interface{}) (*pb.HostMessage_PlayerRequest, error) {
 	switch req := req.(type) {
 	case *pb.JoinRequest:
 		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_JoinRequest{req}}, nil
 	case *pb.GameStartRequest:
 		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_GameStartRequest{req}}, nil
+	case *pb.GameEndRequest:
+		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_GameEndRequest{req}}, nil
 	case *pb.HandStartRequest:
 		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_HandStartRequest{req}}, nil
+	case *pb.HandEndRequest:
+		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_HandEndRequest{req}}, nil
 	case *pb.ShuffleRequest:
 		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_ShuffleRequest{req}}, nil
 	case *pb.ChooseColorSinceFirstCardIsWildRequest:
@@ -191,6 +247,12 @@ func hostMessageFromPlayerRequest(req interface{}) (*pb.HostMessage_PlayerReques
 		return &pb.HostMessage_PlayerRequest{
 			Message: &pb.HostMessage_PlayerRequest_RevealedCardsForChallengeRequest{req},
 		}, nil
+	case *pb.JumpInRequest:
+		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_JumpInRequest{req}}, nil
+	case *pb.ChooseSwapTargetRequest:
+		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_ChooseSwapTargetRequest{req}}, nil
+	case *pb.MoveHandRequest:
+		return &pb.HostMessage_PlayerRequest{Message: &pb.HostMessage_PlayerRequest_MoveHandRequest{req}}, nil
 	default:
 		return nil, fmt.Errorf("Unrecognized request type %T", req)
 	}
@@ -207,10 +269,18 @@ func playerResponseFromMatchingRequest(req interface{}, resp *pb.ClientMessage_P
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_GameStartResponse); ok {
 			ret = respMsg.GameStartResponse
 		}
+	case *pb.GameEndRequest:
+		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_GameEndResponse); ok {
+			ret = respMsg.GameEndResponse
+		}
 	case *pb.HandStartRequest:
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_HandStartResponse); ok {
 			ret = respMsg.HandStartResponse
 		}
+	case *pb.HandEndRequest:
+		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_HandEndResponse); ok {
+			ret = respMsg.HandEndResponse
+		}
 	case *pb.ShuffleRequest:
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_ShuffleResponse); ok {
 			ret = respMsg.ShuffleResponse
@@ -219,11 +289,11 @@ func playerResponseFromMatchingRequest(req interface{}, resp *pb.ClientMessage_P
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_ChooseColorSinceFirstCardIsWildResponse); ok {
 			ret = respMsg.ChooseColorSinceFirstCardIsWildResponse
 		}
-	case *pb.HostMessage_PlayerRequest_GetDeckTopDecryptionKeyRequest:
+	case *pb.GetDeckTopDecryptionKeyRequest:
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_GetDeckTopDecryptionKeyResponse); ok {
 			ret = respMsg.GetDeckTopDecryptionKeyResponse
 		}
-	case *pb.HostMessage_PlayerRequest_GiveDeckTopCardRequest:
+	case *pb.GiveDeckTopCardRequest:
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_GiveDeckTopCardResponse); ok {
 			ret = respMsg.GiveDeckTopCardResponse
 		}
@@ -243,6 +313,18 @@ func playerResponseFromMatchingRequest(req interface{}, resp *pb.ClientMessage_P
 		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse); ok {
 			ret = respMsg.RevealedCardsForChallengeResponse
 		}
+	case *pb.JumpInRequest:
+		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_JumpInResponse); ok {
+			ret = respMsg.JumpInResponse
+		}
+	case *pb.ChooseSwapTargetRequest:
+		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_ChooseSwapTargetResponse); ok {
+			ret = respMsg.ChooseSwapTargetResponse
+		}
+	case *pb.MoveHandRequest:
+		if respMsg, ok := resp.Message.(*pb.ClientMessage_PlayerResponse_MoveHandResponse); ok {
+			ret = respMsg.MoveHandResponse
+		}
 	}
 	if ret == nil {
 		return nil, fmt.Errorf("Response to %T was unrecognized %T", req, resp.Message)
@@ -0,0 +1,42 @@
+package host
+
+import (
+	"time"
+
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/transcript"
+)
+
+// Config is the set of host options. Any zero value is replaced with its default.
+type Config struct {
+	// How long to wait for a player to respond to a request.
+	MaxClientRPCWait time.Duration
+	// How long to wait for a player to decide something in their turn before taking the default for them. Limited to
+	// MaxClientRPCWait.
+	TurnTimeout time.Duration
+	// How long to wait for a player that disconnected during a game to reconnect before ending the game.
+	ReconnectTimeout time.Duration
+	// How many players can join a game.
+	MaxPlayers int
+	// The house rules games are played with. Validated when a game starts.
+	Rules *game.Rules
+	// If set, every game played is written to this transcript. It is not closed by the host.
+	Transcript *transcript.Writer
+	// The bit size of the safe primes hands are shuffled with. Raised to the minimum of any player in the game.
+	SharedPrimeBits int
+	// The largest minimum shared prime size a player can join with.
+	MaxSharedPrimeBits int
+	// If set, shared primes are taken from this pool instead of generated when each hand starts. It is not closed by
+	// the host.
+	SharedPrimePool *sra.SafePrimePool
+	// The cipher hands are shuffled with if every player in the game accepts it. Otherwise, SRA is used.
+	CardCipher pb.CardCipher
+}
+
+const DefaultMaxClientRPCWait = 1 * time.Minute
+const DefaultTurnTimeout = 30 * time.Second
+const DefaultReconnectTimeout = 2 * time.Minute
+const DefaultMaxPlayers = 10
+const DefaultMaxSharedPrimeBits = 2048
@@ -7,14 +7,14 @@ type eventHandler struct {
 }
 
 func (h *eventHandler) OnEvent(event *pb.HostMessage_GameEvent) error {
-	// Set as last event
+	// Set as last event and keep it for players that reconnect. This is all done under the same lock as rejoining so a
+	// rejoining player gets every event exactly once.
 	h.lock.Lock()
+	defer h.lock.Unlock()
 	h.lastGameEvent = event
-	h.lock.Unlock()
+	h.gameEvents = append(h.gameEvents, event)
 	// Just send it to all clients
 	msg := &pb.HostMessage{Message: &pb.HostMessage_GameEvent_{GameEvent: event}}
-	h.lock.RLock()
-	defer h.lock.RUnlock()
 	for _, client := range h.clients {
 		client.Client.SendNonBlocking(msg)
 	}
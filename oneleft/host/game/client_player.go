@@ -4,95 +4,178 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/cretz/one-left/oneleft/game"
 	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/transcript"
 )
 
 type clientPlayer struct {
-	*PlayerInfo
+	identity  *pb.PlayerIdentity
+	client    *playerClient
 	cardCount int
 	index     int
 	currGame  *Game
 	score     int
+
+	oneLeftLock sync.Mutex
+	// Nil when a call was already made since the last reset
+	callOneLeft func(target int)
+
+	jumpInLock sync.Mutex
+	// Nil when a claim was already made since the last reset
+	jumpIn func()
+
+	playLock sync.Mutex
+	// Nil when no play is outstanding
+	cancelPlay context.CancelFunc
+	// True when the outstanding play was cancelled, so its card isn't taken even if it arrives
+	playCancelled bool
 }
 
-func (c *clientPlayer) CardsRemaining() int { return c.cardCount }
+func (c *clientPlayer) CardsRemaining() int {
+	c.currGame.deck.lock.Lock()
+	defer c.currGame.deck.lock.Unlock()
+	return c.cardCount
+}
+
+// turnContext is the context for a request the player decides on in their turn, which ends with the turn
+func (c *clientPlayer) turnContext() (context.Context, context.CancelFunc) {
+	if c.currGame.turnTimeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), c.currGame.turnTimeout)
+}
+
+// turnError makes a request that ran out of time in the player's turn a timeout so the default is taken instead of
+// ending the game
+func turnError(err error) error {
+	if err == context.DeadlineExceeded {
+		return game.ErrTurnTimedOut
+	}
+	return err
+}
 
 func (c *clientPlayer) ChooseColorSinceFirstCardIsWild() (game.CardColor, error) {
+	ctx, cancelFn := c.turnContext()
+	defer cancelFn()
 	req := &pb.ChooseColorSinceFirstCardIsWildRequest{}
-	resp, err := c.Client.ChooseColorSinceFirstCardIsWild(context.Background(), req)
+	resp, err := c.client.ChooseColorSinceFirstCardIsWild(ctx, req)
 	if err != nil {
-		return 0, err
+		return 0, turnError(err)
 	}
 	return game.CardColor(resp.Color), nil
 }
 
 func (c *clientPlayer) Play() (*game.PlayerPlay, error) {
+	ctx, cancelFn := c.turnContext()
+	defer cancelFn()
+	c.playLock.Lock()
+	c.cancelPlay, c.playCancelled = cancelFn, false
+	c.playLock.Unlock()
 	req := &pb.PlayRequest{}
-	resp, err := c.Client.Play(context.Background(), req)
-	if err != nil {
-		return nil, err
+	resp, err := c.client.Play(ctx, req)
+	// Once cancelled, the card stays with the player even if they played it, they get it back when they see the
+	// jump-in
+	c.playLock.Lock()
+	cancelled := c.playCancelled
+	c.cancelPlay, c.playCancelled = nil, false
+	c.playLock.Unlock()
+	if cancelled {
+		return nil, game.ErrPlayCancelled
+	} else if err != nil {
+		return nil, turnError(err)
 	} else if len(resp.EncryptedCard) == 0 {
 		return &game.PlayerPlay{Card: game.NoCard}, nil
 	}
+	card, err := c.takePlayedCard(resp.EncryptedCard, resp.CardDecryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &game.PlayerPlay{Card: card, WildColor: game.CardColor(resp.WildColor)}, nil
+}
+
+// takePlayedCard decrypts a card the player is playing and removes it from the ones they hold
+func (c *clientPlayer) takePlayedCard(encryptedCard []byte, cardDecryptionKeys [][]byte) (game.Card, error) {
 	// Convert all bytes to big ints
-	bigCard := new(big.Int).SetBytes(resp.EncryptedCard)
-	bigKeys := make([]*big.Int, len(resp.CardDecryptionKeys))
-	for i, decKey := range resp.CardDecryptionKeys {
+	bigCard := new(big.Int).SetBytes(encryptedCard)
+	bigKeys := make([]*big.Int, len(cardDecryptionKeys))
+	for i, decKey := range cardDecryptionKeys {
 		// We need to verify that the deck has seen all decryption keys but this players' index
 		bigKeys[i] = new(big.Int).SetBytes(decKey)
 	}
+	// The hand can deal to others while the card is taken
+	deck := c.currGame.deck
+	deck.lock.Lock()
+	defer deck.lock.Unlock()
 	// Make sure it was given to them in the first place, and remove it
-	if i, ok := c.currGame.deck.encryptedCardsHeldByPlayers[bigCard.String()]; !ok || i != c.index {
-		return nil, fmt.Errorf("Card was never given to player")
+	if i, ok := deck.encryptedCardsHeldByPlayers[bigCard.String()]; !ok || i != c.index {
+		return 0, fmt.Errorf("Card was never given to player")
 	}
-	delete(c.currGame.deck.encryptedCardsHeldByPlayers, bigCard.String())
+	delete(deck.encryptedCardsHeldByPlayers, bigCard.String())
+	// The key we haven't seen is the one of the player it was dealt to, which isn't this one if hands have moved
+	dealtToIndex := deck.encryptedCardsDealtToPlayers[bigCard.String()]
 	// Decrypt the card
-	card, err := c.currGame.deck.decryptCard(bigCard, bigKeys)
+	card, err := deck.decryptCard(bigCard, bigKeys)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	// We verify that we've seen all decryption keys *except* the one playing here to prevent spoofing
-	seenKeys := c.currGame.deck.seenDecryptionKeys[bigCard.String()]
+	// We verify that we've seen all decryption keys *except* the one dealt to here to prevent spoofing
+	seenKeys := deck.seenDecryptionKeys[bigCard.String()]
 	if len(seenKeys) != len(bigKeys) {
-		return nil, fmt.Errorf("Invalid decryption key set size")
+		return 0, fmt.Errorf("Invalid decryption key set size")
 	}
 	for i, seenKey := range seenKeys {
-		if i == c.index && seenKey != nil {
-			return nil, fmt.Errorf("We have already seen this player's decryption key before")
-		} else if decKey := bigKeys[i]; i != c.index && (seenKey == nil || seenKey.Cmp(decKey) != 0) {
-			return nil, fmt.Errorf("Decryption key mismatch")
+		if i == dealtToIndex && seenKey != nil {
+			return 0, fmt.Errorf("We have already seen this player's decryption key before")
+		} else if decKey := bigKeys[i]; i != dealtToIndex && (seenKey == nil || seenKey.Cmp(decKey) != 0) {
+			return 0, fmt.Errorf("Decryption key mismatch")
 		}
 	}
 	// Update the decryption keys so the full set it present
-	c.currGame.deck.seenDecryptionKeys[bigCard.String()] = bigKeys
-	return &game.PlayerPlay{Card: card, WildColor: game.CardColor(resp.WildColor)}, nil
+	deck.seenDecryptionKeys[bigCard.String()] = bigKeys
+	c.cardCount--
+	if err = c.currGame.writeTranscript(transcript.DecryptionKeysEntry(encryptedCard, cardDecryptionKeys)); err != nil {
+		return 0, err
+	}
+	return card, nil
 }
 
 func (c *clientPlayer) ShouldChallengeWildDrawFour() (bool, error) {
-	topColor, err := c.currGame.topDiscardColor()
+	prevColor, err := c.currGame.colorBeforeWildDrawFour()
 	if err != nil {
 		return false, err
 	}
-	req := &pb.ShouldChallengeWildDrawFourRequest{PrevColor: uint32(topColor)}
-	resp, err := c.Client.ShouldChallengeWildDrawFour(context.Background(), req)
+	ctx, cancelFn := c.turnContext()
+	defer cancelFn()
+	req := &pb.ShouldChallengeWildDrawFourRequest{PrevColor: uint32(prevColor)}
+	resp, err := c.client.ShouldChallengeWildDrawFour(ctx, req)
 	if err != nil {
-		return false, err
+		return false, turnError(err)
 	}
 	return resp.Challenge, nil
 }
 
 func (c *clientPlayer) ChallengedWildDrawFour(challengerIndex int) (bool, error) {
-	topColor, err := c.currGame.topDiscardColor()
+	prevColor, err := c.currGame.colorBeforeWildDrawFour()
 	if err != nil {
 		return false, err
 	}
 	// First, ask this player for card reveal info
-	meReq := &pb.RevealCardsForChallengeRequest{ChallengerIndex: uint32(challengerIndex), PrevColor: uint32(topColor)}
-	meResp, err := c.Client.RevealCardsForChallenge(context.Background(), meReq)
+	meReq := &pb.RevealCardsForChallengeRequest{ChallengerIndex: uint32(challengerIndex), PrevColor: uint32(prevColor)}
+	meResp, err := c.client.RevealCardsForChallenge(context.Background(), meReq)
 	if err != nil {
 		return false, err
+	} else if len(meResp.CardDecryptionKeys) != len(meResp.EncryptedCards)*len(c.currGame.players) {
+		return false, fmt.Errorf("Invalid decryption key count")
+	}
+	// Every key but the challenger's is revealed for each card
+	for i, encCard := range meResp.EncryptedCards {
+		keys := meResp.CardDecryptionKeys[i*len(c.currGame.players) : (i+1)*len(c.currGame.players)]
+		if err := c.currGame.writeTranscript(transcript.DecryptionKeysEntry(encCard, keys)); err != nil {
+			return false, err
+		}
 	}
 	// Now, tell the other player of the cards and see if they agree on whether this will fail
 	themReq := &pb.RevealedCardsForChallengeRequest{
@@ -100,7 +183,8 @@ func (c *clientPlayer) ChallengedWildDrawFour(challengerIndex int) (bool, error)
 		CardDecryptionKeys:   meResp.CardDecryptionKeys,
 		ChallengeWillSucceed: meResp.ChallengeWillSucceed,
 	}
-	themResp, err := c.Client.RevealedCardsForChallenge(context.Background(), themReq)
+	challenger := c.currGame.players[challengerIndex]
+	themResp, err := challenger.client.RevealedCardsForChallenge(context.Background(), themReq)
 	if err != nil {
 		// This reassigns blame for the error
 		return false, game.PlayerErrorf(challengerIndex, "%v", err)
@@ -116,5 +200,78 @@ func (c *clientPlayer) ChallengedWildDrawFour(challengerIndex int) (bool, error)
 }
 
 func (c *clientPlayer) SetOneLeftCallback(justGotOneLeftIndex int, callOneLeft func(target int)) {
-	// TODO
+	c.oneLeftLock.Lock()
+	c.callOneLeft = callOneLeft
+	c.oneLeftLock.Unlock()
+	// Let the player know who can be called on
+	c.client.sendNonBlocking(&pb.HostMessage{Message: &pb.HostMessage_OneLeftCallWindow_{
+		OneLeftCallWindow: &pb.HostMessage_OneLeftCallWindow{
+			GameId:      c.currGame.id[:],
+			PlayerIndex: int32(justGotOneLeftIndex),
+		},
+	}})
+}
+
+func (c *clientPlayer) doCallOneLeft(targetIndex int) {
+	// Only one call is allowed per callback set, so the player can't flood the game
+	c.oneLeftLock.Lock()
+	callOneLeft := c.callOneLeft
+	c.callOneLeft = nil
+	c.oneLeftLock.Unlock()
+	if callOneLeft != nil {
+		callOneLeft(targetIndex)
+	}
+}
+
+func (c *clientPlayer) SetJumpInCallback(jumpIn func()) {
+	// Players know the top discard from events, so there is nothing to send
+	c.jumpInLock.Lock()
+	c.jumpIn = jumpIn
+	c.jumpInLock.Unlock()
+}
+
+func (c *clientPlayer) doJumpIn() {
+	// Only one claim is allowed per callback set, so the player can't flood the game
+	c.jumpInLock.Lock()
+	jumpIn := c.jumpIn
+	c.jumpIn = nil
+	c.jumpInLock.Unlock()
+	if jumpIn != nil {
+		jumpIn()
+	}
+}
+
+func (c *clientPlayer) CancelPlay() {
+	c.playLock.Lock()
+	defer c.playLock.Unlock()
+	if c.cancelPlay != nil {
+		c.playCancelled = true
+		c.cancelPlay()
+	}
+}
+
+func (c *clientPlayer) JumpIn() (*game.PlayerPlay, error) {
+	ctx, cancelFn := c.turnContext()
+	defer cancelFn()
+	resp, err := c.client.JumpIn(ctx, &pb.JumpInRequest{})
+	if err != nil {
+		return nil, turnError(err)
+	} else if len(resp.EncryptedCard) == 0 {
+		return &game.PlayerPlay{Card: game.NoCard}, nil
+	}
+	card, err := c.takePlayedCard(resp.EncryptedCard, resp.CardDecryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &game.PlayerPlay{Card: card}, nil
+}
+
+func (c *clientPlayer) ChooseSwapTarget() (int, error) {
+	ctx, cancelFn := c.turnContext()
+	defer cancelFn()
+	resp, err := c.client.ChooseSwapTarget(ctx, &pb.ChooseSwapTargetRequest{})
+	if err != nil {
+		return 0, turnError(err)
+	}
+	return int(resp.TargetIndex), nil
 }
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/transcript"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengedWildDrawFour(t *testing.T) {
+	// Player 0 played a wild draw four on red and player 1 challenged it. Player 0 reveals two cards with the keys of
+	// players 0 and 2.
+	reveal := &pb.RevealCardsForChallengeResponse{
+		EncryptedCards:     [][]byte{{1}, {2}},
+		CardDecryptionKeys: [][]byte{{10}, {}, {12}, {20}, {}, {22}},
+	}
+	tests := []struct {
+		name string
+		// Each player's answer, with a nil response failing the request
+		revealResp   *pb.RevealCardsForChallengeResponse
+		revealedResp *pb.RevealedCardsForChallengeResponse
+		succeeded    bool
+		err          string
+		errPlayer    int
+	}{
+		{
+			name:         "succeeded",
+			revealResp:   &pb.RevealCardsForChallengeResponse{ChallengeWillSucceed: true},
+			revealedResp: &pb.RevealedCardsForChallengeResponse{ChallengeSucceeded: true},
+			succeeded:    true,
+		},
+		{
+			name:         "failed",
+			revealResp:   &pb.RevealCardsForChallengeResponse{},
+			revealedResp: &pb.RevealedCardsForChallengeResponse{},
+		},
+		{
+			name:         "disagreed",
+			revealResp:   &pb.RevealCardsForChallengeResponse{},
+			revealedResp: &pb.RevealedCardsForChallengeResponse{ChallengeSucceeded: true},
+			err:          "Challenger has success as true but challengee has it as false",
+			errPlayer:    -1,
+		},
+		{
+			name:      "challenged refused",
+			err:       "Refused",
+			errPlayer: -1,
+		},
+		{
+			name:       "challenger refused",
+			revealResp: &pb.RevealCardsForChallengeResponse{},
+			err:        "Refused",
+			errPlayer:  1,
+		},
+		{
+			name: "missing key",
+			revealResp: &pb.RevealCardsForChallengeResponse{
+				EncryptedCards:     reveal.EncryptedCards,
+				CardDecryptionKeys: reveal.CardDecryptionKeys[:5],
+			},
+			err:       "Invalid decryption key count",
+			errPlayer: -1,
+		},
+	}
+	for _, test := range tests {
+		if test.revealResp != nil && test.revealResp.EncryptedCards == nil {
+			test.revealResp.EncryptedCards, test.revealResp.CardDecryptionKeys =
+				reveal.EncryptedCards, reveal.CardDecryptionKeys
+		}
+		var revealReq *pb.RevealCardsForChallengeRequest
+		var revealedReq *pb.RevealedCardsForChallengeRequest
+		clients := []*testClient{
+			{revealCardsForChallenge: func(req *pb.RevealCardsForChallengeRequest) (
+				*pb.RevealCardsForChallengeResponse, error,
+			) {
+				revealReq = req
+				if test.revealResp == nil {
+					return nil, fmt.Errorf("Refused")
+				}
+				return test.revealResp, nil
+			}},
+			{revealedCardsForChallenge: func(req *pb.RevealedCardsForChallengeRequest) (
+				*pb.RevealedCardsForChallengeResponse, error,
+			) {
+				revealedReq = req
+				if test.revealedResp == nil {
+					return nil, fmt.Errorf("Refused")
+				}
+				return test.revealedResp, nil
+			}},
+			{},
+		}
+		g := newTestGame(clients...)
+		var transcriptBuf bytes.Buffer
+		var err error
+		g.transcript, err = transcript.NewWriter(&transcriptBuf, nil)
+		require.NoError(t, err)
+		g.lastEvent = &pb.HostMessage_GameEvent{Hand: &pb.HostMessage_GameEvent_Hand{DiscardStack: []uint32{3, 104}}}
+		g.colorBeforeLastDiscard = game.ColorRed
+
+		succeeded, err := g.players[0].ChallengedWildDrawFour(1)
+		// The challenged player is asked to reveal to the challenger with the color that was in play
+		require.Equal(t, uint32(1), revealReq.ChallengerIndex, test.name)
+		require.Equal(t, uint32(game.ColorRed), revealReq.PrevColor, test.name)
+		if test.err != "" {
+			require.EqualError(t, err, test.err, test.name)
+			require.Equal(t, test.errPlayer, findErrPlayerIndex(err), test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.succeeded, succeeded, test.name)
+		// The challenger is given the revealed cards, and the keys are kept in the transcript
+		require.Equal(t, reveal.EncryptedCards, revealedReq.EncryptedCards, test.name)
+		require.Equal(t, reveal.CardDecryptionKeys, revealedReq.CardDecryptionKeys, test.name)
+		require.Equal(t, test.revealResp.ChallengeWillSucceed, revealedReq.ChallengeWillSucceed, test.name)
+		r := transcript.NewReader(&transcriptBuf)
+		var revealedKeys [][]byte
+		for {
+			entry, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, test.name)
+			if e, ok := entry.Entry.(*pb.TranscriptEntry_DecryptionKeys_); ok {
+				revealedKeys = append(revealedKeys, e.DecryptionKeys.Keys...)
+			}
+		}
+		require.Equal(t, reveal.CardDecryptionKeys, revealedKeys, test.name)
+	}
+}
@@ -7,62 +7,75 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/cretz/one-left/oneleft/crypto"
 	"github.com/cretz/one-left/oneleft/crypto/sra"
 	"github.com/cretz/one-left/oneleft/game"
 	"github.com/cretz/one-left/oneleft/pb"
-	"github.com/golang/protobuf/proto"
+	"github.com/cretz/one-left/oneleft/transcript"
 	"github.com/google/uuid"
 )
 
 type deck struct {
 	*deckInfo
-	game        *Game
-	handID      uuid.UUID
-	sharedPrime *big.Int
+	game *Game
+	// Guards the maps below, the encrypted cards, and the card count of each player. Plays are taken on the player's
+	// own goroutine, which can be while the hand deals to someone else, e.g. when one-left is called during a play.
+	lock sync.Mutex
 	// Keyed by orig encrypted card big.int serialized to string
 	seenDecryptionKeys map[string][]*big.Int
+	// Keyed by orig encrypted card big.int serialized to string, by player index. Kept for every shuffle in the hand
+	// since players reveal keys for all of them at the end.
+	decryptionKeyCommitments map[string][]*big.Int
 	// Must be sorted and the start since the start of the hand
 	origStartCards []game.Card
 	// Just since last shuffle
 	unencryptedStartCards       []game.Card
 	encryptedCards              []*big.Int
 	encryptedCardsHeldByPlayers map[string]int
+	// The player whose decryption key we haven't seen for a card, which stays the same when hands move
+	encryptedCardsDealtToPlayers map[string]int
 }
 
 type deckInfo struct {
 	handID        uuid.UUID
 	handStartSigs [][]byte
-	sharedPrime   *big.Int
+	cardCipher    crypto.CardCipher
+	cardEncoding  *sra.CardEncoding
 }
 
-// TODO: conf
-const sharedPrimeBits = 256
+// DefaultSharedPrimeBits is the bit size of the safe primes hands are shuffled with unless set otherwise
+const DefaultSharedPrimeBits = 256
 
 func newDeck(g *Game, deckInfo *deckInfo) (*deck, error) {
 	deck := &deck{
-		game:                        g,
-		deckInfo:                    deckInfo,
-		seenDecryptionKeys:          map[string][]*big.Int{},
-		origStartCards:              make([]game.Card, 108),
-		encryptedCardsHeldByPlayers: map[string]int{},
+		game:                         g,
+		deckInfo:                     deckInfo,
+		seenDecryptionKeys:           map[string][]*big.Int{},
+		decryptionKeyCommitments:     map[string][]*big.Int{},
+		origStartCards:               make([]game.Card, 108),
+		encryptedCardsHeldByPlayers:  map[string]int{},
+		encryptedCardsDealtToPlayers: map[string]int{},
 	}
 	// Set up the orig deck
-	// TODO: It'd be nice if we could make a big list of large, random values to represent cards
-	// to mitigate card number leaks based on low values during first encrypt.
 	for i := 0; i < 108; i++ {
 		deck.origStartCards[i] = game.Card(i)
 	}
-	panic("TODO")
+	// Players start the hand with no cards
+	for _, p := range g.players {
+		p.cardCount = 0
+	}
+	g.dataLock.Lock()
+	g.deck = deck
+	g.dataLock.Unlock()
+	return deck, nil
 }
 
 func (d *deck) decryptCard(card *big.Int, decryptionKeys []*big.Int) (game.Card, error) {
 	for _, decryptionKey := range decryptionKeys {
-		card = sra.DecryptInt(d.sharedPrime, decryptionKey, card)
+		card = d.cardCipher.DecryptInt(decryptionKey, card)
 	}
-	if card.BitLen() <= 32 {
-		if ret := game.Card(card.Int64()); ret.Valid() {
-			return ret, nil
-		}
+	if ret, ok := crypto.IntToCard(d.cardEncoding, card); ok {
+		return ret, nil
 	}
 	return 0, fmt.Errorf("Decryption failed, resulting card: %v", card)
 }
@@ -74,6 +87,15 @@ func (d *deck) Shuffle(startCards []game.Card) error {
 	d.unencryptedStartCards = startCards
 	if len(d.unencryptedStartCards) == 0 {
 		d.unencryptedStartCards = d.origStartCards
+		// All cards come back from the players, e.g. when dealing again
+		d.lock.Lock()
+		d.seenDecryptionKeys = map[string][]*big.Int{}
+		d.encryptedCardsHeldByPlayers = map[string]int{}
+		d.encryptedCardsDealtToPlayers = map[string]int{}
+		for _, p := range d.game.players {
+			p.cardCount = 0
+		}
+		d.lock.Unlock()
 	}
 	// Build stage 0 request
 	req := &pb.ShuffleRequest{
@@ -84,44 +106,59 @@ func (d *deck) Shuffle(startCards []game.Card) error {
 	}
 	for i, card := range d.unencryptedStartCards {
 		req.UnencryptedStartCards[i] = uint32(card)
-		req.WorkingCardSet[i] = big.NewInt(int64(card)).Bytes()
+		req.WorkingCardSet[i] = crypto.CardToInt(d.cardEncoding, card).Bytes()
 	}
-	// Pass it around
+	// Pass it around for stage 0 then again for stage 1, checking each proof as it comes back
 	ctx := context.Background()
-	for playerIndex, player := range d.game.players {
-		resp, err := player.Client.Shuffle(ctx, req)
-		if err != nil {
-			// This assigns blame for the error
-			return game.PlayerErrorf(playerIndex, "Failed shuffle stage 0: %v", err)
-		}
-		req.WorkingCardSet = resp.WorkingCardSet
-	}
-	// Pass around again for stage 1
-	req.Stage = 1
-	for playerIndex, player := range d.game.players {
-		resp, err := player.Client.Shuffle(ctx, req)
-		if err != nil {
-			// This assigns blame for the error
-			return game.PlayerErrorf(playerIndex, "Failed shuffle stage 1: %v", err)
+	responses := make([]*pb.ShuffleResponse, 0, len(d.game.players)*2)
+	for stage := uint32(0); stage <= 1; stage++ {
+		req.Stage = stage
+		for playerIndex, player := range d.game.players {
+			if err := d.game.writeTranscript(transcript.ShuffleEntry(playerIndex, req)); err != nil {
+				return err
+			}
+			resp, err := player.client.Shuffle(ctx, req)
+			if err == nil {
+				var stage0Resp *pb.ShuffleResponse
+				if stage == 1 {
+					stage0Resp = responses[playerIndex]
+				}
+				err = crypto.VerifyShuffleResponse(
+					d.cardCipher, d.handID, int(stage), playerIndex, req.WorkingCardSet, resp, stage0Resp)
+			}
+			if err != nil {
+				// This assigns blame for the error
+				return game.PlayerErrorf(playerIndex, "Failed shuffle stage %v: %v", stage, err)
+			}
+			responses = append(responses, resp)
+			req.WorkingCardSet = resp.WorkingCardSet
 		}
-		req.WorkingCardSet = resp.WorkingCardSet
 	}
 	if len(req.WorkingCardSet) != len(d.unencryptedStartCards) {
 		return fmt.Errorf("The deck size changed during encryption")
 	}
-	// Pass around at end just so they can record the result
+	// Pass around at end so they can check the proofs and record the result
 	req.Stage = 2
+	req.Responses = responses
+	if err := d.game.writeTranscript(transcript.ShuffleEntry(0, req)); err != nil {
+		return err
+	}
 	for playerIndex, player := range d.game.players {
-		if _, err := player.Client.Shuffle(ctx, req); err != nil {
+		if _, err := player.client.Shuffle(ctx, req); err != nil {
 			// This assigns blame for the error
 			return game.PlayerErrorf(playerIndex, "Failed shuffle stage 2: %v", err)
 		}
 	}
-	// Now store the new encrypted deck
+	// Now store the new encrypted deck and what its keys must match
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	d.encryptedCards = make([]*big.Int, len(req.WorkingCardSet))
 	for i, card := range req.WorkingCardSet {
 		d.encryptedCards[i] = new(big.Int).SetBytes(card)
 	}
+	for encCardStr, commitments := range crypto.DecryptionKeyCommitments(len(d.game.players), req) {
+		d.decryptionKeyCommitments[encCardStr] = commitments
+	}
 	return nil
 }
 
@@ -134,17 +171,33 @@ func (d *deck) DealTo(playerIndex int) error {
 	// Now send off to the player as a deal
 	giveReq := &pb.GiveDeckTopCardRequest{DecryptionKeys: make([][]byte, len(d.game.players))}
 	for i, decryptionKey := range decryptionKeys {
-		giveReq.DecryptionKeys[i] = decryptionKey.Bytes()
+		// The player's own key is nil and left empty
+		if decryptionKey != nil {
+			giveReq.DecryptionKeys[i] = decryptionKey.Bytes()
+		}
 	}
+	d.lock.Lock()
 	d.encryptedCardsHeldByPlayers[topCard.String()] = playerIndex
-	_, err = d.game.players[playerIndex].Client.GiveDeckTopCard(context.Background(), giveReq)
-	return err
+	d.encryptedCardsDealtToPlayers[topCard.String()] = playerIndex
+	d.lock.Unlock()
+	if _, err = d.game.players[playerIndex].client.GiveDeckTopCard(context.Background(), giveReq); err != nil {
+		return err
+	}
+	d.lock.Lock()
+	d.game.players[playerIndex].cardCount++
+	d.lock.Unlock()
+	return nil
 }
 
 // This also updates seen decryption keys...do not mutate the result. Doesn't give encryption keys for playerIndex or
 // gives em all if playerIndex out of player array bounds.
 func (d *deck) popTopCardForDeal(playerIndex int) (topCard *big.Int, decryptionKeys []*big.Int, err error) {
 	decryptionKeys = make([]*big.Int, len(d.game.players))
+	// Each key is checked against what the player committed to for the card when shuffling
+	commitments := d.decryptionKeyCommitments[d.encryptedCards[len(d.encryptedCards)-1].String()]
+	if commitments == nil {
+		return nil, nil, fmt.Errorf("Missing dec key commitments for top card")
+	}
 	getTopReq := &pb.GetDeckTopDecryptionKeyRequest{ForPlayerIndex: int32(playerIndex)}
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
@@ -156,8 +209,12 @@ func (d *deck) popTopCardForDeal(playerIndex int) (topCard *big.Int, decryptionK
 			wg.Add(1)
 			go func(playerIndex int, player *clientPlayer) {
 				defer wg.Done()
-				if resp, err := player.Client.GetDeckTopDecryptionKey(ctx, getTopReq); err != nil {
+				if resp, err := player.client.GetDeckTopDecryptionKey(ctx, getTopReq); err != nil {
 					errCh <- game.PlayerErrorf(playerIndex, "Failed getting dec key: %v", err)
+				} else if err = sra.VerifyDecryptionKey(
+					d.cardCipher, commitments[playerIndex], new(big.Int).SetBytes(resp.DecryptionKey),
+				); err != nil {
+					errCh <- game.PlayerErrorf(playerIndex, "Invalid dec key: %v", err)
 				} else {
 					decryptionKeys[playerIndex] = new(big.Int).SetBytes(resp.DecryptionKey)
 				}
@@ -171,13 +228,27 @@ func (d *deck) popTopCardForDeal(playerIndex int) (topCard *big.Int, decryptionK
 	case err = <-errCh:
 	case <-doneCh:
 		// Pop the top card and set the seen decryption keys
+		d.lock.Lock()
+		defer d.lock.Unlock()
 		topCard = d.encryptedCards[len(d.encryptedCards)-1]
 		d.encryptedCards = d.encryptedCards[:len(d.encryptedCards)-1]
 		d.seenDecryptionKeys[topCard.String()] = decryptionKeys
+		err = d.game.writeTranscript(transcript.DecryptionKeysEntry(topCard.Bytes(), intsToBytes(decryptionKeys)))
 	}
 	return
 }
 
+// intsToBytes converts each int to bytes, with nil ones left empty
+func intsToBytes(ints []*big.Int) [][]byte {
+	ret := make([][]byte, len(ints))
+	for i, v := range ints {
+		if v != nil {
+			ret[i] = v.Bytes()
+		}
+	}
+	return ret
+}
+
 func (d *deck) PopForFirstDiscard() (game.Card, error) {
 	// Grab all decryption keys for -1 index (which is all of em)
 	topCard, decryptionKeys, err := d.popTopCardForDeal(-1)
@@ -187,6 +258,119 @@ func (d *deck) PopForFirstDiscard() (game.Card, error) {
 	return d.decryptCard(topCard, decryptionKeys)
 }
 
+func (d *deck) MoveHands(fromIndexes []int) error {
+	// Build the stage 0 requests for every player whose hand moves, leaving the others nil
+	toIndexes := make([]int, len(d.game.players))
+	for i := range toIndexes {
+		toIndexes[i] = -1
+	}
+	for i, fromIndex := range fromIndexes {
+		if fromIndex < 0 || fromIndex >= len(toIndexes) || toIndexes[fromIndex] != -1 {
+			return fmt.Errorf("Invalid hand move")
+		}
+		toIndexes[fromIndex] = i
+	}
+	reqs := make([]*pb.MoveHandRequest, len(d.game.players))
+	for i, fromIndex := range fromIndexes {
+		if fromIndex != i {
+			reqs[i] = &pb.MoveHandRequest{
+				Stage:           0,
+				FromPlayerIndex: uint32(fromIndex),
+				ToPlayerIndex:   uint32(toIndexes[i]),
+			}
+		}
+	}
+	// Stage 0, get each hand and its hidden keys masked by the giver
+	givenResps, err := d.doAllMoveHands(reqs)
+	if err != nil {
+		return err
+	}
+	for i, resp := range givenResps {
+		if resp == nil {
+			continue
+		} else if len(resp.EncryptedCards) != d.game.players[i].cardCount {
+			return game.PlayerErrorf(i, "We expected %v cards, got %v",
+				d.game.players[i].cardCount, len(resp.EncryptedCards))
+		} else if len(resp.MaskedDecryptionKeys) != len(resp.EncryptedCards) {
+			return game.PlayerErrorf(i, "Invalid decryption key count")
+		}
+		// Check that we had given them every card, and only once
+		encCardStrs := map[string]struct{}{}
+		for _, encCard := range resp.EncryptedCards {
+			encCardStr := new(big.Int).SetBytes(encCard).String()
+			if playerIndex, ok := d.encryptedCardsHeldByPlayers[encCardStr]; !ok || playerIndex != i {
+				return game.PlayerErrorf(i, "Found card we hadn't given")
+			} else if _, ok := encCardStrs[encCardStr]; ok {
+				return game.PlayerErrorf(i, "Found same card twice")
+			}
+			encCardStrs[encCardStr] = struct{}{}
+		}
+	}
+	// Stage 1, the getter adds its mask to the keys
+	for i, req := range reqs {
+		if req != nil {
+			req.Stage = 1
+			req.EncryptedCards = givenResps[fromIndexes[i]].EncryptedCards
+			req.MaskedDecryptionKeys = givenResps[fromIndexes[i]].MaskedDecryptionKeys
+		}
+	}
+	gotResps, err := d.doAllMoveHands(reqs)
+	if err != nil {
+		return err
+	}
+	// Stage 2, the giver removes its mask from the keys
+	for i, req := range reqs {
+		if req != nil {
+			if len(gotResps[i].MaskedDecryptionKeys) != len(req.EncryptedCards) {
+				return game.PlayerErrorf(i, "Invalid decryption key count")
+			}
+			req.Stage = 2
+			req.EncryptedCards = nil
+			req.MaskedDecryptionKeys = gotResps[toIndexes[i]].MaskedDecryptionKeys
+		}
+	}
+	unmaskedResps, err := d.doAllMoveHands(reqs)
+	if err != nil {
+		return err
+	}
+	// Stage 3, the getter removes its mask and takes the cards with the keys we have seen
+	for i, req := range reqs {
+		if req != nil {
+			if len(unmaskedResps[i].MaskedDecryptionKeys) != len(req.MaskedDecryptionKeys) {
+				return game.PlayerErrorf(i, "Invalid decryption key count")
+			}
+			req.Stage = 3
+			req.EncryptedCards = givenResps[fromIndexes[i]].EncryptedCards
+			req.MaskedDecryptionKeys = unmaskedResps[fromIndexes[i]].MaskedDecryptionKeys
+			req.CardDecryptionKeys = make([][]byte, 0, len(req.EncryptedCards)*len(d.game.players))
+			for _, encCard := range req.EncryptedCards {
+				for _, seenKey := range d.seenDecryptionKeys[new(big.Int).SetBytes(encCard).String()] {
+					if seenKey == nil {
+						req.CardDecryptionKeys = append(req.CardDecryptionKeys, nil)
+					} else {
+						req.CardDecryptionKeys = append(req.CardDecryptionKeys, seenKey.Bytes())
+					}
+				}
+			}
+		}
+	}
+	if _, err = d.doAllMoveHands(reqs); err != nil {
+		return err
+	}
+	// Everyone has their new hand
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for i, req := range reqs {
+		if req != nil {
+			for _, encCard := range req.EncryptedCards {
+				d.encryptedCardsHeldByPlayers[new(big.Int).SetBytes(encCard).String()] = i
+			}
+			d.game.players[i].cardCount = len(req.EncryptedCards)
+		}
+	}
+	return nil
+}
+
 func (d *deck) CompleteHand() (game.CardDeckHandCompleteReveal, error) {
 	d.game.dataLock.RLock()
 	lastEvent := d.game.lastEvent
@@ -251,30 +435,44 @@ func (d *deck) CompleteHand() (game.CardDeckHandCompleteReveal, error) {
 				return nil, game.PlayerErrorf(i, "Found card we hadn't given")
 			}
 		}
-		// Check all decryption keys to make sure we've either seen them or they are for a card in hand
+		// Check all decryption keys to make sure we've either seen them or they are for a card dealt to them that is
+		// still in someone's hand
 		for encCardStr, decKey := range info.CardDecryptionKeys {
-			_, myCard := encCardsStrsInHand[encCardStr]
-			mySeenKey := d.seenDecryptionKeys[encCardStr][i]
+			_, held := d.encryptedCardsHeldByPlayers[encCardStr]
+			dealtToIndex, dealt := d.encryptedCardsDealtToPlayers[encCardStr]
+			myCard := held && dealt && dealtToIndex == i
+			// Cards still in the deck have no seen keys
+			var mySeenKey *big.Int
+			if seenKeys := d.seenDecryptionKeys[encCardStr]; seenKeys != nil {
+				mySeenKey = seenKeys[i]
+			}
 			if myCard && mySeenKey != nil {
 				return nil, game.PlayerErrorf(i, "Already seen player's dec key for player card")
-			} else if !myCard && mySeenKey.Cmp(new(big.Int).SetBytes(decKey)) != 0 {
+			} else if !myCard && mySeenKey != nil && mySeenKey.Cmp(new(big.Int).SetBytes(decKey)) != 0 {
 				return nil, game.PlayerErrorf(i, "Haven't seen player's dec key before for non-self card")
 			}
+			// Every key must be the one committed to when shuffling
+			if commitments := d.decryptionKeyCommitments[encCardStr]; commitments == nil {
+				return nil, game.PlayerErrorf(i, "Dec key for unknown card")
+			} else if err := sra.VerifyDecryptionKey(
+				d.cardCipher, commitments[i], new(big.Int).SetBytes(decKey),
+			); err != nil {
+				return nil, game.PlayerErrorf(i, "Invalid dec key: %v", err)
+			}
 		}
-		// Add to the score and complete-reveal card set
+		// Add to the score and complete-reveal card set, not scoring the cards of the winner's partners
+		scored := !d.game.rules.Teammates(i, winnerIndex, len(d.game.players))
 		completeReveal.playerCards[i] = make([]game.Card, len(info.UnencryptedCardsInHand))
 		for cardIndex, unencCard := range info.UnencryptedCardsInHand {
-			bigCard := big.NewInt(int64(unencCard))
-			if bigCard.BitLen() > 32 {
-				return nil, game.PlayerErrorf(i, "Invalid unencrypted card")
-			}
-			card := game.Card(bigCard.Int64())
+			card := game.Card(unencCard)
 			if !card.Valid() {
 				return nil, game.PlayerErrorf(i, "Invalid card value")
 			}
 			allCardsTogether = append(allCardsTogether, card)
 			completeReveal.playerCards[i][cardIndex] = card
-			req.Score += uint32(card.Score())
+			if scored {
+				req.Score += uint32(card.Score())
+			}
 		}
 		// Set the info
 		req.PlayerInfos = append(req.PlayerInfos, info)
@@ -291,7 +489,7 @@ func (d *deck) CompleteHand() (game.CardDeckHandCompleteReveal, error) {
 	// First, check each hand
 	for playerIndex, info := range req.PlayerInfos {
 		for cardIndex, encCard := range info.EncryptedCardsInHand {
-			unencCard := game.Card(big.NewInt(int64(info.UnencryptedCardsInHand[cardIndex])).Int64())
+			unencCard := game.Card(info.UnencryptedCardsInHand[cardIndex])
 			if decCard, err := decryptCard(new(big.Int).SetBytes(encCard)); err != nil {
 				return nil, game.PlayerErrorf(playerIndex, "Unable to decrypt hand card: %v", err)
 			} else if decCard != unencCard {
@@ -325,16 +523,21 @@ func (d *deck) CompleteHand() (game.CardDeckHandCompleteReveal, error) {
 			return nil, fmt.Errorf("Card %v ended as %v, expected %v", i, allCardsTogether[i], origCard)
 		}
 	}
-	// Add the score to the winner info score
-	d.game.players[winnerIndex].score += int(req.Score)
-	req.PlayerInfos[winnerIndex].Score += req.Score
+	// Apply the score changes to the player info scores
+	deltas := d.game.rules.ScoringPolicy(len(d.game.players)).HandScoreDeltas(winnerIndex, completeReveal.playerCards)
+	req.PlayerScoreDeltas = make([]uint32, len(deltas))
+	for i, delta := range deltas {
+		d.game.players[i].score += delta
+		req.PlayerInfos[i].Score += uint32(delta)
+		req.PlayerScoreDeltas[i] = uint32(delta)
+	}
 	// Stage 1, send what we've learned and check sigs
 	req.Stage = 1
 	if resps, err = d.doAllHandEnds(req); err != nil {
 		return nil, err
 	}
 	// Check sigs
-	reqBytes, err := proto.Marshal(req)
+	reqBytes, err := pb.MarshalForSig(req)
 	if err != nil {
 		return nil, fmt.Errorf("Failed marshalling req: %v", err)
 	}
@@ -342,7 +545,7 @@ func (d *deck) CompleteHand() (game.CardDeckHandCompleteReveal, error) {
 		respSig, ok := resp.Message.(*pb.HandEndResponse_Sig)
 		if !ok || respSig == nil {
 			return nil, game.PlayerErrorf(i, "Invalid player hand end second response")
-		} else if !d.game.players[i].Identity.VerifySig(reqBytes, respSig.Sig) {
+		} else if !d.game.players[i].identity.VerifySig(reqBytes, respSig.Sig) {
 			return nil, game.PlayerErrorf(i, "Hand end signature verification failed")
 		}
 		completeReveal.endSigs = append(completeReveal.endSigs, respSig.Sig)
@@ -351,6 +554,9 @@ func (d *deck) CompleteHand() (game.CardDeckHandCompleteReveal, error) {
 	d.game.dataLock.Lock()
 	d.game.lastHandEndSigs = completeReveal.endSigs
 	d.game.dataLock.Unlock()
+	if err = d.game.writeTranscript(transcript.HandEndEntry(req, completeReveal.endSigs)); err != nil {
+		return nil, err
+	}
 	return completeReveal, nil
 }
 
@@ -365,7 +571,7 @@ func (d *deck) doAllHandEnds(req *pb.HandEndRequest) ([]*pb.HandEndResponse, err
 		wg.Add(1)
 		go func(i int, p *clientPlayer) {
 			defer wg.Done()
-			if resp, err := p.Client.HandEnd(ctx, req); err != nil {
+			if resp, err := p.client.HandEnd(ctx, req); err != nil {
 				errCh <- game.PlayerErrorf(i, "Failed getting hand end: %v", err)
 			} else {
 				resps[i] = resp
@@ -382,6 +588,37 @@ func (d *deck) doAllHandEnds(req *pb.HandEndRequest) ([]*pb.HandEndResponse, err
 	}
 }
 
+// doAllMoveHands sends the requests to the players at the same index, skipping nil ones
+func (d *deck) doAllMoveHands(reqs []*pb.MoveHandRequest) ([]*pb.MoveHandResponse, error) {
+	// Send em all async, first err causes failure
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+	errCh := make(chan error, len(d.game.players))
+	resps := make([]*pb.MoveHandResponse, len(d.game.players))
+	var wg sync.WaitGroup
+	for i, p := range d.game.players {
+		if reqs[i] != nil {
+			wg.Add(1)
+			go func(i int, p *clientPlayer) {
+				defer wg.Done()
+				if resp, err := p.client.MoveHand(ctx, reqs[i]); err != nil {
+					errCh <- game.PlayerErrorf(i, "Failed moving hand stage %v: %v", reqs[i].Stage, err)
+				} else {
+					resps[i] = resp
+				}
+			}(i, p)
+		}
+	}
+	doneCh := make(chan struct{}, 1)
+	go func() { wg.Wait(); doneCh <- struct{}{} }()
+	select {
+	case err := <-errCh:
+		return nil, err
+	case <-doneCh:
+		return resps, nil
+	}
+}
+
 type handCompleteReveal struct {
 	playerCards [][]game.Card
 	deckCards   []game.Card
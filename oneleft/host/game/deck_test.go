@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/host/client"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/stretchr/testify/require"
+)
+
+// testClient is a connected player that answers the requests its funcs are set for
+type testClient struct {
+	client.Client
+	getDeckTopDecryptionKey   func(*pb.GetDeckTopDecryptionKeyRequest) (*pb.GetDeckTopDecryptionKeyResponse, error)
+	revealCardsForChallenge   func(*pb.RevealCardsForChallengeRequest) (*pb.RevealCardsForChallengeResponse, error)
+	revealedCardsForChallenge func(*pb.RevealedCardsForChallengeRequest) (*pb.RevealedCardsForChallengeResponse, error)
+}
+
+func (*testClient) Running() bool { return true }
+
+func (t *testClient) GetDeckTopDecryptionKey(
+	ctx context.Context, req *pb.GetDeckTopDecryptionKeyRequest,
+) (*pb.GetDeckTopDecryptionKeyResponse, error) {
+	return t.getDeckTopDecryptionKey(req)
+}
+
+func (t *testClient) RevealCardsForChallenge(
+	ctx context.Context, req *pb.RevealCardsForChallengeRequest,
+) (*pb.RevealCardsForChallengeResponse, error) {
+	return t.revealCardsForChallenge(req)
+}
+
+func (t *testClient) RevealedCardsForChallenge(
+	ctx context.Context, req *pb.RevealedCardsForChallengeRequest,
+) (*pb.RevealedCardsForChallengeResponse, error) {
+	return t.revealedCardsForChallenge(req)
+}
+
+func newTestGame(clients ...*testClient) *Game {
+	infos := make([]*PlayerInfo, len(clients))
+	for i, c := range clients {
+		infos[i] = &PlayerInfo{Client: c, Identity: &pb.PlayerIdentity{}}
+	}
+	return New(nil, infos, nil)
+}
+
+func TestPopTopCardForDealBlamesBadKey(t *testing.T) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	cardCipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	// Each player committed to their key for the top card when shuffling
+	pairs := make([]crypto.KeyPair, 3)
+	commitments := make([]*big.Int, len(pairs))
+	for i := range pairs {
+		pairs[i], err = cardCipher.GenerateKeyPair()
+		require.NoError(t, err)
+		commitments[i] = sra.KeyCommitment(cardCipher, pairs[i].EncryptionKey())
+	}
+	topCard := cardCipher.HashToElement([]byte("top card"))
+	require.NotNil(t, topCard)
+
+	// Each test gives the index of the player whose key is replaced by another's, or -1 for none
+	for _, badIndex := range []int{-1, 1, 2} {
+		clients := make([]*testClient, len(pairs))
+		for i := range clients {
+			key := pairs[i].DecryptionKey()
+			if i == badIndex {
+				key = pairs[0].DecryptionKey()
+			}
+			clients[i] = &testClient{
+				getDeckTopDecryptionKey: func(*pb.GetDeckTopDecryptionKeyRequest) (
+					*pb.GetDeckTopDecryptionKeyResponse, error,
+				) {
+					return &pb.GetDeckTopDecryptionKeyResponse{DecryptionKey: key.Bytes()}, nil
+				},
+			}
+		}
+		g := newTestGame(clients...)
+		d, err := newDeck(g, &deckInfo{cardCipher: cardCipher})
+		require.NoError(t, err)
+		d.encryptedCards = []*big.Int{topCard}
+		d.decryptionKeyCommitments[topCard.String()] = commitments
+
+		popped, keys, err := d.popTopCardForDeal(0)
+		if badIndex == -1 {
+			require.NoError(t, err)
+			require.Equal(t, topCard, popped)
+			require.Nil(t, keys[0])
+			for i := 1; i < len(pairs); i++ {
+				require.Equal(t, pairs[i].DecryptionKey(), keys[i])
+			}
+			require.Empty(t, d.encryptedCards)
+			continue
+		}
+		require.Error(t, err)
+		require.Equal(t, badIndex, findErrPlayerIndex(err))
+		require.Contains(t, err.Error(), "Invalid dec key")
+		// The card stays on the deck
+		require.Len(t, d.encryptedCards, 1)
+	}
+}
@@ -25,6 +25,8 @@ func (g *Game) gameEventToPbEvent(event *game.Event) *pb.HostMessage_GameEvent {
 			LastDiscardWildColor: int32(event.Hand.LastDiscardWildColor),
 			Forward:              event.Hand.Forward,
 			OneLeftTarget:        int32(event.Hand.OneLeftTarget),
+			DrawPenalty:          uint32(event.Hand.DrawPenalty),
+			SwapTarget:           int32(event.Hand.SwapTarget),
 		}
 		for i, c := range event.Hand.PlayerCardsRemaining {
 			ret.Hand.PlayerCardsRemaining[i] = uint32(c)
@@ -36,10 +38,14 @@ func (g *Game) gameEventToPbEvent(event *game.Event) *pb.HostMessage_GameEvent {
 	if event.HandComplete != nil {
 		reveal := event.HandComplete.DeckReveal.(*handCompleteReveal)
 		ret.HandComplete = &pb.HostMessage_GameEvent_HandComplete{
-			WinnerIndex: uint32(event.HandComplete.WinnerIndex),
-			Score:       uint32(event.HandComplete.Score),
-			DeckCards:   make([]uint32, len(reveal.deckCards)),
-			PlayerCards: make([]*pb.HostMessage_GameEvent_HandComplete_PlayerCards, len(reveal.playerCards)),
+			WinnerIndex:       uint32(event.HandComplete.WinnerIndex),
+			Score:             uint32(event.HandComplete.Score),
+			DeckCards:         make([]uint32, len(reveal.deckCards)),
+			PlayerCards:       make([]*pb.HostMessage_GameEvent_HandComplete_PlayerCards, len(reveal.playerCards)),
+			PlayerScoreDeltas: make([]uint32, len(event.HandComplete.ScoreDeltas)),
+		}
+		for i, delta := range event.HandComplete.ScoreDeltas {
+			ret.HandComplete.PlayerScoreDeltas[i] = uint32(delta)
 		}
 		for i, c := range reveal.deckCards {
 			ret.HandComplete.DeckCards[i] = uint32(c)
@@ -56,3 +62,20 @@ func (g *Game) gameEventToPbEvent(event *game.Event) *pb.HostMessage_GameEvent {
 	}
 	return ret
 }
+
+func rulesToPb(rules game.Rules) *pb.Rules {
+	return &pb.Rules{
+		HandSize:          uint32(rules.HandSize),
+		TargetScore:       uint32(rules.TargetScore),
+		SingleHand:        rules.SingleHand,
+		DrawUntilPlayable: rules.DrawUntilPlayable,
+		PlayDrawnCard:     rules.PlayDrawnCard,
+		FirstWildDrawFour: pb.Rules_FirstWildDrawFour(rules.FirstWildDrawFour),
+		StackDrawCards:    rules.StackDrawCards,
+		JumpIn:            rules.JumpIn,
+		SevenO:            rules.SevenO,
+		Partners:          rules.Partners,
+		Scoring:           pb.Rules_Scoring(rules.Scoring),
+		HandCount:         uint32(rules.HandCount),
+	}
+}
@@ -1,21 +1,38 @@
 package game
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"fmt"
+	"math/big"
 	"sync"
+	"time"
 
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
 	"github.com/cretz/one-left/oneleft/game"
 	"github.com/cretz/one-left/oneleft/pb"
-	"github.com/golang/protobuf/proto"
+	"github.com/cretz/one-left/oneleft/transcript"
 	"github.com/google/uuid"
 )
 
 type Game struct {
 	id           uuid.UUID
 	players      []*clientPlayer
+	rules        game.Rules
 	eventHandler EventHandler
+	// Nil if no transcript is written
+	transcript *transcript.Writer
+	// 0 if players can take as long as requests can
+	turnTimeout time.Duration
+	// 0 if disconnected players aren't waited for
+	reconnectTimeout time.Duration
+	sharedPrimeBits  int
+	// Nil if primes are generated when each hand starts
+	sharedPrimePool *sra.SafePrimePool
+	// Every player accepts it
+	cardCipher pb.CardCipher
 
 	// Nothing below is ever mutated, always replaced
 	dataLock          sync.RWMutex
@@ -24,29 +41,118 @@ type Game struct {
 	lastEvent         *pb.HostMessage_GameEvent
 	lastGameStartSigs [][]byte
 	lastHandEndSigs   [][]byte
+	// The color in play before the last discard, used for wild draw four challenges
+	colorBeforeLastDiscard game.CardColor
 }
 
 type EventHandler interface {
 	OnEvent(*pb.HostMessage_GameEvent) error
 }
 
-func New(eventHandler EventHandler, players []*PlayerInfo) *Game {
-	ret := &Game{eventHandler: eventHandler, players: make([]*clientPlayer, len(players))}
+// New creates a game for the players. If rules are nil, the default rules are used.
+func New(eventHandler EventHandler, players []*PlayerInfo, rules *game.Rules) *Game {
+	ret := &Game{
+		eventHandler:    eventHandler,
+		players:         make([]*clientPlayer, len(players)),
+		rules:           game.DefaultRules(),
+		sharedPrimeBits: DefaultSharedPrimeBits,
+	}
+	if rules != nil {
+		ret.rules = *rules
+	}
 	var err error
 	if ret.id, err = uuid.NewRandom(); err != nil {
 		panic(err)
 	}
 	for index, playerInfo := range players {
-		ret.players[index] = &clientPlayer{PlayerInfo: playerInfo, index: index, currGame: ret}
+		ret.players[index] = &clientPlayer{
+			identity: playerInfo.Identity,
+			client:   newPlayerClient(ret, playerInfo),
+			index:    index,
+			currGame: ret,
+		}
 	}
 	return ret
 }
 
+// SetTranscript sets the transcript every signed request, event, shuffle, and revealed decryption key is written to.
+// It must be called before the game is played.
+func (g *Game) SetTranscript(w *transcript.Writer) {
+	g.transcript = w
+}
+
+// SetTurnTimeout sets how long players have to decide something in their turn before the default is taken for them.
+// It must be called before the game is played.
+func (g *Game) SetTurnTimeout(turnTimeout time.Duration) {
+	g.turnTimeout = turnTimeout
+}
+
+// SetReconnectTimeout sets how long to wait for a player that disconnected to reconnect before the game fails. It must
+// be called before the game is played.
+func (g *Game) SetReconnectTimeout(reconnectTimeout time.Duration) {
+	g.reconnectTimeout = reconnectTimeout
+}
+
+// SetSharedPrimes sets the bit size of the safe primes hands are shuffled with, which must be at least the minimum of
+// every player, and the pool they're taken from. If the pool is nil, each is generated when its hand starts. It must be
+// called before the game is played.
+func (g *Game) SetSharedPrimes(bits int, pool *sra.SafePrimePool) {
+	g.sharedPrimeBits = bits
+	g.sharedPrimePool = pool
+}
+
+// SetCardCipher sets the cipher hands are shuffled with, which every player must accept. Shared primes are only used
+// for SRA. It must be called before the game is played.
+func (g *Game) SetCardCipher(cardCipher pb.CardCipher) {
+	g.cardCipher = cardCipher
+}
+
+func (g *Game) ID() uuid.UUID { return g.id }
+
 func (g *Game) Player(index int) *PlayerInfo {
 	if index < 0 || index >= len(g.players) {
 		return nil
 	}
-	return g.players[index].PlayerInfo
+	return g.players[index].client.playerInfo()
+}
+
+// Reconnect makes the player at the index, who reconnected as the given player, receive the requests from now on.
+// Requests that failed because they disconnected are sent again.
+func (g *Game) Reconnect(index int, info *PlayerInfo) error {
+	if index < 0 || index >= len(g.players) {
+		return fmt.Errorf("Invalid player index")
+	} else if !bytes.Equal(g.players[index].identity.Id, info.Identity.Id) {
+		return fmt.Errorf("Not the same player")
+	}
+	g.players[index].client.reconnect(info)
+	return nil
+}
+
+// CallOneLeft calls one left on the target for the given player. It does nothing if the player has already called since
+// the last time one left was possible.
+func (g *Game) CallOneLeft(caller *PlayerInfo, targetIndex int) error {
+	if targetIndex < 0 || targetIndex >= len(g.players) {
+		return fmt.Errorf("Invalid target index")
+	}
+	for _, p := range g.players {
+		if p.client.playerInfo() == caller {
+			p.doCallOneLeft(targetIndex)
+			return nil
+		}
+	}
+	return fmt.Errorf("Not a player in the game")
+}
+
+// JumpIn claims a jump-in on the top discard for the given player. It does nothing if the player has already claimed
+// since the top discard changed.
+func (g *Game) JumpIn(claimer *PlayerInfo) error {
+	for _, p := range g.players {
+		if p.client.playerInfo() == claimer {
+			p.doJumpIn()
+			return nil
+		}
+	}
+	return fmt.Errorf("Not a player in the game")
 }
 
 func (g *Game) Play() (*game.GameComplete, error) {
@@ -56,30 +162,52 @@ func (g *Game) Play() (*game.GameComplete, error) {
 		g.dataLock.Unlock()
 		return nil, fmt.Errorf("Already running or already ran")
 	}
+	g.running = true
 	g.dataLock.Unlock()
 	// Run the game
 	gamePlayers := make([]game.Player, len(g.players))
 	for i, p := range g.players {
 		gamePlayers[i] = p
 	}
-	return game.New(gamePlayers, g.newDeck, g.onEvent).Play(0)
+	complete, gameErr := game.New(gamePlayers, g.rules, g.newDeck, g.onEvent).Play(0)
+	// Don't return a nil *game.GameError as a non-nil error
+	if gameErr != nil {
+		return nil, gameErr
+	}
+	return complete, nil
 }
 
-func (g *Game) topDiscardColor() (game.CardColor, error) {
-	if g.lastEvent == nil || g.lastEvent.Hand == nil {
+// colorBeforeWildDrawFour returns the color that was in play before the wild draw four on top of the discard
+func (g *Game) colorBeforeWildDrawFour() (game.CardColor, error) {
+	g.dataLock.RLock()
+	defer g.dataLock.RUnlock()
+	if g.lastEvent == nil || g.lastEvent.Hand == nil || len(g.lastEvent.Hand.DiscardStack) == 0 {
 		return 0, fmt.Errorf("No hand")
 	}
 	topCard := game.Card(g.lastEvent.Hand.DiscardStack[len(g.lastEvent.Hand.DiscardStack)-1])
-	color := topCard.Color()
+	if topCard.Value() != game.WildDrawFour {
+		return 0, fmt.Errorf("Top card is not a wild draw four")
+	}
+	return g.colorBeforeLastDiscard, nil
+}
+
+func topDiscardColor(event *pb.HostMessage_GameEvent) game.CardColor {
+	if event == nil || event.Hand == nil || len(event.Hand.DiscardStack) == 0 {
+		return game.ColorUnknown
+	}
+	topCard := game.Card(event.Hand.DiscardStack[len(event.Hand.DiscardStack)-1])
 	if topCard.Wild() {
-		color = game.CardColor(g.lastEvent.Hand.LastDiscardWildColor)
+		return game.CardColor(event.Hand.LastDiscardWildColor)
 	}
-	return color, nil
+	return topCard.Color()
 }
 
 func (g *Game) onEvent(event *game.Event) error {
 	pbEvent := g.gameEventToPbEvent(event)
 	g.dataLock.Lock()
+	if event.Type == game.EventHandPlayerDiscarded {
+		g.colorBeforeLastDiscard = topDiscardColor(g.lastEvent)
+	}
 	g.lastEvent = pbEvent
 	g.dataLock.Unlock()
 	// On game start and end, we confirm our players agree first
@@ -93,6 +221,9 @@ func (g *Game) onEvent(event *game.Event) error {
 			return err
 		}
 	}
+	if err := g.writeTranscript(transcript.GameEventEntry(pbEvent)); err != nil {
+		return err
+	}
 	// Send it off to the base handler
 	return g.eventHandler.OnEvent(pbEvent)
 }
@@ -102,13 +233,20 @@ func (g *Game) doGameStart() error {
 	defer cancelFn()
 	// Build the request, send it off async, update sigs
 	req := &pb.GameStartRequest{
-		Id:      g.id[:],
-		Players: make([]*pb.PlayerIdentity, len(g.players)),
+		Id:                  g.id[:],
+		Players:             make([]*pb.PlayerIdentity, len(g.players)),
+		Rules:               rulesToPb(g.rules),
+		PlayerTeams:         make([]uint32, len(g.players)),
+		TurnTimeoutMs:       uint32(g.turnTimeout / time.Millisecond),
+		SharedCardPrimeBits: uint32(g.sharedPrimeBits),
 	}
 	for i, p := range g.players {
-		req.Players[i] = p.Identity
+		req.Players[i] = p.identity
+	}
+	for i, team := range g.rules.PlayerTeams(len(g.players)) {
+		req.PlayerTeams[i] = uint32(team)
 	}
-	reqBytes, err := proto.Marshal(req)
+	reqBytes, err := pb.MarshalForSig(req)
 	if err != nil {
 		return err
 	}
@@ -120,10 +258,10 @@ func (g *Game) doGameStart() error {
 		wg.Add(1)
 		go func(i int, p *clientPlayer) {
 			defer wg.Done()
-			resp, err := p.Client.GameStart(ctx, req)
+			resp, err := p.client.GameStart(ctx, req)
 			if err == nil {
 				// Go ahead and verify the sig
-				if p.Identity.VerifySig(reqBytes, resp.Sig) {
+				if p.identity.VerifySig(reqBytes, resp.Sig) {
 					gameStartSigs[i] = resp.Sig
 					return
 				}
@@ -144,7 +282,7 @@ func (g *Game) doGameStart() error {
 	g.dataLock.Lock()
 	g.lastGameStartSigs = gameStartSigs
 	g.dataLock.Unlock()
-	return nil
+	return g.writeTranscript(transcript.GameStartEntry(req, gameStartSigs))
 }
 
 func (g *Game) doGameEnd() error {
@@ -160,21 +298,23 @@ func (g *Game) doGameEnd() error {
 		PlayerScores:          lastEvent.PlayerScores,
 		LastHandEndPlayerSigs: lastHandEndSigs,
 	}
-	reqBytes, err := proto.Marshal(req)
+	reqBytes, err := pb.MarshalForSig(req)
 	if err != nil {
 		return err
 	}
 	// Send off request async and check sigs
+	gameEndSigs := make([][]byte, len(g.players))
 	errCh := make(chan error, len(g.players))
 	var wg sync.WaitGroup
 	for i, p := range g.players {
 		wg.Add(1)
 		go func(i int, p *clientPlayer) {
 			defer wg.Done()
-			resp, err := p.Client.GameEnd(ctx, req)
+			resp, err := p.client.GameEnd(ctx, req)
 			if err == nil {
 				// Go ahead and verify the sig
-				if p.Identity.VerifySig(reqBytes, resp.Sig) {
+				if p.identity.VerifySig(reqBytes, resp.Sig) {
+					gameEndSigs[i] = resp.Sig
 					return
 				}
 				err = fmt.Errorf("Signature invalid")
@@ -189,7 +329,7 @@ func (g *Game) doGameEnd() error {
 	case err := <-errCh:
 		return err
 	case <-doneCh:
-		return nil
+		return g.writeTranscript(transcript.GameEndEntry(req, gameEndSigs))
 	}
 }
 
@@ -197,6 +337,7 @@ func (g *Game) doHandStart() (*deckInfo, error) {
 	// Grab game info
 	g.dataLock.RLock()
 	gameStartSigs := g.lastGameStartSigs
+	lastHandEndSigs := g.lastHandEndSigs
 	lastEvent := g.lastEvent
 	g.dataLock.RUnlock()
 	ctx, cancelFn := context.WithCancel(context.Background())
@@ -207,22 +348,47 @@ func (g *Game) doHandStart() (*deckInfo, error) {
 	if ret.handID, err = uuid.NewRandom(); err != nil {
 		return nil, fmt.Errorf("Failed generating hand ID: %v", err)
 	}
-	if ret.sharedPrime, err = rand.Prime(rand.Reader, sharedPrimeBits); err != nil {
-		return nil, fmt.Errorf("Failed generating shared prime: %v", err)
+	// Only SRA is shuffled with a shared prime
+	var sharedPrime *big.Int
+	if g.cardCipher == pb.CardCipher_SRA {
+		if g.sharedPrimePool != nil {
+			sharedPrime, err = g.sharedPrimePool.Get(ctx, g.sharedPrimeBits)
+		} else {
+			sharedPrime, err = sra.GenerateSafePrime(rand.Reader, g.sharedPrimeBits)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed generating shared prime: %v", err)
+		}
+	}
+	if ret.cardCipher, err = crypto.NewCardCipher(g.cardCipher, sharedPrime); err != nil {
+		return nil, fmt.Errorf("Failed creating card cipher: %v", err)
+	}
+	cardEncodingSeed := make([]byte, sra.MinCardEncodingSeedLen)
+	if _, err = rand.Read(cardEncodingSeed); err != nil {
+		return nil, fmt.Errorf("Failed generating card encoding seed: %v", err)
+	} else if ret.cardEncoding, err = crypto.NewCardEncoding(ret.cardCipher, cardEncodingSeed); err != nil {
+		return nil, fmt.Errorf("Failed creating card encoding: %v", err)
 	}
 	// Build the request, send it off async, update sigs
 	req := &pb.HandStartRequest{
-		Id:                  ret.handID[:],
-		SharedCardPrime:     ret.sharedPrime.Bytes(),
-		PlayerScores:        lastEvent.PlayerScores,
-		DealerIndex:         lastEvent.DealerIndex + 1,
-		GameStartPlayerSigs: gameStartSigs,
+		Id:                    ret.handID[:],
+		PlayerScores:          lastEvent.PlayerScores,
+		DealerIndex:           lastEvent.DealerIndex,
+		GameStartPlayerSigs:   gameStartSigs,
+		LastHandEndPlayerSigs: lastHandEndSigs,
+		CardEncodingSeed:      cardEncodingSeed,
+		CardCipher:            g.cardCipher,
 	}
-	// Wrap the dealer index
-	if req.DealerIndex == uint32(len(g.players)) {
-		req.DealerIndex = 0
+	if sharedPrime != nil {
+		req.SharedCardPrime = sharedPrime.Bytes()
 	}
-	reqBytes, err := proto.Marshal(req)
+	// The first hand uses the game start dealer, others move to the next one and wrap
+	if lastEvent.Type != pb.HostMessage_GameEvent_GAME_START {
+		if req.DealerIndex++; req.DealerIndex == uint32(len(g.players)) {
+			req.DealerIndex = 0
+		}
+	}
+	reqBytes, err := pb.MarshalForSig(req)
 	if err != nil {
 		return nil, err
 	}
@@ -234,10 +400,10 @@ func (g *Game) doHandStart() (*deckInfo, error) {
 		wg.Add(1)
 		go func(i int, p *clientPlayer) {
 			defer wg.Done()
-			resp, err := p.Client.HandStart(ctx, req)
+			resp, err := p.client.HandStart(ctx, req)
 			if err == nil {
 				// Go ahead and verify the sig
-				if p.Identity.VerifySig(reqBytes, resp.Sig) {
+				if p.identity.VerifySig(reqBytes, resp.Sig) {
 					ret.handStartSigs[i] = resp.Sig
 					return
 				}
@@ -253,6 +419,9 @@ func (g *Game) doHandStart() (*deckInfo, error) {
 	case err := <-errCh:
 		return nil, err
 	case <-doneCh:
+		if err := g.writeTranscript(transcript.HandStartEntry(req, ret.handStartSigs)); err != nil {
+			return nil, err
+		}
 		return ret, nil
 	}
 }
@@ -265,6 +434,14 @@ func (g *Game) newDeck() (game.CardDeck, error) {
 	return newDeck(g, info)
 }
 
+// writeTranscript appends the entry to the transcript if there is one
+func (g *Game) writeTranscript(entry *pb.TranscriptEntry) error {
+	if g.transcript == nil {
+		return nil
+	}
+	return g.transcript.Write(entry)
+}
+
 func (g *Game) MakePbError(err error) *pb.HostMessage_Error {
 	return &pb.HostMessage_Error{
 		GameId:         g.id[:],
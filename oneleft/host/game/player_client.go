@@ -0,0 +1,231 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cretz/one-left/oneleft/host/client"
+	"github.com/cretz/one-left/oneleft/pb"
+)
+
+// playerClient makes requests of a player in the game. If the player disconnects before responding, it waits for them
+// to reconnect and sends the request again. Requests keep their sequence number when sent again, so the player can
+// give the same response to a request it already handled.
+type playerClient struct {
+	currGame *Game
+	// Accessed atomically
+	requestSeq uint64
+
+	lock sync.RWMutex
+	// Replaced when the player reconnects
+	info *PlayerInfo
+	// Closed and replaced when the player reconnects
+	reconnectedCh chan struct{}
+}
+
+func newPlayerClient(currGame *Game, info *PlayerInfo) *playerClient {
+	return &playerClient{currGame: currGame, info: info, reconnectedCh: make(chan struct{})}
+}
+
+func (p *playerClient) playerInfo() *PlayerInfo {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.info
+}
+
+// reconnect makes the requests go to the player's new client, including the ones waiting for them to reconnect
+func (p *playerClient) reconnect(info *PlayerInfo) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.info = info
+	close(p.reconnectedCh)
+	p.reconnectedCh = make(chan struct{})
+}
+
+func (p *playerClient) sendNonBlocking(msg *pb.HostMessage) error {
+	return p.playerInfo().Client.SendNonBlocking(msg)
+}
+
+func (p *playerClient) rpc(ctx context.Context, fn func(context.Context, client.Client) error) error {
+	ctx = client.WithRequestSeq(ctx, atomic.AddUint64(&p.requestSeq, 1))
+	for {
+		p.lock.RLock()
+		c, reconnectedCh := p.info.Client, p.reconnectedCh
+		p.lock.RUnlock()
+		err := fn(ctx, c)
+		// Only requests that failed because the player disconnected are sent again
+		if err == nil || c.Running() || ctx.Err() != nil {
+			return err
+		} else if err = p.waitForReconnect(ctx, reconnectedCh); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *playerClient) waitForReconnect(ctx context.Context, reconnectedCh chan struct{}) error {
+	if p.currGame.reconnectTimeout == 0 {
+		return fmt.Errorf("Player disconnected")
+	}
+	timer := time.NewTimer(p.currGame.reconnectTimeout)
+	defer timer.Stop()
+	select {
+	case <-reconnectedCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return fmt.Errorf("Player disconnected and didn't reconnect in time")
+	}
+}
+
+func (p *playerClient) GameStart(ctx context.Context, req *pb.GameStartRequest) (*pb.GameStartResponse, error) {
+	var resp *pb.GameStartResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.GameStart(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) GameEnd(ctx context.Context, req *pb.GameEndRequest) (*pb.GameEndResponse, error) {
+	var resp *pb.GameEndResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.GameEnd(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.HandStartResponse, error) {
+	var resp *pb.HandStartResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.HandStart(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) HandEnd(ctx context.Context, req *pb.HandEndRequest) (*pb.HandEndResponse, error) {
+	var resp *pb.HandEndResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.HandEnd(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.ShuffleResponse, error) {
+	var resp *pb.ShuffleResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.Shuffle(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) ChooseColorSinceFirstCardIsWild(
+	ctx context.Context, req *pb.ChooseColorSinceFirstCardIsWildRequest,
+) (*pb.ChooseColorSinceFirstCardIsWildResponse, error) {
+	var resp *pb.ChooseColorSinceFirstCardIsWildResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.ChooseColorSinceFirstCardIsWild(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) GetDeckTopDecryptionKey(
+	ctx context.Context, req *pb.GetDeckTopDecryptionKeyRequest,
+) (*pb.GetDeckTopDecryptionKeyResponse, error) {
+	var resp *pb.GetDeckTopDecryptionKeyResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.GetDeckTopDecryptionKey(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) GiveDeckTopCard(
+	ctx context.Context, req *pb.GiveDeckTopCardRequest,
+) (*pb.GiveDeckTopCardResponse, error) {
+	var resp *pb.GiveDeckTopCardResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.GiveDeckTopCard(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) Play(ctx context.Context, req *pb.PlayRequest) (*pb.PlayResponse, error) {
+	var resp *pb.PlayResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.Play(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) ShouldChallengeWildDrawFour(
+	ctx context.Context, req *pb.ShouldChallengeWildDrawFourRequest,
+) (*pb.ShouldChallengeWildDrawFourResponse, error) {
+	var resp *pb.ShouldChallengeWildDrawFourResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.ShouldChallengeWildDrawFour(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) RevealCardsForChallenge(
+	ctx context.Context, req *pb.RevealCardsForChallengeRequest,
+) (*pb.RevealCardsForChallengeResponse, error) {
+	var resp *pb.RevealCardsForChallengeResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.RevealCardsForChallenge(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) RevealedCardsForChallenge(
+	ctx context.Context, req *pb.RevealedCardsForChallengeRequest,
+) (*pb.RevealedCardsForChallengeResponse, error) {
+	var resp *pb.RevealedCardsForChallengeResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.RevealedCardsForChallenge(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) JumpIn(ctx context.Context, req *pb.JumpInRequest) (*pb.JumpInResponse, error) {
+	var resp *pb.JumpInResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.JumpIn(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) ChooseSwapTarget(
+	ctx context.Context, req *pb.ChooseSwapTargetRequest,
+) (*pb.ChooseSwapTargetResponse, error) {
+	var resp *pb.ChooseSwapTargetResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.ChooseSwapTarget(ctx, req)
+		return
+	})
+	return resp, err
+}
+
+func (p *playerClient) MoveHand(ctx context.Context, req *pb.MoveHandRequest) (*pb.MoveHandResponse, error) {
+	var resp *pb.MoveHandResponse
+	err := p.rpc(ctx, func(ctx context.Context, c client.Client) (err error) {
+		resp, err = c.MoveHand(ctx, req)
+		return
+	})
+	return resp, err
+}
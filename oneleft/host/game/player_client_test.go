@@ -0,0 +1,96 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cretz/one-left/oneleft/host/client"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/stretchr/testify/require"
+)
+
+// seqClient is a player connection that records the sequence number of each hand start request it gets. Requests to
+// it fail once it stops running.
+type seqClient struct {
+	client.Client
+	running bool
+
+	seqsLock sync.Mutex
+	seqs     []uint64
+}
+
+func (s *seqClient) Running() bool { return s.running }
+
+func (s *seqClient) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.HandStartResponse, error) {
+	s.seqsLock.Lock()
+	s.seqs = append(s.seqs, client.RequestSeq(ctx))
+	s.seqsLock.Unlock()
+	if !s.running {
+		return nil, fmt.Errorf("Client not running")
+	}
+	return &pb.HandStartResponse{}, nil
+}
+
+func (s *seqClient) requestSeqs() []uint64 {
+	s.seqsLock.Lock()
+	defer s.seqsLock.Unlock()
+	return append([]uint64{}, s.seqs...)
+}
+
+func newTestPlayerClient(c *seqClient, reconnectTimeout time.Duration) *playerClient {
+	g := newTestGame(&testClient{})
+	g.SetReconnectTimeout(reconnectTimeout)
+	return newPlayerClient(g, &PlayerInfo{Client: c, Identity: &pb.PlayerIdentity{}})
+}
+
+func TestPlayerClientResendsAfterReconnect(t *testing.T) {
+	disconnected := &seqClient{}
+	p := newTestPlayerClient(disconnected, time.Minute)
+	respCh, errCh := make(chan *pb.HandStartResponse, 1), make(chan error, 1)
+	go func() {
+		resp, err := p.HandStart(context.Background(), &pb.HandStartRequest{})
+		respCh <- resp
+		errCh <- err
+	}()
+	// Wait for the request to fail, then reconnect
+	for len(disconnected.requestSeqs()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	reconnected := &seqClient{running: true}
+	p.reconnect(&PlayerInfo{Client: reconnected, Identity: &pb.PlayerIdentity{}})
+	require.NotNil(t, <-respCh)
+	require.NoError(t, <-errCh)
+	// The request is sent again with the same sequence number, and later requests get the next one
+	require.Equal(t, []uint64{1}, disconnected.requestSeqs())
+	require.Equal(t, []uint64{1}, reconnected.requestSeqs())
+	_, err := p.HandStart(context.Background(), &pb.HandStartRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, reconnected.requestSeqs())
+}
+
+func TestPlayerClientDoesNotResend(t *testing.T) {
+	// Failures from a running client are returned as is
+	p := newTestPlayerClient(&seqClient{running: true}, time.Minute)
+	err := p.rpc(context.Background(), func(context.Context, client.Client) error { return fmt.Errorf("Bad request") })
+	require.EqualError(t, err, "Bad request")
+
+	// Without a reconnect timeout, a disconnected player fails the request
+	p = newTestPlayerClient(&seqClient{}, 0)
+	_, err = p.HandStart(context.Background(), &pb.HandStartRequest{})
+	require.EqualError(t, err, "Player disconnected")
+
+	// Players that don't reconnect in time fail the request
+	p = newTestPlayerClient(&seqClient{}, 10*time.Millisecond)
+	_, err = p.HandStart(context.Background(), &pb.HandStartRequest{})
+	require.EqualError(t, err, "Player disconnected and didn't reconnect in time")
+
+	// Waiting stops when the request is cancelled
+	p = newTestPlayerClient(&seqClient{}, time.Minute)
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelFn()
+	_, err = p.HandStart(ctx, &pb.HandStartRequest{})
+	require.Equal(t, context.DeadlineExceeded, err)
+}
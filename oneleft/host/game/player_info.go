@@ -8,4 +8,31 @@ import (
 type PlayerInfo struct {
 	Client   client.Client
 	Identity *pb.PlayerIdentity
+	// The smallest shared prime the player accepts, 0 if any
+	MinSharedPrimeBits int
+	// The card ciphers the player accepts besides SRA
+	CardCiphers []pb.CardCipher
+}
+
+// AcceptsCardCipher is true if the card cipher is SRA or one of the player's others.
+func (p *PlayerInfo) AcceptsCardCipher(cardCipher pb.CardCipher) bool {
+	if cardCipher == pb.CardCipher_SRA {
+		return true
+	}
+	for _, accepted := range p.CardCiphers {
+		if accepted == cardCipher {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiateCardCipher returns the card cipher if every player accepts it, otherwise SRA, which every player accepts.
+func NegotiateCardCipher(cardCipher pb.CardCipher, players []*PlayerInfo) pb.CardCipher {
+	for _, info := range players {
+		if !info.AcceptsCardCipher(cardCipher) {
+			return pb.CardCipher_SRA
+		}
+	}
+	return cardCipher
 }
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCardCipher(t *testing.T) {
+	ec := &PlayerInfo{CardCiphers: []pb.CardCipher{pb.CardCipher_EC_P256}}
+	sraOnly := &PlayerInfo{}
+	tests := []struct {
+		name      string
+		preferred pb.CardCipher
+		players   []*PlayerInfo
+		expected  pb.CardCipher
+	}{
+		{"all accept EC", pb.CardCipher_EC_P256, []*PlayerInfo{ec, ec, ec}, pb.CardCipher_EC_P256},
+		{"one only accepts SRA", pb.CardCipher_EC_P256, []*PlayerInfo{ec, sraOnly, ec}, pb.CardCipher_SRA},
+		{"SRA preferred", pb.CardCipher_SRA, []*PlayerInfo{ec, ec}, pb.CardCipher_SRA},
+		{"SRA always accepted", pb.CardCipher_SRA, []*PlayerInfo{sraOnly, sraOnly}, pb.CardCipher_SRA},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, NegotiateCardCipher(test.preferred, test.players), test.name)
+	}
+}
@@ -2,7 +2,6 @@ package host
 
 import (
 	"sync"
-	"time"
 
 	"github.com/cretz/one-left/oneleft/host/client"
 	"github.com/cretz/one-left/oneleft/host/game"
@@ -10,6 +9,8 @@ import (
 )
 
 type Host struct {
+	conf *Config
+
 	lock sync.RWMutex
 	// Maps can be added or deleted from, but val is never mutated, always replaced
 	clients            map[uint64]*game.PlayerInfo
@@ -22,19 +23,49 @@ type Host struct {
 	chatMessages []*pb.ChatMessage
 	// Never mutated, always replaced
 	lastGameEvent *pb.HostMessage_GameEvent
-	gameRunning   bool
+	// Every event of the current game so far, only ever appended to
+	gameEvents  []*pb.HostMessage_GameEvent
+	gameRunning bool
+	currGame    *game.Game
 }
 
-// TODO config
-const maxClientRPCWait = 1 * time.Minute
-const maxPlayers = 10
 const maxChatMessagesKept = 50
 const randomNonceSize = 10
 const maxNameLen = 80
 const maxChatContentLen = 500
 
-func New() *Host {
+func New(conf *Config) *Host {
+	// Copy the conf to apply defaults
+	confCopy := Config{}
+	if conf != nil {
+		confCopy = *conf
+	}
+	if confCopy.MaxClientRPCWait == 0 {
+		confCopy.MaxClientRPCWait = DefaultMaxClientRPCWait
+	}
+	if confCopy.TurnTimeout == 0 {
+		confCopy.TurnTimeout = DefaultTurnTimeout
+	}
+	if confCopy.TurnTimeout > confCopy.MaxClientRPCWait {
+		confCopy.TurnTimeout = confCopy.MaxClientRPCWait
+	}
+	if confCopy.ReconnectTimeout == 0 {
+		confCopy.ReconnectTimeout = DefaultReconnectTimeout
+	}
+	if confCopy.MaxPlayers == 0 {
+		confCopy.MaxPlayers = DefaultMaxPlayers
+	}
+	if confCopy.SharedPrimeBits == 0 {
+		confCopy.SharedPrimeBits = game.DefaultSharedPrimeBits
+	}
+	if confCopy.MaxSharedPrimeBits == 0 {
+		confCopy.MaxSharedPrimeBits = DefaultMaxSharedPrimeBits
+	}
+	if confCopy.SharedPrimeBits > confCopy.MaxSharedPrimeBits {
+		confCopy.MaxSharedPrimeBits = confCopy.SharedPrimeBits
+	}
 	return &Host{
+		conf:               &confCopy,
 		clients:            map[uint64]*game.PlayerInfo{},
 		clientChatCounters: map[uint64]uint32{},
 	}
@@ -42,18 +73,42 @@ func New() *Host {
 
 func (h *Host) Stream(stream pb.Host_StreamServer) error {
 	// Just run the client
-	return client.New(&requestHandler{h}, stream, maxClientRPCWait).Run()
+	return client.New(&requestHandler{h}, stream, h.conf.MaxClientRPCWait).Run()
 }
 
 func (h *Host) PlayGame() error {
 	h.lock.Lock()
-	g := game.New(&eventHandler{h}, h.gamePlayers)
+	g := game.New(&eventHandler{h}, h.gamePlayers, h.conf.Rules)
+	g.SetTurnTimeout(h.conf.TurnTimeout)
+	g.SetReconnectTimeout(h.conf.ReconnectTimeout)
+	// Every player has to accept the prime size
+	sharedPrimeBits := h.conf.SharedPrimeBits
+	for _, info := range h.gamePlayers {
+		if info.MinSharedPrimeBits > sharedPrimeBits {
+			sharedPrimeBits = info.MinSharedPrimeBits
+		}
+	}
+	g.SetSharedPrimes(sharedPrimeBits, h.conf.SharedPrimePool)
+	// Every player has to accept the card cipher too
+	g.SetCardCipher(game.NegotiateCardCipher(h.conf.CardCipher, h.gamePlayers))
+	if h.conf.Transcript != nil {
+		g.SetTranscript(h.conf.Transcript)
+	}
 	h.gameRunning = true
+	h.currGame = g
+	h.gameEvents = nil
 	h.lock.Unlock()
 	defer func() {
 		h.lock.Lock()
 		defer h.lock.Unlock()
 		h.gameRunning = false
+		h.currGame = nil
+		// Players that disconnected during the game and never came back lose their seat now that it's over
+		for _, info := range h.gamePlayers {
+			if !info.Client.Running() {
+				h.removePlayerUnsafe(info)
+			}
+		}
 	}()
 	// TODO: what to do with game complete scores?
 	_, err := g.Play()
@@ -64,12 +119,44 @@ func (h *Host) PlayGame() error {
 	return err
 }
 
+func (h *Host) PlayerCount() int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return len(h.gamePlayers)
+}
+
 func (h *Host) GameRunning() bool {
 	h.lock.RLock()
 	defer h.lock.RUnlock()
 	return h.gameRunning
 }
 
+// Unsafe because it expects callers to lock
+func (h *Host) removePlayerUnsafe(info *game.PlayerInfo) {
+	// Player slices are copy-on-write, so filter out the player
+	newProtoPlayers := []*pb.PlayerIdentity{}
+	newGamePlayers := []*game.PlayerInfo{}
+	for _, existingInfo := range h.gamePlayers {
+		if existingInfo != info {
+			newProtoPlayers = append(newProtoPlayers, existingInfo.Identity)
+			newGamePlayers = append(newGamePlayers, existingInfo)
+		}
+	}
+	h.protoPlayers = newProtoPlayers
+	h.gamePlayers = newGamePlayers
+	h.sendPlayerUpdatesUnsafe()
+}
+
+// Unsafe because it expects callers to lock
+func (h *Host) sendPlayerUpdatesUnsafe() {
+	msg := &pb.HostMessage{Message: &pb.HostMessage_PlayersUpdate{
+		PlayersUpdate: &pb.HostMessage_Players{Players: h.protoPlayers},
+	}}
+	for _, client := range h.clients {
+		client.Client.SendNonBlocking(msg)
+	}
+}
+
 func (h *Host) sendGameError(g *game.Game, err error) {
 	pbErr := g.MakePbError(err)
 	msg := &pb.HostMessage{Message: &pb.HostMessage_Error_{Error: pbErr}}
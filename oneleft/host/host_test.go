@@ -0,0 +1,406 @@
+package host_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/host"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/player"
+	"github.com/cretz/one-left/oneleft/player/bot"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// testPrimeBits is the smallest shared prime players accept, to keep the games quick
+const testPrimeBits = 128
+
+// recordingUI passes everything to the bot and records the game events it's sent
+type recordingUI struct {
+	iface.Interface
+
+	lock   sync.Mutex
+	events []*iface.GameEvent
+}
+
+func (r *recordingUI) GameEvent(ctx context.Context, event *iface.GameEvent) error {
+	r.lock.Lock()
+	r.events = append(r.events, event)
+	r.lock.Unlock()
+	return r.Interface.GameEvent(ctx, event)
+}
+
+func (r *recordingUI) eventCount(typ game.EventType) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	count := 0
+	for _, event := range r.events {
+		if event.Type == typ {
+			count++
+		}
+	}
+	return count
+}
+
+// testBot is a bot connected to the host as a player
+type testBot struct {
+	ui      *recordingUI
+	keyPair ed25519.KeyPair
+	conn    *grpc.ClientConn
+	player  player.Player
+}
+
+// uiWrapper gives the UI the bot's player is given instead of the bot itself, which it usually passes calls on to
+type uiWrapper func(b *testBot, ui iface.Interface) iface.Interface
+
+// playBotGame plays a game between bots on a host with the config, which gets small primes if unset. The players'
+// configs are given the bots' UIs and, unless they have them, keys. They are left as is otherwise.
+func playBotGame(t *testing.T, conf *host.Config, playerConfs ...*player.Config) []*testBot {
+	return playWrappedBotGame(t, conf, nil, playerConfs...)
+}
+
+// playWrappedBotGame is playBotGame with each bot's UI wrapped if the wrapper is non-nil
+func playWrappedBotGame(t *testing.T, conf *host.Config, wrap uiWrapper, playerConfs ...*player.Config) []*testBot {
+	if conf.SharedPrimeBits == 0 {
+		conf.SharedPrimeBits = testPrimeBits
+	}
+	h := host.New(conf)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	pb.RegisterHostServer(server, h)
+	go server.Serve(lis)
+	defer server.Stop()
+	bots := make([]*testBot, len(playerConfs))
+	for i, playerConf := range playerConfs {
+		keyPair := playerConf.KeyPair
+		if keyPair == nil {
+			keyPair, err = ed25519.GenerateKey(nil)
+			require.NoError(t, err)
+		}
+		ui := bot.New(&bot.Config{ID: keyPair.PublicKey(), Difficulty: bot.Medium})
+		bots[i] = &testBot{ui: &recordingUI{Interface: ui}, keyPair: keyPair}
+		if wrap != nil {
+			bots[i].ui.Interface = wrap(bots[i], ui)
+		}
+		ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+		bots[i].conn, err = grpc.DialContext(ctx, lis.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+		cancelFn()
+		require.NoError(t, err)
+		defer bots[i].conn.Close()
+		stream, err := pb.NewHostClient(bots[i].conn).Stream(context.Background())
+		require.NoError(t, err)
+		playerConf.KeyPair = keyPair
+		playerConf.UI = bots[i].ui
+		playerConf.JoinOnWelcome = true
+		if playerConf.Name == "" {
+			playerConf.Name = fmt.Sprintf("bot%v", i+1)
+		}
+		if playerConf.MinSharedPrimeBits == 0 {
+			playerConf.MinSharedPrimeBits = testPrimeBits
+		}
+		p := player.New(stream, playerConf)
+		bots[i].player = p
+		ui.SetPlayer(p)
+		go p.Run()
+	}
+	for h.PlayerCount() < len(bots) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, h.PlayGame())
+	// Events are sent without waiting, so let every bot see the end before disconnecting them
+	for _, b := range bots {
+		for b.ui.eventCount(game.EventGameEnd) == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	return bots
+}
+
+func newPlayerConfs(count int) []*player.Config {
+	ret := make([]*player.Config, count)
+	for i := range ret {
+		ret[i] = &player.Config{}
+	}
+	return ret
+}
+
+func TestMoveHands(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.SevenO = true
+	// Enough hands that a 7 or 0 is all but sure to be played
+	rules.Scoring, rules.HandCount = game.ScoringFixedHands, 2
+	bots := playBotGame(t, &host.Config{Rules: &rules}, newPlayerConfs(3)...)
+	// Every hand was completed after the moves, so every player's cards matched what the host gave them
+	moves := bots[0].ui.eventCount(game.EventHandPlayerSwappedHands) + bots[0].ui.eventCount(game.EventHandHandsRotated)
+	require.NotZero(t, moves)
+	require.Equal(t, 2, bots[0].ui.eventCount(game.EventHandEnd))
+}
+
+func TestTranscriptVerifies(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.Scoring, rules.HandCount = game.ScoringFixedHands, 2
+	// The host and one player write transcripts
+	var hostBuf, playerBuf bytes.Buffer
+	hostTranscript, err := transcript.NewWriter(&hostBuf, nil)
+	require.NoError(t, err)
+	playerConfs := newPlayerConfs(3)
+	playerConfs[1].KeyPair, err = ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	playerConfs[1].Transcript, err = transcript.NewWriter(&playerBuf, playerConfs[1].KeyPair.PublicKey())
+	require.NoError(t, err)
+	playBotGame(t, &host.Config{Rules: &rules, Transcript: hostTranscript}, playerConfs...)
+
+	for name, b := range map[string][]byte{"host": hostBuf.Bytes(), "player": playerBuf.Bytes()} {
+		result, err := player.VerifyTranscript(bytes.NewReader(b))
+		require.NoError(t, err, name)
+		require.Len(t, result.Games, 1, name)
+		require.True(t, result.Games[0].Complete, name)
+		require.Equal(t, 2, result.Games[0].Hands, name)
+		if name == "player" {
+			require.Equal(t, []byte(playerConfs[1].KeyPair.PublicKey()), result.WriterID)
+		}
+	}
+
+	// Changing any signature, event, or revealed key fails verification
+	tamperings := []struct {
+		name string
+		// Changes the entry and returns true, or returns false if it isn't one to change
+		tamper func(entry *pb.TranscriptEntry) bool
+		err    string
+	}{
+		{"game start signature", func(entry *pb.TranscriptEntry) bool {
+			e, ok := entry.Entry.(*pb.TranscriptEntry_GameStart)
+			if ok {
+				e.GameStart.PlayerSigs[2][0] ^= 1
+			}
+			return ok
+		}, "Invalid game start signature from player 2"},
+		{"hand end signature", func(entry *pb.TranscriptEntry) bool {
+			e, ok := entry.Entry.(*pb.TranscriptEntry_HandEnd)
+			if ok {
+				e.HandEnd.PlayerSigs[0][0] ^= 1
+			}
+			return ok
+		}, "Invalid hand end signature from player 0"},
+		{"event", func(entry *pb.TranscriptEntry) bool {
+			e, ok := entry.Entry.(*pb.TranscriptEntry_GameEvent)
+			if ok && game.EventType(e.GameEvent.Type) == game.EventHandPlayerDiscarded {
+				e.GameEvent.Hand.PlayerIndex = (e.GameEvent.Hand.PlayerIndex + 1) % 3
+				return true
+			}
+			return false
+		}, "Invalid HandPlayerDiscarded event: Not player's turn"},
+		{"revealed key", func(entry *pb.TranscriptEntry) bool {
+			e, ok := entry.Entry.(*pb.TranscriptEntry_DecryptionKeys_)
+			if !ok {
+				return false
+			}
+			for _, key := range e.DecryptionKeys.Keys {
+				if len(key) > 0 {
+					key[len(key)-1] ^= 1
+					return true
+				}
+			}
+			return false
+		}, "revealed an invalid key: Decryption key doesn't match commitment"},
+	}
+	for _, tampering := range tamperings {
+		tampered, err := tamperTranscript(hostBuf.Bytes(), tampering.tamper)
+		require.NoError(t, err, tampering.name)
+		_, err = player.VerifyTranscript(bytes.NewReader(tampered))
+		require.Error(t, err, tampering.name)
+		require.Contains(t, err.Error(), tampering.err, tampering.name)
+	}
+}
+
+// oneLeftCaller has a player call one-left on the one that just got it while another player is deciding their play, up
+// to a few times since a player called on every time can never win. The players' bots don't call it until then.
+type oneLeftCaller struct {
+	lock sync.Mutex
+	// By game index
+	players []player.Player
+	// -1 if nobody can be called on
+	target   int
+	calls    int
+	maxCalls int
+}
+
+type oneLeftCallerUI struct {
+	iface.Interface
+	caller *oneLeftCaller
+	bot    *testBot
+	index  int
+}
+
+func (o *oneLeftCaller) wrap(b *testBot, ui iface.Interface) iface.Interface {
+	return &oneLeftCallerUI{Interface: ui, caller: o, bot: b}
+}
+
+func (o *oneLeftCallerUI) GameStart(
+	ctx context.Context, id uuid.UUID, players []*iface.Player, rules game.Rules,
+) error {
+	o.caller.lock.Lock()
+	for i, p := range players {
+		if bytes.Equal(p.ID, o.bot.keyPair.PublicKey()) {
+			o.index = i
+			o.caller.players[i] = o.bot.player
+		}
+	}
+	o.caller.lock.Unlock()
+	return o.Interface.GameStart(ctx, id, players, rules)
+}
+
+func (o *oneLeftCallerUI) OneLeftCallWindow(ctx context.Context, playerIndex int) error {
+	o.caller.lock.Lock()
+	done := o.caller.calls >= o.caller.maxCalls
+	if !done {
+		o.caller.target = playerIndex
+	}
+	o.caller.lock.Unlock()
+	if done {
+		return o.Interface.OneLeftCallWindow(ctx, playerIndex)
+	}
+	return nil
+}
+
+func (o *oneLeftCallerUI) Play(ctx context.Context) (game.Card, game.CardColor, error) {
+	// Someone that is neither us nor the target calls it and we give the host time to take the call before playing
+	o.caller.lock.Lock()
+	target := o.caller.target
+	var callerPlayer player.Player
+	if target >= 0 && target != o.index && o.caller.calls < o.caller.maxCalls {
+		o.caller.target = -1
+		o.caller.calls++
+		for i, p := range o.caller.players {
+			if i != o.index && i != target {
+				callerPlayer = p
+			}
+		}
+	}
+	o.caller.lock.Unlock()
+	if callerPlayer != nil {
+		if err := callerPlayer.CallOneLeft(target); err != nil {
+			return 0, 0, err
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return o.Interface.Play(ctx)
+}
+
+func TestOneLeftCalledDuringPlay(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.Scoring, rules.HandCount = game.ScoringFixedHands, 2
+	caller := &oneLeftCaller{players: make([]player.Player, 3), target: -1, maxCalls: 3}
+	bots := playWrappedBotGame(t, &host.Config{Rules: &rules}, caller.wrap, newPlayerConfs(3)...)
+	// The penalty cards were dealt while the player was playing, and every hand was still completed
+	caller.lock.Lock()
+	require.Equal(t, 3, caller.calls)
+	caller.lock.Unlock()
+	require.NotZero(t, bots[0].ui.eventCount(game.EventHandPlayerOneLeftPenaltyDrewTwo))
+	require.Equal(t, 2, bots[0].ui.eventCount(game.EventHandEnd))
+}
+
+// slowUI takes longer than the turn timeout for its first few plays, and then plays anyway
+type slowUI struct {
+	iface.Interface
+
+	lock      sync.Mutex
+	slowPlays int
+}
+
+func (s *slowUI) Play(ctx context.Context) (game.Card, game.CardColor, error) {
+	s.lock.Lock()
+	slow := s.slowPlays > 0
+	if slow {
+		s.slowPlays--
+	}
+	s.lock.Unlock()
+	if slow {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+	}
+	return s.Interface.Play(ctx)
+}
+
+func TestSlowPlayerTimesOut(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.Scoring, rules.HandCount = game.ScoringFixedHands, 1
+	// Only the first bot is slow
+	var slow *slowUI
+	wrap := func(b *testBot, ui iface.Interface) iface.Interface {
+		if slow != nil {
+			return ui
+		}
+		slow = &slowUI{Interface: ui, slowPlays: 2}
+		return slow
+	}
+	conf := &host.Config{Rules: &rules, TurnTimeout: 300 * time.Millisecond}
+	bots := playWrappedBotGame(t, conf, wrap, newPlayerConfs(3)...)
+	// Each slow play timed out and the host drew for the player, who passed
+	bots[1].ui.lock.Lock()
+	events := bots[1].ui.events
+	bots[1].ui.lock.Unlock()
+	slowIndex := -1
+	timeouts := 0
+	for i, event := range events {
+		if event.Type != game.EventHandPlayerTimedOut {
+			continue
+		}
+		timeouts++
+		if slowIndex == -1 {
+			slowIndex = event.Hand.PlayerIndex
+		}
+		require.Equal(t, slowIndex, event.Hand.PlayerIndex)
+		require.Equal(t, game.EventHandPlayerDrewOne, events[i+1].Type)
+		require.Equal(t, slowIndex, events[i+1].Hand.PlayerIndex)
+		require.Equal(t, game.EventHandPlayerPlayedNothing, events[i+2].Type)
+	}
+	require.Equal(t, 2, timeouts)
+	// The late plays were dropped without mixing them up with the responses after, so the hand was completed
+	require.Equal(t, 1, bots[1].ui.eventCount(game.EventHandEnd))
+}
+
+// tamperTranscript writes the transcript again with the first entry tamper changes, failing if it changes none
+func tamperTranscript(b []byte, tamper func(entry *pb.TranscriptEntry) bool) ([]byte, error) {
+	r := transcript.NewReader(bytes.NewReader(b))
+	var buf bytes.Buffer
+	var w *transcript.Writer
+	tampered := false
+	for {
+		entry, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		// The writer writes its own header
+		if header, ok := entry.Entry.(*pb.TranscriptEntry_Header_); ok {
+			if w, err = transcript.NewWriter(&buf, header.Header.WriterId); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !tampered {
+			tampered = tamper(entry)
+		}
+		if err = w.Write(entry); err != nil {
+			return nil, err
+		}
+	}
+	if !tampered {
+		return nil, fmt.Errorf("Nothing tampered with")
+	}
+	return buf.Bytes(), nil
+}
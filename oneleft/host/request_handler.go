@@ -86,20 +86,47 @@ func (h *requestHandler) OnChatMessage(c client.Client, msg *pb.ChatMessage) {
 	h.chatMessages = newChatMessages
 }
 
+func (h *requestHandler) OnCallOneLeft(c client.Client, targetIndex uint32) {
+	h.lock.RLock()
+	info := h.clients[c.Num()]
+	g := h.currGame
+	h.lock.RUnlock()
+	// Calls when there is no game, e.g. just after it ended, are ignored
+	if info == nil || info.Identity == nil {
+		c.FailNonBlocking(fmt.Errorf("Only players can call one left"))
+	} else if g != nil {
+		if err := g.CallOneLeft(info, int(targetIndex)); err != nil {
+			c.FailNonBlocking(err)
+		}
+	}
+}
+
+func (h *requestHandler) OnJumpIn(c client.Client) {
+	h.lock.RLock()
+	info := h.clients[c.Num()]
+	g := h.currGame
+	h.lock.RUnlock()
+	// Claims when there is no game are ignored like one left calls
+	if info == nil || info.Identity == nil {
+		c.FailNonBlocking(fmt.Errorf("Only players can jump in"))
+	} else if g != nil {
+		if err := g.JumpIn(info); err != nil {
+			c.FailNonBlocking(err)
+		}
+	}
+}
+
 func (h *requestHandler) OnStartJoin(c client.Client) {
 	sendErr := func(str string) {
 		c.SendNonBlocking(&pb.HostMessage{Message: &pb.HostMessage_Error_{Error: &pb.HostMessage_Error{Message: str}}})
 	}
-	// Make sure game isn't running
-	if h.GameRunning() {
-		sendErr("Game is already running")
-		return
-	}
+	// Players can't join a running game, but can join it again after reconnecting
+	rejoining := h.GameRunning()
 	// Make sure not max
 	h.lock.RLock()
 	playerCount := len(h.gamePlayers)
 	h.lock.RUnlock()
-	if playerCount >= maxPlayers {
+	if !rejoining && playerCount >= h.conf.MaxPlayers {
 		sendErr("Already at max player count")
 		return
 	}
@@ -115,7 +142,12 @@ func (h *requestHandler) OnStartJoin(c client.Client) {
 		return
 	}
 	// Validate and build info
-	info := &game.PlayerInfo{Client: c, Identity: resp.Player}
+	info := &game.PlayerInfo{
+		Client:             c,
+		Identity:           resp.Player,
+		MinSharedPrimeBits: int(resp.MinSharedCardPrimeBits),
+		CardCiphers:        resp.CardCiphers,
+	}
 	if !bytes.Equal(joinReq.RandomNonce, info.Identity.RandomNonce) {
 		sendErr("Invalid nonce")
 		return
@@ -128,6 +160,12 @@ func (h *requestHandler) OnStartJoin(c client.Client) {
 	} else if info.Identity.Name == "" || len(info.Identity.Name) > maxNameLen {
 		sendErr("Invalid name size")
 		return
+	} else if info.MinSharedPrimeBits > h.conf.MaxSharedPrimeBits {
+		sendErr(fmt.Sprintf("Minimum shared prime size over max of %v bits", h.conf.MaxSharedPrimeBits))
+		return
+	} else if rejoining {
+		h.rejoin(info, resp, sendErr)
+		return
 	}
 	// Now lock the host then do the rest
 	h.lock.Lock()
@@ -138,7 +176,7 @@ func (h *requestHandler) OnStartJoin(c client.Client) {
 		return
 	}
 	// Check max again
-	if len(h.gamePlayers) >= maxPlayers {
+	if len(h.gamePlayers) >= h.conf.MaxPlayers {
 		sendErr("Already at max player count")
 		return
 	}
@@ -175,36 +213,72 @@ func (h *requestHandler) OnStartJoin(c client.Client) {
 	h.sendPlayerUpdatesUnsafe()
 }
 
+// rejoin gives the player their seat in the running game back after they reconnected with a new client. It is only
+// allowed for players that kept the state of the game and are no longer connected with another client.
+func (h *requestHandler) rejoin(info *game.PlayerInfo, resp *pb.JoinResponse, sendErr func(string)) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.currGame == nil {
+		sendErr("Game is no longer running")
+		return
+	}
+	// Check client present
+	if clientInfo := h.clients[info.Client.Num()]; clientInfo == nil || clientInfo.Identity != nil {
+		sendErr("Client no longer present or already a player")
+		return
+	}
+	// Find the seat, which other players can't join
+	index := -1
+	for i, player := range h.gamePlayers {
+		if bytes.Equal(player.Identity.Id, info.Identity.Id) {
+			index = i
+			break
+		}
+	}
+	gameID := h.currGame.ID()
+	if index == -1 {
+		sendErr("Game is already running")
+		return
+	} else if h.gamePlayers[index].Client.Running() {
+		sendErr("Player is still connected")
+		return
+	} else if !bytes.Equal(resp.ResumeGameId, gameID[:]) {
+		sendErr("Player has no state for the running game")
+		return
+	} else if int(resp.ResumeGameEventsSeen) > len(h.gameEvents) {
+		sendErr("Player has seen more events than there are")
+		return
+	}
+	// The player has to catch up before any request is sent again, so resume first
+	info.Identity = h.gamePlayers[index].Identity
+	err := info.Client.SendNonBlocking(&pb.HostMessage{Message: &pb.HostMessage_Resume_{Resume: &pb.HostMessage_Resume{
+		GameId:       gameID[:],
+		PlayerIndex:  uint32(index),
+		MissedEvents: h.gameEvents[resp.ResumeGameEventsSeen:],
+	}}})
+	if err != nil {
+		return
+	} else if err = h.currGame.Reconnect(index, info); err != nil {
+		sendErr(err.Error())
+		return
+	}
+	h.clients[info.Client.Num()] = info
+	// Game players slice is copy-on-write
+	newGamePlayers := make([]*game.PlayerInfo, len(h.gamePlayers))
+	copy(newGamePlayers, h.gamePlayers)
+	newGamePlayers[index] = info
+	h.gamePlayers = newGamePlayers
+}
+
 func (h *requestHandler) OnStop(c client.Client) {
-	// We don't care if this is a player and we're in the game, we expect the game will stop somewhere else and nothing
-	// as part of the game should use the player sets.
+	// Players that stop during a game keep their seat so they can reconnect, and the game waits for them on its own
 	// Lock for all of this
 	h.lock.Lock()
 	defer h.lock.Unlock()
 	info := h.clients[c.Num()]
 	delete(h.clients, c.Num())
 	delete(h.clientChatCounters, c.Num())
-	if info != nil && info.Identity != nil {
-		// Proto players slice is copy-on-write, so filter out the stopped client
-		newProtoPlayers := []*pb.PlayerIdentity{}
-		newGamePlayers := []*game.PlayerInfo{}
-		for _, existingInfo := range h.gamePlayers {
-			if !bytes.Equal(existingInfo.Identity.Id, info.Identity.Id) {
-				newProtoPlayers = append(newProtoPlayers, info.Identity)
-				newGamePlayers = append(newGamePlayers, info)
-			}
-		}
-		h.protoPlayers = newProtoPlayers
-		h.gamePlayers = newGamePlayers
-	}
-}
-
-// Unsafe because it expects callers to lock
-func (h *requestHandler) sendPlayerUpdatesUnsafe() {
-	msg := &pb.HostMessage{Message: &pb.HostMessage_PlayersUpdate{
-		PlayersUpdate: &pb.HostMessage_Players{Players: h.protoPlayers},
-	}}
-	for _, client := range h.clients {
-		client.Client.SendNonBlocking(msg)
+	if info != nil && info.Identity != nil && !h.gameRunning {
+		h.removePlayerUnsafe(info)
 	}
 }
@@ -1,5 +1,49 @@
 package main
 
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: oneleft COMMAND [FLAGS]
+
+Commands:
+  host    Host a game that players can connect to
+  play    Connect to a host and play as a human
+  bot     Connect to a host and play as an automated player
+  sim     Play many games between bots locally and print statistics
+  verify  Verify the games in transcripts written by a host or player
+
+Run 'oneleft COMMAND -h' for the flags of a command.`
+
 func main() {
-	panic("TODO")
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Println(usage)
+		return fmt.Errorf("Missing command")
+	}
+	switch args[0] {
+	case "host":
+		return runHost(args[1:])
+	case "play":
+		return runPlay(args[1:])
+	case "bot":
+		return runBot(args[1:])
+	case "sim":
+		return runSim(args[1:])
+	case "verify":
+		return runVerify(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Println(usage)
+		return nil
+	default:
+		fmt.Println(usage)
+		return fmt.Errorf("Unrecognized command %v", args[0])
+	}
 }
@@ -44,6 +44,13 @@ const (
 	HostMessage_GameEvent_HAND_PLAYER_CALLED_ONE_LEFT             HostMessage_GameEvent_Type = 15
 	HostMessage_GameEvent_HAND_PLAYER_ONE_LEFT_PENALTY_DREW_TWO   HostMessage_GameEvent_Type = 16
 	HostMessage_GameEvent_HAND_END                                HostMessage_GameEvent_Type = 17
+	HostMessage_GameEvent_HAND_PLAYER_PENALTY_STACKED             HostMessage_GameEvent_Type = 18
+	HostMessage_GameEvent_HAND_PLAYER_PENALTY_ABSORBED            HostMessage_GameEvent_Type = 19
+	HostMessage_GameEvent_HAND_PLAYER_JUMPED_IN                   HostMessage_GameEvent_Type = 20
+	HostMessage_GameEvent_HAND_PLAYER_SWAPPED_HANDS               HostMessage_GameEvent_Type = 21
+	HostMessage_GameEvent_HAND_HANDS_ROTATED                      HostMessage_GameEvent_Type = 22
+	HostMessage_GameEvent_HAND_PLAYER_REVERSE_SKIPPED             HostMessage_GameEvent_Type = 23
+	HostMessage_GameEvent_HAND_PLAYER_TIMED_OUT                   HostMessage_GameEvent_Type = 24
 )
 
 var HostMessage_GameEvent_Type_name = map[int32]string{
@@ -65,6 +72,13 @@ var HostMessage_GameEvent_Type_name = map[int32]string{
 	15: "HAND_PLAYER_CALLED_ONE_LEFT",
 	16: "HAND_PLAYER_ONE_LEFT_PENALTY_DREW_TWO",
 	17: "HAND_END",
+	18: "HAND_PLAYER_PENALTY_STACKED",
+	19: "HAND_PLAYER_PENALTY_ABSORBED",
+	20: "HAND_PLAYER_JUMPED_IN",
+	21: "HAND_PLAYER_SWAPPED_HANDS",
+	22: "HAND_HANDS_ROTATED",
+	23: "HAND_PLAYER_REVERSE_SKIPPED",
+	24: "HAND_PLAYER_TIMED_OUT",
 }
 var HostMessage_GameEvent_Type_value = map[string]int32{
 	"GAME_START":                              0,
@@ -85,13 +99,20 @@ var HostMessage_GameEvent_Type_value = map[string]int32{
 	"HAND_PLAYER_CALLED_ONE_LEFT":             15,
 	"HAND_PLAYER_ONE_LEFT_PENALTY_DREW_TWO":   16,
 	"HAND_END":                                17,
+	"HAND_PLAYER_PENALTY_STACKED":             18,
+	"HAND_PLAYER_PENALTY_ABSORBED":            19,
+	"HAND_PLAYER_JUMPED_IN":                   20,
+	"HAND_PLAYER_SWAPPED_HANDS":               21,
+	"HAND_HANDS_ROTATED":                      22,
+	"HAND_PLAYER_REVERSE_SKIPPED":             23,
+	"HAND_PLAYER_TIMED_OUT":                   24,
 }
 
 func (x HostMessage_GameEvent_Type) String() string {
 	return proto.EnumName(HostMessage_GameEvent_Type_name, int32(x))
 }
 func (HostMessage_GameEvent_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 4, 0}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 6, 0}
 }
 
 type ClientMessage struct {
@@ -99,6 +120,8 @@ type ClientMessage struct {
 	//	*ClientMessage_ChatMessage
 	//	*ClientMessage_StartJoin
 	//	*ClientMessage_PlayerResponse_
+	//	*ClientMessage_CallOneLeft
+	//	*ClientMessage_JumpIn
 	Message              isClientMessage_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -109,7 +132,7 @@ func (m *ClientMessage) Reset()         { *m = ClientMessage{} }
 func (m *ClientMessage) String() string { return proto.CompactTextString(m) }
 func (*ClientMessage) ProtoMessage()    {}
 func (*ClientMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{0}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{0}
 }
 func (m *ClientMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ClientMessage.Unmarshal(m, b)
@@ -142,10 +165,18 @@ type ClientMessage_StartJoin struct {
 type ClientMessage_PlayerResponse_ struct {
 	PlayerResponse *ClientMessage_PlayerResponse `protobuf:"bytes,3,opt,name=player_response,json=playerResponse,proto3,oneof"`
 }
+type ClientMessage_CallOneLeft struct {
+	CallOneLeft uint32 `protobuf:"varint,4,opt,name=call_one_left,json=callOneLeft,proto3,oneof"`
+}
+type ClientMessage_JumpIn struct {
+	JumpIn bool `protobuf:"varint,5,opt,name=jump_in,json=jumpIn,proto3,oneof"`
+}
 
 func (*ClientMessage_ChatMessage) isClientMessage_Message()     {}
 func (*ClientMessage_StartJoin) isClientMessage_Message()       {}
 func (*ClientMessage_PlayerResponse_) isClientMessage_Message() {}
+func (*ClientMessage_CallOneLeft) isClientMessage_Message()     {}
+func (*ClientMessage_JumpIn) isClientMessage_Message()          {}
 
 func (m *ClientMessage) GetMessage() isClientMessage_Message {
 	if m != nil {
@@ -175,12 +206,28 @@ func (m *ClientMessage) GetPlayerResponse() *ClientMessage_PlayerResponse {
 	return nil
 }
 
+func (m *ClientMessage) GetCallOneLeft() uint32 {
+	if x, ok := m.GetMessage().(*ClientMessage_CallOneLeft); ok {
+		return x.CallOneLeft
+	}
+	return 0
+}
+
+func (m *ClientMessage) GetJumpIn() bool {
+	if x, ok := m.GetMessage().(*ClientMessage_JumpIn); ok {
+		return x.JumpIn
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ClientMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _ClientMessage_OneofMarshaler, _ClientMessage_OneofUnmarshaler, _ClientMessage_OneofSizer, []interface{}{
 		(*ClientMessage_ChatMessage)(nil),
 		(*ClientMessage_StartJoin)(nil),
 		(*ClientMessage_PlayerResponse_)(nil),
+		(*ClientMessage_CallOneLeft)(nil),
+		(*ClientMessage_JumpIn)(nil),
 	}
 }
 
@@ -205,6 +252,16 @@ func _ClientMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.PlayerResponse); err != nil {
 			return err
 		}
+	case *ClientMessage_CallOneLeft:
+		b.EncodeVarint(4<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.CallOneLeft))
+	case *ClientMessage_JumpIn:
+		t := uint64(0)
+		if x.JumpIn {
+			t = 1
+		}
+		b.EncodeVarint(5<<3 | proto.WireVarint)
+		b.EncodeVarint(t)
 	case nil:
 	default:
 		return fmt.Errorf("ClientMessage.Message has unexpected type %T", x)
@@ -238,6 +295,20 @@ func _ClientMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Message = &ClientMessage_PlayerResponse_{msg}
 		return true, err
+	case 4: // message.call_one_left
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Message = &ClientMessage_CallOneLeft{uint32(x)}
+		return true, err
+	case 5: // message.jump_in
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Message = &ClientMessage_JumpIn{x != 0}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -260,6 +331,12 @@ func _ClientMessage_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ClientMessage_CallOneLeft:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(x.CallOneLeft))
+	case *ClientMessage_JumpIn:
+		n += 1 // tag and wire
+		n += 1
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -280,6 +357,11 @@ type ClientMessage_PlayerResponse struct {
 	//	*ClientMessage_PlayerResponse_ShouldChallengeWildDrawFourResponse
 	//	*ClientMessage_PlayerResponse_RevealCardsForChallengeResponse
 	//	*ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse
+	//	*ClientMessage_PlayerResponse_GameEndResponse
+	//	*ClientMessage_PlayerResponse_HandEndResponse
+	//	*ClientMessage_PlayerResponse_JumpInResponse
+	//	*ClientMessage_PlayerResponse_ChooseSwapTargetResponse
+	//	*ClientMessage_PlayerResponse_MoveHandResponse
 	Message              isClientMessage_PlayerResponse_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
 	XXX_unrecognized     []byte                                 `json:"-"`
@@ -290,7 +372,7 @@ func (m *ClientMessage_PlayerResponse) Reset()         { *m = ClientMessage_Play
 func (m *ClientMessage_PlayerResponse) String() string { return proto.CompactTextString(m) }
 func (*ClientMessage_PlayerResponse) ProtoMessage()    {}
 func (*ClientMessage_PlayerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{0, 0}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{0, 0}
 }
 func (m *ClientMessage_PlayerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ClientMessage_PlayerResponse.Unmarshal(m, b)
@@ -347,6 +429,21 @@ type ClientMessage_PlayerResponse_RevealCardsForChallengeResponse struct {
 type ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse struct {
 	RevealedCardsForChallengeResponse *RevealedCardsForChallengeResponse `protobuf:"bytes,110,opt,name=revealed_cards_for_challenge_response,json=revealedCardsForChallengeResponse,proto3,oneof"`
 }
+type ClientMessage_PlayerResponse_GameEndResponse struct {
+	GameEndResponse *GameEndResponse `protobuf:"bytes,111,opt,name=game_end_response,json=gameEndResponse,proto3,oneof"`
+}
+type ClientMessage_PlayerResponse_HandEndResponse struct {
+	HandEndResponse *HandEndResponse `protobuf:"bytes,112,opt,name=hand_end_response,json=handEndResponse,proto3,oneof"`
+}
+type ClientMessage_PlayerResponse_JumpInResponse struct {
+	JumpInResponse *JumpInResponse `protobuf:"bytes,113,opt,name=jump_in_response,json=jumpInResponse,proto3,oneof"`
+}
+type ClientMessage_PlayerResponse_ChooseSwapTargetResponse struct {
+	ChooseSwapTargetResponse *ChooseSwapTargetResponse `protobuf:"bytes,114,opt,name=choose_swap_target_response,json=chooseSwapTargetResponse,proto3,oneof"`
+}
+type ClientMessage_PlayerResponse_MoveHandResponse struct {
+	MoveHandResponse *MoveHandResponse `protobuf:"bytes,115,opt,name=move_hand_response,json=moveHandResponse,proto3,oneof"`
+}
 
 func (*ClientMessage_PlayerResponse_JoinResponse) isClientMessage_PlayerResponse_Message()      {}
 func (*ClientMessage_PlayerResponse_GameStartResponse) isClientMessage_PlayerResponse_Message() {}
@@ -365,6 +462,12 @@ func (*ClientMessage_PlayerResponse_RevealCardsForChallengeResponse) isClientMes
 }
 func (*ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse) isClientMessage_PlayerResponse_Message() {
 }
+func (*ClientMessage_PlayerResponse_GameEndResponse) isClientMessage_PlayerResponse_Message() {}
+func (*ClientMessage_PlayerResponse_HandEndResponse) isClientMessage_PlayerResponse_Message() {}
+func (*ClientMessage_PlayerResponse_JumpInResponse) isClientMessage_PlayerResponse_Message()  {}
+func (*ClientMessage_PlayerResponse_ChooseSwapTargetResponse) isClientMessage_PlayerResponse_Message() {
+}
+func (*ClientMessage_PlayerResponse_MoveHandResponse) isClientMessage_PlayerResponse_Message() {}
 
 func (m *ClientMessage_PlayerResponse) GetMessage() isClientMessage_PlayerResponse_Message {
 	if m != nil {
@@ -450,6 +553,41 @@ func (m *ClientMessage_PlayerResponse) GetRevealedCardsForChallengeResponse() *R
 	return nil
 }
 
+func (m *ClientMessage_PlayerResponse) GetGameEndResponse() *GameEndResponse {
+	if x, ok := m.GetMessage().(*ClientMessage_PlayerResponse_GameEndResponse); ok {
+		return x.GameEndResponse
+	}
+	return nil
+}
+
+func (m *ClientMessage_PlayerResponse) GetHandEndResponse() *HandEndResponse {
+	if x, ok := m.GetMessage().(*ClientMessage_PlayerResponse_HandEndResponse); ok {
+		return x.HandEndResponse
+	}
+	return nil
+}
+
+func (m *ClientMessage_PlayerResponse) GetJumpInResponse() *JumpInResponse {
+	if x, ok := m.GetMessage().(*ClientMessage_PlayerResponse_JumpInResponse); ok {
+		return x.JumpInResponse
+	}
+	return nil
+}
+
+func (m *ClientMessage_PlayerResponse) GetChooseSwapTargetResponse() *ChooseSwapTargetResponse {
+	if x, ok := m.GetMessage().(*ClientMessage_PlayerResponse_ChooseSwapTargetResponse); ok {
+		return x.ChooseSwapTargetResponse
+	}
+	return nil
+}
+
+func (m *ClientMessage_PlayerResponse) GetMoveHandResponse() *MoveHandResponse {
+	if x, ok := m.GetMessage().(*ClientMessage_PlayerResponse_MoveHandResponse); ok {
+		return x.MoveHandResponse
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ClientMessage_PlayerResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _ClientMessage_PlayerResponse_OneofMarshaler, _ClientMessage_PlayerResponse_OneofUnmarshaler, _ClientMessage_PlayerResponse_OneofSizer, []interface{}{
@@ -464,6 +602,11 @@ func (*ClientMessage_PlayerResponse) XXX_OneofFuncs() (func(msg proto.Message, b
 		(*ClientMessage_PlayerResponse_ShouldChallengeWildDrawFourResponse)(nil),
 		(*ClientMessage_PlayerResponse_RevealCardsForChallengeResponse)(nil),
 		(*ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse)(nil),
+		(*ClientMessage_PlayerResponse_GameEndResponse)(nil),
+		(*ClientMessage_PlayerResponse_HandEndResponse)(nil),
+		(*ClientMessage_PlayerResponse_JumpInResponse)(nil),
+		(*ClientMessage_PlayerResponse_ChooseSwapTargetResponse)(nil),
+		(*ClientMessage_PlayerResponse_MoveHandResponse)(nil),
 	}
 }
 
@@ -526,6 +669,31 @@ func _ClientMessage_PlayerResponse_OneofMarshaler(msg proto.Message, b *proto.Bu
 		if err := b.EncodeMessage(x.RevealedCardsForChallengeResponse); err != nil {
 			return err
 		}
+	case *ClientMessage_PlayerResponse_GameEndResponse:
+		b.EncodeVarint(111<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.GameEndResponse); err != nil {
+			return err
+		}
+	case *ClientMessage_PlayerResponse_HandEndResponse:
+		b.EncodeVarint(112<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HandEndResponse); err != nil {
+			return err
+		}
+	case *ClientMessage_PlayerResponse_JumpInResponse:
+		b.EncodeVarint(113<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.JumpInResponse); err != nil {
+			return err
+		}
+	case *ClientMessage_PlayerResponse_ChooseSwapTargetResponse:
+		b.EncodeVarint(114<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChooseSwapTargetResponse); err != nil {
+			return err
+		}
+	case *ClientMessage_PlayerResponse_MoveHandResponse:
+		b.EncodeVarint(115<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MoveHandResponse); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ClientMessage_PlayerResponse.Message has unexpected type %T", x)
@@ -624,6 +792,46 @@ func _ClientMessage_PlayerResponse_OneofUnmarshaler(msg proto.Message, tag, wire
 		err := b.DecodeMessage(msg)
 		m.Message = &ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse{msg}
 		return true, err
+	case 111: // message.game_end_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(GameEndResponse)
+		err := b.DecodeMessage(msg)
+		m.Message = &ClientMessage_PlayerResponse_GameEndResponse{msg}
+		return true, err
+	case 112: // message.hand_end_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(HandEndResponse)
+		err := b.DecodeMessage(msg)
+		m.Message = &ClientMessage_PlayerResponse_HandEndResponse{msg}
+		return true, err
+	case 113: // message.jump_in_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(JumpInResponse)
+		err := b.DecodeMessage(msg)
+		m.Message = &ClientMessage_PlayerResponse_JumpInResponse{msg}
+		return true, err
+	case 114: // message.choose_swap_target_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChooseSwapTargetResponse)
+		err := b.DecodeMessage(msg)
+		m.Message = &ClientMessage_PlayerResponse_ChooseSwapTargetResponse{msg}
+		return true, err
+	case 115: // message.move_hand_response
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MoveHandResponse)
+		err := b.DecodeMessage(msg)
+		m.Message = &ClientMessage_PlayerResponse_MoveHandResponse{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -688,6 +896,31 @@ func _ClientMessage_PlayerResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ClientMessage_PlayerResponse_GameEndResponse:
+		s := proto.Size(x.GameEndResponse)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ClientMessage_PlayerResponse_HandEndResponse:
+		s := proto.Size(x.HandEndResponse)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ClientMessage_PlayerResponse_JumpInResponse:
+		s := proto.Size(x.JumpInResponse)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ClientMessage_PlayerResponse_ChooseSwapTargetResponse:
+		s := proto.Size(x.ChooseSwapTargetResponse)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ClientMessage_PlayerResponse_MoveHandResponse:
+		s := proto.Size(x.MoveHandResponse)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -703,6 +936,8 @@ type HostMessage struct {
 	//	*HostMessage_GameEvent_
 	//	*HostMessage_PlayerRequest_
 	//	*HostMessage_Error_
+	//	*HostMessage_OneLeftCallWindow_
+	//	*HostMessage_Resume_
 	Message              isHostMessage_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
@@ -713,7 +948,7 @@ func (m *HostMessage) Reset()         { *m = HostMessage{} }
 func (m *HostMessage) String() string { return proto.CompactTextString(m) }
 func (*HostMessage) ProtoMessage()    {}
 func (*HostMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1}
 }
 func (m *HostMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage.Unmarshal(m, b)
@@ -755,13 +990,21 @@ type HostMessage_PlayerRequest_ struct {
 type HostMessage_Error_ struct {
 	Error *HostMessage_Error `protobuf:"bytes,6,opt,name=error,proto3,oneof"`
 }
+type HostMessage_OneLeftCallWindow_ struct {
+	OneLeftCallWindow *HostMessage_OneLeftCallWindow `protobuf:"bytes,7,opt,name=one_left_call_window,json=oneLeftCallWindow,proto3,oneof"`
+}
+type HostMessage_Resume_ struct {
+	Resume *HostMessage_Resume `protobuf:"bytes,8,opt,name=resume,proto3,oneof"`
+}
 
-func (*HostMessage_Welcome_) isHostMessage_Message()         {}
-func (*HostMessage_PlayersUpdate) isHostMessage_Message()    {}
-func (*HostMessage_ChatMessageAdded) isHostMessage_Message() {}
-func (*HostMessage_GameEvent_) isHostMessage_Message()       {}
-func (*HostMessage_PlayerRequest_) isHostMessage_Message()   {}
-func (*HostMessage_Error_) isHostMessage_Message()           {}
+func (*HostMessage_Welcome_) isHostMessage_Message()           {}
+func (*HostMessage_PlayersUpdate) isHostMessage_Message()      {}
+func (*HostMessage_ChatMessageAdded) isHostMessage_Message()   {}
+func (*HostMessage_GameEvent_) isHostMessage_Message()         {}
+func (*HostMessage_PlayerRequest_) isHostMessage_Message()     {}
+func (*HostMessage_Error_) isHostMessage_Message()             {}
+func (*HostMessage_OneLeftCallWindow_) isHostMessage_Message() {}
+func (*HostMessage_Resume_) isHostMessage_Message()            {}
 
 func (m *HostMessage) GetMessage() isHostMessage_Message {
 	if m != nil {
@@ -812,6 +1055,20 @@ func (m *HostMessage) GetError() *HostMessage_Error {
 	return nil
 }
 
+func (m *HostMessage) GetOneLeftCallWindow() *HostMessage_OneLeftCallWindow {
+	if x, ok := m.GetMessage().(*HostMessage_OneLeftCallWindow_); ok {
+		return x.OneLeftCallWindow
+	}
+	return nil
+}
+
+func (m *HostMessage) GetResume() *HostMessage_Resume {
+	if x, ok := m.GetMessage().(*HostMessage_Resume_); ok {
+		return x.Resume
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*HostMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _HostMessage_OneofMarshaler, _HostMessage_OneofUnmarshaler, _HostMessage_OneofSizer, []interface{}{
@@ -821,6 +1078,8 @@ func (*HostMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) e
 		(*HostMessage_GameEvent_)(nil),
 		(*HostMessage_PlayerRequest_)(nil),
 		(*HostMessage_Error_)(nil),
+		(*HostMessage_OneLeftCallWindow_)(nil),
+		(*HostMessage_Resume_)(nil),
 	}
 }
 
@@ -858,6 +1117,16 @@ func _HostMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Error); err != nil {
 			return err
 		}
+	case *HostMessage_OneLeftCallWindow_:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OneLeftCallWindow); err != nil {
+			return err
+		}
+	case *HostMessage_Resume_:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Resume); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("HostMessage.Message has unexpected type %T", x)
@@ -916,6 +1185,22 @@ func _HostMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Bu
 		err := b.DecodeMessage(msg)
 		m.Message = &HostMessage_Error_{msg}
 		return true, err
+	case 7: // message.one_left_call_window
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(HostMessage_OneLeftCallWindow)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_OneLeftCallWindow_{msg}
+		return true, err
+	case 8: // message.resume
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(HostMessage_Resume)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_Resume_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -955,6 +1240,16 @@ func _HostMessage_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *HostMessage_OneLeftCallWindow_:
+		s := proto.Size(x.OneLeftCallWindow)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *HostMessage_Resume_:
+		s := proto.Size(x.Resume)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -976,7 +1271,7 @@ func (m *HostMessage_Welcome) Reset()         { *m = HostMessage_Welcome{} }
 func (m *HostMessage_Welcome) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_Welcome) ProtoMessage()    {}
 func (*HostMessage_Welcome) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 0}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 0}
 }
 func (m *HostMessage_Welcome) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_Welcome.Unmarshal(m, b)
@@ -1035,7 +1330,7 @@ func (m *HostMessage_Players) Reset()         { *m = HostMessage_Players{} }
 func (m *HostMessage_Players) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_Players) ProtoMessage()    {}
 func (*HostMessage_Players) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 1}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 1}
 }
 func (m *HostMessage_Players) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_Players.Unmarshal(m, b)
@@ -1063,6 +1358,9 @@ func (m *HostMessage_Players) GetPlayers() []*PlayerIdentity {
 }
 
 type HostMessage_PlayerRequest struct {
+	// Counts up from 1 with each request to a player in a game, 0 outside of one. A request sent again after the player
+	// reconnects has the same number, so the player can answer it the same way.
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
 	// Types that are valid to be assigned to Message:
 	//	*HostMessage_PlayerRequest_JoinRequest
 	//	*HostMessage_PlayerRequest_GameStartRequest
@@ -1075,6 +1373,11 @@ type HostMessage_PlayerRequest struct {
 	//	*HostMessage_PlayerRequest_ShouldChallengeWildDrawFourRequest
 	//	*HostMessage_PlayerRequest_RevealCardsForChallengeRequest
 	//	*HostMessage_PlayerRequest_RevealedCardsForChallengeRequest
+	//	*HostMessage_PlayerRequest_GameEndRequest
+	//	*HostMessage_PlayerRequest_HandEndRequest
+	//	*HostMessage_PlayerRequest_JumpInRequest
+	//	*HostMessage_PlayerRequest_ChooseSwapTargetRequest
+	//	*HostMessage_PlayerRequest_MoveHandRequest
 	Message              isHostMessage_PlayerRequest_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
 	XXX_unrecognized     []byte                              `json:"-"`
@@ -1085,7 +1388,7 @@ func (m *HostMessage_PlayerRequest) Reset()         { *m = HostMessage_PlayerReq
 func (m *HostMessage_PlayerRequest) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_PlayerRequest) ProtoMessage()    {}
 func (*HostMessage_PlayerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 2}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 2}
 }
 func (m *HostMessage_PlayerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_PlayerRequest.Unmarshal(m, b)
@@ -1105,6 +1408,13 @@ func (m *HostMessage_PlayerRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_HostMessage_PlayerRequest proto.InternalMessageInfo
 
+func (m *HostMessage_PlayerRequest) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
 type isHostMessage_PlayerRequest_Message interface {
 	isHostMessage_PlayerRequest_Message()
 }
@@ -1142,6 +1452,21 @@ type HostMessage_PlayerRequest_RevealCardsForChallengeRequest struct {
 type HostMessage_PlayerRequest_RevealedCardsForChallengeRequest struct {
 	RevealedCardsForChallengeRequest *RevealedCardsForChallengeRequest `protobuf:"bytes,110,opt,name=revealed_cards_for_challenge_request,json=revealedCardsForChallengeRequest,proto3,oneof"`
 }
+type HostMessage_PlayerRequest_GameEndRequest struct {
+	GameEndRequest *GameEndRequest `protobuf:"bytes,111,opt,name=game_end_request,json=gameEndRequest,proto3,oneof"`
+}
+type HostMessage_PlayerRequest_HandEndRequest struct {
+	HandEndRequest *HandEndRequest `protobuf:"bytes,112,opt,name=hand_end_request,json=handEndRequest,proto3,oneof"`
+}
+type HostMessage_PlayerRequest_JumpInRequest struct {
+	JumpInRequest *JumpInRequest `protobuf:"bytes,113,opt,name=jump_in_request,json=jumpInRequest,proto3,oneof"`
+}
+type HostMessage_PlayerRequest_ChooseSwapTargetRequest struct {
+	ChooseSwapTargetRequest *ChooseSwapTargetRequest `protobuf:"bytes,114,opt,name=choose_swap_target_request,json=chooseSwapTargetRequest,proto3,oneof"`
+}
+type HostMessage_PlayerRequest_MoveHandRequest struct {
+	MoveHandRequest *MoveHandRequest `protobuf:"bytes,115,opt,name=move_hand_request,json=moveHandRequest,proto3,oneof"`
+}
 
 func (*HostMessage_PlayerRequest_JoinRequest) isHostMessage_PlayerRequest_Message()      {}
 func (*HostMessage_PlayerRequest_GameStartRequest) isHostMessage_PlayerRequest_Message() {}
@@ -1159,6 +1484,11 @@ func (*HostMessage_PlayerRequest_RevealCardsForChallengeRequest) isHostMessage_P
 }
 func (*HostMessage_PlayerRequest_RevealedCardsForChallengeRequest) isHostMessage_PlayerRequest_Message() {
 }
+func (*HostMessage_PlayerRequest_GameEndRequest) isHostMessage_PlayerRequest_Message()          {}
+func (*HostMessage_PlayerRequest_HandEndRequest) isHostMessage_PlayerRequest_Message()          {}
+func (*HostMessage_PlayerRequest_JumpInRequest) isHostMessage_PlayerRequest_Message()           {}
+func (*HostMessage_PlayerRequest_ChooseSwapTargetRequest) isHostMessage_PlayerRequest_Message() {}
+func (*HostMessage_PlayerRequest_MoveHandRequest) isHostMessage_PlayerRequest_Message()         {}
 
 func (m *HostMessage_PlayerRequest) GetMessage() isHostMessage_PlayerRequest_Message {
 	if m != nil {
@@ -1244,6 +1574,41 @@ func (m *HostMessage_PlayerRequest) GetRevealedCardsForChallengeRequest() *Revea
 	return nil
 }
 
+func (m *HostMessage_PlayerRequest) GetGameEndRequest() *GameEndRequest {
+	if x, ok := m.GetMessage().(*HostMessage_PlayerRequest_GameEndRequest); ok {
+		return x.GameEndRequest
+	}
+	return nil
+}
+
+func (m *HostMessage_PlayerRequest) GetHandEndRequest() *HandEndRequest {
+	if x, ok := m.GetMessage().(*HostMessage_PlayerRequest_HandEndRequest); ok {
+		return x.HandEndRequest
+	}
+	return nil
+}
+
+func (m *HostMessage_PlayerRequest) GetJumpInRequest() *JumpInRequest {
+	if x, ok := m.GetMessage().(*HostMessage_PlayerRequest_JumpInRequest); ok {
+		return x.JumpInRequest
+	}
+	return nil
+}
+
+func (m *HostMessage_PlayerRequest) GetChooseSwapTargetRequest() *ChooseSwapTargetRequest {
+	if x, ok := m.GetMessage().(*HostMessage_PlayerRequest_ChooseSwapTargetRequest); ok {
+		return x.ChooseSwapTargetRequest
+	}
+	return nil
+}
+
+func (m *HostMessage_PlayerRequest) GetMoveHandRequest() *MoveHandRequest {
+	if x, ok := m.GetMessage().(*HostMessage_PlayerRequest_MoveHandRequest); ok {
+		return x.MoveHandRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*HostMessage_PlayerRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _HostMessage_PlayerRequest_OneofMarshaler, _HostMessage_PlayerRequest_OneofUnmarshaler, _HostMessage_PlayerRequest_OneofSizer, []interface{}{
@@ -1258,6 +1623,11 @@ func (*HostMessage_PlayerRequest) XXX_OneofFuncs() (func(msg proto.Message, b *p
 		(*HostMessage_PlayerRequest_ShouldChallengeWildDrawFourRequest)(nil),
 		(*HostMessage_PlayerRequest_RevealCardsForChallengeRequest)(nil),
 		(*HostMessage_PlayerRequest_RevealedCardsForChallengeRequest)(nil),
+		(*HostMessage_PlayerRequest_GameEndRequest)(nil),
+		(*HostMessage_PlayerRequest_HandEndRequest)(nil),
+		(*HostMessage_PlayerRequest_JumpInRequest)(nil),
+		(*HostMessage_PlayerRequest_ChooseSwapTargetRequest)(nil),
+		(*HostMessage_PlayerRequest_MoveHandRequest)(nil),
 	}
 }
 
@@ -1320,6 +1690,31 @@ func _HostMessage_PlayerRequest_OneofMarshaler(msg proto.Message, b *proto.Buffe
 		if err := b.EncodeMessage(x.RevealedCardsForChallengeRequest); err != nil {
 			return err
 		}
+	case *HostMessage_PlayerRequest_GameEndRequest:
+		b.EncodeVarint(111<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.GameEndRequest); err != nil {
+			return err
+		}
+	case *HostMessage_PlayerRequest_HandEndRequest:
+		b.EncodeVarint(112<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HandEndRequest); err != nil {
+			return err
+		}
+	case *HostMessage_PlayerRequest_JumpInRequest:
+		b.EncodeVarint(113<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.JumpInRequest); err != nil {
+			return err
+		}
+	case *HostMessage_PlayerRequest_ChooseSwapTargetRequest:
+		b.EncodeVarint(114<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChooseSwapTargetRequest); err != nil {
+			return err
+		}
+	case *HostMessage_PlayerRequest_MoveHandRequest:
+		b.EncodeVarint(115<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MoveHandRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("HostMessage_PlayerRequest.Message has unexpected type %T", x)
@@ -1418,6 +1813,46 @@ func _HostMessage_PlayerRequest_OneofUnmarshaler(msg proto.Message, tag, wire in
 		err := b.DecodeMessage(msg)
 		m.Message = &HostMessage_PlayerRequest_RevealedCardsForChallengeRequest{msg}
 		return true, err
+	case 111: // message.game_end_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(GameEndRequest)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_PlayerRequest_GameEndRequest{msg}
+		return true, err
+	case 112: // message.hand_end_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(HandEndRequest)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_PlayerRequest_HandEndRequest{msg}
+		return true, err
+	case 113: // message.jump_in_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(JumpInRequest)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_PlayerRequest_JumpInRequest{msg}
+		return true, err
+	case 114: // message.choose_swap_target_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChooseSwapTargetRequest)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_PlayerRequest_ChooseSwapTargetRequest{msg}
+		return true, err
+	case 115: // message.move_hand_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MoveHandRequest)
+		err := b.DecodeMessage(msg)
+		m.Message = &HostMessage_PlayerRequest_MoveHandRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1482,6 +1917,31 @@ func _HostMessage_PlayerRequest_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *HostMessage_PlayerRequest_GameEndRequest:
+		s := proto.Size(x.GameEndRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *HostMessage_PlayerRequest_HandEndRequest:
+		s := proto.Size(x.HandEndRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *HostMessage_PlayerRequest_JumpInRequest:
+		s := proto.Size(x.JumpInRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *HostMessage_PlayerRequest_ChooseSwapTargetRequest:
+		s := proto.Size(x.ChooseSwapTargetRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *HostMessage_PlayerRequest_MoveHandRequest:
+		s := proto.Size(x.MoveHandRequest)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1504,7 +1964,7 @@ func (m *HostMessage_Error) Reset()         { *m = HostMessage_Error{} }
 func (m *HostMessage_Error) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_Error) ProtoMessage()    {}
 func (*HostMessage_Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 3}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 3}
 }
 func (m *HostMessage_Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_Error.Unmarshal(m, b)
@@ -1552,6 +2012,109 @@ func (m *HostMessage_Error) GetTerminatesGame() bool {
 	return false
 }
 
+// Sent to a player that joined again after reconnecting to the game in progress, before any more requests
+type HostMessage_Resume struct {
+	GameId      []byte `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerIndex uint32 `protobuf:"varint,2,opt,name=player_index,json=playerIndex,proto3" json:"player_index,omitempty"`
+	// The events of the game the player hasn't seen yet
+	MissedEvents         []*HostMessage_GameEvent `protobuf:"bytes,3,rep,name=missed_events,json=missedEvents,proto3" json:"missed_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *HostMessage_Resume) Reset()         { *m = HostMessage_Resume{} }
+func (m *HostMessage_Resume) String() string { return proto.CompactTextString(m) }
+func (*HostMessage_Resume) ProtoMessage()    {}
+func (*HostMessage_Resume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 4}
+}
+func (m *HostMessage_Resume) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostMessage_Resume.Unmarshal(m, b)
+}
+func (m *HostMessage_Resume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostMessage_Resume.Marshal(b, m, deterministic)
+}
+func (dst *HostMessage_Resume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostMessage_Resume.Merge(dst, src)
+}
+func (m *HostMessage_Resume) XXX_Size() int {
+	return xxx_messageInfo_HostMessage_Resume.Size(m)
+}
+func (m *HostMessage_Resume) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostMessage_Resume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostMessage_Resume proto.InternalMessageInfo
+
+func (m *HostMessage_Resume) GetGameId() []byte {
+	if m != nil {
+		return m.GameId
+	}
+	return nil
+}
+
+func (m *HostMessage_Resume) GetPlayerIndex() uint32 {
+	if m != nil {
+		return m.PlayerIndex
+	}
+	return 0
+}
+
+func (m *HostMessage_Resume) GetMissedEvents() []*HostMessage_GameEvent {
+	if m != nil {
+		return m.MissedEvents
+	}
+	return nil
+}
+
+type HostMessage_OneLeftCallWindow struct {
+	GameId []byte `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// The player that just got one left, -1 if none
+	PlayerIndex          int32    `protobuf:"varint,2,opt,name=player_index,json=playerIndex,proto3" json:"player_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HostMessage_OneLeftCallWindow) Reset()         { *m = HostMessage_OneLeftCallWindow{} }
+func (m *HostMessage_OneLeftCallWindow) String() string { return proto.CompactTextString(m) }
+func (*HostMessage_OneLeftCallWindow) ProtoMessage()    {}
+func (*HostMessage_OneLeftCallWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 5}
+}
+func (m *HostMessage_OneLeftCallWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HostMessage_OneLeftCallWindow.Unmarshal(m, b)
+}
+func (m *HostMessage_OneLeftCallWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HostMessage_OneLeftCallWindow.Marshal(b, m, deterministic)
+}
+func (dst *HostMessage_OneLeftCallWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostMessage_OneLeftCallWindow.Merge(dst, src)
+}
+func (m *HostMessage_OneLeftCallWindow) XXX_Size() int {
+	return xxx_messageInfo_HostMessage_OneLeftCallWindow.Size(m)
+}
+func (m *HostMessage_OneLeftCallWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostMessage_OneLeftCallWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HostMessage_OneLeftCallWindow proto.InternalMessageInfo
+
+func (m *HostMessage_OneLeftCallWindow) GetGameId() []byte {
+	if m != nil {
+		return m.GameId
+	}
+	return nil
+}
+
+func (m *HostMessage_OneLeftCallWindow) GetPlayerIndex() int32 {
+	if m != nil {
+		return m.PlayerIndex
+	}
+	return 0
+}
+
 type HostMessage_GameEvent struct {
 	GameId               []byte                              `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
 	Type                 HostMessage_GameEvent_Type          `protobuf:"varint,2,opt,name=type,proto3,enum=pb.HostMessage_GameEvent_Type" json:"type,omitempty"`
@@ -1568,7 +2131,7 @@ func (m *HostMessage_GameEvent) Reset()         { *m = HostMessage_GameEvent{} }
 func (m *HostMessage_GameEvent) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_GameEvent) ProtoMessage()    {}
 func (*HostMessage_GameEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 4}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 6}
 }
 func (m *HostMessage_GameEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_GameEvent.Unmarshal(m, b)
@@ -1640,7 +2203,11 @@ type HostMessage_GameEvent_Hand struct {
 	LastDiscardWildColor int32 `protobuf:"varint,6,opt,name=last_discard_wild_color,json=lastDiscardWildColor,proto3" json:"last_discard_wild_color,omitempty"`
 	Forward              bool  `protobuf:"varint,7,opt,name=forward,proto3" json:"forward,omitempty"`
 	// -1 if none
-	OneLeftTarget        int32    `protobuf:"varint,8,opt,name=one_left_target,json=oneLeftTarget,proto3" json:"one_left_target,omitempty"`
+	OneLeftTarget int32 `protobuf:"varint,8,opt,name=one_left_target,json=oneLeftTarget,proto3" json:"one_left_target,omitempty"`
+	// Only when draw cards are stacked
+	DrawPenalty uint32 `protobuf:"varint,9,opt,name=draw_penalty,json=drawPenalty,proto3" json:"draw_penalty,omitempty"`
+	// -1 if none
+	SwapTarget           int32    `protobuf:"varint,10,opt,name=swap_target,json=swapTarget,proto3" json:"swap_target,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1650,7 +2217,7 @@ func (m *HostMessage_GameEvent_Hand) Reset()         { *m = HostMessage_GameEven
 func (m *HostMessage_GameEvent_Hand) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_GameEvent_Hand) ProtoMessage()    {}
 func (*HostMessage_GameEvent_Hand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 4, 0}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 6, 0}
 }
 func (m *HostMessage_GameEvent_Hand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_GameEvent_Hand.Unmarshal(m, b)
@@ -1726,11 +2293,26 @@ func (m *HostMessage_GameEvent_Hand) GetOneLeftTarget() int32 {
 	return 0
 }
 
+func (m *HostMessage_GameEvent_Hand) GetDrawPenalty() uint32 {
+	if m != nil {
+		return m.DrawPenalty
+	}
+	return 0
+}
+
+func (m *HostMessage_GameEvent_Hand) GetSwapTarget() int32 {
+	if m != nil {
+		return m.SwapTarget
+	}
+	return 0
+}
+
 type HostMessage_GameEvent_HandComplete struct {
 	WinnerIndex          uint32                                            `protobuf:"varint,1,opt,name=winner_index,json=winnerIndex,proto3" json:"winner_index,omitempty"`
 	Score                uint32                                            `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
 	DeckCards            []uint32                                          `protobuf:"varint,3,rep,packed,name=deck_cards,json=deckCards,proto3" json:"deck_cards,omitempty"`
 	PlayerCards          []*HostMessage_GameEvent_HandComplete_PlayerCards `protobuf:"bytes,4,rep,name=player_cards,json=playerCards,proto3" json:"player_cards,omitempty"`
+	PlayerScoreDeltas    []uint32                                          `protobuf:"varint,5,rep,packed,name=player_score_deltas,json=playerScoreDeltas,proto3" json:"player_score_deltas,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                                          `json:"-"`
 	XXX_unrecognized     []byte                                            `json:"-"`
 	XXX_sizecache        int32                                             `json:"-"`
@@ -1740,7 +2322,7 @@ func (m *HostMessage_GameEvent_HandComplete) Reset()         { *m = HostMessage_
 func (m *HostMessage_GameEvent_HandComplete) String() string { return proto.CompactTextString(m) }
 func (*HostMessage_GameEvent_HandComplete) ProtoMessage()    {}
 func (*HostMessage_GameEvent_HandComplete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 4, 1}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 6, 1}
 }
 func (m *HostMessage_GameEvent_HandComplete) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_GameEvent_HandComplete.Unmarshal(m, b)
@@ -1788,6 +2370,13 @@ func (m *HostMessage_GameEvent_HandComplete) GetPlayerCards() []*HostMessage_Gam
 	return nil
 }
 
+func (m *HostMessage_GameEvent_HandComplete) GetPlayerScoreDeltas() []uint32 {
+	if m != nil {
+		return m.PlayerScoreDeltas
+	}
+	return nil
+}
+
 type HostMessage_GameEvent_HandComplete_PlayerCards struct {
 	PlayerCards          []uint32 `protobuf:"varint,1,rep,packed,name=player_cards,json=playerCards,proto3" json:"player_cards,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1803,7 +2392,7 @@ func (m *HostMessage_GameEvent_HandComplete_PlayerCards) String() string {
 }
 func (*HostMessage_GameEvent_HandComplete_PlayerCards) ProtoMessage() {}
 func (*HostMessage_GameEvent_HandComplete_PlayerCards) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{1, 4, 1, 0}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{1, 6, 1, 0}
 }
 func (m *HostMessage_GameEvent_HandComplete_PlayerCards) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HostMessage_GameEvent_HandComplete_PlayerCards.Unmarshal(m, b)
@@ -1847,7 +2436,7 @@ func (m *ChatMessage) Reset()         { *m = ChatMessage{} }
 func (m *ChatMessage) String() string { return proto.CompactTextString(m) }
 func (*ChatMessage) ProtoMessage()    {}
 func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_host_2ce598185d449693, []int{2}
+	return fileDescriptor_host_0e921daffe12bb3b, []int{2}
 }
 func (m *ChatMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChatMessage.Unmarshal(m, b)
@@ -1917,6 +2506,8 @@ func init() {
 	proto.RegisterType((*HostMessage_Players)(nil), "pb.HostMessage.Players")
 	proto.RegisterType((*HostMessage_PlayerRequest)(nil), "pb.HostMessage.PlayerRequest")
 	proto.RegisterType((*HostMessage_Error)(nil), "pb.HostMessage.Error")
+	proto.RegisterType((*HostMessage_Resume)(nil), "pb.HostMessage.Resume")
+	proto.RegisterType((*HostMessage_OneLeftCallWindow)(nil), "pb.HostMessage.OneLeftCallWindow")
 	proto.RegisterType((*HostMessage_GameEvent)(nil), "pb.HostMessage.GameEvent")
 	proto.RegisterType((*HostMessage_GameEvent_Hand)(nil), "pb.HostMessage.GameEvent.Hand")
 	proto.RegisterType((*HostMessage_GameEvent_HandComplete)(nil), "pb.HostMessage.GameEvent.HandComplete")
@@ -2029,121 +2620,151 @@ var _Host_serviceDesc = grpc.ServiceDesc{
 	Metadata: "host.proto",
 }
 
-func init() { proto.RegisterFile("host.proto", fileDescriptor_host_2ce598185d449693) }
-
-var fileDescriptor_host_2ce598185d449693 = []byte{
-	// 1807 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xcd, 0x6e, 0x1b, 0xc9,
-	0x11, 0x16, 0x25, 0x4a, 0x24, 0x8b, 0xa4, 0x38, 0x6e, 0x6b, 0x2d, 0x9a, 0xc6, 0xda, 0x5a, 0xc9,
-	0x96, 0x94, 0x6c, 0x56, 0x30, 0xb4, 0x0e, 0x36, 0x08, 0x10, 0x64, 0x19, 0xce, 0x50, 0x64, 0x4c,
-	0x53, 0x42, 0x93, 0x8a, 0x77, 0x91, 0x43, 0x63, 0x3c, 0xd3, 0x24, 0x47, 0x22, 0x67, 0xb8, 0xd3,
-	0x2d, 0x29, 0x3a, 0x04, 0xc8, 0x29, 0x97, 0x00, 0x01, 0x72, 0xc8, 0x39, 0x0f, 0x91, 0x4b, 0x1e,
-	0x21, 0xc8, 0xcb, 0x04, 0xc8, 0x2d, 0xb7, 0xa0, 0xbb, 0xe7, 0xa7, 0x49, 0xd3, 0xa4, 0x72, 0x32,
-	0xab, 0xea, 0xab, 0xaa, 0x9e, 0xaa, 0x9e, 0xef, 0x1b, 0x0b, 0x60, 0x14, 0x30, 0x7e, 0x32, 0x0d,
-	0x03, 0x1e, 0xa0, 0xf5, 0xe9, 0x87, 0x5a, 0x69, 0x3a, 0xb6, 0xef, 0x69, 0xa8, 0x3c, 0xfb, 0xff,
-	0x2d, 0x40, 0xb9, 0x31, 0xf6, 0xa8, 0xcf, 0xdf, 0x51, 0xc6, 0xec, 0x21, 0x45, 0x6f, 0xa0, 0xe4,
-	0x8c, 0x6c, 0x4e, 0x26, 0xca, 0xae, 0x66, 0xf6, 0x32, 0xc7, 0xc5, 0xd3, 0xca, 0xc9, 0xf4, 0xc3,
-	0x49, 0x63, 0x64, 0xc7, 0xb0, 0xd6, 0x1a, 0x2e, 0x3a, 0xa9, 0x89, 0x5e, 0x00, 0x30, 0x6e, 0x87,
-	0x9c, 0x5c, 0x05, 0x9e, 0x5f, 0x5d, 0xdf, 0xcb, 0x1c, 0xe7, 0x5b, 0x6b, 0xb8, 0x20, 0x7d, 0xbf,
-	0x0e, 0x3c, 0x1f, 0xbd, 0x85, 0x8a, 0x6a, 0x4c, 0x42, 0xca, 0xa6, 0x81, 0xcf, 0x68, 0x75, 0x43,
-	0x56, 0xde, 0x93, 0x95, 0xf5, 0x23, 0x9c, 0x5c, 0x48, 0x20, 0x8e, 0x70, 0xad, 0x35, 0xbc, 0x3d,
-	0x9d, 0xf1, 0xd4, 0xfe, 0x91, 0x87, 0xed, 0x59, 0x10, 0xfa, 0x06, 0xca, 0xa2, 0x75, 0x5a, 0xdd,
-	0x95, 0xd5, 0x0d, 0x51, 0x5d, 0x1c, 0x40, 0xab, 0x56, 0xba, 0xd2, 0x6c, 0x74, 0x06, 0x8f, 0x87,
-	0xf6, 0x84, 0x12, 0x75, 0xfc, 0x24, 0x9d, 0xca, 0xf4, 0xcf, 0x44, 0xfa, 0x99, 0x3d, 0xa1, 0x3d,
-	0x11, 0xd5, 0x6a, 0x3c, 0x1a, 0xce, 0x3b, 0x45, 0xa1, 0x91, 0xed, 0xbb, 0xf3, 0x85, 0x06, 0x69,
-	0xa1, 0x96, 0xed, 0xbb, 0x1f, 0x15, 0x1a, 0xcd, 0x3b, 0xd1, 0xb7, 0x60, 0xb0, 0xd1, 0xcd, 0x60,
-	0x30, 0xa6, 0x69, 0x95, 0xa1, 0xac, 0xf2, 0x58, 0x54, 0xe9, 0xa9, 0x98, 0x56, 0xa3, 0xc2, 0x66,
-	0x5d, 0xe8, 0xcf, 0x19, 0x38, 0x71, 0x46, 0x41, 0xc0, 0x28, 0x71, 0x82, 0x71, 0x10, 0x12, 0xe6,
-	0xf9, 0x0e, 0x25, 0x03, 0x2f, 0x64, 0x9c, 0x38, 0x76, 0xe8, 0x12, 0x8f, 0x91, 0x3b, 0x6f, 0xec,
-	0xa6, 0x0d, 0x46, 0xb2, 0xc1, 0x97, 0x6a, 0xcd, 0x22, 0xb3, 0x21, 0x12, 0x7b, 0x22, 0xaf, 0x29,
-	0xd2, 0x1a, 0x76, 0xe8, 0xb6, 0xd9, 0x7b, 0x6f, 0xec, 0x6a, 0x8d, 0x8f, 0x9c, 0x87, 0x41, 0x11,
-	0x87, 0x97, 0x43, 0xca, 0x89, 0x4b, 0x9d, 0x6b, 0xc2, 0x83, 0xa9, 0xf8, 0x11, 0xde, 0x4f, 0xb9,
-	0x17, 0xf8, 0xe4, 0x9a, 0xde, 0xa7, 0xa7, 0xf0, 0xe4, 0x29, 0x0e, 0xe4, 0xd4, 0x29, 0x37, 0xa9,
-	0x73, 0xdd, 0x0f, 0xa6, 0x66, 0x02, 0x7e, 0x4b, 0xef, 0xb5, 0xee, 0x2f, 0x86, 0xcb, 0x21, 0xe8,
-	0xb7, 0xf0, 0x6c, 0xe8, 0xdd, 0xd2, 0xb4, 0xad, 0x7c, 0xf4, 0xa4, 0xd9, 0x95, 0x6c, 0xf6, 0x4c,
-	0x36, 0xf3, 0x6e, 0x69, 0x54, 0x4a, 0x9c, 0x5e, 0x6b, 0xb2, 0x3b, 0x5c, 0x1c, 0x12, 0x17, 0x4e,
-	0xdc, 0xca, 0xb4, 0xdc, 0x75, 0x7a, 0xe1, 0xc4, 0xdd, 0xd4, 0x2f, 0xdc, 0x54, 0xb3, 0xd1, 0x1f,
-	0x32, 0x70, 0xcc, 0x46, 0xc1, 0xcd, 0xd8, 0x25, 0xce, 0xc8, 0x1e, 0x8f, 0xa9, 0x3f, 0xa4, 0x6a,
-	0x19, 0x6e, 0x68, 0xdf, 0x91, 0x41, 0x70, 0xa3, 0xbd, 0x23, 0x63, 0x59, 0xf4, 0x48, 0xed, 0x5d,
-	0xe4, 0x34, 0xe2, 0x14, 0x31, 0x5f, 0x33, 0xb4, 0xef, 0x9a, 0xc1, 0x8d, 0xfe, 0xaa, 0x1c, 0xb0,
-	0xd5, 0x30, 0xc4, 0xe0, 0x20, 0xa4, 0xb7, 0xd4, 0x1e, 0xcb, 0x89, 0x30, 0x32, 0x08, 0x42, 0xed,
-	0x2c, 0x49, 0xf3, 0x49, 0xba, 0x0d, 0x2c, 0xe1, 0x62, 0x00, 0xac, 0x19, 0x84, 0x49, 0x75, 0x7d,
-	0x1b, 0xe1, 0x72, 0x08, 0xba, 0x87, 0x57, 0x0a, 0x42, 0xdd, 0xe5, 0x6d, 0x7d, 0xd9, 0xf6, 0x55,
-	0xda, 0x96, 0xba, 0xcb, 0x1a, 0x7f, 0x11, 0xae, 0x02, 0xfd, 0xaa, 0x00, 0xb9, 0x88, 0xce, 0xb4,
-	0x9f, 0xfb, 0x7f, 0x7b, 0x0a, 0xc5, 0x56, 0xc0, 0x12, 0x0e, 0xfb, 0x1a, 0x72, 0x77, 0x74, 0xec,
-	0x04, 0x93, 0x98, 0xf4, 0x76, 0xe5, 0x4b, 0x9b, 0x22, 0x4e, 0xde, 0xab, 0x70, 0x6b, 0x0d, 0xc7,
-	0x48, 0xf4, 0x2d, 0x44, 0xe4, 0xc4, 0xc8, 0xcd, 0xd4, 0xb5, 0x39, 0x95, 0xe4, 0xb7, 0x20, 0x57,
-	0xf1, 0x15, 0x6b, 0xad, 0xe1, 0x72, 0x94, 0x70, 0x29, 0xf1, 0xe8, 0x97, 0x80, 0x74, 0xc2, 0x25,
-	0xb6, 0xeb, 0x52, 0x37, 0x22, 0xc7, 0x05, 0xb4, 0x6b, 0x68, 0xb4, 0x5b, 0x17, 0x50, 0xf4, 0x73,
-	0x00, 0xc9, 0x60, 0xf4, 0x96, 0xfa, 0xbc, 0x9a, 0x95, 0x89, 0x4f, 0xe7, 0xdb, 0x0b, 0x12, 0xb3,
-	0x04, 0x40, 0xd0, 0xf2, 0x30, 0x36, 0x50, 0x33, 0x3e, 0x3e, 0x09, 0xe9, 0x0f, 0x37, 0x94, 0xf1,
-	0xea, 0xa6, 0xcc, 0xff, 0x7c, 0xf1, 0xf1, 0xb1, 0x02, 0xa5, 0x0f, 0x11, 0x39, 0xd0, 0x57, 0xb0,
-	0x49, 0xc3, 0x30, 0x08, 0xab, 0x5b, 0x1a, 0xdd, 0x69, 0xe9, 0x96, 0x08, 0xb6, 0xd6, 0xb0, 0x42,
-	0xd5, 0xfe, 0x95, 0x81, 0x5c, 0x34, 0x4c, 0x54, 0x85, 0xdc, 0x2d, 0x0d, 0x99, 0x17, 0xf8, 0x72,
-	0xec, 0x65, 0x1c, 0x9b, 0xe8, 0x27, 0x90, 0x8b, 0x46, 0x55, 0x5d, 0xdf, 0xdb, 0x38, 0x2e, 0x9e,
-	0xa2, 0xf8, 0xe5, 0xa2, 0x61, 0xdb, 0xa5, 0x3e, 0xf7, 0xf8, 0x3d, 0x8e, 0x21, 0xe8, 0x0d, 0x94,
-	0xf5, 0x39, 0xb2, 0xea, 0x86, 0xcc, 0x99, 0x1f, 0x21, 0x2e, 0x69, 0x03, 0x64, 0xa8, 0x0e, 0x95,
-	0xb1, 0xcd, 0x38, 0xf9, 0x3f, 0x26, 0x88, 0xcb, 0x22, 0x23, 0x31, 0x6b, 0xdf, 0x40, 0x2e, 0x5a,
-	0xae, 0x7e, 0xe2, 0xcc, 0xca, 0x13, 0xd7, 0xfe, 0x9a, 0x87, 0xf2, 0xcc, 0x5c, 0x85, 0xf8, 0x46,
-	0x2a, 0xa6, 0x96, 0xe1, 0xa6, 0xb7, 0x40, 0x89, 0x58, 0x3c, 0xfe, 0xe2, 0x55, 0x6a, 0x22, 0x13,
-	0xd0, 0x8c, 0x84, 0xa9, 0x5c, 0xa5, 0x60, 0x3b, 0x73, 0x0a, 0x16, 0x17, 0x30, 0x86, 0x73, 0x3e,
-	0x51, 0x65, 0x46, 0xbf, 0x54, 0x95, 0x41, 0x5a, 0x45, 0x93, 0xaf, 0xa4, 0xca, 0x68, 0xce, 0x87,
-	0x7e, 0x01, 0x95, 0x54, 0xbc, 0x54, 0x09, 0xa5, 0x5d, 0x68, 0x46, 0xbb, 0xe2, 0x02, 0xdb, 0x6c,
-	0xc6, 0x83, 0xfe, 0x94, 0x81, 0xaf, 0x1e, 0xaa, 0x5c, 0xaa, 0xba, 0x12, 0xae, 0x1f, 0x3f, 0x48,
-	0xb8, 0xe2, 0xae, 0x87, 0xce, 0x83, 0x90, 0xe8, 0x07, 0x38, 0x58, 0x2e, 0x5b, 0xea, 0x08, 0x4a,
-	0xb5, 0xf6, 0x97, 0xaa, 0x56, 0xdc, 0xfa, 0xf9, 0x70, 0x29, 0x02, 0x7d, 0x07, 0xb5, 0x85, 0x9a,
-	0xa5, 0x3a, 0x29, 0xc9, 0xaa, 0x2d, 0x94, 0xac, 0xb8, 0xc3, 0x93, 0xe1, 0xc2, 0x88, 0xb8, 0x5b,
-	0x91, 0x60, 0xa9, 0x5a, 0xd7, 0xe9, 0xdd, 0x52, 0x7a, 0x95, 0xdc, 0xad, 0x69, 0x6a, 0xa2, 0xdf,
-	0xc3, 0xd1, 0x6a, 0xb1, 0x52, 0x05, 0x95, 0x56, 0x1d, 0xae, 0xd4, 0xaa, 0xb8, 0xcf, 0x3e, 0x5b,
-	0x89, 0x42, 0x53, 0xd8, 0x5f, 0xaa, 0x54, 0xaa, 0xf3, 0x24, 0x5d, 0xc0, 0x27, 0x85, 0x2a, 0x59,
-	0x40, 0xb8, 0x14, 0x81, 0x6e, 0xe1, 0xe5, 0x0a, 0x99, 0x52, 0x3d, 0x95, 0x4a, 0xbd, 0x5c, 0xa1,
-	0x52, 0x71, 0xd7, 0xbd, 0x70, 0x05, 0x46, 0x13, 0xa6, 0xda, 0x1f, 0x33, 0xb0, 0x29, 0x09, 0x13,
-	0xed, 0x42, 0x4e, 0xbe, 0xd9, 0x9e, 0x2b, 0xb9, 0xb1, 0x84, 0xb7, 0x84, 0xd9, 0x76, 0x05, 0x69,
-	0xc6, 0x1f, 0xe8, 0x42, 0x6f, 0x0a, 0x38, 0x36, 0xd1, 0x17, 0x10, 0x7d, 0xe1, 0x13, 0xcf, 0x77,
-	0xe9, 0xef, 0xa4, 0x90, 0x6c, 0xaa, 0x9d, 0xd2, 0xb0, 0x2d, 0x5c, 0xe8, 0x08, 0x2a, 0x9c, 0x86,
-	0x13, 0xcf, 0xb7, 0x39, 0x65, 0x92, 0xf9, 0x24, 0xe7, 0xe5, 0xf1, 0x76, 0xea, 0x16, 0x94, 0x51,
-	0xfb, 0x0f, 0x40, 0x21, 0xe1, 0xb9, 0x4f, 0x1f, 0xe6, 0x14, 0xb2, 0xfc, 0x7e, 0xaa, 0x4e, 0xb2,
-	0x7d, 0xfa, 0xfc, 0x93, 0xc4, 0x79, 0xd2, 0xbf, 0x9f, 0x52, 0x2c, 0xb1, 0xe8, 0x00, 0x22, 0x05,
-	0x21, 0xcc, 0x09, 0xc2, 0x88, 0xad, 0xcb, 0x38, 0x3a, 0x7b, 0x4f, 0xfa, 0xc4, 0xb3, 0xb8, 0x62,
-	0x6a, 0xf1, 0xb3, 0x64, 0xa5, 0x3e, 0x14, 0x95, 0x4f, 0x3d, 0xcb, 0x29, 0x64, 0x05, 0x07, 0x45,
-	0xb2, 0xb5, 0xa4, 0xb7, 0x60, 0x2f, 0x2c, 0xb1, 0xe8, 0x2d, 0x94, 0x25, 0xd3, 0x39, 0xc1, 0x64,
-	0x3a, 0xa6, 0x9c, 0x46, 0xa2, 0x75, 0xb8, 0x3c, 0xb9, 0x11, 0xa1, 0x71, 0x69, 0xa4, 0x59, 0xb5,
-	0x7f, 0xae, 0x43, 0x56, 0x84, 0xc5, 0x78, 0x64, 0xd5, 0x74, 0x3c, 0xc2, 0x6c, 0xbb, 0x1f, 0x6d,
-	0x64, 0x5d, 0x3d, 0x85, 0xbe, 0x91, 0x37, 0xf0, 0x24, 0x82, 0xa8, 0x2b, 0x17, 0xd2, 0x89, 0xed,
-	0xf9, 0x9e, 0x3f, 0x8c, 0xc6, 0xb2, 0xa3, 0xa2, 0xf2, 0xf2, 0xe0, 0x38, 0x86, 0x5e, 0xc3, 0x8e,
-	0xa4, 0x89, 0xf9, 0x1c, 0x35, 0x26, 0x24, 0x62, 0x73, 0x19, 0x07, 0x50, 0x76, 0x3d, 0x26, 0x29,
-	0x85, 0x71, 0xdb, 0xb9, 0xae, 0x6e, 0xaa, 0xa9, 0x47, 0xce, 0x9e, 0xf0, 0xa1, 0x9f, 0xc2, 0xae,
-	0x94, 0xc4, 0x18, 0x29, 0x5f, 0x77, 0xc9, 0xc6, 0x72, 0x50, 0x9b, 0x78, 0x47, 0x84, 0x4d, 0x15,
-	0x15, 0x2f, 0xad, 0xe4, 0x51, 0x71, 0x25, 0x07, 0x41, 0x78, 0x67, 0x87, 0x6e, 0x35, 0x27, 0x6f,
-	0x53, 0x6c, 0xa2, 0x43, 0xa8, 0x04, 0x3e, 0x25, 0x63, 0x3a, 0xe0, 0x84, 0xdb, 0xe1, 0x90, 0xf2,
-	0x6a, 0x5e, 0x16, 0x2a, 0x07, 0x3e, 0xed, 0xd0, 0x01, 0xef, 0x4b, 0x67, 0xed, 0xdf, 0x19, 0x28,
-	0xe9, 0x93, 0x16, 0x93, 0xbb, 0xf3, 0x7c, 0x3f, 0x99, 0x9c, 0xfa, 0x3e, 0x28, 0x2a, 0x9f, 0x9a,
-	0xdc, 0x0e, 0x6c, 0xca, 0x0b, 0x14, 0x4d, 0x55, 0x19, 0xe8, 0x73, 0x80, 0x74, 0x32, 0xd1, 0x0c,
-	0x0b, 0xc9, 0x3c, 0xd0, 0x65, 0xb2, 0x11, 0x05, 0xc8, 0x4a, 0xad, 0x3e, 0x7d, 0xd8, 0xfe, 0x23,
-	0x39, 0x57, 0x93, 0x2d, 0x6a, 0x8b, 0xa9, 0xbd, 0x86, 0xa2, 0x16, 0xd3, 0xf6, 0xae, 0xba, 0x64,
-	0xe4, 0x31, 0xf4, 0x8c, 0xfd, 0xbf, 0x64, 0x21, 0x2b, 0x5e, 0x0a, 0xb4, 0x0d, 0x70, 0x56, 0x7f,
-	0x67, 0x91, 0x5e, 0xbf, 0x8e, 0xfb, 0xc6, 0x1a, 0x2a, 0x41, 0x5e, 0xda, 0x56, 0xd7, 0x34, 0x32,
-	0x68, 0x17, 0x1e, 0xb7, 0xea, 0x5d, 0x53, 0x45, 0x49, 0xaf, 0x75, 0xd9, 0x6c, 0x76, 0x2c, 0xd3,
-	0x58, 0x47, 0x4f, 0xe1, 0x33, 0x2d, 0xd0, 0xa8, 0x63, 0x93, 0x98, 0x56, 0xbd, 0xd3, 0x37, 0x36,
-	0xd0, 0x31, 0xbc, 0xd4, 0x42, 0xfd, 0xf3, 0x0b, 0x15, 0xae, 0x9b, 0xa6, 0x65, 0x92, 0xfe, 0x39,
-	0x31, 0xdb, 0x3d, 0xe1, 0x30, 0xb2, 0xe8, 0x31, 0x54, 0x24, 0x12, 0x5b, 0x49, 0xe5, 0xcd, 0xa4,
-	0xe5, 0x45, 0xa7, 0xfe, 0xbd, 0x85, 0x49, 0xef, 0x6d, 0xfb, 0xe2, 0xc2, 0x32, 0x8d, 0x2d, 0x54,
-	0x85, 0x1d, 0x3d, 0x60, 0x62, 0xeb, 0x3d, 0xe9, 0xbf, 0x3f, 0x37, 0x72, 0xe8, 0x09, 0xa0, 0x24,
-	0x42, 0xb0, 0xf5, 0x1b, 0x0b, 0xf7, 0x2c, 0xd3, 0xc8, 0x2f, 0xcc, 0x38, 0xef, 0x5a, 0x46, 0x01,
-	0x3d, 0x87, 0x9a, 0x1e, 0x91, 0xff, 0x98, 0xa4, 0x7b, 0xde, 0x6f, 0xb5, 0xbb, 0x67, 0x06, 0x24,
-	0x8f, 0x17, 0x67, 0xaa, 0x23, 0x5b, 0xa6, 0x51, 0x44, 0x87, 0xb0, 0xaf, 0x87, 0xba, 0xe7, 0xa4,
-	0xd1, 0xaa, 0x77, 0x3a, 0x56, 0xf7, 0xcc, 0x52, 0x1d, 0x9a, 0xe7, 0x97, 0xd8, 0x28, 0xa1, 0x2f,
-	0xe1, 0x48, 0xc7, 0xa5, 0xa0, 0xde, 0x65, 0xa3, 0x61, 0xf5, 0x7a, 0x1a, 0xb8, 0x8c, 0x7e, 0x04,
-	0xaf, 0x16, 0x83, 0x9b, 0xf5, 0x76, 0xc7, 0x32, 0x15, 0xb6, 0xd7, 0xfe, 0xce, 0xd8, 0x46, 0x2f,
-	0xe0, 0xd9, 0x0c, 0x54, 0x20, 0x4d, 0xf1, 0x58, 0xa4, 0x63, 0x35, 0xfb, 0x46, 0x65, 0xbe, 0x56,
-	0x1c, 0x21, 0x17, 0x56, 0xb7, 0xde, 0xe9, 0x7f, 0x9f, 0x0e, 0xce, 0x10, 0xcb, 0x96, 0x50, 0xb1,
-	0xec, 0x47, 0xfa, 0xff, 0x50, 0xfe, 0x9e, 0x81, 0xa2, 0xf6, 0xe9, 0x8a, 0x9e, 0x41, 0x21, 0x66,
-	0x92, 0x98, 0x64, 0xf2, 0x11, 0x8d, 0xb8, 0xe8, 0x05, 0x44, 0x57, 0x8b, 0xf8, 0x82, 0xd1, 0x95,
-	0x2c, 0x80, 0x72, 0x75, 0x6d, 0xf5, 0xa1, 0xed, 0x04, 0x37, 0x3e, 0xa7, 0xa1, 0x14, 0x85, 0x32,
-	0x8e, 0x4d, 0x54, 0x83, 0xbc, 0x13, 0xf8, 0x9c, 0xfa, 0x9c, 0x49, 0xf2, 0x28, 0xe0, 0xc4, 0x46,
-	0x06, 0x6c, 0x30, 0x6f, 0x28, 0xf9, 0xb5, 0x84, 0xc5, 0x4f, 0xf4, 0x1c, 0x8a, 0xa3, 0x80, 0x71,
-	0x72, 0xc3, 0x1d, 0x32, 0x61, 0x92, 0x13, 0xb2, 0xb8, 0x20, 0x5c, 0x97, 0xdc, 0x79, 0xc7, 0x4e,
-	0x7f, 0x06, 0x59, 0xf1, 0x16, 0xa1, 0xd7, 0xb0, 0xd5, 0xe3, 0x21, 0xb5, 0x27, 0xe8, 0xd1, 0x47,
-	0x7f, 0xe3, 0xa9, 0x55, 0xe6, 0x5e, 0xb6, 0xe3, 0xcc, 0xeb, 0xcc, 0x87, 0x2d, 0xf9, 0x47, 0xa9,
-	0xaf, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0x65, 0xfa, 0xbc, 0xc9, 0xb4, 0x12, 0x00, 0x00,
+func init() { proto.RegisterFile("host.proto", fileDescriptor_host_0e921daffe12bb3b) }
+
+var fileDescriptor_host_0e921daffe12bb3b = []byte{
+	// 2279 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0xdd, 0x6e, 0xdb, 0xc8,
+	0xf5, 0xb7, 0x6c, 0xf9, 0xeb, 0x48, 0xb2, 0xe8, 0xb1, 0x13, 0x33, 0xca, 0x26, 0x71, 0xec, 0x7c,
+	0xf8, 0xff, 0xdf, 0xae, 0x11, 0x78, 0x53, 0x6c, 0xd1, 0xa2, 0xdb, 0x55, 0x44, 0x3a, 0xd2, 0xfa,
+	0x43, 0x06, 0x25, 0xd7, 0xbb, 0x2d, 0x8a, 0x01, 0x43, 0x8e, 0x24, 0xda, 0x14, 0xc9, 0x70, 0x28,
+	0xab, 0xbe, 0x28, 0x50, 0xa0, 0x45, 0x6f, 0x5a, 0xf4, 0x15, 0x7a, 0x51, 0xf4, 0x05, 0xda, 0x8b,
+	0xbe, 0x43, 0x1f, 0xa3, 0x4f, 0x52, 0xcc, 0x0c, 0x3f, 0x46, 0x8c, 0x2c, 0x7b, 0xaf, 0xcc, 0x39,
+	0x1f, 0xbf, 0x73, 0x78, 0xce, 0xcc, 0x9c, 0x1f, 0x2d, 0x80, 0x81, 0x4f, 0xa3, 0xfd, 0x20, 0xf4,
+	0x23, 0x1f, 0xcd, 0x07, 0x1f, 0x6a, 0xe5, 0xc0, 0x35, 0x6f, 0x48, 0x28, 0x24, 0x3b, 0xff, 0xa8,
+	0x40, 0xa5, 0xe1, 0x3a, 0xc4, 0x8b, 0x4e, 0x08, 0xa5, 0x66, 0x9f, 0xa0, 0xb7, 0x50, 0xb6, 0x06,
+	0x66, 0x84, 0x87, 0x62, 0xad, 0x16, 0xb6, 0x0b, 0x7b, 0xa5, 0x83, 0xea, 0x7e, 0xf0, 0x61, 0xbf,
+	0x31, 0x30, 0x13, 0xb3, 0xe6, 0x9c, 0x51, 0xb2, 0xb2, 0x25, 0x7a, 0x06, 0x40, 0x23, 0x33, 0x8c,
+	0xf0, 0xa5, 0xef, 0x78, 0xea, 0xfc, 0x76, 0x61, 0x6f, 0xa5, 0x39, 0x67, 0xac, 0x72, 0xd9, 0xb7,
+	0xbe, 0xe3, 0xa1, 0x23, 0xa8, 0x8a, 0xc0, 0x38, 0x24, 0x34, 0xf0, 0x3d, 0x4a, 0xd4, 0x05, 0x8e,
+	0xbc, 0xcd, 0x91, 0xe5, 0x14, 0xf6, 0xcf, 0xb8, 0xa1, 0x11, 0xdb, 0x35, 0xe7, 0x8c, 0xb5, 0x60,
+	0x42, 0x82, 0x5e, 0x40, 0xc5, 0x32, 0x5d, 0x17, 0xfb, 0x1e, 0xc1, 0x2e, 0xe9, 0x45, 0x6a, 0x71,
+	0xbb, 0xb0, 0x57, 0xe1, 0x39, 0x99, 0xae, 0xdb, 0xf6, 0xc8, 0x31, 0xe9, 0x45, 0xe8, 0x11, 0x2c,
+	0x5f, 0x8e, 0x86, 0x01, 0x76, 0x3c, 0x75, 0x31, 0x4e, 0x68, 0x89, 0x09, 0x5a, 0x5e, 0xed, 0xef,
+	0x25, 0x58, 0x9b, 0x8c, 0x82, 0xbe, 0x82, 0x0a, 0xcb, 0x3d, 0x4b, 0xcf, 0xe6, 0xe9, 0x29, 0x2c,
+	0x3d, 0xf6, 0x06, 0x52, 0x3a, 0xe5, 0x4b, 0x69, 0x8d, 0xde, 0xc3, 0x46, 0xdf, 0x1c, 0x12, 0x2c,
+	0xde, 0x3f, 0x75, 0x27, 0xdc, 0xfd, 0x01, 0x73, 0x7f, 0x6f, 0x0e, 0x49, 0x87, 0x69, 0x25, 0x8c,
+	0xf5, 0x7e, 0x5e, 0xc8, 0x80, 0x06, 0xa6, 0x67, 0xe7, 0x81, 0x7a, 0x19, 0x50, 0xd3, 0xf4, 0xec,
+	0x4f, 0x80, 0x06, 0x79, 0x21, 0xfa, 0x06, 0x14, 0x3a, 0x18, 0xf5, 0x7a, 0x2e, 0xc9, 0x50, 0xfa,
+	0x1c, 0x65, 0x83, 0xa1, 0x74, 0x84, 0x4e, 0xc2, 0xa8, 0xd2, 0x49, 0x11, 0xfa, 0x6b, 0x01, 0xf6,
+	0xad, 0x81, 0xef, 0x53, 0x82, 0x2d, 0xdf, 0xf5, 0x43, 0x4c, 0x1d, 0xcf, 0x22, 0xb8, 0xe7, 0x84,
+	0x34, 0xc2, 0x96, 0x19, 0xda, 0xd8, 0xa1, 0x78, 0xec, 0xb8, 0x76, 0x16, 0x60, 0xc0, 0x03, 0x7c,
+	0x2e, 0xf6, 0x09, 0xf3, 0x6c, 0x30, 0xc7, 0x0e, 0xf3, 0x3b, 0x64, 0x6e, 0x0d, 0x33, 0xb4, 0x5b,
+	0xf4, 0xc2, 0x71, 0x6d, 0x29, 0xf0, 0x6b, 0xeb, 0x7e, 0xa6, 0x28, 0x82, 0x17, 0x7d, 0x12, 0x61,
+	0x9b, 0x58, 0x57, 0x38, 0xf2, 0x03, 0xf6, 0x10, 0xde, 0x04, 0x91, 0xe3, 0x7b, 0xf8, 0x8a, 0xdc,
+	0x64, 0x59, 0x38, 0x3c, 0x8b, 0x5d, 0x5e, 0x75, 0x12, 0x69, 0xc4, 0xba, 0xea, 0xfa, 0x81, 0x96,
+	0x1a, 0x1f, 0x91, 0x1b, 0x29, 0xfa, 0xb3, 0xfe, 0x6c, 0x13, 0xf4, 0x6b, 0x78, 0xdc, 0x77, 0xae,
+	0x49, 0x16, 0x96, 0xbf, 0x7a, 0x1a, 0xec, 0x92, 0x07, 0x7b, 0xcc, 0x83, 0x39, 0xd7, 0x24, 0x86,
+	0x62, 0xd9, 0x4b, 0x41, 0xb6, 0xfa, 0xd3, 0x55, 0x6c, 0xc3, 0xb1, 0x6d, 0x9d, 0xc1, 0x5d, 0x65,
+	0x1b, 0x8e, 0xed, 0x4d, 0x79, 0xc3, 0x05, 0xd2, 0x1a, 0xfd, 0xbe, 0x00, 0x7b, 0x74, 0xe0, 0x8f,
+	0x5c, 0x1b, 0x5b, 0x03, 0xd3, 0x75, 0x89, 0xd7, 0x27, 0xa2, 0x19, 0x76, 0x68, 0x8e, 0x71, 0xcf,
+	0x1f, 0x49, 0x87, 0xcc, 0xe5, 0xa0, 0xaf, 0x45, 0xdf, 0x99, 0x4f, 0x23, 0x71, 0x61, 0xf5, 0xd5,
+	0x42, 0x73, 0x7c, 0xe8, 0x8f, 0xe4, 0xb3, 0xb6, 0x4b, 0xef, 0x36, 0x43, 0x14, 0x76, 0x43, 0x72,
+	0x4d, 0x4c, 0x97, 0x57, 0x84, 0xe2, 0x9e, 0x1f, 0x4a, 0xb9, 0xa4, 0xc1, 0x87, 0x59, 0x37, 0x0c,
+	0x6e, 0xce, 0x0a, 0x40, 0x0f, 0xfd, 0x30, 0x45, 0x97, 0xbb, 0x11, 0xce, 0x36, 0x41, 0x37, 0xf0,
+	0x52, 0x98, 0x10, 0x7b, 0x76, 0x58, 0x8f, 0x87, 0x7d, 0x99, 0x85, 0x25, 0xf6, 0xac, 0xc0, 0xcf,
+	0xc3, 0xbb, 0x8c, 0x50, 0x1d, 0xf8, 0x79, 0xc5, 0xc4, 0x93, 0xda, 0xef, 0x67, 0x47, 0x8a, 0x9d,
+	0x70, 0xdd, 0x93, 0xdb, 0x5e, 0xed, 0x4f, 0x8a, 0x18, 0x04, 0x3f, 0xdd, 0x13, 0x10, 0x41, 0x06,
+	0xc1, 0xce, 0x76, 0x0e, 0x62, 0x30, 0x29, 0x42, 0x5f, 0x83, 0x12, 0x5f, 0x68, 0x19, 0xc2, 0x47,
+	0x8e, 0x80, 0xf8, 0x2d, 0xc5, 0xef, 0x36, 0xf9, 0xda, 0xbc, 0x9c, 0x90, 0xa0, 0xdf, 0xc0, 0xe3,
+	0xf8, 0x50, 0xd3, 0xb1, 0x19, 0xe0, 0xc8, 0x0c, 0xd9, 0x99, 0x4a, 0xa1, 0x42, 0x0e, 0xf5, 0x59,
+	0x76, 0x82, 0x3b, 0x63, 0x33, 0xe8, 0x72, 0x23, 0x09, 0x54, 0xb5, 0x6e, 0xd1, 0x21, 0x0d, 0xd0,
+	0xd0, 0xbf, 0x26, 0x98, 0xbf, 0x66, 0x8a, 0x4a, 0x39, 0xea, 0x26, 0x43, 0x3d, 0xf1, 0xaf, 0x09,
+	0x7b, 0x4d, 0x09, 0x4d, 0x19, 0xe6, 0x64, 0xef, 0x56, 0x61, 0x39, 0x1e, 0x3d, 0xd2, 0xe3, 0xce,
+	0x3f, 0x9f, 0x43, 0xa9, 0xe9, 0xd3, 0x74, 0xde, 0x7c, 0x09, 0xcb, 0x63, 0xe2, 0x5a, 0xfe, 0x30,
+	0x19, 0x50, 0x5b, 0xbc, 0x86, 0x99, 0xc5, 0xfe, 0x85, 0x50, 0x37, 0xe7, 0x8c, 0xc4, 0x12, 0x7d,
+	0x03, 0xf1, 0x20, 0xa1, 0x78, 0x14, 0xd8, 0x66, 0x44, 0xd4, 0xf9, 0xe9, 0xbe, 0x62, 0x34, 0xd0,
+	0xe6, 0x9c, 0x51, 0x89, 0x1d, 0xce, 0xb9, 0x3d, 0xfa, 0x05, 0x20, 0x79, 0x38, 0x62, 0xd3, 0xb6,
+	0x89, 0xad, 0x2e, 0xdc, 0x36, 0x22, 0x15, 0x69, 0x44, 0xd6, 0x99, 0x29, 0xfa, 0x29, 0x80, 0xd8,
+	0x48, 0xd7, 0xc4, 0x13, 0x63, 0xab, 0x74, 0xf0, 0x28, 0x1f, 0x9e, 0xef, 0x26, 0x66, 0xc0, 0x46,
+	0x68, 0x3f, 0x59, 0xa0, 0xc3, 0x24, 0x7d, 0x1c, 0x92, 0x8f, 0x23, 0x42, 0x23, 0x3e, 0xd6, 0x4a,
+	0x07, 0x4f, 0xa6, 0xa7, 0x6f, 0x08, 0xa3, 0xec, 0x25, 0x62, 0x01, 0xfa, 0x02, 0x16, 0x49, 0x18,
+	0xfa, 0xa1, 0xba, 0x24, 0x4d, 0x16, 0xc9, 0x5d, 0x67, 0xca, 0xe6, 0x9c, 0x21, 0xac, 0x50, 0x17,
+	0x36, 0x93, 0x39, 0x8b, 0xf9, 0xd4, 0x1d, 0x3b, 0x9e, 0xed, 0x8f, 0xd5, 0x65, 0xee, 0xfd, 0x3c,
+	0xef, 0x1d, 0x4f, 0xdf, 0x86, 0xe9, 0xba, 0x17, 0xdc, 0x90, 0xcd, 0x28, 0x3f, 0x2f, 0x44, 0x6f,
+	0x60, 0x29, 0x24, 0x74, 0x34, 0x24, 0xea, 0x0a, 0xc7, 0x79, 0x98, 0xc7, 0x31, 0xb8, 0x96, 0xcd,
+	0x6c, 0x61, 0x57, 0xfb, 0x4f, 0x01, 0x96, 0xe3, 0xa6, 0x22, 0x15, 0x96, 0xaf, 0x49, 0x48, 0x1d,
+	0xdf, 0xe3, 0xed, 0xaf, 0x18, 0xc9, 0x12, 0xfd, 0x08, 0x96, 0xe3, 0x96, 0xa9, 0xf3, 0xdb, 0x0b,
+	0xc9, 0xd1, 0x10, 0x15, 0x69, 0xd9, 0xc4, 0x8b, 0x9c, 0xe8, 0xc6, 0x48, 0x4c, 0xd0, 0x5b, 0xa8,
+	0xc8, 0xfd, 0xa4, 0xea, 0xc2, 0xf6, 0xc2, 0x94, 0x56, 0x1a, 0x65, 0xa9, 0x91, 0x14, 0xd5, 0xa1,
+	0xea, 0x9a, 0x34, 0xc2, 0x3f, 0xa0, 0x93, 0x46, 0x85, 0x79, 0xa4, 0xcb, 0xda, 0x57, 0xb0, 0x1c,
+	0x6f, 0x32, 0x39, 0xe3, 0xc2, 0x9d, 0x19, 0xd7, 0xfe, 0x50, 0x82, 0xca, 0x44, 0x7f, 0x91, 0x02,
+	0x0b, 0x94, 0x7c, 0xe4, 0x75, 0x28, 0x1a, 0xec, 0x91, 0x51, 0xb8, 0x98, 0xca, 0x88, 0x6d, 0x62,
+	0x67, 0xfb, 0x53, 0x30, 0x99, 0x64, 0x63, 0x94, 0x2e, 0xb3, 0x25, 0x3b, 0xbe, 0x13, 0x3c, 0x46,
+	0xf8, 0x92, 0xec, 0xf8, 0x4a, 0x34, 0x26, 0x01, 0x50, 0xfa, 0x39, 0x19, 0x43, 0x99, 0x20, 0x31,
+	0x02, 0xa5, 0x97, 0xa1, 0x48, 0x1c, 0x26, 0x45, 0x19, 0xe4, 0x64, 0xe8, 0xe7, 0x50, 0xcd, 0x18,
+	0x8c, 0x80, 0xe8, 0x67, 0x17, 0x5d, 0x4a, 0x60, 0x12, 0x80, 0x35, 0x3a, 0x21, 0x41, 0x7f, 0x2e,
+	0xc0, 0x17, 0xf7, 0xa5, 0x2f, 0x02, 0x5d, 0xb0, 0x97, 0xff, 0xbf, 0x17, 0x7b, 0x49, 0xa2, 0xbe,
+	0xb2, 0xee, 0x65, 0x89, 0x3e, 0xc2, 0xee, 0x6c, 0xee, 0x22, 0x52, 0x10, 0xd4, 0x65, 0x67, 0x26,
+	0x75, 0x49, 0x42, 0x3f, 0xed, 0xcf, 0xb4, 0x40, 0xdf, 0x41, 0x6d, 0x2a, 0x71, 0x11, 0x91, 0x04,
+	0x6f, 0xa9, 0x4d, 0xe5, 0x2d, 0x49, 0x84, 0x87, 0xfd, 0xa9, 0x1a, 0xb6, 0xb7, 0x62, 0xd6, 0x22,
+	0xb0, 0xae, 0xb2, 0xbd, 0x25, 0x48, 0x4b, 0xba, 0xb7, 0x82, 0x6c, 0x89, 0x7e, 0x07, 0xaf, 0xef,
+	0x66, 0x2c, 0x02, 0x50, 0x10, 0x96, 0x57, 0x77, 0x12, 0x96, 0x24, 0xce, 0x0e, 0xbd, 0xd3, 0x0a,
+	0x05, 0xb0, 0x33, 0x93, 0xae, 0x88, 0xc8, 0xc3, 0xac, 0x01, 0xb7, 0xb2, 0x95, 0xb4, 0x01, 0xe1,
+	0x4c, 0x0b, 0x74, 0x0d, 0x2f, 0xee, 0xe0, 0x2a, 0x22, 0xa6, 0xa0, 0x2a, 0x2f, 0xee, 0xa0, 0x2a,
+	0x49, 0xd4, 0xed, 0xf0, 0x0e, 0x1b, 0x46, 0x11, 0x24, 0xa2, 0x22, 0x62, 0xf8, 0xd9, 0xc9, 0x49,
+	0x79, 0x4a, 0x7a, 0x72, 0xfa, 0x13, 0x12, 0xe6, 0x2f, 0xb1, 0x14, 0xe1, 0x1f, 0x64, 0xfe, 0x29,
+	0x49, 0x49, 0xfd, 0x07, 0x13, 0x12, 0xf4, 0x33, 0xa8, 0x66, 0x14, 0x45, 0xb8, 0x0b, 0x86, 0xb2,
+	0x2e, 0x33, 0x94, 0x74, 0x30, 0x5d, 0xca, 0x02, 0xf4, 0x2b, 0xa8, 0x4d, 0xe5, 0x27, 0x02, 0x27,
+	0xcc, 0xd8, 0xf6, 0xa7, 0xf4, 0x24, 0x41, 0xdc, 0xb2, 0xa6, 0xab, 0x18, 0xfd, 0x92, 0xc9, 0x89,
+	0x80, 0xa4, 0x19, 0xfd, 0xca, 0xb8, 0x49, 0x02, 0x55, 0x1d, 0x4e, 0x8a, 0x24, 0x3a, 0x52, 0xfb,
+	0x53, 0x01, 0x16, 0xf9, 0x98, 0x44, 0x5b, 0xb0, 0xcc, 0x0b, 0xee, 0xd8, 0xfc, 0x06, 0x2e, 0x1b,
+	0x4b, 0x6c, 0xd9, 0xb2, 0x91, 0x9a, 0x5a, 0x73, 0x96, 0xb1, 0x6a, 0x24, 0x4b, 0xf4, 0x1c, 0xe2,
+	0x6f, 0x70, 0xec, 0x78, 0x36, 0xf9, 0x2d, 0xa7, 0x0f, 0x8b, 0xe2, 0xbc, 0x90, 0xb0, 0xc5, 0x44,
+	0xe8, 0x35, 0x54, 0x23, 0x12, 0x0e, 0x1d, 0xcf, 0x8c, 0x08, 0xe5, 0x73, 0x86, 0x4f, 0x98, 0x15,
+	0x63, 0x2d, 0x13, 0xb3, 0x5e, 0xd6, 0xfe, 0x58, 0x80, 0x25, 0x31, 0x29, 0x6f, 0xcf, 0x24, 0x1f,
+	0x6f, 0x9e, 0x4f, 0xcc, 0x89, 0x78, 0x5f, 0x43, 0x65, 0xe8, 0x50, 0x4a, 0x6c, 0x31, 0xce, 0x92,
+	0x39, 0x38, 0x63, 0x9e, 0x95, 0x85, 0x3d, 0x5f, 0xd0, 0x5a, 0x1b, 0xd6, 0x3f, 0x99, 0xfb, 0x3f,
+	0x2c, 0xa1, 0xc9, 0x02, 0xd4, 0xfe, 0x5d, 0x81, 0xd5, 0x34, 0xd8, 0xed, 0x48, 0x07, 0x50, 0x8c,
+	0x6e, 0x02, 0x51, 0xe1, 0xb5, 0x83, 0xa7, 0xb7, 0xa6, 0xbb, 0xdf, 0xbd, 0x09, 0x88, 0xc1, 0x6d,
+	0xd1, 0x2e, 0xc4, 0x7c, 0x08, 0x53, 0xcb, 0x0f, 0xe3, 0x99, 0x5f, 0x31, 0xe2, 0x94, 0x3a, 0x5c,
+	0xc6, 0x52, 0xb4, 0x89, 0xe9, 0xa6, 0x29, 0x16, 0x45, 0xcd, 0x84, 0x4c, 0xd4, 0xec, 0x00, 0x8a,
+	0x6c, 0x33, 0xc5, 0x24, 0x6c, 0x46, 0x6c, 0xbe, 0x87, 0xb8, 0x2d, 0x3a, 0x82, 0x0a, 0xfb, 0x8b,
+	0x2d, 0x7f, 0x18, 0xb8, 0x24, 0x22, 0xea, 0x52, 0x76, 0xdb, 0xdd, 0xee, 0xdc, 0x88, 0xad, 0x8d,
+	0xf2, 0x40, 0x5a, 0xd5, 0xfe, 0xb2, 0x00, 0x45, 0xa6, 0x66, 0xe5, 0xe1, 0xa8, 0x59, 0x79, 0xd8,
+	0xf2, 0x7e, 0x9d, 0x7f, 0x0b, 0x0f, 0x63, 0x13, 0x71, 0x4d, 0x85, 0x64, 0x68, 0x3a, 0x9e, 0xe3,
+	0xf5, 0xe3, 0xb2, 0x6c, 0x0a, 0x2d, 0xbf, 0x70, 0x8c, 0x44, 0x87, 0xde, 0xc0, 0x26, 0x1f, 0x2d,
+	0x79, 0x1f, 0x51, 0x26, 0xc4, 0x74, 0x39, 0x8f, 0x5d, 0xa8, 0xd8, 0x0e, 0x65, 0xf6, 0x8c, 0x1a,
+	0x58, 0x57, 0xea, 0xa2, 0xa8, 0x7a, 0x2c, 0xec, 0x30, 0x19, 0xfa, 0x31, 0x6c, 0x71, 0x62, 0x95,
+	0x58, 0xf2, 0x11, 0xc1, 0x27, 0x38, 0x2f, 0xd4, 0xa2, 0xb1, 0xc9, 0xd4, 0x9a, 0xd0, 0xb2, 0x8b,
+	0x9e, 0xcf, 0x5e, 0x76, 0xd4, 0x7a, 0x7e, 0x38, 0x36, 0x43, 0x9b, 0x93, 0xd2, 0x15, 0x23, 0x59,
+	0xa2, 0x57, 0x50, 0x4d, 0xb9, 0xab, 0xb8, 0x4e, 0x38, 0xdd, 0x5c, 0x34, 0x2a, 0x31, 0x23, 0x15,
+	0x97, 0x04, 0x6f, 0x37, 0x9b, 0x44, 0x01, 0xf1, 0x4c, 0x37, 0xba, 0x51, 0x57, 0xe3, 0x76, 0x87,
+	0xe6, 0xf8, 0x4c, 0x88, 0xd0, 0x33, 0x28, 0x49, 0xb7, 0x92, 0x0a, 0x1c, 0x06, 0x68, 0x7a, 0xd1,
+	0xd4, 0xfe, 0x36, 0x0f, 0x65, 0xb9, 0x5b, 0x0c, 0x74, 0xec, 0x78, 0x5e, 0x5a, 0x7d, 0xc1, 0x54,
+	0x4b, 0x42, 0x26, 0xaa, 0xbf, 0x09, 0x8b, 0x7c, 0x13, 0xc6, 0x9d, 0x11, 0x0b, 0xf4, 0x04, 0x20,
+	0xab, 0x6e, 0xdc, 0x87, 0xd5, 0xb4, 0xa6, 0xe8, 0x3c, 0xed, 0xaa, 0x30, 0x28, 0xf2, 0xb3, 0x7a,
+	0x70, 0xbf, 0x3d, 0x14, 0x13, 0x4b, 0xd1, 0x9d, 0x92, 0xd4, 0x5c, 0xb4, 0x0f, 0x1b, 0xf2, 0xb9,
+	0xc0, 0x36, 0x71, 0x23, 0x93, 0xc6, 0x7d, 0x5a, 0x97, 0x4e, 0x87, 0xc6, 0x15, 0xb5, 0x37, 0x50,
+	0x92, 0xb0, 0xd0, 0xf3, 0x5c, 0x56, 0x05, 0xee, 0x27, 0x47, 0xd8, 0xf9, 0xef, 0x22, 0x14, 0xd9,
+	0x41, 0x44, 0x6b, 0x00, 0xef, 0xeb, 0x27, 0x3a, 0xee, 0x74, 0xeb, 0x46, 0x57, 0x99, 0x43, 0x65,
+	0x58, 0xe1, 0x6b, 0xfd, 0x54, 0x53, 0x0a, 0x68, 0x0b, 0x36, 0x9a, 0xf5, 0x53, 0x4d, 0x68, 0x71,
+	0xa7, 0x79, 0x7e, 0x78, 0x78, 0xac, 0x6b, 0xca, 0x3c, 0x7a, 0x04, 0x0f, 0x24, 0x45, 0xa3, 0x6e,
+	0x68, 0x58, 0xd3, 0xeb, 0xc7, 0x5d, 0x65, 0x01, 0xed, 0xc1, 0x0b, 0x49, 0xd5, 0x6d, 0x9f, 0x09,
+	0x75, 0x5d, 0xd3, 0x74, 0x0d, 0x77, 0xdb, 0x58, 0x6b, 0x75, 0x98, 0x40, 0x29, 0xa2, 0x0d, 0xa8,
+	0x72, 0x4b, 0x43, 0x4f, 0x91, 0x17, 0xd3, 0x90, 0x67, 0xc7, 0xf5, 0xef, 0x75, 0x03, 0x77, 0x8e,
+	0x5a, 0x67, 0x67, 0xba, 0xa6, 0x2c, 0x21, 0x15, 0x36, 0x65, 0x85, 0x66, 0xe8, 0x17, 0xb8, 0x7b,
+	0xd1, 0x56, 0x96, 0xd1, 0x43, 0x40, 0xa9, 0x06, 0x1b, 0xfa, 0x2f, 0x75, 0xa3, 0xa3, 0x6b, 0xca,
+	0xca, 0x54, 0x8f, 0xf6, 0xa9, 0xae, 0xac, 0xa2, 0xa7, 0x50, 0x93, 0x35, 0xfc, 0x8f, 0x86, 0x4f,
+	0xdb, 0xdd, 0x66, 0xeb, 0xf4, 0xbd, 0x02, 0xe9, 0xeb, 0x25, 0x9e, 0x22, 0x65, 0x5d, 0x53, 0x4a,
+	0xe8, 0x15, 0xec, 0xc8, 0xaa, 0xd3, 0x36, 0x6e, 0x34, 0xeb, 0xc7, 0xc7, 0xfa, 0xe9, 0x7b, 0x5d,
+	0x44, 0x38, 0x6c, 0x9f, 0x1b, 0x4a, 0x19, 0x7d, 0x0e, 0xaf, 0x65, 0xbb, 0xcc, 0xa8, 0x73, 0xde,
+	0x68, 0xe8, 0x9d, 0x8e, 0x64, 0x5c, 0x41, 0xff, 0x07, 0x2f, 0xa7, 0x1b, 0x1f, 0xd6, 0x5b, 0xc7,
+	0xba, 0x26, 0x6c, 0x3b, 0xad, 0xef, 0x94, 0x35, 0xf4, 0x0c, 0x1e, 0x4f, 0x98, 0x32, 0x4b, 0x8d,
+	0xbd, 0x16, 0x3e, 0xd6, 0x0f, 0xbb, 0x4a, 0x35, 0x8f, 0x95, 0x68, 0xf0, 0x99, 0x7e, 0x5a, 0x3f,
+	0xee, 0x7e, 0x9f, 0x15, 0x4e, 0x61, 0xcd, 0xe6, 0xa6, 0xac, 0xd9, 0xeb, 0x79, 0xe4, 0xc4, 0xbe,
+	0xd3, 0xad, 0x37, 0x8e, 0x74, 0x4d, 0x41, 0x68, 0x1b, 0x3e, 0x9b, 0x66, 0x50, 0x7f, 0xd7, 0x69,
+	0x1b, 0xef, 0x74, 0x4d, 0xd9, 0xc8, 0xd7, 0xed, 0xdb, 0xf3, 0x93, 0x33, 0x5d, 0xc3, 0xad, 0x53,
+	0x65, 0x13, 0x3d, 0x81, 0x47, 0x13, 0x7d, 0xbd, 0xa8, 0xb3, 0xbe, 0x62, 0x26, 0xeb, 0x28, 0x0f,
+	0xd2, 0x1e, 0xf2, 0x35, 0x36, 0xda, 0xdd, 0x7a, 0x57, 0xd7, 0x94, 0x87, 0xf9, 0xa4, 0xe2, 0xee,
+	0xa6, 0xdb, 0x62, 0x2b, 0x1f, 0xb2, 0xdb, 0x3a, 0x61, 0xe5, 0x38, 0xef, 0x2a, 0xaa, 0xfc, 0x4f,
+	0x8b, 0x7f, 0x15, 0xa0, 0x24, 0x7d, 0x45, 0xa2, 0xc7, 0xb0, 0x9a, 0x5c, 0xc7, 0xc9, 0x4d, 0xbd,
+	0x12, 0xdf, 0xc5, 0x36, 0xbb, 0x5f, 0x62, 0xa5, 0x67, 0x0e, 0xc5, 0x85, 0xb0, 0x6a, 0x80, 0x10,
+	0x9d, 0x9a, 0xe2, 0x9b, 0xd7, 0xf2, 0x47, 0x5e, 0x44, 0x42, 0xce, 0x18, 0x2a, 0x46, 0xb2, 0x44,
+	0x35, 0x58, 0xb1, 0x7c, 0x2f, 0xe2, 0x83, 0xbb, 0xc8, 0xfd, 0xd2, 0x35, 0xff, 0x3a, 0x74, 0xfa,
+	0x7c, 0x48, 0x95, 0x0d, 0xf6, 0x88, 0x9e, 0x42, 0x89, 0xfd, 0x24, 0x80, 0x47, 0x91, 0x85, 0x87,
+	0x94, 0x5f, 0xac, 0x45, 0x63, 0x95, 0x89, 0xce, 0x23, 0xeb, 0x84, 0x1e, 0xfc, 0x04, 0x8a, 0xec,
+	0x1a, 0x61, 0x5f, 0xe8, 0x9d, 0x28, 0x24, 0xe6, 0x10, 0xad, 0x7f, 0xf2, 0x2f, 0xfa, 0x5a, 0x35,
+	0x77, 0xdb, 0xec, 0x15, 0xde, 0x14, 0x3e, 0x2c, 0xf1, 0xdf, 0x14, 0xbe, 0xfc, 0xdf, 0x00, 0xcb,
+	0x26, 0x6b, 0x45, 0x73, 0x18, 0x00, 0x00,
 }
@@ -23,6 +23,81 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// The commutative cipher cards are encrypted with during a hand
+type CardCipher int32
+
+const (
+	// Exponentiation mod a shared safe prime, with cards as quadratic residues
+	CardCipher_SRA CardCipher = 0
+	// Scalar multiplication on the NIST P-256 curve, with cards as points
+	CardCipher_EC_P256 CardCipher = 1
+)
+
+var CardCipher_name = map[int32]string{
+	0: "SRA",
+	1: "EC_P256",
+}
+var CardCipher_value = map[string]int32{
+	"SRA":     0,
+	"EC_P256": 1,
+}
+
+func (x CardCipher) String() string {
+	return proto.EnumName(CardCipher_name, int32(x))
+}
+func (CardCipher) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{0}
+}
+
+type Rules_FirstWildDrawFour int32
+
+const (
+	Rules_REDRAW    Rules_FirstWildDrawFour = 0
+	Rules_RESHUFFLE Rules_FirstWildDrawFour = 1
+)
+
+var Rules_FirstWildDrawFour_name = map[int32]string{
+	0: "REDRAW",
+	1: "RESHUFFLE",
+}
+var Rules_FirstWildDrawFour_value = map[string]int32{
+	"REDRAW":    0,
+	"RESHUFFLE": 1,
+}
+
+func (x Rules_FirstWildDrawFour) String() string {
+	return proto.EnumName(Rules_FirstWildDrawFour_name, int32(x))
+}
+func (Rules_FirstWildDrawFour) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{5, 0}
+}
+
+type Rules_Scoring int32
+
+const (
+	Rules_STANDARD    Rules_Scoring = 0
+	Rules_PENALTY     Rules_Scoring = 1
+	Rules_FIXED_HANDS Rules_Scoring = 2
+)
+
+var Rules_Scoring_name = map[int32]string{
+	0: "STANDARD",
+	1: "PENALTY",
+	2: "FIXED_HANDS",
+}
+var Rules_Scoring_value = map[string]int32{
+	"STANDARD":    0,
+	"PENALTY":     1,
+	"FIXED_HANDS": 2,
+}
+
+func (x Rules_Scoring) String() string {
+	return proto.EnumName(Rules_Scoring_name, int32(x))
+}
+func (Rules_Scoring) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{5, 1}
+}
+
 type PlayerIdentity struct {
 	// ID is pub key
 	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -38,7 +113,7 @@ func (m *PlayerIdentity) Reset()         { *m = PlayerIdentity{} }
 func (m *PlayerIdentity) String() string { return proto.CompactTextString(m) }
 func (*PlayerIdentity) ProtoMessage()    {}
 func (*PlayerIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{0}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{0}
 }
 func (m *PlayerIdentity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlayerIdentity.Unmarshal(m, b)
@@ -97,7 +172,7 @@ func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
 func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
 func (*JoinRequest) ProtoMessage()    {}
 func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{1}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{1}
 }
 func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinRequest.Unmarshal(m, b)
@@ -125,17 +200,26 @@ func (m *JoinRequest) GetRandomNonce() []byte {
 }
 
 type JoinResponse struct {
-	Player               *PlayerIdentity `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Player *PlayerIdentity `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	// Set when joining again after reconnecting to the game in progress with this ID, which the player has kept the
+	// state of
+	ResumeGameId []byte `protobuf:"bytes,2,opt,name=resume_game_id,json=resumeGameId,proto3" json:"resume_game_id,omitempty"`
+	// How many of the resumed game's events the player has already seen
+	ResumeGameEventsSeen uint32 `protobuf:"varint,3,opt,name=resume_game_events_seen,json=resumeGameEventsSeen,proto3" json:"resume_game_events_seen,omitempty"`
+	// The smallest shared card prime the player accepts in bits, 0 if any
+	MinSharedCardPrimeBits uint32 `protobuf:"varint,4,opt,name=min_shared_card_prime_bits,json=minSharedCardPrimeBits,proto3" json:"min_shared_card_prime_bits,omitempty"`
+	// The card ciphers the player accepts besides SRA, which every player accepts
+	CardCiphers          []CardCipher `protobuf:"varint,5,rep,packed,name=card_ciphers,json=cardCiphers,proto3,enum=pb.CardCipher" json:"card_ciphers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
 func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
 func (*JoinResponse) ProtoMessage()    {}
 func (*JoinResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{2}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{2}
 }
 func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinResponse.Unmarshal(m, b)
@@ -162,21 +246,59 @@ func (m *JoinResponse) GetPlayer() *PlayerIdentity {
 	return nil
 }
 
+func (m *JoinResponse) GetResumeGameId() []byte {
+	if m != nil {
+		return m.ResumeGameId
+	}
+	return nil
+}
+
+func (m *JoinResponse) GetResumeGameEventsSeen() uint32 {
+	if m != nil {
+		return m.ResumeGameEventsSeen
+	}
+	return 0
+}
+
+func (m *JoinResponse) GetMinSharedCardPrimeBits() uint32 {
+	if m != nil {
+		return m.MinSharedCardPrimeBits
+	}
+	return 0
+}
+
+func (m *JoinResponse) GetCardCiphers() []CardCipher {
+	if m != nil {
+		return m.CardCiphers
+	}
+	return nil
+}
+
 type GameStartRequest struct {
 	// The ID of this new game.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// The set of players that are participating in this game. Always at least 2.
-	Players              []*PlayerIdentity `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Players []*PlayerIdentity `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`
+	// The house rules for this game.
+	Rules *Rules `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`
+	// The team index of each player in the same order as players. Unless partners are set in the rules, every player is
+	// their own team.
+	PlayerTeams []uint32 `protobuf:"varint,5,rep,packed,name=player_teams,json=playerTeams,proto3" json:"player_teams,omitempty"`
+	// How long the host waits for a player's decision in their turn before taking the default for them. 0 if it never
+	// does.
+	TurnTimeoutMs uint32 `protobuf:"varint,6,opt,name=turn_timeout_ms,json=turnTimeoutMs,proto3" json:"turn_timeout_ms,omitempty"`
+	// The bit size of the safe prime each hand is shuffled with. At least the minimum of every player.
+	SharedCardPrimeBits  uint32   `protobuf:"varint,7,opt,name=shared_card_prime_bits,json=sharedCardPrimeBits,proto3" json:"shared_card_prime_bits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GameStartRequest) Reset()         { *m = GameStartRequest{} }
 func (m *GameStartRequest) String() string { return proto.CompactTextString(m) }
 func (*GameStartRequest) ProtoMessage()    {}
 func (*GameStartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{3}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{3}
 }
 func (m *GameStartRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameStartRequest.Unmarshal(m, b)
@@ -210,6 +332,34 @@ func (m *GameStartRequest) GetPlayers() []*PlayerIdentity {
 	return nil
 }
 
+func (m *GameStartRequest) GetRules() *Rules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *GameStartRequest) GetPlayerTeams() []uint32 {
+	if m != nil {
+		return m.PlayerTeams
+	}
+	return nil
+}
+
+func (m *GameStartRequest) GetTurnTimeoutMs() uint32 {
+	if m != nil {
+		return m.TurnTimeoutMs
+	}
+	return 0
+}
+
+func (m *GameStartRequest) GetSharedCardPrimeBits() uint32 {
+	if m != nil {
+		return m.SharedCardPrimeBits
+	}
+	return 0
+}
+
 type GameStartResponse struct {
 	Sig                  []byte   `protobuf:"bytes,1,opt,name=sig,proto3" json:"sig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -221,7 +371,7 @@ func (m *GameStartResponse) Reset()         { *m = GameStartResponse{} }
 func (m *GameStartResponse) String() string { return proto.CompactTextString(m) }
 func (*GameStartResponse) ProtoMessage()    {}
 func (*GameStartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{4}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{4}
 }
 func (m *GameStartResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameStartResponse.Unmarshal(m, b)
@@ -248,6 +398,132 @@ func (m *GameStartResponse) GetSig() []byte {
 	return nil
 }
 
+type Rules struct {
+	HandSize             uint32                  `protobuf:"varint,1,opt,name=hand_size,json=handSize,proto3" json:"hand_size,omitempty"`
+	TargetScore          uint32                  `protobuf:"varint,2,opt,name=target_score,json=targetScore,proto3" json:"target_score,omitempty"`
+	SingleHand           bool                    `protobuf:"varint,3,opt,name=single_hand,json=singleHand,proto3" json:"single_hand,omitempty"`
+	DrawUntilPlayable    bool                    `protobuf:"varint,4,opt,name=draw_until_playable,json=drawUntilPlayable,proto3" json:"draw_until_playable,omitempty"`
+	PlayDrawnCard        bool                    `protobuf:"varint,5,opt,name=play_drawn_card,json=playDrawnCard,proto3" json:"play_drawn_card,omitempty"`
+	FirstWildDrawFour    Rules_FirstWildDrawFour `protobuf:"varint,6,opt,name=first_wild_draw_four,json=firstWildDrawFour,proto3,enum=pb.Rules_FirstWildDrawFour" json:"first_wild_draw_four,omitempty"`
+	StackDrawCards       bool                    `protobuf:"varint,7,opt,name=stack_draw_cards,json=stackDrawCards,proto3" json:"stack_draw_cards,omitempty"`
+	JumpIn               bool                    `protobuf:"varint,8,opt,name=jump_in,json=jumpIn,proto3" json:"jump_in,omitempty"`
+	SevenO               bool                    `protobuf:"varint,9,opt,name=seven_o,json=sevenO,proto3" json:"seven_o,omitempty"`
+	Partners             bool                    `protobuf:"varint,10,opt,name=partners,proto3" json:"partners,omitempty"`
+	Scoring              Rules_Scoring           `protobuf:"varint,11,opt,name=scoring,proto3,enum=pb.Rules_Scoring" json:"scoring,omitempty"`
+	HandCount            uint32                  `protobuf:"varint,12,opt,name=hand_count,json=handCount,proto3" json:"hand_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *Rules) Reset()         { *m = Rules{} }
+func (m *Rules) String() string { return proto.CompactTextString(m) }
+func (*Rules) ProtoMessage()    {}
+func (*Rules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{5}
+}
+func (m *Rules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rules.Unmarshal(m, b)
+}
+func (m *Rules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rules.Marshal(b, m, deterministic)
+}
+func (dst *Rules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rules.Merge(dst, src)
+}
+func (m *Rules) XXX_Size() int {
+	return xxx_messageInfo_Rules.Size(m)
+}
+func (m *Rules) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rules proto.InternalMessageInfo
+
+func (m *Rules) GetHandSize() uint32 {
+	if m != nil {
+		return m.HandSize
+	}
+	return 0
+}
+
+func (m *Rules) GetTargetScore() uint32 {
+	if m != nil {
+		return m.TargetScore
+	}
+	return 0
+}
+
+func (m *Rules) GetSingleHand() bool {
+	if m != nil {
+		return m.SingleHand
+	}
+	return false
+}
+
+func (m *Rules) GetDrawUntilPlayable() bool {
+	if m != nil {
+		return m.DrawUntilPlayable
+	}
+	return false
+}
+
+func (m *Rules) GetPlayDrawnCard() bool {
+	if m != nil {
+		return m.PlayDrawnCard
+	}
+	return false
+}
+
+func (m *Rules) GetFirstWildDrawFour() Rules_FirstWildDrawFour {
+	if m != nil {
+		return m.FirstWildDrawFour
+	}
+	return Rules_REDRAW
+}
+
+func (m *Rules) GetStackDrawCards() bool {
+	if m != nil {
+		return m.StackDrawCards
+	}
+	return false
+}
+
+func (m *Rules) GetJumpIn() bool {
+	if m != nil {
+		return m.JumpIn
+	}
+	return false
+}
+
+func (m *Rules) GetSevenO() bool {
+	if m != nil {
+		return m.SevenO
+	}
+	return false
+}
+
+func (m *Rules) GetPartners() bool {
+	if m != nil {
+		return m.Partners
+	}
+	return false
+}
+
+func (m *Rules) GetScoring() Rules_Scoring {
+	if m != nil {
+		return m.Scoring
+	}
+	return Rules_STANDARD
+}
+
+func (m *Rules) GetHandCount() uint32 {
+	if m != nil {
+		return m.HandCount
+	}
+	return 0
+}
+
 type GameEndRequest struct {
 	PlayerScores          []uint32 `protobuf:"varint,1,rep,packed,name=player_scores,json=playerScores,proto3" json:"player_scores,omitempty"`
 	LastHandEndPlayerSigs [][]byte `protobuf:"bytes,2,rep,name=last_hand_end_player_sigs,json=lastHandEndPlayerSigs,proto3" json:"last_hand_end_player_sigs,omitempty"`
@@ -260,7 +536,7 @@ func (m *GameEndRequest) Reset()         { *m = GameEndRequest{} }
 func (m *GameEndRequest) String() string { return proto.CompactTextString(m) }
 func (*GameEndRequest) ProtoMessage()    {}
 func (*GameEndRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{5}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{6}
 }
 func (m *GameEndRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameEndRequest.Unmarshal(m, b)
@@ -305,7 +581,7 @@ func (m *GameEndResponse) Reset()         { *m = GameEndResponse{} }
 func (m *GameEndResponse) String() string { return proto.CompactTextString(m) }
 func (*GameEndResponse) ProtoMessage()    {}
 func (*GameEndResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{6}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{7}
 }
 func (m *GameEndResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameEndResponse.Unmarshal(m, b)
@@ -335,7 +611,7 @@ func (m *GameEndResponse) GetSig() []byte {
 type HandStartRequest struct {
 	// The ID of this hand.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// The prime that will be used for shuffling during this hand.
+	// The prime that will be used for shuffling during this hand. Only set for the SRA card cipher.
 	SharedCardPrime []byte `protobuf:"bytes,2,opt,name=shared_card_prime,json=sharedCardPrime,proto3" json:"shared_card_prime,omitempty"`
 	// The scores of the players at the start of this hand.
 	PlayerScores []uint32 `protobuf:"varint,3,rep,packed,name=player_scores,json=playerScores,proto3" json:"player_scores,omitempty"`
@@ -344,16 +620,20 @@ type HandStartRequest struct {
 	// The signatures of the game start binaries for the players.
 	GameStartPlayerSigs   [][]byte `protobuf:"bytes,5,rep,name=game_start_player_sigs,json=gameStartPlayerSigs,proto3" json:"game_start_player_sigs,omitempty"`
 	LastHandEndPlayerSigs [][]byte `protobuf:"bytes,6,rep,name=last_hand_end_player_sigs,json=lastHandEndPlayerSigs,proto3" json:"last_hand_end_player_sigs,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+	// The seed the values encrypted for each card in this hand are derived from.
+	CardEncodingSeed []byte `protobuf:"bytes,7,opt,name=card_encoding_seed,json=cardEncodingSeed,proto3" json:"card_encoding_seed,omitempty"`
+	// The cipher cards are encrypted with during this hand, one every player accepts.
+	CardCipher           CardCipher `protobuf:"varint,8,opt,name=card_cipher,json=cardCipher,proto3,enum=pb.CardCipher" json:"card_cipher,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *HandStartRequest) Reset()         { *m = HandStartRequest{} }
 func (m *HandStartRequest) String() string { return proto.CompactTextString(m) }
 func (*HandStartRequest) ProtoMessage()    {}
 func (*HandStartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{7}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{8}
 }
 func (m *HandStartRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandStartRequest.Unmarshal(m, b)
@@ -415,6 +695,20 @@ func (m *HandStartRequest) GetLastHandEndPlayerSigs() [][]byte {
 	return nil
 }
 
+func (m *HandStartRequest) GetCardEncodingSeed() []byte {
+	if m != nil {
+		return m.CardEncodingSeed
+	}
+	return nil
+}
+
+func (m *HandStartRequest) GetCardCipher() CardCipher {
+	if m != nil {
+		return m.CardCipher
+	}
+	return CardCipher_SRA
+}
+
 type HandStartResponse struct {
 	Sig                  []byte   `protobuf:"bytes,1,opt,name=sig,proto3" json:"sig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -426,7 +720,7 @@ func (m *HandStartResponse) Reset()         { *m = HandStartResponse{} }
 func (m *HandStartResponse) String() string { return proto.CompactTextString(m) }
 func (*HandStartResponse) ProtoMessage()    {}
 func (*HandStartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{8}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{9}
 }
 func (m *HandStartResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandStartResponse.Unmarshal(m, b)
@@ -461,17 +755,19 @@ type HandEndRequest struct {
 	Score              uint32   `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
 	EncryptedDeckCards [][]byte `protobuf:"bytes,4,rep,name=encrypted_deck_cards,json=encryptedDeckCards,proto3" json:"encrypted_deck_cards,omitempty"`
 	// Empty on stage 0
-	PlayerInfos          []*HandEndRequest_PlayerInfo `protobuf:"bytes,5,rep,name=player_infos,json=playerInfos,proto3" json:"player_infos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	PlayerInfos []*HandEndRequest_PlayerInfo `protobuf:"bytes,5,rep,name=player_infos,json=playerInfos,proto3" json:"player_infos,omitempty"`
+	// Empty on stage 0. Otherwise, how much this hand changed each player's score.
+	PlayerScoreDeltas    []uint32 `protobuf:"varint,6,rep,packed,name=player_score_deltas,json=playerScoreDeltas,proto3" json:"player_score_deltas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *HandEndRequest) Reset()         { *m = HandEndRequest{} }
 func (m *HandEndRequest) String() string { return proto.CompactTextString(m) }
 func (*HandEndRequest) ProtoMessage()    {}
 func (*HandEndRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{9}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{10}
 }
 func (m *HandEndRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandEndRequest.Unmarshal(m, b)
@@ -526,6 +822,13 @@ func (m *HandEndRequest) GetPlayerInfos() []*HandEndRequest_PlayerInfo {
 	return nil
 }
 
+func (m *HandEndRequest) GetPlayerScoreDeltas() []uint32 {
+	if m != nil {
+		return m.PlayerScoreDeltas
+	}
+	return nil
+}
+
 type HandEndRequest_PlayerInfo struct {
 	// The set of encrypted cards the player holds.
 	EncryptedCardsInHand [][]byte `protobuf:"bytes,1,rep,name=encrypted_cards_in_hand,json=encryptedCardsInHand,proto3" json:"encrypted_cards_in_hand,omitempty"`
@@ -533,7 +836,7 @@ type HandEndRequest_PlayerInfo struct {
 	UnencryptedCardsInHand []uint32 `protobuf:"varint,2,rep,packed,name=unencrypted_cards_in_hand,json=unencryptedCardsInHand,proto3" json:"unencrypted_cards_in_hand,omitempty"`
 	// The map of all the player's decryption keys for all cards. Key is big int string.
 	CardDecryptionKeys map[string][]byte `protobuf:"bytes,3,rep,name=card_decryption_keys,json=cardDecryptionKeys,proto3" json:"card_decryption_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// The score for this player after the hand.
+	// The score for this player after the hand. With partners, this is the team's score.
 	Score                uint32   `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -544,7 +847,7 @@ func (m *HandEndRequest_PlayerInfo) Reset()         { *m = HandEndRequest_Player
 func (m *HandEndRequest_PlayerInfo) String() string { return proto.CompactTextString(m) }
 func (*HandEndRequest_PlayerInfo) ProtoMessage()    {}
 func (*HandEndRequest_PlayerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{9, 0}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{10, 0}
 }
 func (m *HandEndRequest_PlayerInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandEndRequest_PlayerInfo.Unmarshal(m, b)
@@ -606,7 +909,7 @@ func (m *HandEndResponse) Reset()         { *m = HandEndResponse{} }
 func (m *HandEndResponse) String() string { return proto.CompactTextString(m) }
 func (*HandEndResponse) ProtoMessage()    {}
 func (*HandEndResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{10}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{11}
 }
 func (m *HandEndResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandEndResponse.Unmarshal(m, b)
@@ -747,7 +1050,7 @@ func (m *HandEndResponse_HandReveal) Reset()         { *m = HandEndResponse_Hand
 func (m *HandEndResponse_HandReveal) String() string { return proto.CompactTextString(m) }
 func (*HandEndResponse_HandReveal) ProtoMessage()    {}
 func (*HandEndResponse_HandReveal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{10, 0}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{11, 0}
 }
 func (m *HandEndResponse_HandReveal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HandEndResponse_HandReveal.Unmarshal(m, b)
@@ -799,17 +1102,20 @@ type ShuffleRequest struct {
 	// they are unencrypted from the one key and re-encrypted with a per-card key. Completion, they are just stored.
 	WorkingCardSet [][]byte `protobuf:"bytes,3,rep,name=working_card_set,json=workingCardSet,proto3" json:"working_card_set,omitempty"`
 	// The set of signatures of the HandStart message for each player.
-	HandStartPlayerSigs  [][]byte `protobuf:"bytes,4,rep,name=hand_start_player_sigs,json=handStartPlayerSigs,proto3" json:"hand_start_player_sigs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	HandStartPlayerSigs [][]byte `protobuf:"bytes,4,rep,name=hand_start_player_sigs,json=handStartPlayerSigs,proto3" json:"hand_start_player_sigs,omitempty"`
+	// Only on stage 2, every player's response to stage 0 then every player's response to stage 1, so their proofs can
+	// be checked.
+	Responses            []*ShuffleResponse `protobuf:"bytes,5,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ShuffleRequest) Reset()         { *m = ShuffleRequest{} }
 func (m *ShuffleRequest) String() string { return proto.CompactTextString(m) }
 func (*ShuffleRequest) ProtoMessage()    {}
 func (*ShuffleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{11}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{12}
 }
 func (m *ShuffleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShuffleRequest.Unmarshal(m, b)
@@ -857,19 +1163,29 @@ func (m *ShuffleRequest) GetHandStartPlayerSigs() [][]byte {
 	return nil
 }
 
+func (m *ShuffleRequest) GetResponses() []*ShuffleResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
 type ShuffleResponse struct {
 	// Only used on stage 0 and 1.
-	WorkingCardSet       [][]byte `protobuf:"bytes,1,rep,name=working_card_set,json=workingCardSet,proto3" json:"working_card_set,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	WorkingCardSet [][]byte `protobuf:"bytes,1,rep,name=working_card_set,json=workingCardSet,proto3" json:"working_card_set,omitempty"`
+	// Only used on stage 0 and 1. Proves the working card set was made from the request's without adding, removing, or
+	// changing any cards.
+	Proof                *ShuffleProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *ShuffleResponse) Reset()         { *m = ShuffleResponse{} }
 func (m *ShuffleResponse) String() string { return proto.CompactTextString(m) }
 func (*ShuffleResponse) ProtoMessage()    {}
 func (*ShuffleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{12}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{13}
 }
 func (m *ShuffleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShuffleResponse.Unmarshal(m, b)
@@ -896,6 +1212,137 @@ func (m *ShuffleResponse) GetWorkingCardSet() [][]byte {
 	return nil
 }
 
+func (m *ShuffleResponse) GetProof() *ShuffleProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+// On stage 0, a cut-and-choose proof the cards were shuffled and encrypted with one key. On stage 1, a proof of knowing
+// the key each card was changed to and that it's the one committed to.
+type ShuffleProof struct {
+	// The hash the rest of the proof is checked against.
+	Challenge []byte `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// Only on stage 0.
+	Rounds []*ShuffleProof_Round `protobuf:"bytes,2,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	// Only on stage 1, one per card.
+	RekeyResponses [][]byte `protobuf:"bytes,3,rep,name=rekey_responses,json=rekeyResponses,proto3" json:"rekey_responses,omitempty"`
+	// Only on stage 0, the commitment to the key every card was encrypted with.
+	KeyCommitment []byte `protobuf:"bytes,4,opt,name=key_commitment,json=keyCommitment,proto3" json:"key_commitment,omitempty"`
+	// Only on stage 1, one per card. The key the player later reveals to decrypt the card is checked against this, with
+	// the player's stage 0 key commitment as the base.
+	DecryptionKeyCommitments [][]byte `protobuf:"bytes,5,rep,name=decryption_key_commitments,json=decryptionKeyCommitments,proto3" json:"decryption_key_commitments,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
+}
+
+func (m *ShuffleProof) Reset()         { *m = ShuffleProof{} }
+func (m *ShuffleProof) String() string { return proto.CompactTextString(m) }
+func (*ShuffleProof) ProtoMessage()    {}
+func (*ShuffleProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{14}
+}
+func (m *ShuffleProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShuffleProof.Unmarshal(m, b)
+}
+func (m *ShuffleProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShuffleProof.Marshal(b, m, deterministic)
+}
+func (dst *ShuffleProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShuffleProof.Merge(dst, src)
+}
+func (m *ShuffleProof) XXX_Size() int {
+	return xxx_messageInfo_ShuffleProof.Size(m)
+}
+func (m *ShuffleProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShuffleProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShuffleProof proto.InternalMessageInfo
+
+func (m *ShuffleProof) GetChallenge() []byte {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
+
+func (m *ShuffleProof) GetRounds() []*ShuffleProof_Round {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
+}
+
+func (m *ShuffleProof) GetRekeyResponses() [][]byte {
+	if m != nil {
+		return m.RekeyResponses
+	}
+	return nil
+}
+
+func (m *ShuffleProof) GetKeyCommitment() []byte {
+	if m != nil {
+		return m.KeyCommitment
+	}
+	return nil
+}
+
+func (m *ShuffleProof) GetDecryptionKeyCommitments() [][]byte {
+	if m != nil {
+		return m.DecryptionKeyCommitments
+	}
+	return nil
+}
+
+type ShuffleProof_Round struct {
+	Exponent             []byte   `protobuf:"bytes,1,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	Permutation          []uint32 `protobuf:"varint,2,rep,packed,name=permutation,proto3" json:"permutation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShuffleProof_Round) Reset()         { *m = ShuffleProof_Round{} }
+func (m *ShuffleProof_Round) String() string { return proto.CompactTextString(m) }
+func (*ShuffleProof_Round) ProtoMessage()    {}
+func (*ShuffleProof_Round) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{14, 0}
+}
+func (m *ShuffleProof_Round) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShuffleProof_Round.Unmarshal(m, b)
+}
+func (m *ShuffleProof_Round) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShuffleProof_Round.Marshal(b, m, deterministic)
+}
+func (dst *ShuffleProof_Round) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShuffleProof_Round.Merge(dst, src)
+}
+func (m *ShuffleProof_Round) XXX_Size() int {
+	return xxx_messageInfo_ShuffleProof_Round.Size(m)
+}
+func (m *ShuffleProof_Round) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShuffleProof_Round.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShuffleProof_Round proto.InternalMessageInfo
+
+func (m *ShuffleProof_Round) GetExponent() []byte {
+	if m != nil {
+		return m.Exponent
+	}
+	return nil
+}
+
+func (m *ShuffleProof_Round) GetPermutation() []uint32 {
+	if m != nil {
+		return m.Permutation
+	}
+	return nil
+}
+
 type ChooseColorSinceFirstCardIsWildRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -908,7 +1355,7 @@ func (m *ChooseColorSinceFirstCardIsWildRequest) Reset() {
 func (m *ChooseColorSinceFirstCardIsWildRequest) String() string { return proto.CompactTextString(m) }
 func (*ChooseColorSinceFirstCardIsWildRequest) ProtoMessage()    {}
 func (*ChooseColorSinceFirstCardIsWildRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{13}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{15}
 }
 func (m *ChooseColorSinceFirstCardIsWildRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChooseColorSinceFirstCardIsWildRequest.Unmarshal(m, b)
@@ -941,7 +1388,7 @@ func (m *ChooseColorSinceFirstCardIsWildResponse) Reset() {
 func (m *ChooseColorSinceFirstCardIsWildResponse) String() string { return proto.CompactTextString(m) }
 func (*ChooseColorSinceFirstCardIsWildResponse) ProtoMessage()    {}
 func (*ChooseColorSinceFirstCardIsWildResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{14}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{16}
 }
 func (m *ChooseColorSinceFirstCardIsWildResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChooseColorSinceFirstCardIsWildResponse.Unmarshal(m, b)
@@ -980,7 +1427,7 @@ func (m *GetDeckTopDecryptionKeyRequest) Reset()         { *m = GetDeckTopDecryp
 func (m *GetDeckTopDecryptionKeyRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeckTopDecryptionKeyRequest) ProtoMessage()    {}
 func (*GetDeckTopDecryptionKeyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{15}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{17}
 }
 func (m *GetDeckTopDecryptionKeyRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeckTopDecryptionKeyRequest.Unmarshal(m, b)
@@ -1018,7 +1465,7 @@ func (m *GetDeckTopDecryptionKeyResponse) Reset()         { *m = GetDeckTopDecry
 func (m *GetDeckTopDecryptionKeyResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeckTopDecryptionKeyResponse) ProtoMessage()    {}
 func (*GetDeckTopDecryptionKeyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{16}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{18}
 }
 func (m *GetDeckTopDecryptionKeyResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetDeckTopDecryptionKeyResponse.Unmarshal(m, b)
@@ -1057,7 +1504,7 @@ func (m *GiveDeckTopCardRequest) Reset()         { *m = GiveDeckTopCardRequest{}
 func (m *GiveDeckTopCardRequest) String() string { return proto.CompactTextString(m) }
 func (*GiveDeckTopCardRequest) ProtoMessage()    {}
 func (*GiveDeckTopCardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{17}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{19}
 }
 func (m *GiveDeckTopCardRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GiveDeckTopCardRequest.Unmarshal(m, b)
@@ -1094,7 +1541,7 @@ func (m *GiveDeckTopCardResponse) Reset()         { *m = GiveDeckTopCardResponse
 func (m *GiveDeckTopCardResponse) String() string { return proto.CompactTextString(m) }
 func (*GiveDeckTopCardResponse) ProtoMessage()    {}
 func (*GiveDeckTopCardResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{18}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{20}
 }
 func (m *GiveDeckTopCardResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GiveDeckTopCardResponse.Unmarshal(m, b)
@@ -1124,7 +1571,7 @@ func (m *PlayRequest) Reset()         { *m = PlayRequest{} }
 func (m *PlayRequest) String() string { return proto.CompactTextString(m) }
 func (*PlayRequest) ProtoMessage()    {}
 func (*PlayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{19}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{21}
 }
 func (m *PlayRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlayRequest.Unmarshal(m, b)
@@ -1162,7 +1609,7 @@ func (m *PlayResponse) Reset()         { *m = PlayResponse{} }
 func (m *PlayResponse) String() string { return proto.CompactTextString(m) }
 func (*PlayResponse) ProtoMessage()    {}
 func (*PlayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{20}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{22}
 }
 func (m *PlayResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlayResponse.Unmarshal(m, b)
@@ -1221,7 +1668,7 @@ func (m *ShouldChallengeWildDrawFourRequest) Reset()         { *m = ShouldChalle
 func (m *ShouldChallengeWildDrawFourRequest) String() string { return proto.CompactTextString(m) }
 func (*ShouldChallengeWildDrawFourRequest) ProtoMessage()    {}
 func (*ShouldChallengeWildDrawFourRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{21}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{23}
 }
 func (m *ShouldChallengeWildDrawFourRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShouldChallengeWildDrawFourRequest.Unmarshal(m, b)
@@ -1259,7 +1706,7 @@ func (m *ShouldChallengeWildDrawFourResponse) Reset()         { *m = ShouldChall
 func (m *ShouldChallengeWildDrawFourResponse) String() string { return proto.CompactTextString(m) }
 func (*ShouldChallengeWildDrawFourResponse) ProtoMessage()    {}
 func (*ShouldChallengeWildDrawFourResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{22}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{24}
 }
 func (m *ShouldChallengeWildDrawFourResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShouldChallengeWildDrawFourResponse.Unmarshal(m, b)
@@ -1298,7 +1745,7 @@ func (m *RevealCardsForChallengeRequest) Reset()         { *m = RevealCardsForCh
 func (m *RevealCardsForChallengeRequest) String() string { return proto.CompactTextString(m) }
 func (*RevealCardsForChallengeRequest) ProtoMessage()    {}
 func (*RevealCardsForChallengeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{23}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{25}
 }
 func (m *RevealCardsForChallengeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RevealCardsForChallengeRequest.Unmarshal(m, b)
@@ -1346,7 +1793,7 @@ func (m *RevealCardsForChallengeResponse) Reset()         { *m = RevealCardsForC
 func (m *RevealCardsForChallengeResponse) String() string { return proto.CompactTextString(m) }
 func (*RevealCardsForChallengeResponse) ProtoMessage()    {}
 func (*RevealCardsForChallengeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{24}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{26}
 }
 func (m *RevealCardsForChallengeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RevealCardsForChallengeResponse.Unmarshal(m, b)
@@ -1401,7 +1848,7 @@ func (m *RevealedCardsForChallengeRequest) Reset()         { *m = RevealedCardsF
 func (m *RevealedCardsForChallengeRequest) String() string { return proto.CompactTextString(m) }
 func (*RevealedCardsForChallengeRequest) ProtoMessage()    {}
 func (*RevealedCardsForChallengeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{25}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{27}
 }
 func (m *RevealedCardsForChallengeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RevealedCardsForChallengeRequest.Unmarshal(m, b)
@@ -1453,7 +1900,7 @@ func (m *RevealedCardsForChallengeResponse) Reset()         { *m = RevealedCards
 func (m *RevealedCardsForChallengeResponse) String() string { return proto.CompactTextString(m) }
 func (*RevealedCardsForChallengeResponse) ProtoMessage()    {}
 func (*RevealedCardsForChallengeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_820d60d161ada840, []int{26}
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{28}
 }
 func (m *RevealedCardsForChallengeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RevealedCardsForChallengeResponse.Unmarshal(m, b)
@@ -1480,12 +1927,304 @@ func (m *RevealedCardsForChallengeResponse) GetChallengeSucceeded() bool {
 	return false
 }
 
+type JumpInRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JumpInRequest) Reset()         { *m = JumpInRequest{} }
+func (m *JumpInRequest) String() string { return proto.CompactTextString(m) }
+func (*JumpInRequest) ProtoMessage()    {}
+func (*JumpInRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{29}
+}
+func (m *JumpInRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JumpInRequest.Unmarshal(m, b)
+}
+func (m *JumpInRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JumpInRequest.Marshal(b, m, deterministic)
+}
+func (dst *JumpInRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JumpInRequest.Merge(dst, src)
+}
+func (m *JumpInRequest) XXX_Size() int {
+	return xxx_messageInfo_JumpInRequest.Size(m)
+}
+func (m *JumpInRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JumpInRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JumpInRequest proto.InternalMessageInfo
+
+type JumpInResponse struct {
+	// The encrypted card identical to the top discard or empty to withdraw
+	EncryptedCard []byte `protobuf:"bytes,1,opt,name=encrypted_card,json=encryptedCard,proto3" json:"encrypted_card,omitempty"`
+	// The unencrypted card to play
+	UnencryptedCard uint32 `protobuf:"varint,2,opt,name=unencrypted_card,json=unencryptedCard,proto3" json:"unencrypted_card,omitempty"`
+	// Everyone's card decryption keys, by player index
+	CardDecryptionKeys   [][]byte `protobuf:"bytes,3,rep,name=card_decryption_keys,json=cardDecryptionKeys,proto3" json:"card_decryption_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JumpInResponse) Reset()         { *m = JumpInResponse{} }
+func (m *JumpInResponse) String() string { return proto.CompactTextString(m) }
+func (*JumpInResponse) ProtoMessage()    {}
+func (*JumpInResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{30}
+}
+func (m *JumpInResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JumpInResponse.Unmarshal(m, b)
+}
+func (m *JumpInResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JumpInResponse.Marshal(b, m, deterministic)
+}
+func (dst *JumpInResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JumpInResponse.Merge(dst, src)
+}
+func (m *JumpInResponse) XXX_Size() int {
+	return xxx_messageInfo_JumpInResponse.Size(m)
+}
+func (m *JumpInResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JumpInResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JumpInResponse proto.InternalMessageInfo
+
+func (m *JumpInResponse) GetEncryptedCard() []byte {
+	if m != nil {
+		return m.EncryptedCard
+	}
+	return nil
+}
+
+func (m *JumpInResponse) GetUnencryptedCard() uint32 {
+	if m != nil {
+		return m.UnencryptedCard
+	}
+	return 0
+}
+
+func (m *JumpInResponse) GetCardDecryptionKeys() [][]byte {
+	if m != nil {
+		return m.CardDecryptionKeys
+	}
+	return nil
+}
+
+type ChooseSwapTargetRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChooseSwapTargetRequest) Reset()         { *m = ChooseSwapTargetRequest{} }
+func (m *ChooseSwapTargetRequest) String() string { return proto.CompactTextString(m) }
+func (*ChooseSwapTargetRequest) ProtoMessage()    {}
+func (*ChooseSwapTargetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{31}
+}
+func (m *ChooseSwapTargetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChooseSwapTargetRequest.Unmarshal(m, b)
+}
+func (m *ChooseSwapTargetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChooseSwapTargetRequest.Marshal(b, m, deterministic)
+}
+func (dst *ChooseSwapTargetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChooseSwapTargetRequest.Merge(dst, src)
+}
+func (m *ChooseSwapTargetRequest) XXX_Size() int {
+	return xxx_messageInfo_ChooseSwapTargetRequest.Size(m)
+}
+func (m *ChooseSwapTargetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChooseSwapTargetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChooseSwapTargetRequest proto.InternalMessageInfo
+
+type ChooseSwapTargetResponse struct {
+	TargetIndex          uint32   `protobuf:"varint,1,opt,name=target_index,json=targetIndex,proto3" json:"target_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChooseSwapTargetResponse) Reset()         { *m = ChooseSwapTargetResponse{} }
+func (m *ChooseSwapTargetResponse) String() string { return proto.CompactTextString(m) }
+func (*ChooseSwapTargetResponse) ProtoMessage()    {}
+func (*ChooseSwapTargetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{32}
+}
+func (m *ChooseSwapTargetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChooseSwapTargetResponse.Unmarshal(m, b)
+}
+func (m *ChooseSwapTargetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChooseSwapTargetResponse.Marshal(b, m, deterministic)
+}
+func (dst *ChooseSwapTargetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChooseSwapTargetResponse.Merge(dst, src)
+}
+func (m *ChooseSwapTargetResponse) XXX_Size() int {
+	return xxx_messageInfo_ChooseSwapTargetResponse.Size(m)
+}
+func (m *ChooseSwapTargetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChooseSwapTargetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChooseSwapTargetResponse proto.InternalMessageInfo
+
+func (m *ChooseSwapTargetResponse) GetTargetIndex() uint32 {
+	if m != nil {
+		return m.TargetIndex
+	}
+	return 0
+}
+
+type MoveHandRequest struct {
+	// Hands move by passing the key of the player each card was dealt to (the one key the host hasn't seen) from the
+	// giving player to the getting player, with each masking it with their own temporary key so the host can't read
+	// it. Stage 0 is the give-cards-and-masked-keys stage. Stage 1 is the mask-keys-for-hand-getting stage. Stage 2 is
+	// the unmask-keys-for-hand-giving stage. Stage 3 is the unmask-keys-and-take-cards stage.
+	Stage uint32 `protobuf:"varint,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// The player whose hand this player gets
+	FromPlayerIndex uint32 `protobuf:"varint,2,opt,name=from_player_index,json=fromPlayerIndex,proto3" json:"from_player_index,omitempty"`
+	// The player that gets this player's hand
+	ToPlayerIndex uint32 `protobuf:"varint,3,opt,name=to_player_index,json=toPlayerIndex,proto3" json:"to_player_index,omitempty"`
+	// Stage 1 and 3, the cards given by the from player in stage 0
+	EncryptedCards [][]byte `protobuf:"bytes,4,rep,name=encrypted_cards,json=encryptedCards,proto3" json:"encrypted_cards,omitempty"`
+	// Stage 1 and 3, the keys from the from player. Stage 2, the keys from the to player.
+	MaskedDecryptionKeys [][]byte `protobuf:"bytes,5,rep,name=masked_decryption_keys,json=maskedDecryptionKeys,proto3" json:"masked_decryption_keys,omitempty"`
+	// Stage 3, every key the host has seen for the cards, by player index for each card. The key of the player the
+	// card was dealt to is empty.
+	CardDecryptionKeys   [][]byte `protobuf:"bytes,6,rep,name=card_decryption_keys,json=cardDecryptionKeys,proto3" json:"card_decryption_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveHandRequest) Reset()         { *m = MoveHandRequest{} }
+func (m *MoveHandRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveHandRequest) ProtoMessage()    {}
+func (*MoveHandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{33}
+}
+func (m *MoveHandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveHandRequest.Unmarshal(m, b)
+}
+func (m *MoveHandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveHandRequest.Marshal(b, m, deterministic)
+}
+func (dst *MoveHandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveHandRequest.Merge(dst, src)
+}
+func (m *MoveHandRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveHandRequest.Size(m)
+}
+func (m *MoveHandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveHandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveHandRequest proto.InternalMessageInfo
+
+func (m *MoveHandRequest) GetStage() uint32 {
+	if m != nil {
+		return m.Stage
+	}
+	return 0
+}
+
+func (m *MoveHandRequest) GetFromPlayerIndex() uint32 {
+	if m != nil {
+		return m.FromPlayerIndex
+	}
+	return 0
+}
+
+func (m *MoveHandRequest) GetToPlayerIndex() uint32 {
+	if m != nil {
+		return m.ToPlayerIndex
+	}
+	return 0
+}
+
+func (m *MoveHandRequest) GetEncryptedCards() [][]byte {
+	if m != nil {
+		return m.EncryptedCards
+	}
+	return nil
+}
+
+func (m *MoveHandRequest) GetMaskedDecryptionKeys() [][]byte {
+	if m != nil {
+		return m.MaskedDecryptionKeys
+	}
+	return nil
+}
+
+func (m *MoveHandRequest) GetCardDecryptionKeys() [][]byte {
+	if m != nil {
+		return m.CardDecryptionKeys
+	}
+	return nil
+}
+
+type MoveHandResponse struct {
+	// Stage 0, the cards in hand
+	EncryptedCards [][]byte `protobuf:"bytes,1,rep,name=encrypted_cards,json=encryptedCards,proto3" json:"encrypted_cards,omitempty"`
+	// Stage 0, 1, and 2, the keys for each card after this player's masking or unmasking
+	MaskedDecryptionKeys [][]byte `protobuf:"bytes,2,rep,name=masked_decryption_keys,json=maskedDecryptionKeys,proto3" json:"masked_decryption_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveHandResponse) Reset()         { *m = MoveHandResponse{} }
+func (m *MoveHandResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveHandResponse) ProtoMessage()    {}
+func (*MoveHandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_3197f8f4f0a681e4, []int{34}
+}
+func (m *MoveHandResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveHandResponse.Unmarshal(m, b)
+}
+func (m *MoveHandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveHandResponse.Marshal(b, m, deterministic)
+}
+func (dst *MoveHandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveHandResponse.Merge(dst, src)
+}
+func (m *MoveHandResponse) XXX_Size() int {
+	return xxx_messageInfo_MoveHandResponse.Size(m)
+}
+func (m *MoveHandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveHandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveHandResponse proto.InternalMessageInfo
+
+func (m *MoveHandResponse) GetEncryptedCards() [][]byte {
+	if m != nil {
+		return m.EncryptedCards
+	}
+	return nil
+}
+
+func (m *MoveHandResponse) GetMaskedDecryptionKeys() [][]byte {
+	if m != nil {
+		return m.MaskedDecryptionKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PlayerIdentity)(nil), "pb.PlayerIdentity")
 	proto.RegisterType((*JoinRequest)(nil), "pb.JoinRequest")
 	proto.RegisterType((*JoinResponse)(nil), "pb.JoinResponse")
 	proto.RegisterType((*GameStartRequest)(nil), "pb.GameStartRequest")
 	proto.RegisterType((*GameStartResponse)(nil), "pb.GameStartResponse")
+	proto.RegisterType((*Rules)(nil), "pb.Rules")
 	proto.RegisterType((*GameEndRequest)(nil), "pb.GameEndRequest")
 	proto.RegisterType((*GameEndResponse)(nil), "pb.GameEndResponse")
 	proto.RegisterType((*HandStartRequest)(nil), "pb.HandStartRequest")
@@ -1498,6 +2237,8 @@ func init() {
 	proto.RegisterMapType((map[string][]byte)(nil), "pb.HandEndResponse.HandReveal.CardDecryptionKeysEntry")
 	proto.RegisterType((*ShuffleRequest)(nil), "pb.ShuffleRequest")
 	proto.RegisterType((*ShuffleResponse)(nil), "pb.ShuffleResponse")
+	proto.RegisterType((*ShuffleProof)(nil), "pb.ShuffleProof")
+	proto.RegisterType((*ShuffleProof_Round)(nil), "pb.ShuffleProof.Round")
 	proto.RegisterType((*ChooseColorSinceFirstCardIsWildRequest)(nil), "pb.ChooseColorSinceFirstCardIsWildRequest")
 	proto.RegisterType((*ChooseColorSinceFirstCardIsWildResponse)(nil), "pb.ChooseColorSinceFirstCardIsWildResponse")
 	proto.RegisterType((*GetDeckTopDecryptionKeyRequest)(nil), "pb.GetDeckTopDecryptionKeyRequest")
@@ -1512,6 +2253,15 @@ func init() {
 	proto.RegisterType((*RevealCardsForChallengeResponse)(nil), "pb.RevealCardsForChallengeResponse")
 	proto.RegisterType((*RevealedCardsForChallengeRequest)(nil), "pb.RevealedCardsForChallengeRequest")
 	proto.RegisterType((*RevealedCardsForChallengeResponse)(nil), "pb.RevealedCardsForChallengeResponse")
+	proto.RegisterType((*JumpInRequest)(nil), "pb.JumpInRequest")
+	proto.RegisterType((*JumpInResponse)(nil), "pb.JumpInResponse")
+	proto.RegisterType((*ChooseSwapTargetRequest)(nil), "pb.ChooseSwapTargetRequest")
+	proto.RegisterType((*ChooseSwapTargetResponse)(nil), "pb.ChooseSwapTargetResponse")
+	proto.RegisterType((*MoveHandRequest)(nil), "pb.MoveHandRequest")
+	proto.RegisterType((*MoveHandResponse)(nil), "pb.MoveHandResponse")
+	proto.RegisterEnum("pb.CardCipher", CardCipher_name, CardCipher_value)
+	proto.RegisterEnum("pb.Rules_FirstWildDrawFour", Rules_FirstWildDrawFour_name, Rules_FirstWildDrawFour_value)
+	proto.RegisterEnum("pb.Rules_Scoring", Rules_Scoring_name, Rules_Scoring_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1539,6 +2289,9 @@ type PlayerClient interface {
 	ShouldChallengeWildDrawFour(ctx context.Context, in *ShouldChallengeWildDrawFourRequest, opts ...grpc.CallOption) (*ShouldChallengeWildDrawFourResponse, error)
 	RevealCardsForChallenge(ctx context.Context, in *RevealCardsForChallengeRequest, opts ...grpc.CallOption) (*RevealCardsForChallengeResponse, error)
 	RevealedCardsForChallenge(ctx context.Context, in *RevealedCardsForChallengeRequest, opts ...grpc.CallOption) (*RevealedCardsForChallengeResponse, error)
+	JumpIn(ctx context.Context, in *JumpInRequest, opts ...grpc.CallOption) (*JumpInResponse, error)
+	ChooseSwapTarget(ctx context.Context, in *ChooseSwapTargetRequest, opts ...grpc.CallOption) (*ChooseSwapTargetResponse, error)
+	MoveHand(ctx context.Context, in *MoveHandRequest, opts ...grpc.CallOption) (*MoveHandResponse, error)
 }
 
 type playerClient struct {
@@ -1666,6 +2419,33 @@ func (c *playerClient) RevealedCardsForChallenge(ctx context.Context, in *Reveal
 	return out, nil
 }
 
+func (c *playerClient) JumpIn(ctx context.Context, in *JumpInRequest, opts ...grpc.CallOption) (*JumpInResponse, error) {
+	out := new(JumpInResponse)
+	err := c.cc.Invoke(ctx, "/pb.Player/JumpIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) ChooseSwapTarget(ctx context.Context, in *ChooseSwapTargetRequest, opts ...grpc.CallOption) (*ChooseSwapTargetResponse, error) {
+	out := new(ChooseSwapTargetResponse)
+	err := c.cc.Invoke(ctx, "/pb.Player/ChooseSwapTarget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) MoveHand(ctx context.Context, in *MoveHandRequest, opts ...grpc.CallOption) (*MoveHandResponse, error) {
+	out := new(MoveHandResponse)
+	err := c.cc.Invoke(ctx, "/pb.Player/MoveHand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlayerServer is the server API for Player service.
 type PlayerServer interface {
 	Join(context.Context, *JoinRequest) (*JoinResponse, error)
@@ -1681,6 +2461,9 @@ type PlayerServer interface {
 	ShouldChallengeWildDrawFour(context.Context, *ShouldChallengeWildDrawFourRequest) (*ShouldChallengeWildDrawFourResponse, error)
 	RevealCardsForChallenge(context.Context, *RevealCardsForChallengeRequest) (*RevealCardsForChallengeResponse, error)
 	RevealedCardsForChallenge(context.Context, *RevealedCardsForChallengeRequest) (*RevealedCardsForChallengeResponse, error)
+	JumpIn(context.Context, *JumpInRequest) (*JumpInResponse, error)
+	ChooseSwapTarget(context.Context, *ChooseSwapTargetRequest) (*ChooseSwapTargetResponse, error)
+	MoveHand(context.Context, *MoveHandRequest) (*MoveHandResponse, error)
 }
 
 func RegisterPlayerServer(s *grpc.Server, srv PlayerServer) {
@@ -1921,6 +2704,60 @@ func _Player_RevealedCardsForChallenge_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_JumpIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JumpInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).JumpIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Player/JumpIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).JumpIn(ctx, req.(*JumpInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_ChooseSwapTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChooseSwapTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ChooseSwapTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Player/ChooseSwapTarget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ChooseSwapTarget(ctx, req.(*ChooseSwapTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_MoveHand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveHandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).MoveHand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Player/MoveHand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).MoveHand(ctx, req.(*MoveHandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Player_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.Player",
 	HandlerType: (*PlayerServer)(nil),
@@ -1977,98 +2814,165 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevealedCardsForChallenge",
 			Handler:    _Player_RevealedCardsForChallenge_Handler,
 		},
+		{
+			MethodName: "JumpIn",
+			Handler:    _Player_JumpIn_Handler,
+		},
+		{
+			MethodName: "ChooseSwapTarget",
+			Handler:    _Player_ChooseSwapTarget_Handler,
+		},
+		{
+			MethodName: "MoveHand",
+			Handler:    _Player_MoveHand_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "player.proto",
 }
 
-func init() { proto.RegisterFile("player.proto", fileDescriptor_player_820d60d161ada840) }
-
-var fileDescriptor_player_820d60d161ada840 = []byte{
-	// 1347 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcb, 0x6e, 0xdb, 0x46,
-	0x17, 0x0e, 0x29, 0xd9, 0x8e, 0x8e, 0x64, 0x49, 0x19, 0x3b, 0xb6, 0xcc, 0xfc, 0x49, 0x1c, 0xfa,
-	0x77, 0xac, 0xa4, 0x85, 0x1b, 0x28, 0x4d, 0xe0, 0xa6, 0x8b, 0x5e, 0x1c, 0x27, 0x76, 0x0a, 0x14,
-	0x01, 0x15, 0xa0, 0x4b, 0x82, 0x26, 0xc7, 0x12, 0x6b, 0x7a, 0xa8, 0x72, 0x28, 0x3b, 0xea, 0xbe,
-	0x2f, 0x51, 0xf4, 0x1d, 0x8a, 0xb6, 0xeb, 0xee, 0xfa, 0x10, 0x7d, 0x9c, 0x62, 0x6e, 0xbc, 0x48,
-	0x22, 0xe5, 0x02, 0x05, 0xda, 0x9d, 0x78, 0xe6, 0x5c, 0xbf, 0x73, 0xce, 0x37, 0x63, 0x43, 0x63,
-	0x14, 0x38, 0x13, 0x1c, 0xed, 0x8f, 0xa2, 0x30, 0x0e, 0x91, 0x3e, 0x3a, 0x35, 0x7d, 0x68, 0xbe,
-	0xe5, 0xb2, 0x13, 0x0f, 0x93, 0xd8, 0x8f, 0x27, 0xa8, 0x09, 0xba, 0xef, 0x75, 0xb4, 0x6d, 0xad,
-	0xdb, 0xb0, 0x74, 0xdf, 0x43, 0x0f, 0xa0, 0x11, 0x39, 0xc4, 0x0b, 0x2f, 0x6c, 0x12, 0x12, 0x17,
-	0x77, 0x74, 0x7e, 0x52, 0x17, 0xb2, 0xaf, 0x99, 0x08, 0x21, 0xa8, 0x12, 0xe7, 0x02, 0x77, 0x2a,
-	0xdb, 0x5a, 0xb7, 0x66, 0xf1, 0xdf, 0xa8, 0x0d, 0x15, 0xea, 0x0f, 0x3a, 0x55, 0xae, 0xcd, 0x7e,
-	0x9a, 0x4f, 0xa0, 0xfe, 0x26, 0xf4, 0x89, 0x85, 0xbf, 0x1b, 0x63, 0x1a, 0xcf, 0xf8, 0xd5, 0x66,
-	0xfc, 0x9a, 0x2f, 0xa0, 0x21, 0x2c, 0xe8, 0x28, 0x24, 0x14, 0xa3, 0xc7, 0xb0, 0x2c, 0x0a, 0xe0,
-	0xca, 0xf5, 0x1e, 0xda, 0x1f, 0x9d, 0xee, 0xe7, 0xd3, 0xb7, 0xa4, 0x86, 0xf9, 0x16, 0xda, 0xaf,
-	0x9d, 0x0b, 0xdc, 0x8f, 0x9d, 0x28, 0x56, 0x21, 0xa7, 0x4b, 0xfb, 0x10, 0x56, 0x84, 0x36, 0xed,
-	0x54, 0xb6, 0x2b, 0x05, 0x0e, 0x95, 0x8a, 0xb9, 0x0b, 0xb7, 0x32, 0x1e, 0x65, 0x4a, 0xb2, 0x4c,
-	0x2d, 0x2d, 0x33, 0x84, 0x26, 0x53, 0x3b, 0x22, 0x9e, 0x0a, 0xbb, 0x03, 0xab, 0xc2, 0x87, 0x4d,
-	0xdd, 0x30, 0xc2, 0xb4, 0xa3, 0x6d, 0x57, 0xba, 0xab, 0x96, 0x6c, 0x46, 0x9f, 0xcb, 0xd0, 0x01,
-	0x6c, 0x05, 0x0e, 0x8d, 0xed, 0xa1, 0x43, 0x3c, 0x1b, 0x13, 0xcf, 0x56, 0x26, 0xfe, 0x80, 0x76,
-	0xf4, 0xed, 0x4a, 0xb7, 0x61, 0xdd, 0x66, 0x0a, 0xc7, 0x0e, 0xf1, 0x8e, 0x88, 0x27, 0x92, 0xec,
-	0xfb, 0x03, 0x6a, 0xee, 0x40, 0x2b, 0x09, 0x58, 0x98, 0xd5, 0x0f, 0x3a, 0xb4, 0x99, 0x69, 0x29,
-	0x1e, 0x8f, 0xe1, 0x16, 0x1d, 0x3a, 0x11, 0xf6, 0x6c, 0xd7, 0x89, 0x3c, 0x7b, 0x14, 0xf9, 0x17,
-	0xaa, 0xdf, 0x2d, 0x71, 0x70, 0xe8, 0x44, 0xde, 0x5b, 0x26, 0x9e, 0x2d, 0xaa, 0x32, 0xa7, 0xa8,
-	0x07, 0xd0, 0xf0, 0xb0, 0x13, 0xe0, 0xc8, 0xf6, 0x89, 0x87, 0xdf, 0xf3, 0x69, 0x58, 0xb5, 0xea,
-	0x42, 0x76, 0xc2, 0x44, 0xe8, 0x29, 0x6c, 0x0c, 0x9c, 0x0b, 0x6c, 0x53, 0x96, 0x58, 0xae, 0xe8,
-	0x25, 0x5e, 0xf4, 0xda, 0x40, 0x61, 0x9e, 0x96, 0x5c, 0x0e, 0xd6, 0x72, 0x19, 0x58, 0xbb, 0x70,
-	0x2b, 0x03, 0x43, 0x21, 0x5c, 0x3f, 0x56, 0xa1, 0x29, 0x8d, 0x15, 0x58, 0xeb, 0xb0, 0x44, 0x63,
-	0x67, 0x20, 0x06, 0x75, 0xd5, 0x12, 0x1f, 0xac, 0xc2, 0x2b, 0x9f, 0x90, 0xa4, 0x42, 0x5d, 0x54,
-	0x28, 0x64, 0xa2, 0x42, 0x66, 0xc8, 0xe0, 0xe0, 0xeb, 0xc1, 0x0c, 0xd9, 0x07, 0x7a, 0x02, 0xeb,
-	0x98, 0xb8, 0xd1, 0x64, 0x14, 0x63, 0xcf, 0xf6, 0xb0, 0x7b, 0xce, 0x31, 0xa7, 0x9d, 0x2a, 0xcf,
-	0x1e, 0x25, 0x67, 0x2f, 0xb1, 0x7b, 0xce, 0x50, 0xa7, 0xe8, 0x73, 0xb5, 0xbe, 0xb6, 0x4f, 0xce,
-	0x42, 0x81, 0x4f, 0xbd, 0x77, 0x97, 0x8d, 0x6c, 0x3e, 0x55, 0x35, 0xc1, 0xe4, 0x2c, 0xb4, 0xea,
-	0xa3, 0xe4, 0x37, 0x35, 0xfe, 0xd0, 0x01, 0xd2, 0x33, 0xf4, 0x0c, 0x36, 0xd3, 0x14, 0x78, 0x74,
-	0xdb, 0x27, 0x1c, 0x53, 0x3e, 0xa1, 0x0d, 0x2b, 0xcd, 0x90, 0x67, 0x70, 0x42, 0x58, 0x1c, 0xf4,
-	0x09, 0x6c, 0x8d, 0x49, 0x91, 0xa1, 0xce, 0xa7, 0x60, 0x23, 0xa3, 0x90, 0x35, 0x1d, 0xc0, 0x3a,
-	0x9f, 0x2c, 0x0f, 0xf3, 0x43, 0x3f, 0x24, 0xf6, 0x39, 0x9e, 0xa8, 0xed, 0x7b, 0x56, 0x5a, 0xca,
-	0x3e, 0x73, 0xf4, 0x32, 0x31, 0xfc, 0x0a, 0x4f, 0xe8, 0x11, 0x89, 0xa3, 0x89, 0x85, 0xdc, 0x99,
-	0x83, 0x14, 0xf3, 0x6a, 0x06, 0x73, 0xe3, 0x08, 0x36, 0x0b, 0x9c, 0xb0, 0x11, 0x38, 0xc7, 0x13,
-	0xde, 0xdb, 0x9a, 0xc5, 0x7e, 0x32, 0x17, 0x97, 0x4e, 0x30, 0x56, 0x0b, 0x20, 0x3e, 0x5e, 0xe8,
-	0x07, 0x9a, 0xf9, 0x53, 0x05, 0x5a, 0x49, 0x9a, 0x72, 0x84, 0x50, 0x66, 0x84, 0x8e, 0x6f, 0xf0,
-	0x21, 0x42, 0x07, 0xb0, 0x1c, 0xe1, 0x4b, 0xec, 0x04, 0xdc, 0x45, 0xbd, 0x77, 0x2f, 0x57, 0x9f,
-	0x30, 0xe4, 0xdf, 0x16, 0xd7, 0x3a, 0xbe, 0x61, 0x49, 0x7d, 0xe3, 0x67, 0x1d, 0x20, 0x3d, 0xf8,
-	0x17, 0x1a, 0x35, 0x2c, 0x6d, 0xd4, 0xf3, 0xf2, 0x42, 0xfe, 0x4e, 0xa7, 0xfe, 0xa1, 0x9e, 0x7c,
-	0x59, 0x83, 0x95, 0x0b, 0x4c, 0xa9, 0x33, 0xc0, 0xe6, 0xef, 0x1a, 0x34, 0xfb, 0xc3, 0xf1, 0xd9,
-	0x59, 0x80, 0xcb, 0x77, 0xf7, 0x39, 0x6c, 0x66, 0xf1, 0x11, 0x0c, 0x24, 0xb6, 0x50, 0xa0, 0x73,
-	0x3b, 0x73, 0xcc, 0x19, 0x43, 0x2c, 0x62, 0x17, 0xda, 0x57, 0x61, 0x74, 0xee, 0x93, 0x81, 0xe0,
-	0x49, 0x8a, 0x63, 0x0e, 0x4c, 0xc3, 0x6a, 0x4a, 0x39, 0xd3, 0xeb, 0xe3, 0x98, 0x91, 0x1b, 0xa7,
-	0xa8, 0x59, 0x72, 0x13, 0x6b, 0xbe, 0x36, 0x54, 0x5c, 0x94, 0xa1, 0xa8, 0x4f, 0xa1, 0x95, 0xa4,
-	0x2f, 0xa7, 0x6b, 0x5e, 0x44, 0x6d, 0x5e, 0x44, 0xb3, 0x0b, 0x0f, 0x0f, 0x87, 0x61, 0x48, 0xf1,
-	0x61, 0x18, 0x84, 0x51, 0xdf, 0x27, 0x2e, 0x7e, 0xe5, 0x47, 0x94, 0x67, 0x7e, 0x42, 0xbf, 0xf1,
-	0x03, 0xb5, 0x59, 0xe6, 0x67, 0xb0, 0xb7, 0x50, 0x53, 0x86, 0x5f, 0x87, 0x25, 0x97, 0x29, 0x29,
-	0xf8, 0xf8, 0x87, 0xf9, 0x06, 0xee, 0xbd, 0xc6, 0x31, 0xe3, 0xa7, 0x77, 0xe1, 0x28, 0xd7, 0x3f,
-	0x05, 0x7b, 0x17, 0xda, 0x67, 0x61, 0x64, 0x27, 0xac, 0xc5, 0x08, 0x92, 0xb9, 0x58, 0xb2, 0x9a,
-	0x67, 0x61, 0xa4, 0x56, 0xdb, 0xc3, 0xef, 0xcd, 0x63, 0xb8, 0x5f, 0xe8, 0x4b, 0x26, 0xb1, 0x0b,
-	0xcd, 0xfc, 0x34, 0x4a, 0xbe, 0x5e, 0xf5, 0xb2, 0xea, 0xe6, 0x17, 0xb0, 0xf1, 0xda, 0xbf, 0xc4,
-	0xd2, 0x15, 0x2b, 0x46, 0x65, 0xb3, 0x07, 0xad, 0xe9, 0x71, 0x96, 0x18, 0xe6, 0x3c, 0x50, 0x73,
-	0x0b, 0x36, 0x67, 0x5c, 0x88, 0x24, 0xcc, 0x55, 0xa8, 0xb3, 0xb4, 0x15, 0x86, 0xbf, 0x68, 0xd0,
-	0x10, 0xdf, 0x69, 0x92, 0xf9, 0x85, 0x53, 0x49, 0xe6, 0xb6, 0x0c, 0x3d, 0x82, 0xf6, 0xf4, 0x66,
-	0xca, 0x9b, 0xa3, 0x35, 0xb5, 0x90, 0xec, 0x9e, 0x28, 0xdc, 0xc4, 0xc6, 0x5c, 0xee, 0xbb, 0x0b,
-	0x70, 0xe5, 0x07, 0x9e, 0x2d, 0x5a, 0x26, 0x08, 0xb0, 0xc6, 0x24, 0xbc, 0xd1, 0xe6, 0x21, 0x98,
-	0xfd, 0x61, 0x38, 0x0e, 0xbc, 0xc3, 0xa1, 0x13, 0x04, 0x98, 0x0c, 0x30, 0xeb, 0xf5, 0xcb, 0xc8,
-	0xb9, 0x7a, 0x15, 0x8e, 0x23, 0x05, 0xd6, 0x5d, 0x80, 0x51, 0x84, 0x2f, 0xed, 0x6c, 0xdf, 0x6b,
-	0x4c, 0xa2, 0x9c, 0xec, 0x94, 0x3a, 0x91, 0x70, 0xfc, 0x0f, 0x6a, 0xae, 0x52, 0xe0, 0x4e, 0x6e,
-	0x5a, 0xa9, 0xc0, 0xfc, 0x16, 0xee, 0x09, 0xc2, 0xe0, 0x6b, 0xf5, 0x2a, 0x8c, 0x12, 0x67, 0xd7,
-	0xcb, 0x82, 0xc1, 0x98, 0x78, 0xcb, 0x5f, 0xc0, 0xad, 0x54, 0x2e, 0x06, 0xec, 0x57, 0x0d, 0xee,
-	0x17, 0x06, 0x93, 0xd9, 0xee, 0x41, 0x6b, 0x8a, 0x2d, 0xd5, 0x80, 0xe4, 0x39, 0xb2, 0xb0, 0x27,
-	0x7a, 0x61, 0x4f, 0x3e, 0x86, 0x8d, 0x24, 0x23, 0xfb, 0xca, 0x0f, 0x02, 0x9b, 0x8e, 0x5d, 0x17,
-	0x63, 0x8f, 0x3f, 0x0a, 0x6e, 0x5a, 0xeb, 0x6e, 0x06, 0xc7, 0xa0, 0x2f, 0xce, 0xcc, 0xdf, 0x34,
-	0xd8, 0x16, 0x49, 0xcb, 0xc8, 0xf3, 0x30, 0xfa, 0xcf, 0x65, 0xfd, 0x0e, 0x1e, 0x94, 0x24, 0x2d,
-	0xb1, 0xfe, 0x08, 0xd6, 0x52, 0xd7, 0xd2, 0x2b, 0xf6, 0xe4, 0x8c, 0xa0, 0xe4, 0xa8, 0xaf, 0x4e,
-	0x7a, 0x7f, 0xae, 0xc0, 0xb2, 0x60, 0x0c, 0xf4, 0x08, 0xaa, 0xec, 0xcf, 0x02, 0xd4, 0x62, 0xd7,
-	0x50, 0xe6, 0x4f, 0x0a, 0xa3, 0x9d, 0x0a, 0x64, 0x98, 0x03, 0xa8, 0x25, 0x6f, 0x76, 0xb4, 0xce,
-	0x8e, 0xa7, 0xff, 0x28, 0x30, 0x6e, 0x4f, 0x49, 0xa5, 0x65, 0x0f, 0x56, 0xe4, 0xab, 0x1a, 0x21,
-	0xa5, 0x91, 0xbe, 0x4b, 0x8c, 0xb5, 0x9c, 0x2c, 0x8d, 0x96, 0x3c, 0x2e, 0x45, 0xb4, 0xe9, 0x27,
-	0xb7, 0x88, 0x36, 0xfb, 0x02, 0xed, 0xc1, 0x8a, 0xbc, 0x4f, 0x45, 0xb4, 0xfc, 0x2b, 0x48, 0x44,
-	0x9b, 0x7e, 0x72, 0xf4, 0x60, 0x45, 0xde, 0x13, 0xc2, 0x26, 0x7f, 0xe7, 0x09, 0x9b, 0xe9, 0x8b,
-	0xe4, 0x7b, 0xb8, 0xbf, 0x80, 0xf4, 0xd1, 0x63, 0x66, 0x77, 0xbd, 0x3b, 0xc4, 0xf8, 0xe0, 0x5a,
-	0xba, 0x32, 0xf6, 0x29, 0x6c, 0x16, 0x70, 0x3c, 0x32, 0x39, 0x9a, 0xa5, 0x97, 0x89, 0xb1, 0x53,
-	0xaa, 0x23, 0x63, 0xbc, 0x81, 0xd6, 0x14, 0x75, 0x23, 0x83, 0xdb, 0xcd, 0xbd, 0x12, 0x8c, 0x3b,
-	0x73, 0xcf, 0xa4, 0xaf, 0x47, 0x50, 0x65, 0x03, 0x27, 0xc6, 0x2c, 0xc3, 0xfa, 0x62, 0xcc, 0x72,
-	0xb4, 0x4f, 0xe0, 0x4e, 0x09, 0x1d, 0xa2, 0x87, 0xa2, 0x15, 0x8b, 0x48, 0xd7, 0xd8, 0x5b, 0xa8,
-	0x97, 0x42, 0x59, 0x40, 0x66, 0x02, 0xca, 0x72, 0x5a, 0x15, 0x50, 0x2e, 0x62, 0xc3, 0x21, 0x6c,
-	0x15, 0xae, 0x31, 0xfa, 0x7f, 0xea, 0xa1, 0x98, 0x9a, 0x8c, 0xdd, 0x05, 0x5a, 0x22, 0xd2, 0xe9,
-	0x32, 0xff, 0x77, 0xc4, 0xd3, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xe5, 0xa2, 0xce, 0x0d, 0x9e,
-	0x10, 0x00, 0x00,
+func init() { proto.RegisterFile("player.proto", fileDescriptor_player_3197f8f4f0a681e4) }
+
+var fileDescriptor_player_3197f8f4f0a681e4 = []byte{
+	// 2226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcd, 0x72, 0x1b, 0xc7,
+	0x11, 0x16, 0x00, 0x02, 0x24, 0x1a, 0xbf, 0x1c, 0x42, 0x24, 0xb4, 0xb2, 0x2c, 0x6a, 0x65, 0x49,
+	0x94, 0xec, 0xa2, 0x6d, 0xd8, 0x52, 0x29, 0xae, 0xa4, 0x12, 0x9a, 0x04, 0x45, 0x2a, 0x92, 0xc2,
+	0xda, 0xa5, 0xcb, 0xc9, 0x69, 0x6b, 0xb9, 0x3b, 0x00, 0xd6, 0x04, 0x66, 0xe1, 0x9d, 0x05, 0x29,
+	0xea, 0x01, 0x72, 0x4a, 0x55, 0x2e, 0x39, 0xe6, 0x1d, 0x52, 0x4e, 0x4e, 0xb9, 0xe7, 0x94, 0xe7,
+	0xc8, 0x03, 0x24, 0x6f, 0x90, 0xea, 0x99, 0xd9, 0x3f, 0xfc, 0x51, 0xae, 0x4a, 0x55, 0x7c, 0xc3,
+	0x76, 0xf7, 0xf4, 0x74, 0x7f, 0xfd, 0x37, 0x33, 0x80, 0xea, 0x78, 0x68, 0x5f, 0xd1, 0x60, 0x77,
+	0x1c, 0xf8, 0xa1, 0x4f, 0xf2, 0xe3, 0x33, 0xdd, 0x83, 0xfa, 0x89, 0xa0, 0x1d, 0xbb, 0x94, 0x85,
+	0x5e, 0x78, 0x45, 0xea, 0x90, 0xf7, 0xdc, 0x76, 0x6e, 0x3b, 0xb7, 0x53, 0x35, 0xf2, 0x9e, 0x4b,
+	0xee, 0x41, 0x35, 0xb0, 0x99, 0xeb, 0x8f, 0x2c, 0xe6, 0x33, 0x87, 0xb6, 0xf3, 0x82, 0x53, 0x91,
+	0xb4, 0x37, 0x48, 0x22, 0x04, 0x56, 0x98, 0x3d, 0xa2, 0xed, 0xc2, 0x76, 0x6e, 0xa7, 0x6c, 0x88,
+	0xdf, 0xa4, 0x09, 0x05, 0xee, 0xf5, 0xdb, 0x2b, 0x42, 0x1a, 0x7f, 0xea, 0x9f, 0x41, 0xe5, 0xa5,
+	0xef, 0x31, 0x83, 0x7e, 0x3f, 0xa1, 0x3c, 0x9c, 0xd1, 0x9b, 0x9b, 0xd1, 0xab, 0xff, 0x3e, 0x0f,
+	0x55, 0xb9, 0x84, 0x8f, 0x7d, 0xc6, 0x29, 0x79, 0x02, 0x25, 0xe9, 0x81, 0x90, 0xae, 0x74, 0xc8,
+	0xee, 0xf8, 0x6c, 0x37, 0x6b, 0xbf, 0xa1, 0x24, 0xc8, 0x47, 0x50, 0x0f, 0x28, 0x9f, 0x8c, 0xa8,
+	0xd5, 0xb7, 0x47, 0xd4, 0xf2, 0x5c, 0x65, 0x79, 0x55, 0x52, 0x5f, 0xd8, 0x23, 0x7a, 0xec, 0x92,
+	0xa7, 0xb0, 0x95, 0x96, 0xa2, 0x17, 0x94, 0x85, 0xdc, 0xe2, 0x94, 0x32, 0xe1, 0x4d, 0xcd, 0x68,
+	0x25, 0xe2, 0x5d, 0xc1, 0x34, 0x29, 0x65, 0xe4, 0x2b, 0xd0, 0x46, 0x1e, 0xb3, 0xf8, 0xc0, 0x0e,
+	0xa8, 0x6b, 0x39, 0x76, 0xe0, 0x5a, 0xe3, 0xc0, 0x1b, 0x51, 0xeb, 0xcc, 0x0b, 0xb9, 0x70, 0xba,
+	0x66, 0x6c, 0x8e, 0x3c, 0x66, 0x0a, 0x81, 0x7d, 0x3b, 0x70, 0x4f, 0x90, 0xfd, 0xb5, 0x17, 0x72,
+	0xf2, 0x39, 0x54, 0xc5, 0x02, 0xc7, 0x1b, 0x0f, 0x68, 0xc0, 0xdb, 0xc5, 0xed, 0xc2, 0x4e, 0xbd,
+	0x53, 0x47, 0x57, 0x50, 0x70, 0x5f, 0x90, 0x8d, 0x8a, 0x13, 0xff, 0xe6, 0xfa, 0x7f, 0x72, 0xd0,
+	0x44, 0x0b, 0xcc, 0xd0, 0x0e, 0xc2, 0x08, 0xc0, 0xe9, 0x40, 0x7d, 0x02, 0xab, 0xd2, 0x75, 0xde,
+	0x2e, 0x6c, 0x17, 0x16, 0xa0, 0x13, 0x89, 0x90, 0xbb, 0x50, 0x0c, 0x26, 0x43, 0x2a, 0x8d, 0xad,
+	0x74, 0xca, 0x28, 0x6b, 0x20, 0xc1, 0x90, 0x74, 0x8c, 0x8f, 0x94, 0xb5, 0x42, 0x6a, 0x8f, 0xa4,
+	0x99, 0x35, 0xa3, 0x22, 0x69, 0xa7, 0x48, 0x22, 0x0f, 0xa1, 0x11, 0x4e, 0x02, 0x66, 0x85, 0xde,
+	0x88, 0xfa, 0x93, 0xd0, 0x1a, 0xf1, 0x76, 0x49, 0xb8, 0x5e, 0x43, 0xf2, 0xa9, 0xa4, 0xbe, 0xe6,
+	0xe4, 0x0b, 0xd8, 0x5c, 0x80, 0xd4, 0xaa, 0x10, 0xdf, 0xe0, 0xb3, 0x30, 0xe9, 0x0f, 0x60, 0x3d,
+	0xe5, 0xb2, 0x4a, 0x00, 0x95, 0x55, 0xb9, 0x24, 0xab, 0xfe, 0xbe, 0x02, 0x45, 0x61, 0x37, 0xb9,
+	0x0d, 0xe5, 0x81, 0xcd, 0x5c, 0x8b, 0x7b, 0xef, 0x64, 0x36, 0xd5, 0x8c, 0x35, 0x24, 0x98, 0xde,
+	0x3b, 0x8a, 0xde, 0x84, 0x76, 0xd0, 0xa7, 0xa1, 0xc5, 0x1d, 0x3f, 0x90, 0x59, 0x5c, 0x33, 0x2a,
+	0x92, 0x66, 0x22, 0x89, 0xdc, 0x85, 0x0a, 0xf7, 0x58, 0x7f, 0x48, 0x2d, 0x5c, 0x25, 0xc2, 0xbf,
+	0x66, 0x80, 0x24, 0x1d, 0xd9, 0xcc, 0x25, 0xbb, 0xb0, 0xe1, 0x06, 0xf6, 0xa5, 0x35, 0x61, 0xa1,
+	0x37, 0xb4, 0x10, 0x08, 0xfb, 0x6c, 0x48, 0x05, 0x80, 0x6b, 0xc6, 0x3a, 0xb2, 0xbe, 0x41, 0xce,
+	0x89, 0x62, 0x20, 0x3c, 0x28, 0x64, 0x21, 0x87, 0x09, 0xd7, 0xdb, 0x45, 0x21, 0x5b, 0x43, 0xf2,
+	0x01, 0x52, 0xd1, 0x65, 0xf2, 0x0a, 0x5a, 0x3d, 0x2f, 0xe0, 0xa1, 0x75, 0xe9, 0x0d, 0x5d, 0x21,
+	0x6d, 0xf5, 0xfc, 0x49, 0x20, 0xb0, 0xac, 0x77, 0x6e, 0xc7, 0x91, 0xd9, 0x3d, 0x44, 0xa9, 0x6f,
+	0xbd, 0xa1, 0x8b, 0x6b, 0x0f, 0xfd, 0x49, 0x60, 0xac, 0xf7, 0xa6, 0x49, 0x64, 0x07, 0x9a, 0x3c,
+	0xb4, 0x9d, 0x73, 0xa9, 0x08, 0x77, 0x95, 0x30, 0xaf, 0x19, 0x75, 0x41, 0x47, 0x41, 0xdc, 0x96,
+	0x93, 0x2d, 0x58, 0xfd, 0x6e, 0x32, 0x1a, 0x5b, 0x1e, 0x6b, 0xaf, 0x09, 0x81, 0x12, 0x7e, 0x1e,
+	0x33, 0x64, 0x70, 0xac, 0x04, 0xcb, 0x6f, 0x97, 0x25, 0x43, 0x7c, 0xfe, 0x86, 0x68, 0xb0, 0x36,
+	0xb6, 0x83, 0x90, 0x61, 0x8e, 0x81, 0xe0, 0xc4, 0xdf, 0xe4, 0x63, 0x58, 0x45, 0x68, 0x3d, 0xd6,
+	0x6f, 0x57, 0x84, 0xe1, 0xeb, 0x89, 0xe1, 0xa6, 0x64, 0x18, 0x91, 0x04, 0xb9, 0x03, 0x20, 0x62,
+	0xe5, 0xf8, 0x13, 0x16, 0xb6, 0xab, 0x22, 0x18, 0x22, 0x7a, 0xfb, 0x48, 0xd0, 0x77, 0x61, 0x7d,
+	0xc6, 0x57, 0x02, 0x50, 0x32, 0xba, 0x07, 0xc6, 0xde, 0xb7, 0xcd, 0x1b, 0xa4, 0x06, 0x65, 0xa3,
+	0x6b, 0x1e, 0x7d, 0x73, 0x78, 0xf8, 0xaa, 0xdb, 0xcc, 0xe9, 0x4f, 0x61, 0x55, 0x6d, 0x41, 0xaa,
+	0xb0, 0x66, 0x9e, 0xee, 0xbd, 0x39, 0xd8, 0x33, 0x0e, 0x9a, 0x37, 0x48, 0x05, 0x56, 0x4f, 0xba,
+	0x6f, 0xf6, 0x5e, 0x9d, 0xfe, 0xae, 0x99, 0x23, 0x0d, 0xa8, 0x1c, 0x1e, 0xff, 0xb6, 0x7b, 0x60,
+	0x1d, 0xed, 0xbd, 0x39, 0x30, 0x9b, 0x79, 0xdd, 0x87, 0xba, 0xa8, 0x6b, 0xe6, 0x46, 0x35, 0x75,
+	0x1f, 0x6a, 0x2a, 0xe9, 0x45, 0x9a, 0xf0, 0x76, 0x4e, 0x64, 0xbd, 0xaa, 0x04, 0x53, 0xd0, 0xc8,
+	0x73, 0xb8, 0x35, 0xb4, 0x79, 0x28, 0xd2, 0xc4, 0xa2, 0xcc, 0xb5, 0xa2, 0x25, 0x5e, 0x9f, 0xb7,
+	0xf3, 0xdb, 0x85, 0x9d, 0xaa, 0x71, 0x13, 0x05, 0x30, 0x69, 0xba, 0xcc, 0x95, 0x15, 0x68, 0x7a,
+	0x7d, 0xae, 0xdf, 0x87, 0x46, 0xbc, 0xe1, 0xc2, 0x8c, 0xfe, 0x57, 0x1e, 0x9a, 0xb8, 0x74, 0x69,
+	0xb1, 0x3f, 0x81, 0xf5, 0x99, 0x92, 0x52, 0x0d, 0xae, 0x31, 0x55, 0x4d, 0xb3, 0x4e, 0x15, 0xe6,
+	0x38, 0x75, 0x0f, 0xaa, 0x2e, 0xb5, 0x87, 0x34, 0xb0, 0x3c, 0xe6, 0xd2, 0xb7, 0xaa, 0x87, 0x55,
+	0x24, 0xed, 0x18, 0x49, 0x58, 0xc6, 0xa2, 0x49, 0x72, 0x34, 0x2c, 0xe3, 0x74, 0x51, 0x38, 0xbd,
+	0xd1, 0x8f, 0xea, 0x35, 0x71, 0x79, 0x39, 0x58, 0xa5, 0x25, 0x60, 0x91, 0x4f, 0x80, 0x08, 0xdf,
+	0x28, 0x73, 0x7c, 0xd7, 0x63, 0x7d, 0xec, 0xca, 0xae, 0x48, 0xe5, 0xaa, 0xd1, 0x44, 0x4e, 0x57,
+	0x31, 0x4c, 0x4a, 0x5d, 0xf2, 0x29, 0x54, 0x52, 0x5d, 0x55, 0x24, 0xf4, 0x6c, 0x53, 0x85, 0xa4,
+	0xa9, 0x62, 0x7f, 0x49, 0xa1, 0xbc, 0x30, 0x1a, 0xff, 0x5c, 0x81, 0xba, 0xb2, 0x2d, 0x8a, 0x45,
+	0x0b, 0x8a, 0x3c, 0xb4, 0xfb, 0x51, 0x93, 0x91, 0x1f, 0x08, 0xe0, 0xa5, 0xc7, 0x58, 0x0c, 0xa0,
+	0xea, 0x30, 0x92, 0x26, 0x01, 0xc4, 0x85, 0xa2, 0xfb, 0x14, 0xd4, 0x42, 0xfc, 0x20, 0x9f, 0x41,
+	0x8b, 0x32, 0x27, 0xb8, 0x1a, 0x87, 0xd4, 0xb5, 0x5c, 0xea, 0x9c, 0xab, 0xa2, 0x5d, 0x11, 0xe0,
+	0x90, 0x98, 0x77, 0x40, 0x9d, 0x73, 0x59, 0xb8, 0xbf, 0x8a, 0x5b, 0xb3, 0xc7, 0x7a, 0xbe, 0x84,
+	0xbf, 0xd2, 0xb9, 0x83, 0xce, 0x66, 0x4d, 0x8d, 0xba, 0x3f, 0xeb, 0xf9, 0x51, 0xe7, 0xc6, 0xdf,
+	0x1c, 0x5b, 0x59, 0x3a, 0x25, 0x2c, 0x97, 0x0e, 0x43, 0x5b, 0xc6, 0xa3, 0x66, 0xac, 0xa7, 0x12,
+	0xe3, 0x40, 0x30, 0xb4, 0x7f, 0xe4, 0x01, 0x12, 0x5d, 0x38, 0x35, 0x13, 0x93, 0x85, 0xb5, 0x96,
+	0xc7, 0x64, 0xdb, 0xcc, 0x09, 0xab, 0x13, 0x8f, 0x84, 0xc5, 0xc7, 0x4c, 0x34, 0xd0, 0x9f, 0xc1,
+	0xad, 0x09, 0x5b, 0xb4, 0x30, 0x2f, 0xf6, 0xde, 0x9c, 0xb0, 0xb9, 0x4b, 0xfb, 0xd0, 0x12, 0xe1,
+	0x75, 0xa9, 0x60, 0x7a, 0x3e, 0xb3, 0xce, 0xe9, 0x55, 0x34, 0xe9, 0x9e, 0x2e, 0x75, 0x5d, 0x64,
+	0xc0, 0x41, 0xbc, 0xf0, 0xd7, 0xf4, 0x8a, 0x77, 0x59, 0x18, 0x5c, 0x19, 0xc4, 0x99, 0x61, 0x24,
+	0x31, 0x5a, 0x49, 0xc5, 0x48, 0xeb, 0xc2, 0xd6, 0x02, 0x25, 0x98, 0x32, 0xe7, 0xf4, 0x4a, 0xe4,
+	0x42, 0xd9, 0xc0, 0x9f, 0xa8, 0xe2, 0xc2, 0x1e, 0x4e, 0xa2, 0x7a, 0x94, 0x1f, 0x5f, 0xe5, 0x9f,
+	0xe7, 0xf4, 0x3f, 0x17, 0xa0, 0x11, 0x9b, 0xa9, 0x52, 0x8e, 0xa4, 0x52, 0xee, 0xe8, 0x86, 0x48,
+	0x3a, 0xf2, 0x1c, 0x4a, 0x01, 0xbd, 0xa0, 0xf6, 0x50, 0xa8, 0xa8, 0x74, 0x3e, 0xcc, 0xf8, 0x27,
+	0x17, 0x8a, 0x6f, 0x43, 0x48, 0x1d, 0xdd, 0x30, 0x94, 0xbc, 0xf6, 0x97, 0x3c, 0x40, 0xc2, 0xf8,
+	0x3f, 0x04, 0x6a, 0xb0, 0x34, 0x50, 0xcf, 0x96, 0x3b, 0xf2, 0x63, 0x22, 0xf5, 0x3f, 0x8a, 0xc9,
+	0xd7, 0x65, 0x58, 0x1d, 0x51, 0xce, 0xed, 0x3e, 0xd5, 0xff, 0x9d, 0x83, 0xba, 0x39, 0x98, 0xf4,
+	0x7a, 0x43, 0xba, 0xbc, 0xd6, 0x9f, 0xc1, 0x56, 0x1a, 0x1f, 0xd9, 0x10, 0x65, 0xd5, 0x4a, 0x74,
+	0x6e, 0xa6, 0xd8, 0xa2, 0xc3, 0xc8, 0xc2, 0xdd, 0x81, 0xe6, 0xa5, 0x1f, 0x9c, 0x63, 0x33, 0x13,
+	0x20, 0x71, 0x1a, 0x0a, 0x60, 0xaa, 0x46, 0x5d, 0xd1, 0x51, 0xce, 0xa4, 0x21, 0xf6, 0x5a, 0x79,
+	0x98, 0x99, 0xe9, 0xb5, 0xb2, 0x2d, 0x6c, 0x0c, 0xa2, 0xde, 0x95, 0xea, 0x98, 0x9f, 0x43, 0x39,
+	0x50, 0xa0, 0x46, 0x4d, 0x61, 0x03, 0x01, 0x8f, 0x7d, 0x92, 0x3c, 0x23, 0x91, 0xd2, 0x1d, 0x68,
+	0x4c, 0x71, 0xe7, 0x1a, 0x99, 0x9b, 0x6b, 0xe4, 0x43, 0x28, 0x8e, 0x03, 0xdf, 0xef, 0xa9, 0x2c,
+	0x6d, 0xa6, 0xf6, 0x3a, 0x41, 0xba, 0x21, 0xd9, 0xfa, 0x0f, 0x79, 0xa8, 0xa6, 0xe9, 0xe4, 0x03,
+	0x28, 0x3b, 0x03, 0x7b, 0x38, 0xa4, 0xac, 0x1f, 0x1d, 0xfc, 0x13, 0x02, 0xd9, 0x85, 0x52, 0xe0,
+	0x4f, 0x98, 0x02, 0xb3, 0xd2, 0xd9, 0x9c, 0xd6, 0xbb, 0x6b, 0x20, 0xdb, 0x50, 0x52, 0xe4, 0x11,
+	0x34, 0x02, 0x7a, 0x4e, 0xaf, 0xac, 0xc4, 0x79, 0x05, 0xaa, 0x20, 0x47, 0x8e, 0x71, 0xf2, 0x00,
+	0xea, 0x28, 0xe6, 0xf8, 0xa3, 0x91, 0x17, 0x8e, 0x28, 0x0b, 0xd5, 0xf5, 0xa4, 0x76, 0x4e, 0xaf,
+	0xf6, 0x63, 0x22, 0xf9, 0x39, 0x68, 0xd9, 0xec, 0x4d, 0xad, 0x88, 0x66, 0x5d, 0xdb, 0x4d, 0xa7,
+	0x5d, 0xb2, 0x18, 0xd3, 0xb2, 0x28, 0xcc, 0xc3, 0xc3, 0x12, 0x7d, 0x3b, 0xf6, 0x19, 0xee, 0x23,
+	0x7d, 0x8c, 0xbf, 0xc9, 0x36, 0x54, 0xc6, 0x34, 0x18, 0x4d, 0x42, 0x1b, 0x35, 0xa8, 0xa4, 0x49,
+	0x93, 0xf4, 0x1d, 0x78, 0xb8, 0x3f, 0xf0, 0x7d, 0x4e, 0xf7, 0xfd, 0xa1, 0x1f, 0x98, 0x1e, 0x73,
+	0xa8, 0x38, 0x12, 0x21, 0xf6, 0xc7, 0x1c, 0x0f, 0x46, 0x2a, 0x45, 0xf5, 0x5f, 0xc2, 0xa3, 0x6b,
+	0x25, 0x55, 0x68, 0x5b, 0x50, 0x74, 0x50, 0x28, 0xca, 0x66, 0xf1, 0xa1, 0xbf, 0x84, 0x0f, 0x5f,
+	0xd0, 0x10, 0xc7, 0xcb, 0xa9, 0x3f, 0xce, 0x94, 0x53, 0x54, 0x05, 0x3b, 0xd0, 0xec, 0xf9, 0x81,
+	0x15, 0x0f, 0x1d, 0x9c, 0x6f, 0xa8, 0xa2, 0x68, 0xd4, 0x7b, 0x7e, 0x10, 0x75, 0x5a, 0x97, 0xbe,
+	0xd5, 0x8f, 0xe0, 0xee, 0x42, 0x5d, 0xca, 0x88, 0x07, 0x50, 0xcf, 0xc2, 0xab, 0xd0, 0xa9, 0x65,
+	0x20, 0xd5, 0xf7, 0x60, 0xf3, 0x85, 0x77, 0x41, 0x95, 0x2a, 0x74, 0x26, 0xb2, 0xe6, 0x11, 0x34,
+	0xa6, 0xbb, 0x8b, 0xca, 0xcf, 0x8c, 0x06, 0xae, 0xdf, 0x82, 0xad, 0x19, 0x15, 0xd2, 0x08, 0xbd,
+	0x06, 0x15, 0x34, 0x3b, 0xc2, 0xf0, 0x87, 0x1c, 0x54, 0xe5, 0x77, 0x62, 0x64, 0xb6, 0xff, 0x45,
+	0x46, 0x66, 0x9a, 0x1e, 0x79, 0x0c, 0xcd, 0xe9, 0x46, 0xa9, 0x06, 0x7f, 0x63, 0xaa, 0x3f, 0xe2,
+	0x98, 0x5f, 0xd8, 0x18, 0xab, 0x73, 0x47, 0xd1, 0x1d, 0x00, 0x71, 0x23, 0x90, 0x21, 0x93, 0xf3,
+	0xa8, 0x8c, 0x14, 0x11, 0x68, 0x7d, 0x1f, 0x74, 0x73, 0xe0, 0x4f, 0x86, 0xee, 0x7e, 0x54, 0x39,
+	0x99, 0xab, 0x81, 0x02, 0xeb, 0x0e, 0xc0, 0x38, 0xa0, 0x17, 0x56, 0x3a, 0xee, 0x65, 0xa4, 0x44,
+	0x4a, 0xee, 0x2f, 0x55, 0xa2, 0xe0, 0x98, 0x29, 0xd8, 0xb5, 0x54, 0xc1, 0xea, 0xdf, 0xc1, 0x87,
+	0xb2, 0x7f, 0x8b, 0x2e, 0x77, 0xe8, 0x07, 0xb1, 0xb2, 0xf7, 0xb3, 0x02, 0x61, 0x8c, 0xb5, 0x65,
+	0xcf, 0x4f, 0x8d, 0x84, 0x2e, 0x13, 0xec, 0xaf, 0x39, 0xb8, 0xbb, 0x70, 0x33, 0x65, 0xed, 0x23,
+	0x68, 0x4c, 0x0d, 0xaf, 0x28, 0x41, 0xb2, 0x23, 0x6b, 0x61, 0x4c, 0xf2, 0x0b, 0x63, 0xf2, 0x25,
+	0x6c, 0xc6, 0x16, 0xe1, 0x7d, 0x6d, 0x68, 0xf1, 0x89, 0xe3, 0x50, 0x1a, 0xdd, 0x17, 0x5b, 0x4e,
+	0x0a, 0xc7, 0xa1, 0x29, 0x79, 0xfa, 0xdf, 0x72, 0xb0, 0x2d, 0x8d, 0xa6, 0xee, 0x1c, 0xb3, 0xe3,
+	0xb4, 0xfe, 0x69, 0x59, 0x7d, 0x0a, 0xf7, 0x96, 0x18, 0xad, 0xb0, 0xfe, 0x14, 0x36, 0x12, 0xd5,
+	0x4a, 0x2b, 0x75, 0x55, 0x8e, 0x90, 0x98, 0x65, 0x46, 0x1c, 0xbd, 0x01, 0xb5, 0x97, 0xe2, 0x9a,
+	0x19, 0xd5, 0xde, 0x9f, 0x72, 0x50, 0x8f, 0x28, 0x3f, 0x9d, 0xea, 0xc3, 0xe6, 0x21, 0xdb, 0xaa,
+	0x79, 0x69, 0x8f, 0x4f, 0xc5, 0x3b, 0x41, 0x64, 0xf1, 0x2f, 0xa0, 0x3d, 0xcb, 0x52, 0xa6, 0x27,
+	0x0f, 0x0d, 0x49, 0x9b, 0x8c, 0x1f, 0x1a, 0x64, 0x0a, 0xff, 0x21, 0x0f, 0x8d, 0xd7, 0xfe, 0x05,
+	0x95, 0xe7, 0x9e, 0x65, 0xe7, 0x8c, 0x27, 0xb0, 0xde, 0x0b, 0xfc, 0x51, 0xb6, 0xf1, 0x2a, 0x0f,
+	0x91, 0x91, 0xea, 0xbc, 0xe2, 0x31, 0xc6, 0xcf, 0x4a, 0x16, 0xd4, 0x63, 0x8c, 0x9f, 0x96, 0x9b,
+	0x93, 0x66, 0x2b, 0x73, 0xd3, 0xec, 0x4b, 0xd8, 0x1c, 0xd9, 0xfc, 0x9c, 0xce, 0x82, 0x26, 0x47,
+	0x60, 0x4b, 0x72, 0xa7, 0x52, 0x6d, 0x11, 0xd0, 0xa5, 0x85, 0x40, 0x7f, 0x0f, 0xcd, 0x04, 0x8d,
+	0x1f, 0x5b, 0xc1, 0x8b, 0x8d, 0xcc, 0x2f, 0x36, 0xf2, 0x89, 0x0e, 0x90, 0xdc, 0x0a, 0xc9, 0x2a,
+	0x14, 0x4c, 0x63, 0x4f, 0xbe, 0x16, 0x74, 0xf7, 0xad, 0x93, 0xce, 0xd3, 0x67, 0xcd, 0x5c, 0xe7,
+	0x8f, 0x65, 0x28, 0x49, 0xdc, 0xc8, 0x63, 0x58, 0xc1, 0x67, 0x48, 0xd2, 0xc0, 0x83, 0x48, 0xea,
+	0x0d, 0x53, 0x6b, 0x26, 0x04, 0x65, 0xf8, 0x73, 0x28, 0xc7, 0xaf, 0x56, 0xa4, 0x85, 0xec, 0xe9,
+	0x77, 0x3b, 0xed, 0xe6, 0x14, 0x55, 0xad, 0xec, 0xc0, 0xaa, 0x7a, 0x1b, 0x20, 0x24, 0x92, 0x48,
+	0xae, 0x33, 0xda, 0x46, 0x86, 0x96, 0xec, 0x16, 0xdf, 0x61, 0xe5, 0x6e, 0xd3, 0x0f, 0x07, 0xda,
+	0xcd, 0x29, 0x6a, 0xb2, 0x9b, 0x3a, 0x86, 0xcb, 0xdd, 0xb2, 0x97, 0x27, 0x6d, 0x23, 0x43, 0x4b,
+	0xd6, 0xa8, 0x53, 0x98, 0x5c, 0x93, 0x3d, 0x2a, 0x6b, 0xf3, 0x8e, 0x9a, 0xe4, 0x1d, 0xdc, 0xbd,
+	0xe6, 0x70, 0x42, 0x9e, 0xe0, 0xba, 0xf7, 0x3b, 0xeb, 0x68, 0x1f, 0xbf, 0x97, 0xac, 0xda, 0xfb,
+	0x0c, 0xb6, 0x16, 0x9c, 0x45, 0x88, 0x2e, 0xd0, 0x5c, 0x7a, 0xe8, 0xd1, 0xee, 0x2f, 0x95, 0x51,
+	0x7b, 0xbc, 0x84, 0xc6, 0xd4, 0x11, 0x83, 0x68, 0x62, 0xdd, 0xdc, 0xa3, 0x8b, 0x76, 0x7b, 0x2e,
+	0x4f, 0xe9, 0x7a, 0x0c, 0x2b, 0x98, 0x70, 0x32, 0xcd, 0x52, 0xa7, 0x13, 0xad, 0x99, 0x10, 0x94,
+	0x28, 0x83, 0xdb, 0x4b, 0xc6, 0x36, 0x79, 0x28, 0x43, 0x71, 0xdd, 0xe1, 0x40, 0x7b, 0x74, 0xad,
+	0x5c, 0x02, 0xe5, 0x82, 0xa1, 0x2b, 0xa1, 0x5c, 0x3e, 0xfe, 0xb5, 0xfb, 0x4b, 0x65, 0xd4, 0x1e,
+	0x03, 0xb8, 0xb5, 0x70, 0xdc, 0x90, 0x8f, 0x12, 0x0d, 0x8b, 0x47, 0xa8, 0xf6, 0xe0, 0x1a, 0xa9,
+	0x78, 0x66, 0x95, 0xe4, 0xc0, 0x21, 0xe2, 0x8d, 0x32, 0x33, 0x8e, 0x34, 0x92, 0x26, 0xa9, 0x05,
+	0xaf, 0xa1, 0x39, 0xdd, 0xf0, 0xc9, 0xed, 0x24, 0x15, 0x67, 0x26, 0x84, 0xf6, 0xc1, 0x7c, 0xa6,
+	0x52, 0xf7, 0x14, 0xd6, 0xa2, 0x8e, 0x47, 0x44, 0xd5, 0x4c, 0x4d, 0x03, 0xad, 0x95, 0x25, 0xca,
+	0x65, 0x67, 0x25, 0xf1, 0xb7, 0xcd, 0x17, 0xff, 0x1d, 0x00, 0x73, 0xad, 0xf3, 0x80, 0xc6, 0x19,
+	0x00, 0x00,
 }
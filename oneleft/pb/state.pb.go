@@ -0,0 +1,577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: state.proto
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// The state a player keeps of the game it is playing in, saved so it can resume the game after restarting
+type PlayerState struct {
+	GameStart *GameStartRequest `protobuf:"bytes,1,opt,name=game_start,json=gameStart,proto3" json:"game_start,omitempty"`
+	// Empty before the first hand
+	HandStart *HandStartRequest `protobuf:"bytes,2,opt,name=hand_start,json=handStart,proto3" json:"hand_start,omitempty"`
+	// Empty before the first hand ends
+	HandEnd *HandEndRequest `protobuf:"bytes,3,opt,name=hand_end,json=handEnd,proto3" json:"hand_end,omitempty"`
+	// How many of the game's events have been seen
+	GameEventsSeen uint32              `protobuf:"varint,4,opt,name=game_events_seen,json=gameEventsSeen,proto3" json:"game_events_seen,omitempty"`
+	Replay         *PlayerState_Replay `protobuf:"bytes,5,opt,name=replay,proto3" json:"replay,omitempty"`
+	// -1 if unknown
+	ColorBeforeLastDiscard int32                  `protobuf:"varint,6,opt,name=color_before_last_discard,json=colorBeforeLastDiscard,proto3" json:"color_before_last_discard,omitempty"`
+	ShuffleStage0Pair      *PlayerState_KeyPair   `protobuf:"bytes,7,opt,name=shuffle_stage0_pair,json=shuffleStage0Pair,proto3" json:"shuffle_stage0_pair,omitempty"`
+	ShuffleStage1Pairs     []*PlayerState_KeyPair `protobuf:"bytes,8,rep,name=shuffle_stage1_pairs,json=shuffleStage1Pairs,proto3" json:"shuffle_stage1_pairs,omitempty"`
+	// Keyed by the encrypted card as a decimal string
+	CardPairs          map[string]*PlayerState_KeyPair `protobuf:"bytes,9,rep,name=card_pairs,json=cardPairs,proto3" json:"card_pairs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EncryptedDeckCards [][]byte                        `protobuf:"bytes,10,rep,name=encrypted_deck_cards,json=encryptedDeckCards,proto3" json:"encrypted_deck_cards,omitempty"`
+	// Keyed by the encrypted card as a decimal string
+	EncryptedCardsGivenToPlayers map[string]uint32    `protobuf:"bytes,11,rep,name=encrypted_cards_given_to_players,json=encryptedCardsGivenToPlayers,proto3" json:"encrypted_cards_given_to_players,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MyCards                      []*PlayerState_Card  `protobuf:"bytes,12,rep,name=my_cards,json=myCards,proto3" json:"my_cards,omitempty"`
+	UnconfirmedCard              *PlayerState_Card    `protobuf:"bytes,13,opt,name=unconfirmed_card,json=unconfirmedCard,proto3" json:"unconfirmed_card,omitempty"`
+	MoveHandGivePair             *PlayerState_KeyPair `protobuf:"bytes,14,opt,name=move_hand_give_pair,json=moveHandGivePair,proto3" json:"move_hand_give_pair,omitempty"`
+	MoveHandTakePair             *PlayerState_KeyPair `protobuf:"bytes,15,opt,name=move_hand_take_pair,json=moveHandTakePair,proto3" json:"move_hand_take_pair,omitempty"`
+	MoveHandFromIndex            uint32               `protobuf:"varint,16,opt,name=move_hand_from_index,json=moveHandFromIndex,proto3" json:"move_hand_from_index,omitempty"`
+	MoveHandToIndex              uint32               `protobuf:"varint,17,opt,name=move_hand_to_index,json=moveHandToIndex,proto3" json:"move_hand_to_index,omitempty"`
+	FirstUnencryptedStartCards   []uint32             `protobuf:"varint,18,rep,packed,name=first_unencrypted_start_cards,json=firstUnencryptedStartCards,proto3" json:"first_unencrypted_start_cards,omitempty"`
+	// The last requests answered, oldest first, so the same response is sent if the host repeats one
+	AnsweredRequests []*PlayerState_AnsweredRequest `protobuf:"bytes,19,rep,name=answered_requests,json=answeredRequests,proto3" json:"answered_requests,omitempty"`
+	// Keyed by the encrypted card as a decimal string
+	DecryptionKeyCommitments map[string]*PlayerState_DecryptionKeyCommitments `protobuf:"bytes,20,rep,name=decryption_key_commitments,json=decryptionKeyCommitments,proto3" json:"decryption_key_commitments,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral     struct{}                                         `json:"-"`
+	XXX_unrecognized         []byte                                           `json:"-"`
+	XXX_sizecache            int32                                            `json:"-"`
+}
+
+func (m *PlayerState) Reset()         { *m = PlayerState{} }
+func (m *PlayerState) String() string { return proto.CompactTextString(m) }
+func (*PlayerState) ProtoMessage()    {}
+func (*PlayerState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_8a6a4471170be880, []int{0}
+}
+func (m *PlayerState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerState.Unmarshal(m, b)
+}
+func (m *PlayerState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerState.Marshal(b, m, deterministic)
+}
+func (dst *PlayerState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerState.Merge(dst, src)
+}
+func (m *PlayerState) XXX_Size() int {
+	return xxx_messageInfo_PlayerState.Size(m)
+}
+func (m *PlayerState) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerState proto.InternalMessageInfo
+
+func (m *PlayerState) GetGameStart() *GameStartRequest {
+	if m != nil {
+		return m.GameStart
+	}
+	return nil
+}
+
+func (m *PlayerState) GetHandStart() *HandStartRequest {
+	if m != nil {
+		return m.HandStart
+	}
+	return nil
+}
+
+func (m *PlayerState) GetHandEnd() *HandEndRequest {
+	if m != nil {
+		return m.HandEnd
+	}
+	return nil
+}
+
+func (m *PlayerState) GetGameEventsSeen() uint32 {
+	if m != nil {
+		return m.GameEventsSeen
+	}
+	return 0
+}
+
+func (m *PlayerState) GetReplay() *PlayerState_Replay {
+	if m != nil {
+		return m.Replay
+	}
+	return nil
+}
+
+func (m *PlayerState) GetColorBeforeLastDiscard() int32 {
+	if m != nil {
+		return m.ColorBeforeLastDiscard
+	}
+	return 0
+}
+
+func (m *PlayerState) GetShuffleStage0Pair() *PlayerState_KeyPair {
+	if m != nil {
+		return m.ShuffleStage0Pair
+	}
+	return nil
+}
+
+func (m *PlayerState) GetShuffleStage1Pairs() []*PlayerState_KeyPair {
+	if m != nil {
+		return m.ShuffleStage1Pairs
+	}
+	return nil
+}
+
+func (m *PlayerState) GetCardPairs() map[string]*PlayerState_KeyPair {
+	if m != nil {
+		return m.CardPairs
+	}
+	return nil
+}
+
+func (m *PlayerState) GetEncryptedDeckCards() [][]byte {
+	if m != nil {
+		return m.EncryptedDeckCards
+	}
+	return nil
+}
+
+func (m *PlayerState) GetEncryptedCardsGivenToPlayers() map[string]uint32 {
+	if m != nil {
+		return m.EncryptedCardsGivenToPlayers
+	}
+	return nil
+}
+
+func (m *PlayerState) GetMyCards() []*PlayerState_Card {
+	if m != nil {
+		return m.MyCards
+	}
+	return nil
+}
+
+func (m *PlayerState) GetUnconfirmedCard() *PlayerState_Card {
+	if m != nil {
+		return m.UnconfirmedCard
+	}
+	return nil
+}
+
+func (m *PlayerState) GetMoveHandGivePair() *PlayerState_KeyPair {
+	if m != nil {
+		return m.MoveHandGivePair
+	}
+	return nil
+}
+
+func (m *PlayerState) GetMoveHandTakePair() *PlayerState_KeyPair {
+	if m != nil {
+		return m.MoveHandTakePair
+	}
+	return nil
+}
+
+func (m *PlayerState) GetMoveHandFromIndex() uint32 {
+	if m != nil {
+		return m.MoveHandFromIndex
+	}
+	return 0
+}
+
+func (m *PlayerState) GetMoveHandToIndex() uint32 {
+	if m != nil {
+		return m.MoveHandToIndex
+	}
+	return 0
+}
+
+func (m *PlayerState) GetFirstUnencryptedStartCards() []uint32 {
+	if m != nil {
+		return m.FirstUnencryptedStartCards
+	}
+	return nil
+}
+
+func (m *PlayerState) GetAnsweredRequests() []*PlayerState_AnsweredRequest {
+	if m != nil {
+		return m.AnsweredRequests
+	}
+	return nil
+}
+
+func (m *PlayerState) GetDecryptionKeyCommitments() map[string]*PlayerState_DecryptionKeyCommitments {
+	if m != nil {
+		return m.DecryptionKeyCommitments
+	}
+	return nil
+}
+
+type PlayerState_Replay struct {
+	// By player index
+	LastEvent            *HostMessage_GameEvent `protobuf:"bytes,1,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"`
+	BeforeReshuffle      *HostMessage_GameEvent `protobuf:"bytes,2,opt,name=before_reshuffle,json=beforeReshuffle,proto3" json:"before_reshuffle,omitempty"`
+	HandsPlayed          uint32                 `protobuf:"varint,3,opt,name=hands_played,json=handsPlayed,proto3" json:"hands_played,omitempty"`
+	TurnIndex            int32                  `protobuf:"varint,4,opt,name=turn_index,json=turnIndex,proto3" json:"turn_index,omitempty"`
+	TurnDrewOne          bool                   `protobuf:"varint,5,opt,name=turn_drew_one,json=turnDrewOne,proto3" json:"turn_drew_one,omitempty"`
+	TurnTimedOut         bool                   `protobuf:"varint,6,opt,name=turn_timed_out,json=turnTimedOut,proto3" json:"turn_timed_out,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PlayerState_Replay) Reset()         { *m = PlayerState_Replay{} }
+func (m *PlayerState_Replay) String() string { return proto.CompactTextString(m) }
+func (*PlayerState_Replay) ProtoMessage()    {}
+func (*PlayerState_Replay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_8a6a4471170be880, []int{0, 3}
+}
+func (m *PlayerState_Replay) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerState_Replay.Unmarshal(m, b)
+}
+func (m *PlayerState_Replay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerState_Replay.Marshal(b, m, deterministic)
+}
+func (dst *PlayerState_Replay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerState_Replay.Merge(dst, src)
+}
+func (m *PlayerState_Replay) XXX_Size() int {
+	return xxx_messageInfo_PlayerState_Replay.Size(m)
+}
+func (m *PlayerState_Replay) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerState_Replay.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerState_Replay proto.InternalMessageInfo
+
+func (m *PlayerState_Replay) GetLastEvent() *HostMessage_GameEvent {
+	if m != nil {
+		return m.LastEvent
+	}
+	return nil
+}
+
+func (m *PlayerState_Replay) GetBeforeReshuffle() *HostMessage_GameEvent {
+	if m != nil {
+		return m.BeforeReshuffle
+	}
+	return nil
+}
+
+func (m *PlayerState_Replay) GetHandsPlayed() uint32 {
+	if m != nil {
+		return m.HandsPlayed
+	}
+	return 0
+}
+
+func (m *PlayerState_Replay) GetTurnIndex() int32 {
+	if m != nil {
+		return m.TurnIndex
+	}
+	return 0
+}
+
+func (m *PlayerState_Replay) GetTurnDrewOne() bool {
+	if m != nil {
+		return m.TurnDrewOne
+	}
+	return false
+}
+
+func (m *PlayerState_Replay) GetTurnTimedOut() bool {
+	if m != nil {
+		return m.TurnTimedOut
+	}
+	return false
+}
+
+type PlayerState_AnsweredRequest struct {
+	Request              *HostMessage_PlayerRequest    `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Response             *ClientMessage_PlayerResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *PlayerState_AnsweredRequest) Reset()         { *m = PlayerState_AnsweredRequest{} }
+func (m *PlayerState_AnsweredRequest) String() string { return proto.CompactTextString(m) }
+func (*PlayerState_AnsweredRequest) ProtoMessage()    {}
+func (*PlayerState_AnsweredRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_8a6a4471170be880, []int{0, 4}
+}
+func (m *PlayerState_AnsweredRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerState_AnsweredRequest.Unmarshal(m, b)
+}
+func (m *PlayerState_AnsweredRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerState_AnsweredRequest.Marshal(b, m, deterministic)
+}
+func (dst *PlayerState_AnsweredRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerState_AnsweredRequest.Merge(dst, src)
+}
+func (m *PlayerState_AnsweredRequest) XXX_Size() int {
+	return xxx_messageInfo_PlayerState_AnsweredRequest.Size(m)
+}
+func (m *PlayerState_AnsweredRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerState_AnsweredRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerState_AnsweredRequest proto.InternalMessageInfo
+
+func (m *PlayerState_AnsweredRequest) GetRequest() *HostMessage_PlayerRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *PlayerState_AnsweredRequest) GetResponse() *ClientMessage_PlayerResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type PlayerState_KeyPair struct {
+	Prime                []byte   `protobuf:"bytes,1,opt,name=prime,proto3" json:"prime,omitempty"`
+	Enc                  []byte   `protobuf:"bytes,2,opt,name=enc,proto3" json:"enc,omitempty"`
+	Dec                  []byte   `protobuf:"bytes,3,opt,name=dec,proto3" json:"dec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerState_KeyPair) Reset()         { *m = PlayerState_KeyPair{} }
+func (m *PlayerState_KeyPair) String() string { return proto.CompactTextString(m) }
+func (*PlayerState_KeyPair) ProtoMessage()    {}
+func (*PlayerState_KeyPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_8a6a4471170be880, []int{0, 5}
+}
+func (m *PlayerState_KeyPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerState_KeyPair.Unmarshal(m, b)
+}
+func (m *PlayerState_KeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerState_KeyPair.Marshal(b, m, deterministic)
+}
+func (dst *PlayerState_KeyPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerState_KeyPair.Merge(dst, src)
+}
+func (m *PlayerState_KeyPair) XXX_Size() int {
+	return xxx_messageInfo_PlayerState_KeyPair.Size(m)
+}
+func (m *PlayerState_KeyPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerState_KeyPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerState_KeyPair proto.InternalMessageInfo
+
+func (m *PlayerState_KeyPair) GetPrime() []byte {
+	if m != nil {
+		return m.Prime
+	}
+	return nil
+}
+
+func (m *PlayerState_KeyPair) GetEnc() []byte {
+	if m != nil {
+		return m.Enc
+	}
+	return nil
+}
+
+func (m *PlayerState_KeyPair) GetDec() []byte {
+	if m != nil {
+		return m.Dec
+	}
+	return nil
+}
+
+type PlayerState_DecryptionKeyCommitments struct {
+	Commitments          [][]byte `protobuf:"bytes,1,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerState_DecryptionKeyCommitments) Reset()         { *m = PlayerState_DecryptionKeyCommitments{} }
+func (m *PlayerState_DecryptionKeyCommitments) String() string { return proto.CompactTextString(m) }
+func (*PlayerState_DecryptionKeyCommitments) ProtoMessage()    {}
+func (*PlayerState_DecryptionKeyCommitments) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_8a6a4471170be880, []int{0, 6}
+}
+func (m *PlayerState_DecryptionKeyCommitments) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerState_DecryptionKeyCommitments.Unmarshal(m, b)
+}
+func (m *PlayerState_DecryptionKeyCommitments) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerState_DecryptionKeyCommitments.Marshal(b, m, deterministic)
+}
+func (dst *PlayerState_DecryptionKeyCommitments) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerState_DecryptionKeyCommitments.Merge(dst, src)
+}
+func (m *PlayerState_DecryptionKeyCommitments) XXX_Size() int {
+	return xxx_messageInfo_PlayerState_DecryptionKeyCommitments.Size(m)
+}
+func (m *PlayerState_DecryptionKeyCommitments) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerState_DecryptionKeyCommitments.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerState_DecryptionKeyCommitments proto.InternalMessageInfo
+
+func (m *PlayerState_DecryptionKeyCommitments) GetCommitments() [][]byte {
+	if m != nil {
+		return m.Commitments
+	}
+	return nil
+}
+
+type PlayerState_Card struct {
+	Card                 uint32   `protobuf:"varint,1,opt,name=card,proto3" json:"card,omitempty"`
+	EncryptedCard        []byte   `protobuf:"bytes,2,opt,name=encrypted_card,json=encryptedCard,proto3" json:"encrypted_card,omitempty"`
+	DecryptionKeys       [][]byte `protobuf:"bytes,3,rep,name=decryption_keys,json=decryptionKeys,proto3" json:"decryption_keys,omitempty"`
+	DealtToIndex         uint32   `protobuf:"varint,4,opt,name=dealt_to_index,json=dealtToIndex,proto3" json:"dealt_to_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerState_Card) Reset()         { *m = PlayerState_Card{} }
+func (m *PlayerState_Card) String() string { return proto.CompactTextString(m) }
+func (*PlayerState_Card) ProtoMessage()    {}
+func (*PlayerState_Card) Descriptor() ([]byte, []int) {
+	return fileDescriptor_state_8a6a4471170be880, []int{0, 7}
+}
+func (m *PlayerState_Card) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerState_Card.Unmarshal(m, b)
+}
+func (m *PlayerState_Card) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerState_Card.Marshal(b, m, deterministic)
+}
+func (dst *PlayerState_Card) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerState_Card.Merge(dst, src)
+}
+func (m *PlayerState_Card) XXX_Size() int {
+	return xxx_messageInfo_PlayerState_Card.Size(m)
+}
+func (m *PlayerState_Card) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerState_Card.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerState_Card proto.InternalMessageInfo
+
+func (m *PlayerState_Card) GetCard() uint32 {
+	if m != nil {
+		return m.Card
+	}
+	return 0
+}
+
+func (m *PlayerState_Card) GetEncryptedCard() []byte {
+	if m != nil {
+		return m.EncryptedCard
+	}
+	return nil
+}
+
+func (m *PlayerState_Card) GetDecryptionKeys() [][]byte {
+	if m != nil {
+		return m.DecryptionKeys
+	}
+	return nil
+}
+
+func (m *PlayerState_Card) GetDealtToIndex() uint32 {
+	if m != nil {
+		return m.DealtToIndex
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PlayerState)(nil), "pb.PlayerState")
+	proto.RegisterMapType((map[string]*PlayerState_KeyPair)(nil), "pb.PlayerState.CardPairsEntry")
+	proto.RegisterMapType((map[string]*PlayerState_DecryptionKeyCommitments)(nil), "pb.PlayerState.DecryptionKeyCommitmentsEntry")
+	proto.RegisterMapType((map[string]uint32)(nil), "pb.PlayerState.EncryptedCardsGivenToPlayersEntry")
+	proto.RegisterType((*PlayerState_Replay)(nil), "pb.PlayerState.Replay")
+	proto.RegisterType((*PlayerState_AnsweredRequest)(nil), "pb.PlayerState.AnsweredRequest")
+	proto.RegisterType((*PlayerState_KeyPair)(nil), "pb.PlayerState.KeyPair")
+	proto.RegisterType((*PlayerState_DecryptionKeyCommitments)(nil), "pb.PlayerState.DecryptionKeyCommitments")
+	proto.RegisterType((*PlayerState_Card)(nil), "pb.PlayerState.Card")
+}
+
+func init() { proto.RegisterFile("state.proto", fileDescriptor_state_8a6a4471170be880) }
+
+var fileDescriptor_state_8a6a4471170be880 = []byte{
+	// 988 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6e, 0x1b, 0x37,
+	0x10, 0x85, 0x24, 0x5f, 0xa4, 0xd1, 0xd5, 0xb4, 0x90, 0x6e, 0x84, 0xba, 0xdd, 0x04, 0x2d, 0x2a,
+	0xa0, 0xb0, 0x12, 0x37, 0x0f, 0x4d, 0x8b, 0xb4, 0x45, 0x6a, 0x3b, 0x6e, 0x90, 0x14, 0x0e, 0x68,
+	0xe7, 0x79, 0x41, 0x6b, 0x47, 0xf6, 0x42, 0x5a, 0x52, 0x25, 0x29, 0xbb, 0xfa, 0x83, 0x3e, 0xf6,
+	0xa1, 0x1f, 0xda, 0x4f, 0x28, 0x38, 0xe4, 0x5a, 0x97, 0xc6, 0x4e, 0xde, 0x96, 0x87, 0xe7, 0x1c,
+	0x0e, 0xc9, 0x99, 0xe1, 0x42, 0xdd, 0x58, 0x61, 0x71, 0x30, 0xd5, 0xca, 0x2a, 0x56, 0x9e, 0x5e,
+	0xf4, 0xe0, 0x4a, 0x19, 0xeb, 0xc7, 0xbd, 0xc6, 0x74, 0x22, 0xe6, 0xa8, 0xfd, 0xe8, 0xf1, 0xbf,
+	0x3b, 0x50, 0x7f, 0x47, 0xc0, 0x99, 0xd3, 0xb0, 0x67, 0x00, 0x97, 0x22, 0xc7, 0xc4, 0x58, 0xa1,
+	0x6d, 0x54, 0x8a, 0x4b, 0xfd, 0xfa, 0x77, 0xdd, 0xc1, 0xf4, 0x62, 0x70, 0x22, 0x72, 0x3c, 0x73,
+	0x20, 0xc7, 0x3f, 0x66, 0x68, 0x2c, 0xaf, 0x5d, 0x16, 0x88, 0x13, 0x5d, 0x09, 0x99, 0x06, 0x51,
+	0x79, 0x21, 0xfa, 0x4d, 0xc8, 0x74, 0x55, 0x74, 0x55, 0x20, 0x6c, 0x1f, 0xaa, 0x24, 0x42, 0x99,
+	0x46, 0x15, 0x92, 0xb0, 0x42, 0x72, 0x2c, 0xd3, 0x42, 0xb0, 0x7d, 0xe5, 0xc7, 0xac, 0x0f, 0x1d,
+	0x0a, 0x0c, 0xaf, 0x51, 0x5a, 0x93, 0x18, 0x44, 0x19, 0x6d, 0xc4, 0xa5, 0x7e, 0x93, 0xb7, 0x1c,
+	0x7e, 0x4c, 0xf0, 0x19, 0xa2, 0x64, 0x03, 0xd8, 0xd2, 0xe8, 0x36, 0x19, 0x6d, 0x92, 0xed, 0x03,
+	0x67, 0xbb, 0xb4, 0xc7, 0x01, 0xa7, 0x59, 0x1e, 0x58, 0xec, 0x07, 0x78, 0x38, 0x54, 0x13, 0xa5,
+	0x93, 0x0b, 0x1c, 0x29, 0x8d, 0xc9, 0x44, 0x18, 0x9b, 0xa4, 0x99, 0x19, 0x0a, 0x9d, 0x46, 0x5b,
+	0x71, 0xa9, 0xbf, 0xc9, 0x1f, 0x10, 0xe1, 0x57, 0x9a, 0x7f, 0x2b, 0x8c, 0x3d, 0xf2, 0xb3, 0xec,
+	0x04, 0x76, 0xcd, 0xd5, 0x6c, 0x34, 0x9a, 0xd0, 0x81, 0x5d, 0xe2, 0xd3, 0x64, 0x2a, 0x32, 0x1d,
+	0x6d, 0xd3, 0xba, 0x9f, 0xad, 0xaf, 0xfb, 0x06, 0xe7, 0xef, 0x44, 0xa6, 0xf9, 0x4e, 0xd0, 0x9c,
+	0x91, 0xc4, 0x41, 0xec, 0x35, 0x74, 0x57, 0x8c, 0x0e, 0xc8, 0xc8, 0x44, 0xd5, 0xb8, 0x72, 0x9f,
+	0x13, 0x5b, 0x76, 0x3a, 0x70, 0x90, 0x61, 0x3f, 0x01, 0xb8, 0xd8, 0x82, 0x41, 0x8d, 0x0c, 0xbe,
+	0x58, 0x37, 0x38, 0x14, 0x3a, 0x25, 0xfa, 0xb1, 0xb4, 0x7a, 0xce, 0x6b, 0xc3, 0x62, 0xcc, 0x9e,
+	0x42, 0x17, 0xe5, 0x50, 0xcf, 0xa7, 0x16, 0xd3, 0x24, 0xc5, 0xe1, 0x38, 0x71, 0x73, 0x26, 0x82,
+	0xb8, 0xd2, 0x6f, 0x70, 0x76, 0x3b, 0x77, 0x84, 0xc3, 0xb1, 0x73, 0x31, 0x6c, 0x0e, 0xf1, 0x42,
+	0x41, 0xe4, 0xe4, 0x32, 0xbb, 0x46, 0x99, 0x58, 0x95, 0xf8, 0x5c, 0x33, 0x51, 0x9d, 0xc2, 0x38,
+	0x58, 0x0f, 0xe3, 0xb8, 0xd0, 0x91, 0xd3, 0x89, 0x53, 0x9d, 0x2b, 0x4f, 0x08, 0x91, 0x7d, 0x8e,
+	0xf7, 0x50, 0xd8, 0x13, 0xa8, 0xe6, 0xf3, 0x10, 0x60, 0x23, 0xae, 0x14, 0x69, 0xb7, 0xbe, 0x53,
+	0xbe, 0x9d, 0xcf, 0x7d, 0xac, 0xbf, 0x40, 0x67, 0x26, 0x87, 0x4a, 0x8e, 0x32, 0x9d, 0x87, 0x68,
+	0xa3, 0x66, 0x5c, 0xba, 0x53, 0xd8, 0x5e, 0x62, 0x3b, 0x80, 0xbd, 0x82, 0xdd, 0x5c, 0x5d, 0x63,
+	0x42, 0xa9, 0xeb, 0xb6, 0xe9, 0x6f, 0xbc, 0x75, 0xff, 0x8d, 0x77, 0x9c, 0xc6, 0x65, 0xb6, 0x8b,
+	0x9f, 0x2e, 0x7c, 0xc5, 0xc7, 0x8a, 0x71, 0xf0, 0x69, 0x7f, 0xa2, 0xcf, 0xb9, 0x18, 0x7b, 0x9f,
+	0x27, 0xd0, 0x5d, 0xf8, 0x8c, 0xb4, 0xca, 0x93, 0x4c, 0xa6, 0xf8, 0x67, 0xd4, 0xa1, 0xd2, 0xd8,
+	0x29, 0xf8, 0xaf, 0xb4, 0xca, 0x5f, 0xbb, 0x09, 0xf6, 0x2d, 0xb0, 0xa5, 0x85, 0x55, 0xa0, 0xef,
+	0x10, 0xbd, 0x7d, 0x6b, 0xaf, 0x3c, 0xf9, 0x25, 0xec, 0x8d, 0x32, 0x6d, 0x6c, 0x32, 0x93, 0x8b,
+	0x2b, 0xa6, 0x2a, 0x0f, 0x87, 0xce, 0xe2, 0x4a, 0xbf, 0xc9, 0x7b, 0x44, 0x7a, 0xbf, 0xe0, 0x50,
+	0x85, 0xfb, 0x13, 0x7f, 0x0b, 0x3b, 0x42, 0x9a, 0x1b, 0xd4, 0x98, 0x26, 0xda, 0x17, 0xb5, 0x89,
+	0x76, 0xe9, 0xae, 0xbe, 0x5c, 0xdf, 0xe6, 0xcb, 0x40, 0x2c, 0x8a, 0xbf, 0x23, 0x56, 0x01, 0xc3,
+	0xc6, 0xd0, 0x4b, 0x91, 0x16, 0xc9, 0x94, 0x4c, 0xc6, 0x38, 0x4f, 0x86, 0x2a, 0xcf, 0x33, 0x9b,
+	0xbb, 0xea, 0x8f, 0xba, 0x64, 0xbb, 0xbf, 0x6e, 0x7b, 0x74, 0xab, 0x78, 0x83, 0xf3, 0xc3, 0x05,
+	0xdf, 0x67, 0x58, 0x94, 0xde, 0x31, 0xdd, 0x7b, 0x0f, 0xad, 0xd5, 0x3a, 0x61, 0x1d, 0xa8, 0x8c,
+	0x71, 0x4e, 0x6d, 0xb1, 0xc6, 0xdd, 0x27, 0xdb, 0x87, 0xcd, 0x6b, 0x31, 0x99, 0x61, 0x54, 0xbe,
+	0xff, 0xe6, 0x3c, 0xeb, 0xc7, 0xf2, 0xf3, 0x52, 0xef, 0x14, 0x1e, 0x7d, 0x34, 0xef, 0x3f, 0xb0,
+	0x52, 0x77, 0x79, 0xa5, 0xe6, 0xb2, 0xe1, 0x0c, 0xf6, 0xee, 0xdd, 0xe2, 0x07, 0xcc, 0x7e, 0x5e,
+	0x0d, 0xbb, 0xff, 0xa9, 0x47, 0xb6, 0xbc, 0xec, 0x3f, 0x65, 0xd8, 0xf2, 0xad, 0x94, 0x3d, 0x07,
+	0xa0, 0xae, 0x49, 0xcd, 0x39, 0xbc, 0x1a, 0x0f, 0xa9, 0x9b, 0x2b, 0x63, 0x7f, 0x47, 0x63, 0xc4,
+	0x25, 0x0e, 0x4e, 0x8a, 0x36, 0xcd, 0x6b, 0x8e, 0x4c, 0x9f, 0xec, 0x08, 0x3a, 0xa1, 0xed, 0x6a,
+	0x0c, 0xcd, 0x2c, 0x2a, 0x7f, 0x4c, 0xdf, 0xf6, 0x12, 0x5e, 0x28, 0xd8, 0x23, 0x68, 0xb8, 0x7c,
+	0x36, 0xbe, 0xdf, 0xf8, 0xf7, 0xa4, 0xc9, 0xeb, 0x84, 0xd1, 0xb6, 0x52, 0xb6, 0x07, 0x60, 0x67,
+	0x5a, 0x86, 0x7c, 0xdf, 0xa0, 0xb6, 0x5e, 0x73, 0x88, 0xcf, 0xf4, 0xc7, 0xd0, 0xa4, 0xe9, 0x54,
+	0xe3, 0x4d, 0xa2, 0x24, 0xd2, 0xdb, 0x51, 0xe5, 0x75, 0x07, 0x1e, 0x69, 0xbc, 0x39, 0x95, 0xc8,
+	0xbe, 0x82, 0x16, 0x71, 0x6c, 0xe6, 0x7a, 0x87, 0x9a, 0x59, 0x7a, 0x1d, 0xaa, 0xbc, 0xe1, 0xd0,
+	0x73, 0x07, 0x9e, 0xce, 0x6c, 0xef, 0xaf, 0x12, 0xb4, 0xd7, 0x12, 0x99, 0x7d, 0x0f, 0xdb, 0x21,
+	0xf7, 0xc3, 0xe1, 0xec, 0xad, 0x6f, 0xce, 0x1f, 0xfe, 0xed, 0xab, 0x17, 0xd8, 0xec, 0x05, 0x54,
+	0x35, 0x9a, 0xa9, 0x92, 0xa6, 0x38, 0x96, 0xd8, 0x29, 0x0f, 0x27, 0x19, 0xca, 0xff, 0x69, 0x3d,
+	0x8f, 0xdf, 0x2a, 0x7a, 0x87, 0xb0, 0x1d, 0xf2, 0xcf, 0x65, 0xcf, 0x54, 0x67, 0x39, 0xd2, 0xfa,
+	0x0d, 0xee, 0x07, 0x2e, 0x31, 0x50, 0x0e, 0xc9, 0xb9, 0xc1, 0xdd, 0xa7, 0x43, 0x52, 0x1c, 0xd2,
+	0x01, 0x36, 0xb8, 0xfb, 0xec, 0xbd, 0x80, 0xe8, 0xae, 0x6c, 0x60, 0x31, 0xd4, 0x97, 0xeb, 0xaf,
+	0x44, 0x6f, 0xc4, 0x32, 0xd4, 0xfb, 0xbb, 0x04, 0x1b, 0xd4, 0x38, 0x19, 0x6c, 0x50, 0xb7, 0x2d,
+	0xd1, 0xd5, 0xd0, 0x37, 0xfb, 0x1a, 0x5a, 0xab, 0x2f, 0x47, 0x88, 0xa4, 0xb9, 0xd2, 0xf4, 0xd9,
+	0x37, 0xd0, 0x5e, 0x2d, 0x7a, 0x13, 0x55, 0x68, 0xa5, 0xd6, 0x4a, 0xe9, 0x1a, 0x77, 0x41, 0x29,
+	0x8a, 0x89, 0x5d, 0xf4, 0x35, 0xff, 0x87, 0xd0, 0x20, 0x34, 0x34, 0xb5, 0x8b, 0x2d, 0xfa, 0xf3,
+	0x79, 0xf6, 0xdf, 0x00, 0x8a, 0xb6, 0xab, 0x92, 0x26, 0x09, 0x00, 0x00,
+}
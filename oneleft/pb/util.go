@@ -7,11 +7,22 @@ import (
 	"github.com/golang/protobuf/proto"
 )
 
+// MarshalForSig marshals the message deterministically (i.e. sorted map keys) so signatures over it can be checked by
+// others that marshal it themselves.
+func MarshalForSig(msg proto.Message) ([]byte, error) {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (p *PlayerIdentity) VerifyIdentity() bool {
 	// Clone the identity, remove the sig, make the bytes, verify the sig
 	cloned := proto.Clone(p).(*PlayerIdentity)
 	cloned.Sig = nil
-	clonedBytes, err := proto.Marshal(cloned)
+	clonedBytes, err := MarshalForSig(cloned)
 	if err != nil {
 		panic(fmt.Errorf("Failed cloning: %v", err))
 	}
@@ -28,7 +39,7 @@ func (c *ChatMessage) Verify() bool {
 	cloned := proto.Clone(c).(*ChatMessage)
 	cloned.Sig = nil
 	cloned.HostUtcMs = 0
-	clonedBytes, err := proto.Marshal(cloned)
+	clonedBytes, err := MarshalForSig(cloned)
 	if err != nil {
 		panic(fmt.Errorf("Failed cloning: %v", err))
 	}
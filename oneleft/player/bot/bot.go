@@ -0,0 +1,452 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/google/uuid"
+)
+
+// Difficulty is how well a bot plays
+type Difficulty int
+
+// Plays a random legal card and color, never challenges or jumps in, and only sometimes remembers to call one left on
+// itself
+const Easy Difficulty = 0
+
+// Plays the first matching card before any wild as its most common color, calls one left on anyone as soon as it can,
+// and jumps in whenever it can
+const Medium Difficulty = 1
+
+// Plays like medium but weighs every play. It keeps to the colors it holds most of, holds wilds until late in the hand,
+// saves skips and draw cards for the player closest to going out, and challenges wild draw fours of players that have
+// been caught playing them with a color match. It never targets its partner.
+const Hard Difficulty = 2
+
+var difficultyNames = map[Difficulty]string{
+	Easy:   "easy",
+	Medium: "medium",
+	Hard:   "hard",
+}
+
+func (d Difficulty) String() string { return difficultyNames[d] }
+
+// ParseDifficulty returns the difficulty with the given name
+func ParseDifficulty(name string) (Difficulty, error) {
+	for difficulty, difficultyName := range difficultyNames {
+		if difficultyName == name {
+			return difficulty, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown difficulty %v", name)
+}
+
+// A hard bot challenges a player none of whose wild draw fours have been challenged one time in this many
+const untestedChallengeOdds = 5
+
+// Config is the set of bot options
+type Config struct {
+	// The public key of the player the bot plays for, used to find its seat
+	ID         ed25519.PublicKey
+	Difficulty Difficulty
+	// The source of the bot's random choices. If nil, one seeded with the current time is used.
+	Rand *rand.Rand
+}
+
+// Bot is an iface.Interface that plays automatically. It tracks its own hand from the cards it receives.
+type Bot struct {
+	id         ed25519.PublicKey
+	difficulty Difficulty
+
+	lock sync.Mutex
+	// Set after the player is created
+	player      player.Player
+	rand        *rand.Rand
+	rules       game.Rules
+	playerCount int
+	myIndex     int
+	cards       []game.Card
+	lastEvent   *iface.GameEvent
+	// The color that was to be matched before the last card was discarded
+	colorBeforeTop game.CardColor
+	// Per player for the game, how many of their wild draw fours were challenged and how many of those succeeded
+	challenges          []int
+	challengesSucceeded []int
+	// Per player, the color they last passed on if they haven't been given a card since, or ColorUnknown
+	passedColors []game.CardColor
+}
+
+// New creates a bot that must have its player set via SetPlayer before the game starts
+func New(conf *Config) *Bot {
+	ret := &Bot{id: conf.ID, difficulty: conf.Difficulty, rand: conf.Rand, myIndex: -1}
+	if ret.rand == nil {
+		ret.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return ret
+}
+
+// SetPlayer sets the player the bot is the UI of, which is needed to call one left and jump in
+func (b *Bot) SetPlayer(p player.Player) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.player = p
+}
+
+func (b *Bot) Connected(context.Context, []*iface.Player, []*iface.ChatMessage, *iface.GameEvent) error {
+	return nil
+}
+
+func (b *Bot) PlayersUpdated(context.Context, []*iface.Player) error { return nil }
+
+func (b *Bot) ChatMessage(context.Context, *iface.ChatMessage) error { return nil }
+
+func (b *Bot) GameEvent(ctx context.Context, event *iface.GameEvent) error {
+	b.lock.Lock()
+	b.observeEventUnsafe(event)
+	b.lastEvent = event
+	// Cards can be dealt again in the same hand
+	if event.Type == game.EventHandStartShuffled {
+		b.cards = nil
+	}
+	p := b.player
+	canJumpIn := b.difficulty >= Medium && b.rules.JumpIn && b.identicalCardIndexUnsafe() >= 0
+	b.lock.Unlock()
+	if canJumpIn && p != nil {
+		return p.JumpIn()
+	}
+	return nil
+}
+
+func (b *Bot) Error(context.Context, *iface.Error) error { return nil }
+
+func (b *Bot) OneLeftCallWindow(ctx context.Context, playerIndex int) error {
+	b.lock.Lock()
+	p := b.player
+	call := false
+	switch {
+	case playerIndex < 0 || b.myIndex < 0:
+	case playerIndex == b.myIndex:
+		call = b.difficulty > Easy || b.rand.Intn(2) == 0
+	case b.difficulty == Hard:
+		// Calling it on a partner would only penalize our own team
+		call = !b.rules.Teammates(playerIndex, b.myIndex, b.playerCount)
+	default:
+		call = b.difficulty == Medium
+	}
+	b.lock.Unlock()
+	if call && p != nil {
+		return p.CallOneLeft(playerIndex)
+	}
+	return nil
+}
+
+func (b *Bot) GameStart(ctx context.Context, id uuid.UUID, players []*iface.Player, rules game.Rules) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rules = rules
+	b.playerCount = len(players)
+	b.challenges = make([]int, len(players))
+	b.challengesSucceeded = make([]int, len(players))
+	b.passedColors = make([]game.CardColor, len(players))
+	b.forgetPassedColorsUnsafe()
+	b.colorBeforeTop = game.ColorUnknown
+	b.myIndex = -1
+	for i, p := range players {
+		if bytes.Equal(p.ID, b.id) {
+			b.myIndex = i
+		}
+	}
+	if b.myIndex == -1 {
+		return fmt.Errorf("Unable to find myself")
+	}
+	return nil
+}
+
+func (b *Bot) GameEnd(context.Context, []int) error { return nil }
+
+func (b *Bot) HandStart(context.Context, int) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.cards = nil
+	return nil
+}
+
+func (b *Bot) HandEnd(context.Context, int, int, []game.Card, [][]game.Card) error { return nil }
+
+func (b *Bot) ChooseColorSinceFirstCardIsWild(context.Context) (game.CardColor, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.difficulty == Easy {
+		return game.CardColor(b.rand.Intn(4)), nil
+	}
+	return game.MostCommonColor(b.cards), nil
+}
+
+func (b *Bot) ReceiveCard(ctx context.Context, card game.Card) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.cards = append(b.cards, card)
+	return nil
+}
+
+func (b *Bot) Play(context.Context) (game.Card, game.CardColor, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.lastEvent == nil || b.lastEvent.Hand == nil || len(b.lastEvent.Hand.DiscardStack) == 0 {
+		return game.NoCard, 0, fmt.Errorf("No discard")
+	}
+	hand := b.lastEvent.Hand
+	plays := game.LegalPlays(b.cards, topCard(hand), hand.LastDiscardWildColor, hand.DrawPenalty)
+	if len(plays) == 0 {
+		return game.NoCard, 0, nil
+	}
+	var play game.PlayerPlay
+	switch b.difficulty {
+	case Easy:
+		play = plays[b.rand.Intn(len(plays))]
+	case Medium:
+		play = b.firstPlayUnsafe(plays)
+	default:
+		play = b.bestPlayUnsafe(plays)
+	}
+	b.removeCardUnsafe(play.Card)
+	return play.Card, play.WildColor, nil
+}
+
+func (b *Bot) ShouldChallengeWildDrawFour(context.Context) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.difficulty != Hard || b.lastEvent == nil || b.lastEvent.Hand == nil {
+		return false, nil
+	}
+	// A challenge succeeds if the player had a card of the previous color, which they can't have if they passed on it
+	// and haven't been given a card since
+	playerIndex := b.lastEvent.Hand.PlayerIndex
+	if b.colorBeforeTop != game.ColorUnknown && b.passedColors[playerIndex] == b.colorBeforeTop {
+		return false, nil
+	}
+	// Most players only play a wild draw four when they can't match the color, so how often challenges of this player
+	// succeeded is what matters. Players nobody has challenged yet are challenged now and then to find out.
+	if b.challenges[playerIndex] == 0 {
+		return b.rand.Intn(untestedChallengeOdds) == 0, nil
+	}
+	// A failed challenge draws 6 instead of 4, so it's only worth it if it succeeds more than a third of the time. The
+	// estimate starts from one success in four to stay doubtful after a single lucky challenge.
+	chance := float64(b.challengesSucceeded[playerIndex]+1) / float64(b.challenges[playerIndex]+4)
+	return chance > 1.0/3, nil
+}
+
+func (b *Bot) RevealedCardsForChallenge(context.Context, int, []game.Card, bool) error { return nil }
+
+func (b *Bot) JumpIn(context.Context) (game.Card, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	// The top card may have changed since the claim
+	i := b.identicalCardIndexUnsafe()
+	if i == -1 {
+		return game.NoCard, nil
+	}
+	card := b.cards[i]
+	b.cards = append(b.cards[:i], b.cards[i+1:]...)
+	return card, nil
+}
+
+func (b *Bot) ChooseSwapTarget(context.Context) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.lastEvent == nil || b.lastEvent.Hand == nil {
+		return -1, fmt.Errorf("No hand")
+	}
+	// Take the smallest hand, only from opponents when hard
+	counts := b.lastEvent.Hand.PlayerCardsRemaining
+	targets := []int{}
+	for i := range counts {
+		if i != b.myIndex && (b.difficulty != Hard || !b.rules.Teammates(i, b.myIndex, b.playerCount)) {
+			targets = append(targets, i)
+		}
+	}
+	if b.difficulty == Easy {
+		return targets[b.rand.Intn(len(targets))], nil
+	}
+	target := targets[0]
+	for _, i := range targets {
+		if counts[i] < counts[target] {
+			target = i
+		}
+	}
+	return target, nil
+}
+
+func (b *Bot) ReceiveHand(ctx context.Context, fromIndex int, cards []game.Card) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.cards = append([]game.Card{}, cards...)
+	return nil
+}
+
+// firstPlayUnsafe returns the first non-wild play or the first wild as the most common color. Unsafe because it
+// expects callers to lock.
+func (b *Bot) firstPlayUnsafe(plays []game.PlayerPlay) game.PlayerPlay {
+	wildColor := game.MostCommonColor(b.cards)
+	for _, wild := range []bool{false, true} {
+		for _, play := range plays {
+			if play.Card.Wild() == wild && (!wild || play.WildColor == wildColor) {
+				return play
+			}
+		}
+	}
+	return plays[0]
+}
+
+// bestPlayUnsafe returns the play with the highest weight. Unsafe because it expects callers to lock.
+func (b *Bot) bestPlayUnsafe(plays []game.PlayerPlay) game.PlayerPlay {
+	hand := b.lastEvent.Hand
+	playerCount := b.playerCount
+	// The player closest to going out is the leader, and wilds are only worth holding while nobody is close
+	nextIndex := b.myIndex + 1
+	if !hand.Forward {
+		nextIndex = b.myIndex - 1 + playerCount
+	}
+	nextIndex %= playerCount
+	leaderCount := -1
+	for i, count := range hand.PlayerCardsRemaining {
+		if !b.rules.Teammates(i, b.myIndex, playerCount) && (leaderCount == -1 || count < leaderCount) {
+			leaderCount = count
+		}
+	}
+	nextIsPartner := b.rules.Teammates(nextIndex, b.myIndex, playerCount)
+	nextIsLeader := !nextIsPartner && hand.PlayerCardsRemaining[nextIndex] == leaderCount
+	late := len(b.cards) <= 3 || leaderCount <= 2
+	challengeable := !b.rules.StackDrawCards &&
+		game.WildDrawFourChallengeable(b.cards, topCard(hand), hand.LastDiscardWildColor)
+	best, bestWeight := plays[0], math.MinInt32
+	for _, play := range plays {
+		// Getting rid of high points first and keeping to the color we have most of
+		weight := play.Card.Score()
+		color := play.Card.Color()
+		if play.Card.Wild() {
+			color = play.WildColor
+		}
+		for _, card := range b.cards {
+			if card != play.Card && card.Color() == color {
+				weight += 10
+			}
+		}
+		if play.Card.Wild() && !late {
+			weight -= 100
+		}
+		if play.Card.Value() == game.WildDrawFour && challengeable {
+			weight -= 60
+		}
+		// With two players, a reverse is a skip
+		switch value := play.Card.Value(); {
+		case value == game.Skip, value == game.DrawTwo, value == game.WildDrawFour,
+			value == game.Reverse && playerCount == 2:
+			if nextIsLeader {
+				weight += 80
+			} else if nextIsPartner {
+				weight -= 80
+			} else {
+				weight -= 15
+			}
+		}
+		if weight > bestWeight {
+			best, bestWeight = play, weight
+		}
+	}
+	return best
+}
+
+// observeEventUnsafe remembers what the event shows about the other players' hands before it becomes the last event.
+// Unsafe because it expects callers to lock.
+func (b *Bot) observeEventUnsafe(event *iface.GameEvent) {
+	if b.passedColors == nil {
+		return
+	}
+	hand, prev := event.Hand, b.lastEvent
+	if hand == nil || prev == nil || prev.Hand == nil || event.Type == game.EventHandStartShuffled ||
+		event.Type == game.EventHandPlayerSwappedHands || event.Type == game.EventHandHandsRotated {
+		b.forgetPassedColorsUnsafe()
+		return
+	}
+	for i, count := range hand.PlayerCardsRemaining {
+		if i < len(prev.Hand.PlayerCardsRemaining) && count > prev.Hand.PlayerCardsRemaining[i] {
+			b.passedColors[i] = game.ColorUnknown
+		}
+	}
+	switch event.Type {
+	case game.EventHandPlayerDiscarded, game.EventHandPlayerJumpedIn:
+		b.colorBeforeTop = topColor(prev.Hand)
+	case game.EventHandPlayerPlayedNothing:
+		// A drawn card that matched could have been played
+		if b.rules.PlayDrawnCard || b.rules.DrawUntilPlayable {
+			b.passedColors[hand.PlayerIndex] = topColor(hand)
+		}
+	case game.EventHandPlayerChallengeSuccessDrewFour:
+		// The player that played the wild draw four draws
+		b.challenges[hand.PlayerIndex]++
+		b.challengesSucceeded[hand.PlayerIndex]++
+	case game.EventHandPlayerChallengeFailedDrewSix:
+		// The challenger draws, so the player that played the wild draw four is the one before them
+		challengedIndex := hand.PlayerIndex - 1 + b.playerCount
+		if !hand.Forward {
+			challengedIndex = hand.PlayerIndex + 1
+		}
+		b.challenges[challengedIndex%b.playerCount]++
+	}
+}
+
+// Unsafe because it expects callers to lock
+func (b *Bot) forgetPassedColorsUnsafe() {
+	for i := range b.passedColors {
+		b.passedColors[i] = game.ColorUnknown
+	}
+}
+
+// Unsafe because it expects callers to lock
+func (b *Bot) identicalCardIndexUnsafe() int {
+	if b.lastEvent == nil || b.lastEvent.Hand == nil || len(b.lastEvent.Hand.DiscardStack) == 0 {
+		return -1
+	}
+	top := topCard(b.lastEvent.Hand)
+	for i, card := range b.cards {
+		if !card.Wild() && card.Identical(top) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Unsafe because it expects callers to lock
+func (b *Bot) removeCardUnsafe(card game.Card) {
+	for i, myCard := range b.cards {
+		if myCard == card {
+			b.cards = append(b.cards[:i], b.cards[i+1:]...)
+			return
+		}
+	}
+}
+
+func topCard(hand *iface.GameEventHand) game.Card {
+	return hand.DiscardStack[len(hand.DiscardStack)-1]
+}
+
+// topColor is the color to match on the top card, or ColorUnknown if there is no discard
+func topColor(hand *iface.GameEventHand) game.CardColor {
+	if len(hand.DiscardStack) == 0 {
+		return game.ColorUnknown
+	} else if top := topCard(hand); top.Wild() {
+		return hand.LastDiscardWildColor
+	} else {
+		return top.Color()
+	}
+}
@@ -0,0 +1,359 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBots creates started bots of the given difficulties, each with a random source seeded from the seed
+func newTestBots(t *testing.T, seed int64, rules game.Rules, difficulties ...Difficulty) []*Bot {
+	r := rand.New(rand.NewSource(seed))
+	players := make([]*iface.Player, len(difficulties))
+	bots := make([]*Bot, len(difficulties))
+	for i, difficulty := range difficulties {
+		players[i] = &iface.Player{ID: []byte{byte(i)}, Name: fmt.Sprintf("%v %v", difficulty, i)}
+		bots[i] = New(&Config{ID: players[i].ID, Difficulty: difficulty, Rand: rand.New(rand.NewSource(r.Int63()))})
+	}
+	for _, b := range bots {
+		require.NoError(t, b.GameStart(context.Background(), uuid.New(), players, rules))
+	}
+	return bots
+}
+
+func sortedCards(cards []game.Card) []game.Card {
+	ret := append([]game.Card{}, cards...)
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+func TestPlaysAreLegal(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.StackDrawCards = true
+	r := rand.New(rand.NewSource(0))
+	for _, difficulty := range []Difficulty{Easy, Medium, Hard} {
+		for i := 0; i < 500; i++ {
+			b := newTestBots(t, int64(i), rules, difficulty, Easy, Easy)[0]
+			cards := make([]game.Card, 1+r.Intn(10))
+			for j := range cards {
+				cards[j] = game.Card(r.Intn(108))
+			}
+			require.NoError(t, b.ReceiveHand(context.Background(), 1, cards))
+			hand := &iface.GameEventHand{
+				PlayerCardsRemaining: []int{len(cards), 1 + r.Intn(10), 1 + r.Intn(10)},
+				DiscardStack:         []game.Card{game.Card(r.Intn(108))},
+				LastDiscardWildColor: game.ColorUnknown,
+				Forward:              r.Intn(2) == 0,
+			}
+			top := hand.DiscardStack[0]
+			if top.Wild() {
+				hand.LastDiscardWildColor = game.CardColor(r.Intn(4))
+			}
+			if value := top.Value(); (value == game.DrawTwo || value == game.WildDrawFour) && r.Intn(2) == 0 {
+				hand.DrawPenalty = 2 * (1 + r.Intn(3))
+			}
+			event := &iface.GameEvent{Type: game.EventHandPlayerDiscarded, Hand: hand}
+			require.NoError(t, b.GameEvent(context.Background(), event))
+
+			card, wildColor, err := b.Play(context.Background())
+			require.NoError(t, err)
+			plays := game.LegalPlays(cards, top, hand.LastDiscardWildColor, hand.DrawPenalty)
+			if len(plays) == 0 {
+				require.Equal(t, game.NoCard, card, "%v with %v on %v", difficulty, cards, top)
+				continue
+			}
+			// Wild colors are only checked for wilds
+			if !card.Wild() {
+				wildColor = 0
+			}
+			require.Contains(t, plays, game.PlayerPlay{Card: card, WildColor: wildColor},
+				"%v with %v on %v", difficulty, cards, top)
+		}
+	}
+}
+
+func TestTracksHand(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.JumpIn = true
+	bots := newTestBots(t, 0, rules, Medium, Medium)
+	b := bots[0]
+	ctx := context.Background()
+	red1, red5, yellow5, otherYellow5, wild := game.Card(1), game.Card(5), game.Card(30), game.Card(39), game.Card(100)
+	require.True(t, yellow5.Identical(otherYellow5))
+
+	// Received cards are added
+	require.NoError(t, b.HandStart(ctx, 0))
+	for _, card := range []game.Card{red1, red5, yellow5, wild} {
+		require.NoError(t, b.ReceiveCard(ctx, card))
+	}
+	require.Equal(t, []game.Card{red1, red5, yellow5, wild}, b.cards)
+
+	// Played cards are removed
+	hand := &iface.GameEventHand{
+		PlayerCardsRemaining: []int{4, 7},
+		DiscardStack:         []game.Card{game.Card(3)},
+		LastDiscardWildColor: game.ColorUnknown,
+	}
+	require.NoError(t, b.GameEvent(ctx, &iface.GameEvent{Type: game.EventHandStartTopCardAddedToDiscard, Hand: hand}))
+	card, _, err := b.Play(ctx)
+	require.NoError(t, err)
+	require.Equal(t, red1, card)
+	require.Equal(t, []game.Card{red5, yellow5, wild}, b.cards)
+
+	// Jumping in removes the identical card
+	hand = &iface.GameEventHand{
+		PlayerCardsRemaining: []int{3, 6},
+		DiscardStack:         []game.Card{game.Card(3), red1, game.Card(26), otherYellow5},
+		LastDiscardWildColor: game.ColorUnknown,
+	}
+	require.NoError(t, b.GameEvent(ctx, &iface.GameEvent{Type: game.EventHandPlayerDiscarded, Hand: hand}))
+	jumpInCard, err := b.JumpIn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, yellow5, jumpInCard)
+	require.Equal(t, []game.Card{red5, wild}, b.cards)
+	// Nothing to jump in with once it's gone
+	jumpInCard, err = b.JumpIn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, game.NoCard, jumpInCard)
+
+	// A received hand replaces the cards, and isn't changed by later changes to the given slice
+	swapped := []game.Card{yellow5, red5, red1}
+	require.NoError(t, b.ReceiveHand(ctx, 1, swapped))
+	swapped[0] = wild
+	require.Equal(t, []game.Card{yellow5, red5, red1}, b.cards)
+
+	// Cards are forgotten when dealt again and when a new hand starts
+	require.NoError(t, b.GameEvent(ctx, &iface.GameEvent{Type: game.EventHandStartShuffled}))
+	require.Empty(t, b.cards)
+	require.NoError(t, b.ReceiveCard(ctx, red1))
+	require.NoError(t, b.HandStart(ctx, 1))
+	require.Empty(t, b.cards)
+}
+
+// testSeat plays a game as a bot, holding the real hand to check the bot's copy against
+type testSeat struct {
+	index int
+	bot   *Bot
+	cards []game.Card
+	// Whether the last wild draw four played would lose a challenge
+	challengeable bool
+}
+
+func (s *testSeat) CardsRemaining() int      { return len(s.cards) }
+func (s *testSeat) HeldCards() []game.Card   { return s.cards }
+func (s *testSeat) SetJumpInCallback(func()) {}
+func (s *testSeat) CancelPlay()              {}
+func (s *testSeat) JumpIn() (*game.PlayerPlay, error) {
+	return &game.PlayerPlay{Card: game.NoCard}, nil
+}
+
+func (s *testSeat) ReceiveCard(card game.Card) error {
+	s.cards = append(s.cards, card)
+	return s.bot.ReceiveCard(context.Background(), card)
+}
+
+func (s *testSeat) ReceiveHand(fromIndex int, cards []game.Card) error {
+	s.cards = cards
+	// The bot forgets its cards itself when they are taken back for a shuffle
+	if fromIndex == -1 {
+		return nil
+	}
+	return s.bot.ReceiveHand(context.Background(), fromIndex, append([]game.Card{}, cards...))
+}
+
+func (s *testSeat) ChooseColorSinceFirstCardIsWild() (game.CardColor, error) {
+	return s.bot.ChooseColorSinceFirstCardIsWild(context.Background())
+}
+
+func (s *testSeat) Play() (*game.PlayerPlay, error) {
+	card, wildColor, err := s.bot.Play(context.Background())
+	if err != nil || card == game.NoCard {
+		return &game.PlayerPlay{Card: game.NoCard}, err
+	}
+	if card.Value() == game.WildDrawFour {
+		hand := s.bot.lastEvent.Hand
+		s.challengeable = game.WildDrawFourChallengeable(s.cards, topCard(hand), hand.LastDiscardWildColor)
+	}
+	for i, myCard := range s.cards {
+		if myCard == card {
+			s.cards = append(s.cards[:i], s.cards[i+1:]...)
+			break
+		}
+	}
+	return &game.PlayerPlay{Card: card, WildColor: wildColor}, nil
+}
+
+func (s *testSeat) ShouldChallengeWildDrawFour() (bool, error) {
+	return s.bot.ShouldChallengeWildDrawFour(context.Background())
+}
+
+func (s *testSeat) ChallengedWildDrawFour(int) (bool, error) { return s.challengeable, nil }
+
+func (s *testSeat) SetOneLeftCallback(justGotOneLeftIndex int, callOneLeft func(target int)) {
+	// Bots without a player can't call it themselves, so the player with one left always calls it on themselves
+	if justGotOneLeftIndex == s.index {
+		callOneLeft(justGotOneLeftIndex)
+	}
+}
+
+func (s *testSeat) ChooseSwapTarget() (int, error) {
+	return s.bot.ChooseSwapTarget(context.Background())
+}
+
+// playTestGame plays a seeded game between bots of the given difficulties, checking after every event that each bot
+// knows its real hand
+func playTestGame(t *testing.T, seed int64, rules game.Rules, difficulties ...Difficulty) *game.GameComplete {
+	r := rand.New(rand.NewSource(seed))
+	seats := make([]*testSeat, len(difficulties))
+	players := make([]game.Player, len(seats))
+	holders := make([]game.CardHolder, len(seats))
+	for i, b := range newTestBots(t, seed, rules, difficulties...) {
+		seats[i] = &testSeat{index: i, bot: b}
+		players[i], holders[i] = seats[i], seats[i]
+	}
+	newDeck := func() (game.CardDeck, error) { return game.NewMemoryDeck(r, holders), nil }
+	eventCb := func(event *game.Event) error {
+		ifaceEvent := &iface.GameEvent{
+			Type:         event.Type,
+			PlayerScores: event.PlayerScores,
+			DealerIndex:  event.DealerIndex,
+		}
+		if hand := event.Hand; hand != nil {
+			ifaceEvent.Hand = &iface.GameEventHand{
+				PlayerIndex:          hand.PlayerIndex,
+				PlayerCardsRemaining: hand.PlayerCardsRemaining,
+				DeckCardsRemaining:   hand.DeckCardsRemaining,
+				DiscardStack:         hand.DiscardStack,
+				LastDiscardWildColor: hand.LastDiscardWildColor,
+				Forward:              hand.Forward,
+				OneLeftTarget:        hand.OneLeftTarget,
+				DrawPenalty:          hand.DrawPenalty,
+				SwapTarget:           hand.SwapTarget,
+			}
+		}
+		for i, s := range seats {
+			if err := s.bot.GameEvent(context.Background(), ifaceEvent); err != nil {
+				return err
+			} else if !cardsEqual(sortedCards(s.cards), sortedCards(s.bot.cards)) {
+				return fmt.Errorf("After %v, bot %v has %v instead of %v", event.Type, i, s.bot.cards, s.cards)
+			}
+		}
+		return nil
+	}
+	complete, gameErr := game.New(players, rules, newDeck, eventCb).Play(0)
+	if gameErr != nil {
+		require.NoError(t, gameErr)
+	}
+	return complete
+}
+
+func cardsEqual(a []game.Card, b []game.Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBotsFinishGame(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.SevenO, rules.StackDrawCards = true, true
+	for seed := int64(0); seed < 5; seed++ {
+		complete := playTestGame(t, seed, rules, Easy, Medium, Hard)
+		require.NotNil(t, complete)
+		require.GreaterOrEqual(t, complete.PlayerScores[complete.WinnerIndexes[0]], rules.TargetScore)
+		// The same seed plays the same game
+		require.Equal(t, complete, playTestGame(t, seed, rules, Easy, Medium, Hard))
+	}
+}
+
+func TestChallengeWildDrawFour(t *testing.T) {
+	b := newTestBots(t, 0, game.DefaultRules(), Hard, Easy, Easy)[0]
+	ctx := context.Background()
+	red3, blue3, wildDrawFour := game.Card(3), game.Card(78), game.Card(104)
+	send := func(typ game.EventType, playerIndex int, forward bool, discard ...game.Card) {
+		event := &iface.GameEvent{Type: typ, Hand: &iface.GameEventHand{
+			PlayerIndex:          playerIndex,
+			PlayerCardsRemaining: []int{5, 5, 5},
+			DiscardStack:         discard,
+			LastDiscardWildColor: game.ColorBlue,
+			Forward:              forward,
+		}}
+		require.NoError(t, b.GameEvent(ctx, event))
+	}
+	shouldChallenge := func() bool {
+		challenge, err := b.ShouldChallengeWildDrawFour(ctx)
+		require.NoError(t, err)
+		return challenge
+	}
+	send(game.EventHandStartTopCardAddedToDiscard, 0, true, red3)
+
+	// Players nobody has challenged are only challenged now and then
+	challenges := 0
+	for i := 0; i < 1000; i++ {
+		send(game.EventHandPlayerDiscarded, 1, true, red3, wildDrawFour)
+		if shouldChallenge() {
+			challenges++
+		}
+	}
+	require.InDelta(t, 1000/untestedChallengeOdds, challenges, 50)
+
+	// Players caught playing one with a color match are challenged
+	send(game.EventHandPlayerChallengeSuccessDrewFour, 2, true, red3, wildDrawFour)
+	send(game.EventHandPlayerDiscarded, 1, true, red3)
+	send(game.EventHandPlayerDiscarded, 2, true, red3, wildDrawFour)
+	require.True(t, shouldChallenge())
+	// Unless they just passed on the color and haven't been given a card since
+	send(game.EventHandPlayerDiscarded, 1, true, red3, blue3)
+	send(game.EventHandPlayerPlayedNothing, 2, true, red3, blue3)
+	send(game.EventHandPlayerDiscarded, 0, true, red3, blue3, wildDrawFour)
+	send(game.EventHandPlayerDiscarded, 2, true, red3, blue3, wildDrawFour, wildDrawFour)
+	require.False(t, shouldChallenge())
+
+	// Once challenges of them mostly fail, they aren't. Challengers that fail draw six after play moves to them.
+	send(game.EventHandPlayerChallengeFailedDrewSix, 0, true, red3)
+	send(game.EventHandPlayerChallengeFailedDrewSix, 1, false, red3)
+	require.Equal(t, []int{0, 0, 3}, b.challenges)
+	send(game.EventHandPlayerDiscarded, 1, true, red3)
+	send(game.EventHandPlayerDiscarded, 2, true, red3, wildDrawFour)
+	require.False(t, shouldChallenge())
+}
+
+func TestSkipsSavedForLeader(t *testing.T) {
+	red3, red9, redSkip := game.Card(3), game.Card(9), game.Card(19)
+	require.Equal(t, game.Skip, redSkip.Value())
+	// The next player is the leader or only another opponent is close to going out
+	for _, nextIsLeader := range []bool{true, false} {
+		b := newTestBots(t, 0, game.DefaultRules(), Hard, Medium, Medium)[0]
+		require.NoError(t, b.ReceiveHand(context.Background(), 1, []game.Card{red9, redSkip}))
+		counts := []int{2, 5, 1}
+		if nextIsLeader {
+			counts = []int{2, 1, 5}
+		}
+		event := &iface.GameEvent{Type: game.EventHandPlayerDiscarded, Hand: &iface.GameEventHand{
+			PlayerCardsRemaining: counts,
+			DiscardStack:         []game.Card{red3},
+			LastDiscardWildColor: game.ColorUnknown,
+			Forward:              true,
+		}}
+		require.NoError(t, b.GameEvent(context.Background(), event))
+		card, _, err := b.Play(context.Background())
+		require.NoError(t, err)
+		if nextIsLeader {
+			require.Equal(t, redSkip, card)
+		} else {
+			require.Equal(t, red9, card)
+		}
+	}
+}
@@ -24,15 +24,26 @@ type RequestHandler interface {
 	OnChatMessage(context.Context, *pb.ChatMessage) error
 	OnGameEvent(context.Context, *pb.HostMessage_GameEvent) error
 	OnError(context.Context, *pb.HostMessage_Error) error
+	OnOneLeftCallWindow(context.Context, *pb.HostMessage_OneLeftCallWindow) error
+	OnResume(context.Context, *pb.HostMessage_Resume) error
+	// AnsweredRequest returns the response already sent for the request if the host is sending it again after we
+	// reconnected, or nil if it is new
+	AnsweredRequest(*pb.HostMessage_PlayerRequest) *pb.ClientMessage_PlayerResponse
+	// OnResponse is called with every response to a request just before it is sent
+	OnResponse(context.Context, *pb.HostMessage_PlayerRequest, *pb.ClientMessage_PlayerResponse) error
 }
 
 type client struct {
 	handler RequestHandler
 	stream  pb.Host_StreamClient
 
-	chLock           sync.RWMutex
-	sendCh           chan *pb.ClientMessage
+	chLock sync.RWMutex
+	// Only signals that sendQueue has messages, the queue keeps them in order
+	sendCh           chan struct{}
 	terminatingErrCh chan error
+
+	sendQueueLock sync.Mutex
+	sendQueue     []*pb.ClientMessage
 }
 
 func New(handler RequestHandler, stream pb.Host_StreamClient) Client {
@@ -53,19 +64,19 @@ func (c *client) Run() error {
 		c.chLock.Unlock()
 		return fmt.Errorf("Already running or have run")
 	}
-	c.sendCh = make(chan *pb.ClientMessage)
+	c.sendCh = make(chan struct{}, 1)
 	c.terminatingErrCh = make(chan error)
 	c.chLock.Unlock()
 	recvMsgCh := make(chan *pb.HostMessage)
-	recvErrCh := make(chan error)
+	recvErrCh := make(chan error, 1)
+	// Closed when done so the receiver stops
+	recvDoneCh := make(chan struct{})
 	// Close the chans when done
 	defer func() {
 		c.chLock.Lock()
 		defer c.chLock.Unlock()
-		close(c.sendCh)
 		close(c.terminatingErrCh)
-		close(recvMsgCh)
-		close(recvErrCh)
+		close(recvDoneCh)
 	}()
 	// Receive messages asynchronously
 	go func() {
@@ -74,7 +85,11 @@ func (c *client) Run() error {
 				recvErrCh <- err
 				break
 			} else {
-				recvMsgCh <- msg
+				select {
+				case recvMsgCh <- msg:
+				case <-recvDoneCh:
+					return
+				}
 			}
 		}
 	}()
@@ -83,8 +98,12 @@ func (c *client) Run() error {
 	// Stream requests and responses
 	for err == nil {
 		select {
-		case sendMsg := <-c.sendCh:
-			err = c.stream.Send(sendMsg)
+		case <-c.sendCh:
+			for _, sendMsg := range c.takeSendQueue() {
+				if err = c.stream.Send(sendMsg); err != nil {
+					break
+				}
+			}
 		case recvMsg := <-recvMsgCh:
 			switch recvMsg := recvMsg.Message.(type) {
 			case *pb.HostMessage_Welcome_:
@@ -97,6 +116,10 @@ func (c *client) Run() error {
 				err = c.handler.OnGameEvent(c.stream.Context(), recvMsg.GameEvent)
 			case *pb.HostMessage_Error_:
 				err = c.handler.OnError(c.stream.Context(), recvMsg.Error)
+			case *pb.HostMessage_OneLeftCallWindow_:
+				err = c.handler.OnOneLeftCallWindow(c.stream.Context(), recvMsg.OneLeftCallWindow)
+			case *pb.HostMessage_Resume_:
+				err = c.handler.OnResume(c.stream.Context(), recvMsg.Resume)
 			case *pb.HostMessage_PlayerRequest_:
 				err = c.doRPC(c.stream.Context(), recvMsg.PlayerRequest)
 			default:
@@ -116,10 +139,25 @@ func (c *client) SendNonBlocking(msg *pb.ClientMessage) error {
 	if c.sendCh == nil {
 		return fmt.Errorf("Not running")
 	}
-	go func(ch chan *pb.ClientMessage) { ch <- msg }(c.sendCh)
+	c.sendQueueLock.Lock()
+	c.sendQueue = append(c.sendQueue, msg)
+	c.sendQueueLock.Unlock()
+	// Signal, but if there is already a signal pending it will pick this up too
+	select {
+	case c.sendCh <- struct{}{}:
+	default:
+	}
 	return nil
 }
 
+func (c *client) takeSendQueue() []*pb.ClientMessage {
+	c.sendQueueLock.Lock()
+	defer c.sendQueueLock.Unlock()
+	ret := c.sendQueue
+	c.sendQueue = nil
+	return ret
+}
+
 func (c *client) FailNonBlocking(err error) error {
 	c.chLock.RLock()
 	defer c.chLock.RUnlock()
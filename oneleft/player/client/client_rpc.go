@@ -8,46 +8,67 @@ import (
 )
 
 func (c *client) doRPC(ctx context.Context, req *pb.HostMessage_PlayerRequest) error {
+	// Requests already answered get the same answer
+	if resp := c.handler.AnsweredRequest(req); resp != nil {
+		return c.SendNonBlocking(&pb.ClientMessage{Message: &pb.ClientMessage_PlayerResponse_{resp}})
+	}
 	switch msg := req.Message.(type) {
 	case *pb.HostMessage_PlayerRequest_JoinRequest:
 		resp, err := c.handler.Join(ctx, msg.JoinRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_GameStartRequest:
 		resp, err := c.handler.GameStart(ctx, msg.GameStartRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
+	case *pb.HostMessage_PlayerRequest_GameEndRequest:
+		resp, err := c.handler.GameEnd(ctx, msg.GameEndRequest)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_HandStartRequest:
 		resp, err := c.handler.HandStart(ctx, msg.HandStartRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
+	case *pb.HostMessage_PlayerRequest_HandEndRequest:
+		resp, err := c.handler.HandEnd(ctx, msg.HandEndRequest)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_ShuffleRequest:
 		resp, err := c.handler.Shuffle(ctx, msg.ShuffleRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_ChooseColorSinceFirstCardIsWildRequest:
 		resp, err := c.handler.ChooseColorSinceFirstCardIsWild(ctx, msg.ChooseColorSinceFirstCardIsWildRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_GetDeckTopDecryptionKeyRequest:
 		resp, err := c.handler.GetDeckTopDecryptionKey(ctx, msg.GetDeckTopDecryptionKeyRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_GiveDeckTopCardRequest:
 		resp, err := c.handler.GiveDeckTopCard(ctx, msg.GiveDeckTopCardRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_PlayRequest:
 		resp, err := c.handler.Play(ctx, msg.PlayRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_ShouldChallengeWildDrawFourRequest:
 		resp, err := c.handler.ShouldChallengeWildDrawFour(ctx, msg.ShouldChallengeWildDrawFourRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_RevealCardsForChallengeRequest:
 		resp, err := c.handler.RevealCardsForChallenge(ctx, msg.RevealCardsForChallengeRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	case *pb.HostMessage_PlayerRequest_RevealedCardsForChallengeRequest:
 		resp, err := c.handler.RevealedCardsForChallenge(ctx, msg.RevealedCardsForChallengeRequest)
-		return c.sendRPCResponse(resp, err)
+		return c.sendRPCResponse(ctx, req, resp, err)
+	case *pb.HostMessage_PlayerRequest_JumpInRequest:
+		resp, err := c.handler.JumpIn(ctx, msg.JumpInRequest)
+		return c.sendRPCResponse(ctx, req, resp, err)
+	case *pb.HostMessage_PlayerRequest_ChooseSwapTargetRequest:
+		resp, err := c.handler.ChooseSwapTarget(ctx, msg.ChooseSwapTargetRequest)
+		return c.sendRPCResponse(ctx, req, resp, err)
+	case *pb.HostMessage_PlayerRequest_MoveHandRequest:
+		resp, err := c.handler.MoveHand(ctx, msg.MoveHandRequest)
+		return c.sendRPCResponse(ctx, req, resp, err)
 	default:
 		return fmt.Errorf("Unrecognized message type: %T", msg)
 	}
 }
 
-func (c *client) sendRPCResponse(resp interface{}, err error) error {
+func (c *client) sendRPCResponse(
+	ctx context.Context, req *pb.HostMessage_PlayerRequest, resp interface{}, err error,
+) error {
 	if err != nil {
 		return err
 	}
@@ -57,8 +78,14 @@ func (c *client) sendRPCResponse(resp interface{}, err error) error {
 		playerResp.Message = &pb.ClientMessage_PlayerResponse_JoinResponse{resp}
 	case *pb.GameStartResponse:
 		playerResp.Message = &pb.ClientMessage_PlayerResponse_GameStartResponse{resp}
+	case *pb.GameEndResponse:
+		playerResp.Message = &pb.ClientMessage_PlayerResponse_GameEndResponse{resp}
 	case *pb.HandStartResponse:
 		playerResp.Message = &pb.ClientMessage_PlayerResponse_HandStartResponse{resp}
+	case *pb.HandEndResponse:
+		playerResp.Message = &pb.ClientMessage_PlayerResponse_HandEndResponse{resp}
+	case *pb.ShuffleResponse:
+		playerResp.Message = &pb.ClientMessage_PlayerResponse_ShuffleResponse{resp}
 	case *pb.ChooseColorSinceFirstCardIsWildResponse:
 		playerResp.Message = &pb.ClientMessage_PlayerResponse_ChooseColorSinceFirstCardIsWildResponse{resp}
 	case *pb.GetDeckTopDecryptionKeyResponse:
@@ -73,8 +100,17 @@ func (c *client) sendRPCResponse(resp interface{}, err error) error {
 		playerResp.Message = &pb.ClientMessage_PlayerResponse_RevealCardsForChallengeResponse{resp}
 	case *pb.RevealedCardsForChallengeResponse:
 		playerResp.Message = &pb.ClientMessage_PlayerResponse_RevealedCardsForChallengeResponse{resp}
+	case *pb.JumpInResponse:
+		playerResp.Message = &pb.ClientMessage_PlayerResponse_JumpInResponse{resp}
+	case *pb.ChooseSwapTargetResponse:
+		playerResp.Message = &pb.ClientMessage_PlayerResponse_ChooseSwapTargetResponse{resp}
+	case *pb.MoveHandResponse:
+		playerResp.Message = &pb.ClientMessage_PlayerResponse_MoveHandResponse{resp}
 	default:
 		return fmt.Errorf("Unrecognized client response type: %T", resp)
 	}
+	if err = c.handler.OnResponse(ctx, req, playerResp); err != nil {
+		return err
+	}
 	return c.SendNonBlocking(&pb.ClientMessage{Message: &pb.ClientMessage_PlayerResponse_{playerResp}})
 }
@@ -0,0 +1,639 @@
+package player
+
+import (
+	"fmt"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player/iface"
+)
+
+// eventReplay follows the game rules state from event to event so that any event which could not legally follow the
+// previous one can be rejected. It only knows what every player knows, so it can't check hidden things like which
+// card was drawn.
+type eventReplay struct {
+	playerCount int
+	rules       game.Rules
+	scoring     game.ScoringPolicy
+	dealerIndex int
+	lastEvent   *iface.GameEvent
+	handsPlayed int
+	// The last event before reshuffles. Draws that cause reshuffles have no events of their own, so card counts are
+	// checked against this instead of the reshuffle event.
+	beforeReshuffle *iface.GameEvent
+	// The player expected to play next, -1 if the hand is still starting
+	turnIndex int
+	// Whether the turn's player has already drawn a card for not playing
+	turnDrewOne bool
+	// Whether the turn's player took too long to play, so they only draw once before passing
+	turnTimedOut bool
+}
+
+func newEventReplay(playerCount int, rules game.Rules) *eventReplay {
+	return &eventReplay{
+		playerCount: playerCount,
+		rules:       rules,
+		scoring:     rules.ScoringPolicy(playerCount),
+		turnIndex:   -1,
+	}
+}
+
+// apply validates the event against the current state and, if valid, makes it the current state. The dealer index is
+// the one agreed upon for the current hand. The hand end score is the one agreed upon in the hand end or -1 if unknown.
+func (r *eventReplay) apply(event *iface.GameEvent, dealerIndex int, handEndScore int) error {
+	r.dealerIndex = dealerIndex
+	err := r.validateCommon(event)
+	if err == nil && event.Hand == nil {
+		err = r.validateGameEvent(event)
+	} else if err == nil {
+		err = r.validateHandEvent(event, handEndScore)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid %v event: %v", event.Type, err)
+	}
+	// Timeouts change nothing, so the events after them follow the one before
+	if event.Type == game.EventHandPlayerTimedOut {
+		return nil
+	}
+	// Update the state
+	if event.Type == game.EventHandReshuffled {
+		if r.beforeReshuffle == nil {
+			r.beforeReshuffle = r.lastEvent
+		}
+	} else {
+		r.beforeReshuffle = nil
+	}
+	r.lastEvent = event
+	return nil
+}
+
+func (r *eventReplay) validateCommon(event *iface.GameEvent) error {
+	if len(event.PlayerScores) != r.playerCount {
+		return fmt.Errorf("Invalid player score count")
+	} else if event.DealerIndex != r.dealerIndex {
+		return fmt.Errorf("Invalid dealer index")
+	} else if r.lastEvent != nil && r.lastEvent.GameID != event.GameID {
+		return fmt.Errorf("Game ID changed")
+	}
+	// Scores can only change on hand end
+	if event.Type != game.EventGameStart && event.Type != game.EventHandEnd {
+		if r.lastEvent == nil {
+			return fmt.Errorf("Missing game start")
+		}
+		for i, score := range event.PlayerScores {
+			if score != r.lastEvent.PlayerScores[i] {
+				return fmt.Errorf("Score changed")
+			}
+		}
+	}
+	if event.Hand != nil {
+		if len(event.Hand.PlayerCardsRemaining) != r.playerCount {
+			return fmt.Errorf("Invalid player card count size")
+		} else if event.Hand.PlayerIndex < 0 || event.Hand.PlayerIndex >= r.playerCount {
+			return fmt.Errorf("Invalid player index")
+		}
+		// Every card must be accounted for
+		total := event.Hand.DeckCardsRemaining + len(event.Hand.DiscardStack)
+		for _, count := range event.Hand.PlayerCardsRemaining {
+			total += count
+		}
+		if total != 108 {
+			return fmt.Errorf("Expected 108 total cards, got %v", total)
+		}
+		for _, card := range event.Hand.DiscardStack {
+			if !card.Valid() {
+				return fmt.Errorf("Invalid discard")
+			}
+		}
+		// Other than hand start, must be in the same hand
+		if event.Type != game.EventHandStartShuffled {
+			if r.lastEvent == nil || r.lastEvent.Hand == nil || r.lastEvent.Type == game.EventHandEnd {
+				return fmt.Errorf("Not in a hand")
+			} else if r.lastEvent.Hand.HandID != event.Hand.HandID {
+				return fmt.Errorf("Hand ID changed")
+			}
+		}
+	}
+	return nil
+}
+
+func (r *eventReplay) validateGameEvent(event *iface.GameEvent) error {
+	switch event.Type {
+	case game.EventGameStart:
+		if r.lastEvent != nil {
+			return fmt.Errorf("Game already started")
+		}
+		for _, score := range event.PlayerScores {
+			if score != 0 {
+				return fmt.Errorf("Score not 0")
+			}
+		}
+	case game.EventGameEnd:
+		if r.lastEvent == nil || r.lastEvent.Type != game.EventHandEnd {
+			return fmt.Errorf("Expected previous hand end")
+		}
+		if !r.gameOver(r.lastEvent) {
+			return fmt.Errorf("Game ended early")
+		}
+	default:
+		return fmt.Errorf("Missing hand")
+	}
+	return nil
+}
+
+func (r *eventReplay) validateHandEvent(event *iface.GameEvent, handEndScore int) error {
+	prev, curr := r.lastEvent, event.Hand
+	// The previous event with counts to compare to
+	countPrev := r.lastEvent
+	if r.beforeReshuffle != nil {
+		countPrev = r.beforeReshuffle
+	}
+	// Most events can't change direction, the draw penalty, or the discard
+	if event.Type != game.EventHandStartShuffled && event.Type != game.EventHandPlayReversed &&
+		curr.Forward != prev.Hand.Forward {
+		return fmt.Errorf("Direction changed")
+	} else if event.Type != game.EventHandStartShuffled && event.Type != game.EventHandPlayerPenaltyStacked &&
+		event.Type != game.EventHandPlayerPenaltyAbsorbed && curr.DrawPenalty != prev.Hand.DrawPenalty {
+		return fmt.Errorf("Draw penalty changed")
+	}
+	switch event.Type {
+	case game.EventHandStartShuffled, game.EventHandStartCardDealt, game.EventHandStartTopCardAddedToDiscard,
+		game.EventHandReshuffled, game.EventHandPlayerDiscarded, game.EventHandPlayerJumpedIn:
+	default:
+		if err := validateSameDiscard(prev.Hand, curr); err != nil {
+			return err
+		}
+	}
+	switch event.Type {
+	case game.EventHandStartShuffled:
+		switch {
+		case prev == nil:
+			return fmt.Errorf("Not at game start or hand end")
+		case prev.Type == game.EventHandEnd:
+			if r.gameOver(prev) {
+				return fmt.Errorf("Game should have ended")
+			}
+		case r.redealExpected(prev):
+			if prev.Hand.HandID != curr.HandID {
+				return fmt.Errorf("Hand ID changed")
+			}
+		case prev.Type != game.EventGameStart:
+			return fmt.Errorf("Not at game start or hand end")
+		}
+		if curr.PlayerIndex != r.dealerIndex || !curr.Forward || len(curr.DiscardStack) != 0 ||
+			curr.DeckCardsRemaining != 108 || curr.DrawPenalty != 0 {
+			return fmt.Errorf("Invalid hand start")
+		}
+		r.startTurn(-1)
+	case game.EventHandStartCardDealt:
+		if prev.Type != game.EventHandStartShuffled && prev.Type != game.EventHandStartCardDealt {
+			return fmt.Errorf("Not dealing")
+		} else if curr.PlayerIndex != r.nextIndex(prev.Hand.PlayerIndex, true) {
+			return fmt.Errorf("Dealt to wrong player")
+		} else if curr.PlayerCardsRemaining[curr.PlayerIndex] > r.rules.HandSize || len(curr.DiscardStack) != 0 {
+			return fmt.Errorf("Dealt too many")
+		}
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, 1)
+	case game.EventHandStartTopCardAddedToDiscard:
+		switch {
+		case prev.Type == game.EventHandStartCardDealt:
+			// Dealer is dealt last and the one after goes first
+			if prev.Hand.PlayerIndex != r.dealerIndex {
+				return fmt.Errorf("Dealing not complete")
+			} else if curr.PlayerIndex != r.nextIndex(r.dealerIndex, true) {
+				return fmt.Errorf("Wrong first player")
+			}
+		case prev.Type == game.EventHandStartTopCardAddedToDiscard && topCard(prev.Hand).Value() == game.WildDrawFour &&
+			r.rules.FirstWildDrawFour == game.FirstWildDrawFourRedraw:
+			if curr.PlayerIndex != prev.Hand.PlayerIndex {
+				return fmt.Errorf("Wrong first player")
+			}
+		default:
+			return fmt.Errorf("Not at hand start")
+		}
+		for _, count := range curr.PlayerCardsRemaining {
+			if count != r.rules.HandSize {
+				return fmt.Errorf("Not all dealt %v cards", r.rules.HandSize)
+			}
+		}
+		if err := validateDiscardAdded(prev.Hand, curr); err != nil {
+			return err
+		} else if topCard(curr).Value() == game.Wild && !curr.LastDiscardWildColor.Valid() {
+			return fmt.Errorf("Wild without color")
+		}
+		// Actions have their own events, others start the turn
+		switch topCard(curr).Value() {
+		case game.Skip, game.DrawTwo, game.Reverse, game.WildDrawFour:
+		default:
+			r.startTurn(curr.PlayerIndex)
+		}
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandReshuffled:
+		// Only the top discard stays, and only draws to a single player could have happened since
+		if len(curr.DiscardStack) != 1 || curr.DiscardStack[0] != topCard(prev.Hand) ||
+			curr.LastDiscardWildColor != prev.Hand.LastDiscardWildColor {
+			return fmt.Errorf("Invalid discard after reshuffle")
+		}
+		// A draw penalty being taken can be more than the usual max
+		maxDraw := maxDrawAmount
+		if countPrev.Hand.DrawPenalty > maxDraw {
+			maxDraw = countPrev.Hand.DrawPenalty
+		}
+		for i, count := range curr.PlayerCardsRemaining {
+			if diff := count - countPrev.Hand.PlayerCardsRemaining[i]; diff < 0 || diff > maxDraw {
+				return fmt.Errorf("Invalid card count change")
+			}
+		}
+	case game.EventHandPlayerSkipped:
+		if err := r.validateActionFollows(countPrev, curr, game.Skip); err != nil {
+			return err
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandPlayerDrewTwo:
+		// Played draw twos are stacked instead when stacking
+		if r.rules.StackDrawCards && isDiscard(countPrev) {
+			return fmt.Errorf("Expected penalty stacked")
+		} else if err := r.validateActionFollows(countPrev, curr, game.DrawTwo); err != nil {
+			return err
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, 2)
+	case game.EventHandPlayerReverseSkipped:
+		// With two players, a reverse skips the other player instead
+		if r.playerCount != 2 {
+			return fmt.Errorf("Reverse only skips with two players")
+		} else if err := r.validateActionFollows(countPrev, curr, game.Reverse); err != nil {
+			return err
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandPlayReversed:
+		if r.playerCount == 2 {
+			return fmt.Errorf("Reverse must skip with two players")
+		} else if countPrev.Type != game.EventHandStartTopCardAddedToDiscard && !isDiscard(countPrev) {
+			return fmt.Errorf("Not after discard")
+		} else if topCard(curr).Value() != game.Reverse {
+			return fmt.Errorf("Top card not reverse")
+		} else if curr.PlayerIndex != countPrev.Hand.PlayerIndex || curr.Forward == countPrev.Hand.Forward {
+			return fmt.Errorf("Invalid reverse")
+		}
+		// At hand start, the same player still goes first
+		if countPrev.Type == game.EventHandStartTopCardAddedToDiscard {
+			r.startTurn(curr.PlayerIndex)
+		} else {
+			r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		}
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandPlayerDrewOne:
+		if r.turnIndex != curr.PlayerIndex || (r.turnDrewOne && !r.rules.DrawUntilPlayable) {
+			return fmt.Errorf("Not player's turn to draw")
+		} else if curr.DrawPenalty > 0 {
+			return fmt.Errorf("Must stack or take draw penalty")
+		}
+		r.turnDrewOne = true
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, 1)
+	case game.EventHandPlayerPlayedNothing:
+		// Players must draw as much as the rules say unless there is nothing left to draw
+		if r.turnIndex != curr.PlayerIndex {
+			return fmt.Errorf("Not player's turn")
+		} else if curr.DrawPenalty > 0 {
+			return fmt.Errorf("Must stack or take draw penalty")
+		} else if canDraw(curr) && (!r.turnDrewOne || (r.rules.DrawUntilPlayable && !r.turnTimedOut)) {
+			return fmt.Errorf("Player didn't draw")
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandPlayerDiscarded, game.EventHandPlayerJumpedIn:
+		if event.Type == game.EventHandPlayerJumpedIn {
+			// Jumping in takes the turn of another player before they draw
+			if !r.rules.JumpIn {
+				return fmt.Errorf("Jumping in not allowed")
+			} else if r.turnIndex == -1 || r.turnIndex == curr.PlayerIndex || r.turnDrewOne {
+				return fmt.Errorf("Not another player's turn")
+			}
+		} else if r.turnIndex != curr.PlayerIndex {
+			return fmt.Errorf("Not player's turn")
+		} else if r.turnDrewOne && !r.rules.PlayDrawnCard {
+			return fmt.Errorf("Cannot play after drawing")
+		}
+		if err := validateDiscardAdded(prev.Hand, curr); err != nil {
+			return err
+		}
+		card := topCard(curr)
+		if event.Type == game.EventHandPlayerJumpedIn &&
+			(card.Wild() || !card.Identical(topCard(prev.Hand)) || prev.Hand.DrawPenalty > 0) {
+			return fmt.Errorf("Cannot jump in with %v on %v", card, topCard(prev.Hand))
+		}
+		if !card.CanPlayOn(topCard(prev.Hand), prev.Hand.LastDiscardWildColor) {
+			return fmt.Errorf("Cannot play %v on %v", card, topCard(prev.Hand))
+		} else if prev.Hand.DrawPenalty > 0 && !card.CanStackOn(topCard(prev.Hand)) {
+			return fmt.Errorf("Cannot stack %v on %v", card, topCard(prev.Hand))
+		} else if card.Wild() && !curr.LastDiscardWildColor.Valid() {
+			return fmt.Errorf("Wild without color")
+		}
+		// Actions have their own events, others end the turn
+		switch card.Value() {
+		case game.Skip, game.DrawTwo, game.Reverse, game.WildDrawFour:
+			r.turnIndex = -1
+		case 7, 0:
+			// Hands move when playing Seven-O unless the player went out
+			if r.rules.SevenO && curr.PlayerCardsRemaining[curr.PlayerIndex] > 0 {
+				r.turnIndex = -1
+			} else {
+				r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+			}
+		default:
+			r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		}
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, -1)
+	case game.EventHandPlayerNoChallengeDrewFour, game.EventHandPlayerChallengeFailedDrewSix:
+		// Wild draw fours at hand start are just reshuffled
+		if r.rules.StackDrawCards {
+			return fmt.Errorf("Expected penalty stacked")
+		} else if countPrev.Type != game.EventHandPlayerDiscarded {
+			return fmt.Errorf("Not after discard")
+		} else if err := r.validateActionFollows(countPrev, curr, game.WildDrawFour); err != nil {
+			return err
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		amount := 4
+		if event.Type == game.EventHandPlayerChallengeFailedDrewSix {
+			amount = 6
+		}
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, amount)
+	case game.EventHandPlayerChallengeSuccessDrewFour:
+		// The one that played it draws instead
+		if r.rules.StackDrawCards {
+			return fmt.Errorf("Expected penalty stacked")
+		} else if countPrev.Type != game.EventHandPlayerDiscarded || topCard(curr).Value() != game.WildDrawFour {
+			return fmt.Errorf("Not after wild draw four")
+		} else if curr.PlayerIndex != countPrev.Hand.PlayerIndex {
+			return fmt.Errorf("Wrong player drew")
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, 4)
+	case game.EventHandPlayerCalledOneLeft:
+		// Calls only happen before the turn's play
+		if r.turnIndex == -1 || r.turnDrewOne {
+			return fmt.Errorf("Call not at start of turn")
+		} else if curr.OneLeftTarget < 0 || curr.OneLeftTarget >= r.playerCount {
+			return fmt.Errorf("Invalid target")
+		} else if curr.PlayerCardsRemaining[curr.OneLeftTarget] != 1 && curr.OneLeftTarget != curr.PlayerIndex {
+			return fmt.Errorf("Called on player without one left")
+		}
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandPlayerOneLeftPenaltyDrewTwo:
+		// Penalty is for being called on or calling on yourself when not having one left
+		if countPrev.Type != game.EventHandPlayerCalledOneLeft {
+			return fmt.Errorf("Not after one left call")
+		} else if countPrev.Hand.PlayerIndex == countPrev.Hand.OneLeftTarget {
+			if curr.PlayerIndex != countPrev.Hand.PlayerIndex ||
+				countPrev.Hand.PlayerCardsRemaining[curr.PlayerIndex] == 1 {
+				return fmt.Errorf("Invalid self penalty")
+			}
+		} else if curr.PlayerIndex != countPrev.Hand.OneLeftTarget {
+			return fmt.Errorf("Penalty not for target")
+		}
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, 2)
+	case game.EventHandPlayerPenaltyStacked:
+		if !r.rules.StackDrawCards || !isDiscard(countPrev) {
+			return fmt.Errorf("Not after stacked discard")
+		} else if curr.PlayerIndex != countPrev.Hand.PlayerIndex {
+			return fmt.Errorf("Wrong player stacked")
+		}
+		amount := 2
+		if topCard(curr).Value() == game.WildDrawFour {
+			amount = 4
+		} else if topCard(curr).Value() != game.DrawTwo {
+			return fmt.Errorf("Top card not a draw card")
+		}
+		if curr.DrawPenalty != countPrev.Hand.DrawPenalty+amount {
+			return fmt.Errorf("Expected draw penalty of %v, got %v",
+				countPrev.Hand.DrawPenalty+amount, curr.DrawPenalty)
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, -1, 0)
+	case game.EventHandPlayerPenaltyAbsorbed:
+		if r.turnIndex != curr.PlayerIndex || r.turnDrewOne {
+			return fmt.Errorf("Not player's turn")
+		} else if countPrev.Hand.DrawPenalty == 0 || curr.DrawPenalty != 0 {
+			return fmt.Errorf("Invalid draw penalty")
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		return validateCountChange(countPrev.Hand, curr, curr.PlayerIndex, countPrev.Hand.DrawPenalty)
+	case game.EventHandPlayerSwappedHands, game.EventHandHandsRotated:
+		v := game.CardValue(0)
+		if event.Type == game.EventHandPlayerSwappedHands {
+			v = 7
+		}
+		if !r.rules.SevenO || !isDiscard(countPrev) {
+			return fmt.Errorf("Not after Seven-O discard")
+		} else if topCard(curr).Value() != v {
+			return fmt.Errorf("Top card not %v", v)
+		} else if curr.PlayerIndex != countPrev.Hand.PlayerIndex {
+			return fmt.Errorf("Wrong player moved hands")
+		} else if event.Type == game.EventHandPlayerSwappedHands &&
+			(curr.SwapTarget < 0 || curr.SwapTarget >= r.playerCount || curr.SwapTarget == curr.PlayerIndex) {
+			return fmt.Errorf("Invalid swap target")
+		}
+		r.startTurn(r.nextIndex(curr.PlayerIndex, curr.Forward))
+		for i, fromIndex := range handMoveFromIndexes(event) {
+			if expected := countPrev.Hand.PlayerCardsRemaining[fromIndex]; curr.PlayerCardsRemaining[i] != expected {
+				return fmt.Errorf("Expected player %v to have %v cards, got %v",
+					i, expected, curr.PlayerCardsRemaining[i])
+			}
+		}
+	case game.EventHandPlayerTimedOut:
+		// Nothing changes until the events for the default taken
+		if prev.Hand == nil || prev.Type == game.EventHandEnd || r.beforeReshuffle != nil {
+			return fmt.Errorf("Not during play")
+		} else if curr.PlayerIndex == r.turnIndex {
+			r.turnTimedOut = true
+		}
+		return validateCountChange(prev.Hand, curr, -1, 0)
+	case game.EventHandEnd:
+		if err := validateCountChange(countPrev.Hand, curr, -1, 0); err != nil {
+			return err
+		}
+		return r.validateHandEnd(event, handEndScore)
+	default:
+		return fmt.Errorf("Unknown type")
+	}
+	return nil
+}
+
+// Max that can be drawn by one player without an event, except for draw penalties
+const maxDrawAmount = 6
+
+func (r *eventReplay) validateHandEnd(event *iface.GameEvent, handEndScore int) error {
+	complete := event.HandComplete
+	if complete == nil {
+		return fmt.Errorf("Missing hand complete")
+	} else if complete.WinnerIndex < 0 || complete.WinnerIndex >= r.playerCount {
+		return fmt.Errorf("Invalid winner")
+	} else if event.Hand.PlayerCardsRemaining[complete.WinnerIndex] != 0 {
+		return fmt.Errorf("Winner has cards")
+	} else if handEndScore >= 0 && complete.Score != handEndScore {
+		return fmt.Errorf("Score does not match agreed upon score")
+	} else if len(complete.PlayerCards) != r.playerCount {
+		return fmt.Errorf("Invalid player card set")
+	}
+	// Score is the sum of the cards held by players on other teams
+	score := 0
+	for i, cards := range complete.PlayerCards {
+		if len(cards) != event.Hand.PlayerCardsRemaining[i] {
+			return fmt.Errorf("Player card count mismatch")
+		} else if r.rules.Teammates(i, complete.WinnerIndex, r.playerCount) {
+			continue
+		}
+		for _, card := range cards {
+			score += card.Score()
+		}
+	}
+	if score != complete.Score {
+		return fmt.Errorf("Invalid score")
+	}
+	// Score changes must be the ones the scoring policy gives
+	deltas := r.scoring.HandScoreDeltas(complete.WinnerIndex, complete.PlayerCards)
+	if len(complete.ScoreDeltas) != len(deltas) {
+		return fmt.Errorf("Invalid score delta count")
+	}
+	for i, playerScore := range event.PlayerScores {
+		if complete.ScoreDeltas[i] != deltas[i] {
+			return fmt.Errorf("Invalid score delta")
+		} else if playerScore != r.lastEvent.PlayerScores[i]+deltas[i] {
+			return fmt.Errorf("Invalid player score")
+		}
+	}
+	r.handsPlayed++
+	return nil
+}
+
+// validateActionFollows confirms the event comes right after the given action card was put on the discard and the
+// event's player is the one affected.
+func (r *eventReplay) validateActionFollows(prev *iface.GameEvent, curr *iface.GameEventHand, v game.CardValue) error {
+	if topCard(curr).Value() != v {
+		return fmt.Errorf("Top card not %v", v)
+	}
+	switch prev.Type {
+	case game.EventHandStartTopCardAddedToDiscard:
+		// At hand start, the first player is affected
+		if curr.PlayerIndex != prev.Hand.PlayerIndex {
+			return fmt.Errorf("Wrong player affected")
+		}
+	case game.EventHandPlayerDiscarded, game.EventHandPlayerJumpedIn:
+		if curr.PlayerIndex != r.nextIndex(prev.Hand.PlayerIndex, prev.Hand.Forward) {
+			return fmt.Errorf("Wrong player affected")
+		}
+	default:
+		return fmt.Errorf("Not after discard")
+	}
+	return nil
+}
+
+// gameOver is true if no more hands should be played after the given hand end
+func (r *eventReplay) gameOver(handEnd *iface.GameEvent) bool {
+	return r.rules.SingleHand || r.scoring.GameOver(handEnd.PlayerScores, r.handsPlayed)
+}
+
+// redealExpected is true if the event is a first discard that requires the cards to be dealt again
+func (r *eventReplay) redealExpected(event *iface.GameEvent) bool {
+	return event.Type == game.EventHandStartTopCardAddedToDiscard && topCard(event.Hand).Value() == game.WildDrawFour &&
+		r.rules.FirstWildDrawFour == game.FirstWildDrawFourReshuffle
+}
+
+func (r *eventReplay) startTurn(playerIndex int) {
+	r.turnIndex = playerIndex
+	r.turnDrewOne = false
+	r.turnTimedOut = false
+}
+
+func (r *eventReplay) nextIndex(index int, forward bool) int {
+	if forward {
+		return (index + 1) % r.playerCount
+	}
+	return (index - 1 + r.playerCount) % r.playerCount
+}
+
+// handMoveFromIndexes gives the index each player's hand came from for events that move hands, or nil for other events
+func handMoveFromIndexes(event *iface.GameEvent) []int {
+	if event.Hand == nil ||
+		(event.Type != game.EventHandPlayerSwappedHands && event.Type != game.EventHandHandsRotated) {
+		return nil
+	}
+	playerCount := len(event.Hand.PlayerCardsRemaining)
+	fromIndexes := make([]int, playerCount)
+	for i := range fromIndexes {
+		switch {
+		case event.Type == game.EventHandPlayerSwappedHands:
+			fromIndexes[i] = i
+		case event.Hand.Forward:
+			fromIndexes[i] = (i - 1 + playerCount) % playerCount
+		default:
+			fromIndexes[i] = (i + 1) % playerCount
+		}
+	}
+	if event.Type == game.EventHandPlayerSwappedHands {
+		playerIndex, target := event.Hand.PlayerIndex, event.Hand.SwapTarget
+		if target < 0 || target >= playerCount {
+			return nil
+		}
+		fromIndexes[playerIndex], fromIndexes[target] = target, playerIndex
+	}
+	return fromIndexes
+}
+
+// isDiscard is true if the event is a player putting a card on the discard, in turn or not
+func isDiscard(event *iface.GameEvent) bool {
+	return event.Type == game.EventHandPlayerDiscarded || event.Type == game.EventHandPlayerJumpedIn
+}
+
+func topCard(hand *iface.GameEventHand) game.Card {
+	if len(hand.DiscardStack) == 0 {
+		return game.NoCard
+	}
+	return hand.DiscardStack[len(hand.DiscardStack)-1]
+}
+
+// canDraw is false if there are no cards in the deck and none to reshuffle into it
+func canDraw(hand *iface.GameEventHand) bool {
+	return hand.DeckCardsRemaining > 0 || len(hand.DiscardStack) > 1
+}
+
+func validateSameDiscard(prev *iface.GameEventHand, curr *iface.GameEventHand) error {
+	if len(prev.DiscardStack) != len(curr.DiscardStack) {
+		return fmt.Errorf("Discard changed")
+	}
+	for i, card := range prev.DiscardStack {
+		if curr.DiscardStack[i] != card {
+			return fmt.Errorf("Discard changed")
+		}
+	}
+	return nil
+}
+
+func validateDiscardAdded(prev *iface.GameEventHand, curr *iface.GameEventHand) error {
+	if len(prev.DiscardStack)+1 != len(curr.DiscardStack) {
+		return fmt.Errorf("Expected a single card added to discard")
+	}
+	for i, card := range prev.DiscardStack {
+		if curr.DiscardStack[i] != card {
+			return fmt.Errorf("Discard changed")
+		}
+	}
+	return nil
+}
+
+// validateCountChange makes sure only the player at the index had the amount added to their card count
+func validateCountChange(prev *iface.GameEventHand, curr *iface.GameEventHand, playerIndex int, amount int) error {
+	for i, count := range curr.PlayerCardsRemaining {
+		expected := prev.PlayerCardsRemaining[i]
+		if i == playerIndex {
+			expected += amount
+		}
+		if count != expected {
+			return fmt.Errorf("Expected player %v to have %v cards, got %v", i, expected, count)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package player
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/game/gametest"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// playedEvents plays a game between practical players and returns its events as players see them
+func playedEvents(t *testing.T, seed int64, playerCount int, rules game.Rules) []*iface.GameEvent {
+	gameID, handID := uuid.New(), uuid.Nil
+	events := []*iface.GameEvent{}
+	_, err := gametest.Run(seed, playerCount, rules, func(event *game.Event) error {
+		ret := &iface.GameEvent{
+			GameID:       gameID,
+			Type:         event.Type,
+			PlayerScores: append([]int{}, event.PlayerScores...),
+			DealerIndex:  event.DealerIndex,
+		}
+		if hand := event.Hand; hand != nil {
+			// Cards dealt again keep the hand ID
+			if prev := events[len(events)-1]; event.Type == game.EventHandStartShuffled &&
+				(prev.Hand == nil || prev.Type == game.EventHandEnd) {
+				handID = uuid.New()
+			}
+			ret.Hand = &iface.GameEventHand{
+				HandID:               handID,
+				PlayerIndex:          hand.PlayerIndex,
+				PlayerCardsRemaining: append([]int{}, hand.PlayerCardsRemaining...),
+				DeckCardsRemaining:   hand.DeckCardsRemaining,
+				DiscardStack:         append([]game.Card{}, hand.DiscardStack...),
+				LastDiscardWildColor: hand.LastDiscardWildColor,
+				Forward:              hand.Forward,
+				OneLeftTarget:        hand.OneLeftTarget,
+				DrawPenalty:          hand.DrawPenalty,
+				SwapTarget:           hand.SwapTarget,
+			}
+		}
+		if complete := event.HandComplete; complete != nil {
+			ret.HandComplete = &iface.GameEventHandComplete{
+				WinnerIndex: complete.WinnerIndex,
+				Score:       complete.Score,
+				PlayerCards: complete.DeckReveal.PlayerCards(),
+				ScoreDeltas: complete.ScoreDeltas,
+			}
+		}
+		events = append(events, ret)
+		return nil
+	})
+	require.NoError(t, err)
+	return events
+}
+
+// applyEvent applies the event with the dealer index and hand end score the players agreed upon
+func applyEvent(replay *eventReplay, event *iface.GameEvent) error {
+	handEndScore := -1
+	if event.HandComplete != nil {
+		handEndScore = event.HandComplete.Score
+	}
+	return replay.apply(event, event.DealerIndex, handEndScore)
+}
+
+func TestEventReplayValid(t *testing.T) {
+	stacking := game.DefaultRules()
+	stacking.JumpIn, stacking.SevenO, stacking.StackDrawCards = true, true, true
+	drawUntilPlayable := game.DefaultRules()
+	drawUntilPlayable.DrawUntilPlayable, drawUntilPlayable.FirstWildDrawFour = true, game.FirstWildDrawFourReshuffle
+	partners := game.DefaultRules()
+	partners.Partners = true
+	fixedHands := game.DefaultRules()
+	fixedHands.Scoring, fixedHands.HandCount = game.ScoringFixedHands, 3
+	tests := map[string]game.Rules{
+		"default":                         game.DefaultRules(),
+		"jump-in, seven-o, and stacking":  stacking,
+		"draw until playable, reshuffled": drawUntilPlayable,
+		"partners":                        partners,
+		"fixed hands":                     fixedHands,
+	}
+	for name, rules := range tests {
+		for seed := int64(0); seed < 3; seed++ {
+			events := playedEvents(t, seed, 4, rules)
+			replay := newEventReplay(4, rules)
+			for i, event := range events {
+				require.NoError(t, applyEvent(replay, event), "%v, seed %v, event %v", name, seed, i)
+			}
+			require.Equal(t, game.EventGameEnd, events[len(events)-1].Type)
+		}
+	}
+}
+
+func TestEventReplayInvalid(t *testing.T) {
+	rules := game.DefaultRules()
+	events := playedEvents(t, 0, 4, rules)
+	tests := []struct {
+		name string
+		// Changes the event and returns true, or returns false if the event can't be changed this way
+		tamper func(event *iface.GameEvent, prev *iface.GameEvent) bool
+		err    string
+	}{
+		{"wrong next player", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandPlayerDiscarded {
+				return false
+			}
+			event.Hand.PlayerIndex = (event.Hand.PlayerIndex + 1) % 4
+			return true
+		}, "Invalid HandPlayerDiscarded event: Not player's turn"},
+		{"discard that can't be played", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandPlayerDiscarded || topCard(prev.Hand).Wild() {
+				return false
+			}
+			// Any non-wild card that can't be played on the top discard
+			for card := game.Card(0); card < 100; card++ {
+				if !card.CanPlayOn(topCard(prev.Hand), prev.Hand.LastDiscardWildColor) {
+					event.Hand.DiscardStack[len(event.Hand.DiscardStack)-1] = card
+					return true
+				}
+			}
+			return false
+		}, "Invalid HandPlayerDiscarded event: Cannot play"},
+		{"bad draw count", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandPlayerDrewOne {
+				return false
+			}
+			event.Hand.PlayerCardsRemaining[event.Hand.PlayerIndex]++
+			event.Hand.DeckCardsRemaining--
+			return true
+		}, "Invalid HandPlayerDrewOne event: Expected player"},
+		{"wrong score", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandEnd {
+				return false
+			}
+			event.HandComplete.Score++
+			return true
+		}, "Invalid HandEnd event: Score does not match agreed upon score"},
+		{"score changed mid-hand", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandPlayerDiscarded {
+				return false
+			}
+			event.PlayerScores[0]++
+			return true
+		}, "Invalid HandPlayerDiscarded event: Score changed"},
+		{"cards missing", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandPlayerDiscarded {
+				return false
+			}
+			event.Hand.DeckCardsRemaining--
+			return true
+		}, "Invalid HandPlayerDiscarded event: Expected 108 total cards, got 107"},
+		{"cards added", func(event *iface.GameEvent, prev *iface.GameEvent) bool {
+			if event.Type != game.EventHandPlayerDrewOne {
+				return false
+			}
+			event.Hand.PlayerCardsRemaining[event.Hand.PlayerIndex]++
+			return true
+		}, "Invalid HandPlayerDrewOne event: Expected 108 total cards, got 109"},
+	}
+	for _, test := range tests {
+		replay := newEventReplay(4, rules)
+		tampered := false
+		for i, event := range events {
+			if i > 0 {
+				event = cloneEvent(event)
+				// The hand end score is the agreed upon one, so tampering with it makes it not match
+				handEndScore := -1
+				if event.HandComplete != nil {
+					handEndScore = event.HandComplete.Score
+				}
+				if test.tamper(event, events[i-1]) {
+					err := replay.apply(event, event.DealerIndex, handEndScore)
+					require.Error(t, err, test.name)
+					require.True(t, strings.HasPrefix(err.Error(), test.err), "%v: %v", test.name, err)
+					tampered = true
+					break
+				}
+			}
+			require.NoError(t, applyEvent(replay, events[i]), test.name)
+		}
+		require.True(t, tampered, "%v: no event to tamper with", test.name)
+	}
+}
+
+func cloneEvent(event *iface.GameEvent) *iface.GameEvent {
+	ret := *event
+	ret.PlayerScores = append([]int{}, event.PlayerScores...)
+	if event.Hand != nil {
+		hand := *event.Hand
+		hand.PlayerCardsRemaining = append([]int{}, event.Hand.PlayerCardsRemaining...)
+		hand.DiscardStack = append([]game.Card{}, event.Hand.DiscardStack...)
+		ret.Hand = &hand
+	}
+	if event.HandComplete != nil {
+		complete := *event.HandComplete
+		ret.HandComplete = &complete
+	}
+	return &ret
+}
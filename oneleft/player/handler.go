@@ -6,53 +6,124 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cretz/one-left/oneleft/crypto"
 	"github.com/cretz/one-left/oneleft/crypto/sra"
 	"github.com/cretz/one-left/oneleft/game"
 	"github.com/cretz/one-left/oneleft/pb"
 	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
 	"github.com/google/uuid"
 )
 
 type handler struct {
-	player *player
-	ui     iface.Interface
+	player             *player
+	ui                 iface.Interface
+	maxIfaceHandleTime time.Duration
+	// Nil if no transcript is written
+	transcript *transcript.Writer
+	// Empty if no state is kept
+	stateFile          string
+	minSharedPrimeBits int
+	sraOnly            bool
 
 	dataLock           sync.RWMutex
 	myIndex            int
-	sharedPrime        *big.Int
-	shuffleStage0Pair  *sra.KeyPair
-	shuffleStage1Pairs []*sra.KeyPair
+	rules              game.Rules
+	cardCipher         crypto.CardCipher
+	cardEncoding       *sra.CardEncoding
+	shuffleStage0Pair  crypto.KeyPair
+	shuffleStage1Pairs []crypto.KeyPair
 	// Key is enc card string
-	cardPairs                    map[string]*sra.KeyPair
+	cardPairs map[string]crypto.KeyPair
+	// Key is enc card string, by player index
+	decryptionKeyCommitments     map[string][]*big.Int
 	encryptedDeckCards           []*big.Int
 	encryptedCardsGivenToPlayers map[string]int
 	myCards                      []*myCardInfo
 	lastEvent                    *iface.GameEvent
-	lastGameStart                *pb.GameStartRequest
-	lastHandStart                *pb.HandStartRequest
-	lastHandEnd                  *pb.HandEndRequest
-	firstUnencryptedStartCards   []uint32
-	lastHandID                   uuid.UUID
+	colorBeforeLastDiscard       game.CardColor
+	// The card last played or jumped in with until it's discarded, given back if the host took the default instead
+	unconfirmedCard *myCardInfo
+	// Temporary pairs to mask the moving key with when moving hands, nil when not moving
+	moveHandGivePair  *sra.KeyPair
+	moveHandTakePair  *sra.KeyPair
+	moveHandFromIndex int
+	moveHandToIndex   int
+	// How long the host gives us to decide in our turn, 0 if forever
+	turnTimeout time.Duration
+	// Nil when not playing in a game
+	replay                     *eventReplay
+	lastGameStart              *pb.GameStartRequest
+	lastHandStart              *pb.HandStartRequest
+	lastHandEnd                *pb.HandEndRequest
+	firstUnencryptedStartCards []uint32
+	lastHandID                 uuid.UUID
+	// How many events of the game we've seen, to tell the host which ones we missed when resuming
+	gameEventsSeen int
+	// The last requests in the game we answered, to answer the same if they're sent again after reconnecting
+	answeredRequests []*pb.PlayerState_AnsweredRequest
 }
 
 type myCardInfo struct {
 	card           game.Card
 	encryptedCard  *big.Int
 	decryptionKeys []*big.Int
+	// The player the card was dealt to, whose key is the only one the host hasn't seen
+	dealtToIndex int
 }
 
-// TODO: config
-const maxIfaceHandleTime = 1 * time.Minute
-const sraKeyPairBits = 32
+func (m *myCardInfo) decryptionKeyBytes() [][]byte {
+	ret := make([][]byte, len(m.decryptionKeys))
+	for i, decKey := range m.decryptionKeys {
+		ret[i] = decKey.Bytes()
+	}
+	return ret
+}
+
+// minPrimeBitLen is the smallest shared prime accepted no matter the config
 const minPrimeBitLen = 128
 
+// turnContext is the context for the UI to decide something in our turn, which ends when the host's turn timeout
+// does if that's sooner than the usual UI time
+func (p *handler) turnContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	p.dataLock.RLock()
+	timeout := p.turnTimeout
+	p.dataLock.RUnlock()
+	if timeout == 0 || timeout > p.maxIfaceHandleTime {
+		timeout = p.maxIfaceHandleTime
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// turnTimedOut is true if the UI failed to decide something in our turn because it ran out of time while the host has
+// a turn timeout. The host takes the default for us then, so the response only has to be valid and is ignored if late.
+func (p *handler) turnTimedOut(ctx context.Context, err error) bool {
+	p.dataLock.RLock()
+	defer p.dataLock.RUnlock()
+	return err != nil && p.turnTimeout > 0 && ctx.Err() == context.DeadlineExceeded
+}
+
+// writeTranscript appends the entry to the transcript if there is one
+func (p *handler) writeTranscript(entry *pb.TranscriptEntry) error {
+	if p.transcript == nil {
+		return nil
+	}
+	return p.transcript.Write(entry)
+}
+
+// ownSigs is the signatures by player index with only ours set, since the others are only seen in later requests
+func ownSigs(players []*pb.PlayerIdentity, myIndex int, sig []byte) [][]byte {
+	ret := make([][]byte, len(players))
+	ret[myIndex] = sig
+	return ret
+}
+
 func (p *handler) OnRun(ctx context.Context) error {
-	// Do nothing
-	return nil
+	return p.loadState()
 }
 
 func (p *handler) OnWelcome(ctx context.Context, v *pb.HostMessage_Welcome) error {
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if players, err := convertPlayers(v.Players); err != nil {
 		return err
@@ -60,13 +131,16 @@ func (p *handler) OnWelcome(ctx context.Context, v *pb.HostMessage_Welcome) erro
 		return err
 	} else if lastEvent, err := convertGameEvent(v.LastGameEvent); err != nil {
 		return err
-	} else {
-		return p.ui.Connected(ctx, players, chatMessages, lastEvent)
+	} else if err := p.ui.Connected(ctx, players, chatMessages, lastEvent); err != nil {
+		return err
+	} else if p.player.joinOnWelcome {
+		return p.player.Join()
 	}
+	return nil
 }
 
 func (p *handler) OnPlayersUpdate(ctx context.Context, v *pb.HostMessage_Players) error {
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if players, err := convertPlayers(v.Players); err != nil {
 		return err
@@ -76,7 +150,7 @@ func (p *handler) OnPlayersUpdate(ctx context.Context, v *pb.HostMessage_Players
 }
 
 func (p *handler) OnChatMessage(ctx context.Context, v *pb.ChatMessage) error {
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if msg, err := convertChatMessage(v); err != nil {
 		return err
@@ -85,8 +159,14 @@ func (p *handler) OnChatMessage(ctx context.Context, v *pb.ChatMessage) error {
 	}
 }
 
+func (p *handler) OnOneLeftCallWindow(ctx context.Context, v *pb.HostMessage_OneLeftCallWindow) error {
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
+	defer cancelFn()
+	return p.ui.OneLeftCallWindow(ctx, int(v.PlayerIndex))
+}
+
 func (p *handler) OnError(ctx context.Context, v *pb.HostMessage_Error) error {
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if e, err := convertError(v); err != nil {
 		return err
@@ -1,31 +1,126 @@
 package player
 
 import (
+	"bytes"
 	"context"
 
+	"github.com/cretz/one-left/oneleft/game"
 	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
 
 	"github.com/cretz/one-left/oneleft/pb"
 )
 
 func (p *handler) OnGameEvent(ctx context.Context, v *pb.HostMessage_GameEvent) error {
-	// TODO: validate every event in the context of the game and determine accuracy
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if event, err := convertGameEvent(v); err != nil {
 		return err
+	} else if err := p.validateEvent(event); err != nil {
+		return err
+	} else if err := p.writeEventTranscript(v); err != nil {
+		return err
 	} else {
 		p.dataLock.Lock()
-		p.lastEvent = event
+		if p.replay != nil && bytes.Equal(p.lastGameStart.Id, v.GameId) {
+			p.gameEventsSeen++
+		}
+		// Keep the color in play before a discard for wild draw four challenges
+		if event.Type == game.EventHandPlayerDiscarded {
+			p.colorBeforeLastDiscard = topDiscardColor(p.lastEvent)
+		}
+		// Cards given to players go with the hands that moved
+		if fromIndexes := handMoveFromIndexes(event); fromIndexes != nil {
+			toIndexes := make([]int, len(fromIndexes))
+			for i, fromIndex := range fromIndexes {
+				toIndexes[fromIndex] = i
+			}
+			for encCardStr, playerIndex := range p.encryptedCardsGivenToPlayers {
+				p.encryptedCardsGivenToPlayers[encCardStr] = toIndexes[playerIndex]
+			}
+		}
+		// Our played card is given back if the host took the default for us instead, or if another player jumped in
+		// before it came
+		var restoredCard *myCardInfo
+		if event.Hand != nil && p.unconfirmedCard != nil {
+			switch {
+			case event.Hand.PlayerIndex == p.myIndex && event.Type == game.EventHandPlayerTimedOut,
+				event.Hand.PlayerIndex != p.myIndex && event.Type == game.EventHandPlayerJumpedIn:
+				restoredCard = p.unconfirmedCard
+				p.myCards = append(p.myCards, restoredCard)
+				p.unconfirmedCard = nil
+			case event.Hand.PlayerIndex == p.myIndex && isDiscard(event):
+				p.unconfirmedCard = nil
+			}
+		}
+		// Timeouts change nothing, so requests after them check the event before
+		if event.Type != game.EventHandPlayerTimedOut {
+			p.lastEvent = event
+		}
 		p.dataLock.Unlock()
-		return p.ui.GameEvent(ctx, event)
+		if restoredCard != nil {
+			if err := p.ui.ReceiveCard(ctx, restoredCard.card); err != nil {
+				return err
+			}
+		}
+		if err := p.ui.GameEvent(ctx, event); err != nil {
+			return err
+		}
+		return p.saveState()
 	}
 }
 
 func (p *handler) validateEvent(event *iface.GameEvent) error {
+	p.dataLock.Lock()
+	defer p.dataLock.Unlock()
+	// We can only validate games we are playing in
+	if p.replay == nil {
+		return nil
+	}
+	dealerIndex := 0
+	if p.lastHandStart != nil {
+		dealerIndex = int(p.lastHandStart.DealerIndex)
+	}
+	handEndScore := -1
+	if event.Type == game.EventHandEnd && p.lastHandEnd != nil {
+		handEndScore = int(p.lastHandEnd.Score)
+	}
+	if err := p.replay.apply(event, dealerIndex, handEndScore); err != nil {
+		return err
+	}
+	if event.Type == game.EventGameEnd {
+		p.replay = nil
+	}
+	return nil
+}
+
+// writeEventTranscript writes the event to the transcript if it's for a game we are playing in
+func (p *handler) writeEventTranscript(v *pb.HostMessage_GameEvent) error {
 	p.dataLock.RLock()
-	// lastEvent := p.lastEvent
+	lastGameStart := p.lastGameStart
 	p.dataLock.RUnlock()
-	// TODO
-	return nil
+	if lastGameStart == nil || !bytes.Equal(lastGameStart.Id, v.GameId) {
+		return nil
+	}
+	return p.writeTranscript(transcript.GameEventEntry(v))
+}
+
+func topDiscardColor(event *iface.GameEvent) game.CardColor {
+	if event == nil || event.Hand == nil || len(event.Hand.DiscardStack) == 0 {
+		return game.ColorUnknown
+	}
+	return game.CurrentColor(event.Hand.DiscardStack[len(event.Hand.DiscardStack)-1], event.Hand.LastDiscardWildColor)
+}
+
+func nextPlayerIndex(hand *iface.GameEventHand) int {
+	if hand.Forward {
+		if hand.PlayerIndex == len(hand.PlayerCardsRemaining)-1 {
+			return 0
+		}
+		return hand.PlayerIndex + 1
+	}
+	if hand.PlayerIndex == 0 {
+		return len(hand.PlayerCardsRemaining) - 1
+	}
+	return hand.PlayerIndex - 1
 }
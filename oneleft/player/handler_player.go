@@ -3,18 +3,19 @@ package player
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
 	"fmt"
 	"math/big"
 	"sort"
+	"time"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
 
 	"github.com/cretz/one-left/oneleft/crypto"
 	"github.com/cretz/one-left/oneleft/crypto/sra"
 	"github.com/cretz/one-left/oneleft/game"
 	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
 )
 
 func (p *handler) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinResponse, error) {
@@ -27,7 +28,18 @@ func (p *handler) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinRespon
 	if ident.Sig, err = p.player.signProto(ident); err != nil {
 		return nil, err
 	}
-	return &pb.JoinResponse{Player: ident}, nil
+	resp := &pb.JoinResponse{Player: ident, MinSharedCardPrimeBits: uint32(p.minSharedPrimeBits)}
+	if !p.sraOnly {
+		resp.CardCiphers = []pb.CardCipher{pb.CardCipher_EC_P256}
+	}
+	// Ask to resume the game we're still playing in
+	p.dataLock.RLock()
+	if p.replay != nil && p.lastGameStart != nil {
+		resp.ResumeGameId = p.lastGameStart.Id
+		resp.ResumeGameEventsSeen = uint32(p.gameEventsSeen)
+	}
+	p.dataLock.RUnlock()
+	return resp, nil
 }
 
 func (p *handler) GameStart(ctx context.Context, req *pb.GameStartRequest) (*pb.GameStartResponse, error) {
@@ -42,33 +54,64 @@ func (p *handler) GameStart(ctx context.Context, req *pb.GameStartRequest) (*pb.
 	if myIndex == -1 {
 		return nil, fmt.Errorf("Unable to find myself")
 	}
+	// Make sure the rules are playable
+	rules, err := convertRules(req.Rules)
+	if err != nil {
+		return nil, err
+	} else if err = rules.Validate(len(req.Players)); err != nil {
+		return nil, fmt.Errorf("Invalid rules: %v", err)
+	}
+	// Every hand's prime has to be the size we accept
+	if req.SharedCardPrimeBits < minPrimeBitLen || int(req.SharedCardPrimeBits) < p.minSharedPrimeBits {
+		return nil, fmt.Errorf("Shared prime size of %v bits is below our minimum", req.SharedCardPrimeBits)
+	}
+	// Make sure the teams are the ones the rules give
+	if len(req.PlayerTeams) != len(req.Players) {
+		return nil, fmt.Errorf("Invalid player teams")
+	}
+	for i, team := range rules.PlayerTeams(len(req.Players)) {
+		if req.PlayerTeams[i] != uint32(team) {
+			return nil, fmt.Errorf("Invalid team for player %v", i)
+		}
+	}
 	// Update data
 	p.dataLock.Lock()
 	p.myIndex = myIndex
-	p.sharedPrime = nil
+	p.rules = rules
+	p.cardCipher = nil
 	p.shuffleStage0Pair = nil
 	p.shuffleStage1Pairs = nil
-	p.cardPairs = map[string]*sra.KeyPair{}
+	p.cardPairs = map[string]crypto.KeyPair{}
+	p.decryptionKeyCommitments = map[string][]*big.Int{}
 	p.encryptedDeckCards = nil
 	p.encryptedCardsGivenToPlayers = map[string]int{}
 	p.myCards = nil
+	p.unconfirmedCard = nil
+	p.moveHandGivePair = nil
+	p.moveHandTakePair = nil
+	p.turnTimeout = time.Duration(req.TurnTimeoutMs) * time.Millisecond
 	p.lastEvent = nil
+	p.replay = newEventReplay(len(req.Players), rules)
 	p.lastGameStart = req
 	p.lastHandStart = nil
 	p.lastHandEnd = nil
 	p.firstUnencryptedStartCards = nil
+	p.gameEventsSeen = 0
+	p.answeredRequests = nil
 	p.dataLock.Unlock()
 
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if id, err := uuid.FromBytes(req.Id); err != nil {
 		return nil, err
 	} else if players, err := convertPlayers(req.Players); err != nil {
 		return nil, err
-	} else if err := p.ui.GameStart(ctx, id, players); err != nil {
+	} else if err := p.ui.GameStart(ctx, id, players, rules); err != nil {
 		return nil, err
 	} else if sig, err := p.player.signProto(req); err != nil {
 		return nil, err
+	} else if err := p.writeTranscript(transcript.GameStartEntry(req, ownSigs(req.Players, myIndex, sig))); err != nil {
+		return nil, err
 	} else {
 		return &pb.GameStartResponse{Sig: sig}, nil
 	}
@@ -76,21 +119,18 @@ func (p *handler) GameStart(ctx context.Context, req *pb.GameStartRequest) (*pb.
 
 func (p *handler) GameEnd(ctx context.Context, req *pb.GameEndRequest) (*pb.GameEndResponse, error) {
 	p.dataLock.RLock()
+	myIndex := p.myIndex
 	lastEvent := p.lastEvent
 	lastGameStart := p.lastGameStart
 	lastHandEnd := p.lastHandEnd
+	rules := p.rules
 	p.dataLock.RUnlock()
 	if lastEvent == nil || lastHandEnd == nil {
 		return nil, fmt.Errorf("Missing hand end")
 	}
 	// Make sure scores are what we saw last event (validation is deferred to event handling)
-	if len(lastEvent.PlayerScores) != len(req.PlayerScores) {
-		return nil, fmt.Errorf("Player score size mismatch")
-	}
-	for i, s := range lastEvent.PlayerScores {
-		if uint32(s) != req.PlayerScores[i] {
-			return nil, fmt.Errorf("Invalid player score")
-		}
+	if err := validatePlayerScores(rules, lastEvent.PlayerScores, req.PlayerScores); err != nil {
+		return nil, err
 	}
 	// Check the sigs of all hand ends
 	if err := p.validateHandEndSigs(lastHandEnd, lastGameStart, req.LastHandEndPlayerSigs); err != nil {
@@ -101,8 +141,11 @@ func (p *handler) GameEnd(ctx context.Context, req *pb.GameEndRequest) (*pb.Game
 	if err != nil {
 		return nil, err
 	}
+	if err = p.writeTranscript(transcript.GameEndEntry(req, ownSigs(lastGameStart.Players, myIndex, sig))); err != nil {
+		return nil, err
+	}
 	// Call downstream
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if err := p.ui.GameEnd(ctx, lastEvent.PlayerScores); err != nil {
 		return nil, err
@@ -111,10 +154,31 @@ func (p *handler) GameEnd(ctx context.Context, req *pb.GameEndRequest) (*pb.Game
 }
 
 func (p *handler) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.HandStartResponse, error) {
-	// Check prime (n = 20 like the crypto rand's prime generator)
+	// Check the cipher is one we accept and, for SRA, the prime is a safe one of the agreed size
+	p.dataLock.RLock()
+	sharedPrimeBits := -1
+	if p.lastGameStart != nil {
+		sharedPrimeBits = int(p.lastGameStart.SharedCardPrimeBits)
+	}
+	p.dataLock.RUnlock()
 	sharedPrime := new(big.Int).SetBytes(req.SharedCardPrime)
-	if sharedPrime.BitLen() < minPrimeBitLen || !sharedPrime.ProbablyPrime(20) {
-		return nil, fmt.Errorf("Invalid shared prime")
+	switch req.CardCipher {
+	case pb.CardCipher_SRA:
+		if sharedPrime.BitLen() != sharedPrimeBits || !sra.IsSafePrime(sharedPrime) {
+			return nil, fmt.Errorf("Invalid shared prime")
+		}
+	case pb.CardCipher_EC_P256:
+		if p.sraOnly {
+			return nil, fmt.Errorf("Card cipher %v not accepted", req.CardCipher)
+		}
+	}
+	cardCipher, err := crypto.NewCardCipher(req.CardCipher, sharedPrime)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid card cipher: %v", err)
+	}
+	cardEncoding, err := crypto.NewCardEncoding(cardCipher, req.CardEncodingSeed)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid card encoding: %v", err)
 	}
 	// Get hand ID
 	handID, err := uuid.FromBytes(req.Id)
@@ -123,13 +187,17 @@ func (p *handler) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.
 	}
 	// Grab some data, set some data
 	p.dataLock.Lock()
+	myIndex := p.myIndex
 	lastEvent := p.lastEvent
 	lastGameStart := p.lastGameStart
 	lastHandEnd := p.lastHandEnd
 	lastHandStart := p.lastHandStart
-	p.sharedPrime = sharedPrime
+	rules := p.rules
+	p.cardCipher = cardCipher
+	p.cardEncoding = cardEncoding
 	p.lastHandStart = req
 	p.lastHandID = handID
+	p.resetCardsUnsafe()
 	p.dataLock.Unlock()
 	// Do some validation
 	if lastEvent == nil {
@@ -139,14 +207,8 @@ func (p *handler) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.
 	} else if lastGameStart == nil ||
 		(lastEvent.Type == game.EventHandEnd && (lastHandEnd == nil || lastHandStart == nil)) {
 		return nil, fmt.Errorf("Missing previous game start or hand start/end")
-	} else if len(lastEvent.PlayerScores) != len(req.PlayerScores) {
-		return nil, fmt.Errorf("Invalid player scores")
-	}
-	// Check scores
-	for i, s := range lastEvent.PlayerScores {
-		if req.PlayerScores[i] != uint32(s) {
-			return nil, fmt.Errorf("Invalid player score")
-		}
+	} else if err := validatePlayerScores(rules, lastEvent.PlayerScores, req.PlayerScores); err != nil {
+		return nil, err
 	}
 	// Check dealer index
 	expectedDealerIndex := uint32(0)
@@ -162,7 +224,7 @@ func (p *handler) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.
 	if len(req.GameStartPlayerSigs) != len(lastGameStart.Players) {
 		return nil, fmt.Errorf("Invalid game start sigs")
 	}
-	gameStartBytes, err := proto.Marshal(lastGameStart)
+	gameStartBytes, err := pb.MarshalForSig(lastGameStart)
 	if err != nil {
 		return nil, fmt.Errorf("Failed marshalling: %v", err)
 	}
@@ -182,8 +244,12 @@ func (p *handler) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.
 	if resp.Sig, err = p.player.signProto(req); err != nil {
 		return nil, err
 	}
+	entry := transcript.HandStartEntry(req, ownSigs(lastGameStart.Players, myIndex, resp.Sig))
+	if err = p.writeTranscript(entry); err != nil {
+		return nil, err
+	}
 	// Call downstream
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if err := p.ui.HandStart(ctx, int(req.DealerIndex)); err != nil {
 		return nil, err
@@ -191,6 +257,40 @@ func (p *handler) HandStart(ctx context.Context, req *pb.HandStartRequest) (*pb.
 	return resp, nil
 }
 
+// resetCardsUnsafe clears the card state, which is per hand (or per deal if dealt again). Unsafe because it expects
+// callers to lock.
+func (p *handler) resetCardsUnsafe() {
+	p.shuffleStage0Pair = nil
+	p.shuffleStage1Pairs = nil
+	p.cardPairs = map[string]crypto.KeyPair{}
+	p.decryptionKeyCommitments = map[string][]*big.Int{}
+	p.encryptedDeckCards = nil
+	p.encryptedCardsGivenToPlayers = map[string]int{}
+	p.myCards = nil
+	p.unconfirmedCard = nil
+	p.moveHandGivePair = nil
+	p.moveHandTakePair = nil
+	p.firstUnencryptedStartCards = nil
+}
+
+// validatePlayerScores makes sure the scores are the ones from the last event and that partners share a team total
+func validatePlayerScores(rules game.Rules, lastEventScores []int, scores []uint32) error {
+	if len(lastEventScores) != len(scores) {
+		return fmt.Errorf("Invalid player scores")
+	}
+	for i, s := range lastEventScores {
+		if scores[i] != uint32(s) {
+			return fmt.Errorf("Invalid player score")
+		}
+		for j := 0; j < i; j++ {
+			if rules.Teammates(i, j, len(scores)) && scores[i] != scores[j] {
+				return fmt.Errorf("Partners %v and %v have different scores", j, i)
+			}
+		}
+	}
+	return nil
+}
+
 func (p *handler) validateHandEndSigs(
 	lastHandEnd *pb.HandEndRequest, lastGameStart *pb.GameStartRequest, handEndSigs [][]byte,
 ) error {
@@ -198,7 +298,7 @@ func (p *handler) validateHandEndSigs(
 	if len(handEndSigs) != len(lastGameStart.Players) {
 		return fmt.Errorf("Invalid hand end sigs")
 	}
-	handEndBytes, err := proto.Marshal(lastHandEnd)
+	handEndBytes, err := pb.MarshalForSig(lastHandEnd)
 	if err != nil {
 		return fmt.Errorf("Failed marshalling: %v", err)
 	}
@@ -219,7 +319,7 @@ func (p *handler) HandEnd(ctx context.Context, req *pb.HandEndRequest) (*pb.Hand
 		return resp, nil
 	}
 	// Stage 1, call downstream
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if err := p.ui.HandEnd(ctx, int(req.WinnerIndex), int(req.Score), deckCards, playerCards); err != nil {
 		return nil, err
@@ -260,7 +360,7 @@ func (p *handler) buildHandEnd(
 	// Different things based on stage
 	switch req.Stage {
 	case 0:
-		if req.Score != 0 || len(req.PlayerInfos) != 0 {
+		if req.Score != 0 || len(req.PlayerInfos) != 0 || len(req.PlayerScoreDeltas) != 0 {
 			return nil, nil, nil, fmt.Errorf("Score or player info set on stage 0")
 		}
 		reveal := &pb.HandEndResponse_HandReveal{
@@ -273,20 +373,23 @@ func (p *handler) buildHandEnd(
 			reveal.UnencryptedCardsInHand[i] = uint32(myCard.card)
 		}
 		for encCard, keyPair := range p.cardPairs {
-			reveal.CardDecryptionKeys[encCard] = keyPair.Dec.Bytes()
+			reveal.CardDecryptionKeys[encCard] = keyPair.DecryptionKey().Bytes()
 		}
 		return &pb.HandEndResponse{Message: &pb.HandEndResponse_Reveal{Reveal: reveal}}, nil, nil, nil
 	case 1:
 		// Now that we have all of the player infos, we can validate a few other things like score
 		expectedScore := 0
 		allDecKeys := make(map[string][]*big.Int)
-		for _, playerInfo := range req.PlayerInfos {
+		for i, playerInfo := range req.PlayerInfos {
+			// Cards of the winner's partners aren't scored
+			scored := !p.rules.Teammates(i, int(req.WinnerIndex), len(req.PlayerInfos))
 			for _, cardInt := range playerInfo.UnencryptedCardsInHand {
 				card := game.Card(cardInt)
 				if !card.Valid() {
 					return nil, nil, nil, fmt.Errorf("Invalid player card")
+				} else if scored {
+					expectedScore += card.Score()
 				}
-				expectedScore += card.Score()
 			}
 			for encCard, decKey := range playerInfo.CardDecryptionKeys {
 				allDecKeys[encCard] = append(allDecKeys[encCard], new(big.Int).SetBytes(decKey))
@@ -305,7 +408,7 @@ func (p *handler) buildHandEnd(
 			if len(decKeys) != len(p.lastGameStart.Players) {
 				return nil, nil, nil, fmt.Errorf("Card decryption key size mismatch")
 			}
-			if decKeys[p.myIndex].Cmp(p.cardPairs[encCard].Dec) != 0 {
+			if decKeys[p.myIndex].Cmp(p.cardPairs[encCard].DecryptionKey()) != 0 {
 				return nil, nil, nil, fmt.Errorf("My card decryption key mismatch")
 			}
 			cardInt, ok := new(big.Int).SetString(encCard, 10)
@@ -313,10 +416,10 @@ func (p *handler) buildHandEnd(
 				return nil, nil, nil, fmt.Errorf("Invalid encrypted card")
 			}
 			for _, decKey := range decKeys {
-				cardInt = sra.DecryptInt(p.sharedPrime, decKey, cardInt)
+				cardInt = p.cardCipher.DecryptInt(decKey, cardInt)
 			}
-			card := game.Card(cardInt.Int64())
-			if !card.Valid() {
+			card, ok := crypto.IntToCard(p.cardEncoding, cardInt)
+			if !ok {
 				return nil, nil, nil, fmt.Errorf("Invalid decrypted card")
 			}
 			allDecCards[encCard] = card
@@ -359,11 +462,25 @@ func (p *handler) buildHandEnd(
 				return nil, nil, nil, fmt.Errorf("All cards mismatch orig deck")
 			}
 		}
+		// Make sure the score changes are the ones the rules give
+		deltas := p.rules.ScoringPolicy(len(playerCards)).HandScoreDeltas(int(req.WinnerIndex), playerCards)
+		if len(req.PlayerScoreDeltas) != len(deltas) {
+			return nil, nil, nil, fmt.Errorf("Score delta count mismatch")
+		}
+		for i, delta := range deltas {
+			if req.PlayerScoreDeltas[i] != uint32(delta) {
+				return nil, nil, nil, fmt.Errorf("Score delta mismatch for player %v", i)
+			}
+		}
 		// Return the sig
 		sig, err := p.player.signProto(req)
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		entry := transcript.HandEndEntry(req, ownSigs(p.lastGameStart.Players, p.myIndex, sig))
+		if err = p.writeTranscript(entry); err != nil {
+			return nil, nil, nil, err
+		}
 		return &pb.HandEndResponse{Message: &pb.HandEndResponse_Sig{Sig: sig}}, deckCards, playerCards, nil
 	default:
 		return nil, nil, nil, fmt.Errorf("Invalid stage")
@@ -375,7 +492,7 @@ func (p *handler) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.Shuf
 	p.dataLock.Lock()
 	defer p.dataLock.Unlock()
 	// Some validation
-	if p.sharedPrime == nil {
+	if p.cardCipher == nil || p.cardEncoding == nil {
 		return nil, fmt.Errorf("Never provided shared prime")
 	}
 	if len(req.UnencryptedStartCards) != len(req.WorkingCardSet) {
@@ -387,7 +504,7 @@ func (p *handler) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.Shuf
 	} else if len(req.HandStartPlayerSigs) != len(p.lastGameStart.Players) {
 		return nil, fmt.Errorf("Invalid hand start sigs")
 	}
-	handStartBytes, err := proto.Marshal(p.lastHandStart)
+	handStartBytes, err := pb.MarshalForSig(p.lastHandStart)
 	if err != nil {
 		return nil, err
 	}
@@ -396,9 +513,13 @@ func (p *handler) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.Shuf
 			return nil, fmt.Errorf("Invalid hand start sig")
 		}
 	}
-	// Make sure these are the cards we expect...first deal is all 108, discard stack if deck is empty
-	if p.lastEvent != nil && p.lastEvent.Hand != nil {
-		if p.lastEvent.Hand.DeckCardsRemaining != 0 {
+	// Make sure these are the cards we expect...first deal is all 108 (as is dealing again), discard stack if deck is
+	// empty
+	redeal := p.replay != nil && p.lastEvent != nil && p.replay.redealExpected(p.lastEvent)
+	firstShuffle := p.lastEvent == nil || p.lastEvent.Hand == nil || p.lastEvent.Type == game.EventHandEnd || redeal
+	if !firstShuffle {
+		// We can't use the last event's deck count here since cards may have been dealt since
+		if len(p.encryptedDeckCards) != 0 {
 			return nil, fmt.Errorf("Reshuffling before deck is empty")
 		} else if len(req.UnencryptedStartCards) != len(p.lastEvent.Hand.DiscardStack)-1 {
 			return nil, fmt.Errorf("Expected reshuffle amount to be one less than discard")
@@ -417,26 +538,53 @@ func (p *handler) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.Shuf
 			}
 		}
 	}
+	if err := p.writeTranscript(transcript.ShuffleEntry(p.myIndex, req)); err != nil {
+		return nil, err
+	}
 	// Do the stages
 	switch req.Stage {
 	case 0:
+		// Dealing again means all cards were taken back
+		if redeal {
+			p.resetCardsUnsafe()
+		}
+		// Being first, we make sure the cards are encoded as agreed so their encryptions can't be told apart
+		if p.myIndex == 0 {
+			for i, workingCard := range req.WorkingCardSet {
+				expected := crypto.CardToInt(p.cardEncoding, game.Card(req.UnencryptedStartCards[i]))
+				if new(big.Int).SetBytes(workingCard).Cmp(expected) != 0 {
+					return nil, fmt.Errorf("Card not encoded as agreed")
+				}
+			}
+		}
 		// If we don't have a set of start cards, put this there
 		if len(p.firstUnencryptedStartCards) == 0 {
 			p.firstUnencryptedStartCards = req.UnencryptedStartCards
 		}
 		// Create stage 0 pair
-		if p.shuffleStage0Pair, err = sra.GenerateKeyPair(rand.Reader, p.sharedPrime, sraKeyPairBits); err != nil {
+		if p.shuffleStage0Pair, err = p.cardCipher.GenerateKeyPair(); err != nil {
 			return nil, err
 		}
-		// Encrypt all the cards
-		resp := &pb.ShuffleResponse{WorkingCardSet: make([][]byte, len(req.WorkingCardSet))}
+		// Shuffle and encrypt all the cards
+		in := make([]*big.Int, len(req.WorkingCardSet))
 		for i, workingCard := range req.WorkingCardSet {
-			resp.WorkingCardSet[i] = p.shuffleStage0Pair.EncryptInt(new(big.Int).SetBytes(workingCard)).Bytes()
+			in[i] = new(big.Int).SetBytes(workingCard)
+		}
+		perm := crypto.NewCryptoRand().Perm(len(in))
+		out := make([]*big.Int, len(in))
+		resp := &pb.ShuffleResponse{WorkingCardSet: make([][]byte, len(in))}
+		for i, inIndex := range perm {
+			out[i] = p.shuffleStage0Pair.EncryptInt(in[inIndex])
+			resp.WorkingCardSet[i] = out[i].Bytes()
+		}
+		// Prove we did it without changing any cards
+		var err error
+		resp.Proof, err = crypto.ProveShuffle(
+			p.cardCipher, p.lastHandID, p.myIndex, in, out, perm, p.shuffleStage0Pair,
+		)
+		if err != nil {
+			return nil, err
 		}
-		// Shuffle em
-		crypto.NewCryptoRand().Shuffle(len(resp.WorkingCardSet), func(i, j int) {
-			resp.WorkingCardSet[i], resp.WorkingCardSet[j] = resp.WorkingCardSet[j], resp.WorkingCardSet[i]
-		})
 		return resp, nil
 	case 1:
 		if p.shuffleStage0Pair == nil {
@@ -444,17 +592,28 @@ func (p *handler) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.Shuf
 		}
 		// Decrypt each card and re-encrypt with specific encryption key
 		resp := &pb.ShuffleResponse{WorkingCardSet: make([][]byte, len(req.WorkingCardSet))}
-		p.shuffleStage1Pairs = make([]*sra.KeyPair, len(req.WorkingCardSet))
+		p.shuffleStage1Pairs = make([]crypto.KeyPair, len(req.WorkingCardSet))
+		in := make([]*big.Int, len(req.WorkingCardSet))
+		out := make([]*big.Int, len(req.WorkingCardSet))
+		exponents := make([]*big.Int, len(req.WorkingCardSet))
 		for i, workingCard := range req.WorkingCardSet {
 			// Generate key pair for card
-			pair, err := sra.GenerateKeyPair(rand.Reader, p.sharedPrime, sraKeyPairBits)
+			pair, err := p.cardCipher.GenerateKeyPair()
 			if err != nil {
 				return nil, err
 			}
 			p.shuffleStage1Pairs[i] = pair
 			// Decrypt other key, re-encrypt with this per-card one
-			resp.WorkingCardSet[i] = pair.EncryptInt(p.shuffleStage0Pair.DecryptInt(
-				new(big.Int).SetBytes(workingCard))).Bytes()
+			in[i] = new(big.Int).SetBytes(workingCard)
+			out[i] = pair.EncryptInt(p.shuffleStage0Pair.DecryptInt(in[i]))
+			exponents[i] = new(big.Int).Mul(p.shuffleStage0Pair.DecryptionKey(), pair.EncryptionKey())
+			resp.WorkingCardSet[i] = out[i].Bytes()
+		}
+		// Prove we only changed the keys
+		resp.Proof, err = crypto.ProveRekey(
+			p.cardCipher, p.lastHandID, p.myIndex, p.shuffleStage0Pair, in, out, exponents)
+		if err != nil {
+			return nil, err
 		}
 		p.shuffleStage0Pair = nil
 		return resp, nil
@@ -462,6 +621,19 @@ func (p *handler) Shuffle(ctx context.Context, req *pb.ShuffleRequest) (*pb.Shuf
 		if len(p.shuffleStage1Pairs) != len(req.WorkingCardSet) {
 			return nil, fmt.Errorf("Haven't run stage 1")
 		}
+		// Make sure nobody added, removed, or changed any cards
+		playerCount := len(p.lastGameStart.Players)
+		if playerIndex, err := crypto.VerifyShuffleResponses(
+			p.cardCipher, p.cardEncoding, p.lastHandID, playerCount, req,
+		); err != nil && playerIndex >= 0 {
+			return nil, fmt.Errorf("Player %v shuffled wrong: %v", playerIndex, err)
+		} else if err != nil {
+			return nil, err
+		}
+		// Store what everyone's keys for the cards must match
+		for encCardStr, commitments := range crypto.DecryptionKeyCommitments(playerCount, req) {
+			p.decryptionKeyCommitments[encCardStr] = commitments
+		}
 		// Just store a mapping of each of our pairs to the encrypted card
 		p.encryptedDeckCards = make([]*big.Int, len(req.WorkingCardSet))
 		for i, workingCard := range req.WorkingCardSet {
@@ -488,8 +660,9 @@ func (p *handler) ChooseColorSinceFirstCardIsWild(
 	p.dataLock.RUnlock()
 	if lastGameStart == nil || lastHandStart == nil {
 		return nil, fmt.Errorf("Missing game/hand start")
-	} else if lastEvent.Type != game.EventHandStartCardDealt {
-		return nil, fmt.Errorf("Expected last event to be card deal")
+	} else if lastEvent == nil || (lastEvent.Type != game.EventHandStartCardDealt &&
+		lastEvent.Type != game.EventHandStartTopCardAddedToDiscard) {
+		return nil, fmt.Errorf("Expected last event to be card deal or discard of first card")
 	}
 	// Check that the player after the dealer is me
 	indexAfterDealer := int(lastHandStart.DealerIndex) + 1
@@ -500,10 +673,12 @@ func (p *handler) ChooseColorSinceFirstCardIsWild(
 		return nil, fmt.Errorf("I am not the first player to go")
 	}
 	// Ask
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := p.turnContext(ctx)
 	defer cancelFn()
 	color, err := p.ui.ChooseColorSinceFirstCardIsWild(ctx)
-	if err != nil {
+	if p.turnTimedOut(ctx, err) {
+		color = game.ColorRed
+	} else if err != nil {
 		return nil, err
 	}
 	return &pb.ChooseColorSinceFirstCardIsWildResponse{Color: uint32(color)}, nil
@@ -539,7 +714,7 @@ func (p *handler) GetDeckTopDecryptionKey(
 		p.encryptedCardsGivenToPlayers[encCardStr] = int(req.ForPlayerIndex)
 	}
 	// Give the key
-	return &pb.GetDeckTopDecryptionKeyResponse{DecryptionKey: pair.Dec.Bytes()}, nil
+	return &pb.GetDeckTopDecryptionKeyResponse{DecryptionKey: pair.DecryptionKey().Bytes()}, nil
 }
 
 func (p *handler) GiveDeckTopCard(
@@ -547,52 +722,68 @@ func (p *handler) GiveDeckTopCard(
 ) (*pb.GiveDeckTopCardResponse, error) {
 	p.dataLock.Lock()
 	myIndex := p.myIndex
-	sharedPrime := p.sharedPrime
+	cardCipher := p.cardCipher
+	cardEncoding := p.cardEncoding
 	// Get key and pop card
 	encCard := p.encryptedDeckCards[len(p.encryptedDeckCards)-1]
 	encCardStr := encCard.String()
 	pair := p.cardPairs[encCardStr]
+	commitments := p.decryptionKeyCommitments[encCardStr]
 	p.encryptedDeckCards = p.encryptedDeckCards[:len(p.encryptedDeckCards)-1]
 	_, previouslyGiven := p.encryptedCardsGivenToPlayers[encCardStr]
 	p.encryptedCardsGivenToPlayers[encCardStr] = myIndex
 	p.dataLock.Unlock()
 	if pair == nil {
 		return nil, fmt.Errorf("Unable to find card pair")
+	} else if commitments == nil {
+		return nil, fmt.Errorf("Unable to find card decryption key commitments")
+	} else if len(req.DecryptionKeys) != len(commitments) {
+		return nil, fmt.Errorf("Invalid decryption key count")
 	} else if previouslyGiven {
 		return nil, fmt.Errorf("Card already given out")
 	}
 	// Just take it, we'll have the event handler check normal game state
 	// Decrypt the card with all keys
-	myCard := &myCardInfo{encryptedCard: encCard, decryptionKeys: make([]*big.Int, len(req.DecryptionKeys))}
+	myCard := &myCardInfo{
+		encryptedCard:  encCard,
+		decryptionKeys: make([]*big.Int, len(req.DecryptionKeys)),
+		dealtToIndex:   myIndex,
+	}
 	for i, otherDecKey := range req.DecryptionKeys {
 		// My index should be empty and I'll use my pair
 		if i == myIndex {
 			if len(otherDecKey) != 0 {
 				return nil, fmt.Errorf("A key was given for my index")
 			}
-			myCard.decryptionKeys[i] = pair.Dec
+			myCard.decryptionKeys[i] = pair.DecryptionKey()
 			encCard = pair.DecryptInt(encCard)
 		} else if len(otherDecKey) == 0 {
 			return nil, fmt.Errorf("Missing decryption key")
 		} else {
 			decKey := new(big.Int).SetBytes(otherDecKey)
+			// Check each key on its own so we know who gave a bad one
+			if err := sra.VerifyDecryptionKey(cardCipher, commitments[i], decKey); err != nil {
+				return nil, fmt.Errorf("Invalid decryption key from player %v: %v", i, err)
+			}
 			myCard.decryptionKeys[i] = decKey
-			encCard = sra.DecryptInt(sharedPrime, decKey, encCard)
+			encCard = cardCipher.DecryptInt(decKey, encCard)
 		}
 	}
-	if encCard.BitLen() > 32 {
+	var ok bool
+	if myCard.card, ok = crypto.IntToCard(cardEncoding, encCard); !ok {
 		return nil, fmt.Errorf("Invalid card decryption")
 	}
-	myCard.card = game.Card(int(encCard.Int64()))
-	if !myCard.card.Valid() {
-		return nil, fmt.Errorf("Invalid card")
+	// Our own key isn't revealed until the hand ends
+	entry := transcript.DecryptionKeysEntry(myCard.encryptedCard.Bytes(), req.DecryptionKeys)
+	if err := p.writeTranscript(entry); err != nil {
+		return nil, err
 	}
 	// Add the card
 	p.dataLock.Lock()
 	p.myCards = append(p.myCards, myCard)
 	p.dataLock.Unlock()
 	// Send downstream
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
 	defer cancelFn()
 	if err := p.ui.ReceiveCard(ctx, myCard.card); err != nil {
 		return nil, err
@@ -601,73 +792,454 @@ func (p *handler) GiveDeckTopCard(
 }
 
 func (p *handler) Play(ctx context.Context, req *pb.PlayRequest) (*pb.PlayResponse, error) {
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := p.turnContext(ctx)
 	defer cancelFn()
+	// Being asked again means the host cancelled our last play for a jump-in that didn't happen, so we still hold it
+	p.dataLock.Lock()
+	restoredCard := p.unconfirmedCard
+	if restoredCard != nil {
+		p.myCards = append(p.myCards, restoredCard)
+		p.unconfirmedCard = nil
+	}
+	p.dataLock.Unlock()
+	if restoredCard != nil {
+		if err := p.ui.ReceiveCard(ctx, restoredCard.card); err != nil {
+			return nil, err
+		}
+	}
 	// Ask first
 	card, wildColor, err := p.ui.Play(ctx)
-	if err != nil {
+	if p.turnTimedOut(ctx, err) {
+		return &pb.PlayResponse{}, nil
+	} else if err != nil {
 		return nil, err
-	} else if card.Wild() {
+	} else if card == game.NoCard {
+		return &pb.PlayResponse{}, nil
+	} else if !card.Wild() {
 		wildColor = 0
 	}
-	// Lock the rest of the way
+	myCard, err := p.takeMyCard(card)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PlayResponse{
+		EncryptedCard:      myCard.encryptedCard.Bytes(),
+		UnencryptedCard:    uint32(myCard.card),
+		CardDecryptionKeys: myCard.decryptionKeyBytes(),
+		WildColor:          uint32(wildColor),
+	}, nil
+}
+
+func (p *handler) JumpIn(ctx context.Context, req *pb.JumpInRequest) (*pb.JumpInResponse, error) {
+	p.dataLock.RLock()
+	lastEvent := p.lastEvent
+	jumpIn := p.rules.JumpIn
+	p.dataLock.RUnlock()
+	if !jumpIn {
+		return nil, fmt.Errorf("Jumping in not allowed")
+	} else if lastEvent == nil || lastEvent.Hand == nil || len(lastEvent.Hand.DiscardStack) == 0 {
+		return nil, fmt.Errorf("No discard")
+	}
+	ctx, cancelFn := p.turnContext(ctx)
+	defer cancelFn()
+	// Ask first
+	card, err := p.ui.JumpIn(ctx)
+	if p.turnTimedOut(ctx, err) {
+		return &pb.JumpInResponse{}, nil
+	} else if err != nil {
+		return nil, err
+	} else if card == game.NoCard {
+		return &pb.JumpInResponse{}, nil
+	} else if topCard := lastEvent.Hand.DiscardStack[len(lastEvent.Hand.DiscardStack)-1]; !card.Identical(topCard) {
+		return nil, fmt.Errorf("Card %v not identical to %v", card, topCard)
+	}
+	myCard, err := p.takeMyCard(card)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.JumpInResponse{
+		EncryptedCard:      myCard.encryptedCard.Bytes(),
+		UnencryptedCard:    uint32(myCard.card),
+		CardDecryptionKeys: myCard.decryptionKeyBytes(),
+	}, nil
+}
+
+// takeMyCard removes the card from the ones I hold so it can be played, keeping it until the discard is seen
+func (p *handler) takeMyCard(card game.Card) (*myCardInfo, error) {
 	p.dataLock.Lock()
 	defer p.dataLock.Unlock()
-	// Find the card info based on the card
-	var myCard *myCardInfo
-	myCardIndex := -1
-	for i, c := range p.myCards {
-		if c.card == card {
-			myCard = c
-			myCardIndex = i
-			break
+	for i, myCard := range p.myCards {
+		if myCard.card == card {
+			p.myCards = append(p.myCards[:i], p.myCards[i+1:]...)
+			p.unconfirmedCard = myCard
+			return myCard, nil
 		}
 	}
-	if myCard == nil {
-		return nil, fmt.Errorf("Invalid card")
+	return nil, fmt.Errorf("Invalid card")
+}
+
+func (p *handler) ChooseSwapTarget(
+	ctx context.Context, req *pb.ChooseSwapTargetRequest,
+) (*pb.ChooseSwapTargetResponse, error) {
+	p.dataLock.RLock()
+	myIndex := p.myIndex
+	lastEvent := p.lastEvent
+	sevenO := p.rules.SevenO
+	p.dataLock.RUnlock()
+	if !sevenO {
+		return nil, fmt.Errorf("Hands only move when playing Seven-O")
+	} else if lastEvent == nil || lastEvent.Hand == nil || !isDiscard(lastEvent) {
+		return nil, fmt.Errorf("Expected last event to be discard")
+	} else if lastEvent.Hand.PlayerIndex != myIndex {
+		return nil, fmt.Errorf("Discard not played by me")
+	} else if topCard(lastEvent.Hand).Value() != 7 {
+		return nil, fmt.Errorf("Expected last discard to be 7")
 	}
-	p.myCards = append(p.myCards[:myCardIndex], p.myCards[myCardIndex+1:]...)
-	resp := &pb.PlayResponse{
-		EncryptedCard:      myCard.encryptedCard.Bytes(),
-		UnencryptedCard:    uint32(myCard.card),
-		CardDecryptionKeys: make([][]byte, len(myCard.decryptionKeys)),
-		WildColor:          uint32(wildColor),
+	// Ask
+	ctx, cancelFn := p.turnContext(ctx)
+	defer cancelFn()
+	target, err := p.ui.ChooseSwapTarget(ctx)
+	if p.turnTimedOut(ctx, err) {
+		target = nextPlayerIndex(lastEvent.Hand)
+	} else if err != nil {
+		return nil, err
+	} else if target < 0 || target >= len(lastEvent.Hand.PlayerCardsRemaining) || target == myIndex {
+		return nil, fmt.Errorf("Invalid swap target %v", target)
 	}
-	for i, decKey := range myCard.decryptionKeys {
-		resp.CardDecryptionKeys[i] = decKey.Bytes()
+	return &pb.ChooseSwapTargetResponse{TargetIndex: uint32(target)}, nil
+}
+
+func (p *handler) MoveHand(ctx context.Context, req *pb.MoveHandRequest) (*pb.MoveHandResponse, error) {
+	// Return immediately on error or before the last stage
+	resp, cards, err := p.buildMoveHand(req)
+	if err != nil {
+		return nil, err
+	} else if req.Stage != 3 {
+		return resp, nil
+	}
+	// Stage 3, send downstream
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
+	defer cancelFn()
+	if err := p.ui.ReceiveHand(ctx, int(req.FromPlayerIndex), cards); err != nil {
+		return nil, err
 	}
 	return resp, nil
 }
 
+func (p *handler) buildMoveHand(req *pb.MoveHandRequest) (*pb.MoveHandResponse, []game.Card, error) {
+	// Lock the whole thing
+	p.dataLock.Lock()
+	defer p.dataLock.Unlock()
+	if p.lastGameStart == nil || p.cardCipher == nil {
+		return nil, nil, fmt.Errorf("Missing game/hand start")
+	}
+	playerCount := len(p.lastGameStart.Players)
+	fromIndex, toIndex := int(req.FromPlayerIndex), int(req.ToPlayerIndex)
+	if fromIndex >= playerCount || toIndex >= playerCount || fromIndex == p.myIndex || toIndex == p.myIndex {
+		return nil, nil, fmt.Errorf("Invalid player index")
+	} else if req.Stage > 0 && (fromIndex != p.moveHandFromIndex || toIndex != p.moveHandToIndex) {
+		return nil, nil, fmt.Errorf("Player index changed")
+	}
+	var err error
+	switch req.Stage {
+	case 0:
+		if err = p.validateHandMoveUnsafe(fromIndex, toIndex); err != nil {
+			return nil, nil, err
+		}
+		// Give my cards with the key the host hasn't seen masked by a new pair
+		if p.moveHandGivePair, err = p.cardCipher.GenerateMaskPair(); err != nil {
+			return nil, nil, err
+		}
+		p.moveHandTakePair = nil
+		p.moveHandFromIndex, p.moveHandToIndex = fromIndex, toIndex
+		resp := &pb.MoveHandResponse{
+			EncryptedCards:       make([][]byte, len(p.myCards)),
+			MaskedDecryptionKeys: make([][]byte, len(p.myCards)),
+		}
+		for i, myCard := range p.myCards {
+			resp.EncryptedCards[i] = myCard.encryptedCard.Bytes()
+			resp.MaskedDecryptionKeys[i] =
+				p.moveHandGivePair.EncryptInt(myCard.decryptionKeys[myCard.dealtToIndex]).Bytes()
+		}
+		return resp, nil, nil
+	case 1:
+		if p.moveHandGivePair == nil || p.moveHandTakePair != nil {
+			return nil, nil, fmt.Errorf("Haven't run stage 0")
+		} else if expected := p.lastEvent.Hand.PlayerCardsRemaining[fromIndex]; len(req.EncryptedCards) != expected {
+			return nil, nil, fmt.Errorf("Expected %v cards, got %v", expected, len(req.EncryptedCards))
+		} else if len(req.MaskedDecryptionKeys) != len(req.EncryptedCards) {
+			return nil, nil, fmt.Errorf("Invalid decryption key count")
+		}
+		// Mask the keys of the hand I'm getting with another new pair
+		if p.moveHandTakePair, err = p.cardCipher.GenerateMaskPair(); err != nil {
+			return nil, nil, err
+		}
+		resp := &pb.MoveHandResponse{MaskedDecryptionKeys: make([][]byte, len(req.MaskedDecryptionKeys))}
+		for i, maskedKey := range req.MaskedDecryptionKeys {
+			resp.MaskedDecryptionKeys[i] = p.moveHandTakePair.EncryptInt(new(big.Int).SetBytes(maskedKey)).Bytes()
+		}
+		return resp, nil, nil
+	case 2:
+		if p.moveHandGivePair == nil || p.moveHandTakePair == nil {
+			return nil, nil, fmt.Errorf("Haven't run stage 1")
+		} else if len(req.MaskedDecryptionKeys) != len(p.myCards) {
+			return nil, nil, fmt.Errorf("Invalid decryption key count")
+		}
+		// Remove my mask from the keys of the hand I'm giving, leaving only the getter's mask
+		resp := &pb.MoveHandResponse{MaskedDecryptionKeys: make([][]byte, len(req.MaskedDecryptionKeys))}
+		for i, maskedKey := range req.MaskedDecryptionKeys {
+			resp.MaskedDecryptionKeys[i] = p.moveHandGivePair.DecryptInt(new(big.Int).SetBytes(maskedKey)).Bytes()
+		}
+		p.moveHandGivePair = nil
+		return resp, nil, nil
+	case 3:
+		if p.moveHandGivePair != nil || p.moveHandTakePair == nil {
+			return nil, nil, fmt.Errorf("Haven't run stage 2")
+		} else if expected := p.lastEvent.Hand.PlayerCardsRemaining[fromIndex]; len(req.EncryptedCards) != expected {
+			return nil, nil, fmt.Errorf("Expected %v cards, got %v", expected, len(req.EncryptedCards))
+		} else if len(req.MaskedDecryptionKeys) != len(req.EncryptedCards) ||
+			len(req.CardDecryptionKeys) != len(req.EncryptedCards)*playerCount {
+			return nil, nil, fmt.Errorf("Invalid decryption key count")
+		}
+		// Unmask the key that was missing and decrypt each card with all of the keys
+		newCards := make([]*myCardInfo, len(req.EncryptedCards))
+		cards := make([]game.Card, len(req.EncryptedCards))
+		for i, encCardBytes := range req.EncryptedCards {
+			encCard := new(big.Int).SetBytes(encCardBytes)
+			encCardStr := encCard.String()
+			pair := p.cardPairs[encCardStr]
+			if heldBy, ok := p.encryptedCardsGivenToPlayers[encCardStr]; !ok || heldBy != fromIndex {
+				return nil, nil, fmt.Errorf("Card was not given to player")
+			} else if pair == nil {
+				return nil, nil, fmt.Errorf("Unable to find card pair")
+			}
+			myCard := &myCardInfo{
+				encryptedCard:  encCard,
+				decryptionKeys: make([]*big.Int, playerCount),
+				dealtToIndex:   -1,
+			}
+			for playerIndex, decKey := range req.CardDecryptionKeys[i*playerCount : (i+1)*playerCount] {
+				if len(decKey) != 0 {
+					myCard.decryptionKeys[playerIndex] = new(big.Int).SetBytes(decKey)
+				} else if myCard.dealtToIndex != -1 {
+					return nil, nil, fmt.Errorf("Missing decryption key")
+				} else {
+					myCard.dealtToIndex = playerIndex
+					myCard.decryptionKeys[playerIndex] =
+						p.moveHandTakePair.DecryptInt(new(big.Int).SetBytes(req.MaskedDecryptionKeys[i]))
+				}
+			}
+			if myCard.dealtToIndex == -1 {
+				return nil, nil, fmt.Errorf("Missing masked decryption key")
+			} else if myCard.decryptionKeys[p.myIndex].Cmp(pair.DecryptionKey()) != 0 {
+				return nil, nil, fmt.Errorf("My card decryption key mismatch")
+			}
+			for _, decKey := range myCard.decryptionKeys {
+				encCard = p.cardCipher.DecryptInt(decKey, encCard)
+			}
+			var ok bool
+			if myCard.card, ok = crypto.IntToCard(p.cardEncoding, encCard); !ok {
+				return nil, nil, fmt.Errorf("Invalid card decryption")
+			}
+			newCards[i] = myCard
+			cards[i] = myCard.card
+		}
+		// The hand I gave away is replaced
+		p.myCards = newCards
+		p.moveHandTakePair = nil
+		return &pb.MoveHandResponse{}, cards, nil
+	default:
+		return nil, nil, fmt.Errorf("Invalid stage")
+	}
+}
+
+// validateHandMoveUnsafe makes sure I should be giving my hand to one player and getting the hand of the other based on
+// the last discard. Unsafe because it expects callers to lock.
+func (p *handler) validateHandMoveUnsafe(fromIndex int, toIndex int) error {
+	if !p.rules.SevenO {
+		return fmt.Errorf("Hands only move when playing Seven-O")
+	} else if p.lastEvent == nil || p.lastEvent.Hand == nil || !isDiscard(p.lastEvent) {
+		return fmt.Errorf("Expected last event to be discard")
+	}
+	hand := p.lastEvent.Hand
+	switch topCard(hand).Value() {
+	case 7:
+		// Hands are swapped between the player of the 7 and the target
+		if fromIndex != toIndex || (p.myIndex != hand.PlayerIndex && fromIndex != hand.PlayerIndex) {
+			return fmt.Errorf("Invalid swap")
+		}
+	case 0:
+		// Hands are passed to the next player in the current direction
+		playerCount := len(hand.PlayerCardsRemaining)
+		prevIndex, nextIndex := (p.myIndex-1+playerCount)%playerCount, (p.myIndex+1)%playerCount
+		if !hand.Forward {
+			prevIndex, nextIndex = nextIndex, prevIndex
+		}
+		if fromIndex != prevIndex || toIndex != nextIndex {
+			return fmt.Errorf("Invalid rotation")
+		}
+	default:
+		return fmt.Errorf("Expected last discard to be 7 or 0")
+	}
+	return nil
+}
+
 func (p *handler) ShouldChallengeWildDrawFour(
 	ctx context.Context, req *pb.ShouldChallengeWildDrawFourRequest,
 ) (*pb.ShouldChallengeWildDrawFourResponse, error) {
-	// Make sure color is the last wild color
+	// Make sure it's a wild draw four on me and the color is the one in play before it
 	p.dataLock.RLock()
+	myIndex := p.myIndex
 	lastEvent := p.lastEvent
+	colorBeforeLastDiscard := p.colorBeforeLastDiscard
+	stackDrawCards := p.rules.StackDrawCards
 	p.dataLock.RUnlock()
-	if lastEvent == nil || lastEvent.Hand == nil || uint32(lastEvent.Hand.LastDiscardWildColor) != req.PrevColor {
+	if stackDrawCards {
+		return nil, fmt.Errorf("No challenges when draw cards are stacked")
+	} else if err := validateWildDrawFourOnTop(lastEvent); err != nil {
+		return nil, err
+	} else if nextPlayerIndex(lastEvent.Hand) != myIndex {
+		return nil, fmt.Errorf("Wild draw four not played on me")
+	} else if uint32(colorBeforeLastDiscard) != req.PrevColor {
 		return nil, fmt.Errorf("Invalid color")
 	}
 	// Ask
-	ctx, cancelFn := context.WithTimeout(ctx, maxIfaceHandleTime)
+	ctx, cancelFn := p.turnContext(ctx)
 	defer cancelFn()
-	var err error
-	resp := &pb.ShouldChallengeWildDrawFourResponse{}
-	if resp.Challenge, err = p.ui.ShouldChallengeWildDrawFour(); err != nil {
+	challenge, err := p.ui.ShouldChallengeWildDrawFour(ctx)
+	if p.turnTimedOut(ctx, err) {
+		challenge = false
+	} else if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return &pb.ShouldChallengeWildDrawFourResponse{Challenge: challenge}, nil
 }
 
 func (p *handler) RevealCardsForChallenge(
 	ctx context.Context, req *pb.RevealCardsForChallengeRequest,
 ) (*pb.RevealCardsForChallengeResponse, error) {
-	panic("TODO")
+	p.dataLock.RLock()
+	defer p.dataLock.RUnlock()
+	// Make sure it was my wild draw four being challenged by the next player
+	if err := validateWildDrawFourOnTop(p.lastEvent); err != nil {
+		return nil, err
+	} else if p.lastEvent.Hand.PlayerIndex != p.myIndex {
+		return nil, fmt.Errorf("Wild draw four not played by me")
+	} else if int(req.ChallengerIndex) != nextPlayerIndex(p.lastEvent.Hand) {
+		return nil, fmt.Errorf("Challenger is not the next player")
+	} else if uint32(p.colorBeforeLastDiscard) != req.PrevColor {
+		return nil, fmt.Errorf("Invalid color")
+	}
+	// Give every card with all decryption keys except the challenger's. The keys are in order of the cards, with one
+	// key per player (empty for the challenger) for each card.
+	playerCount := len(p.lastGameStart.Players)
+	resp := &pb.RevealCardsForChallengeResponse{
+		EncryptedCards:     make([][]byte, len(p.myCards)),
+		CardDecryptionKeys: make([][]byte, 0, len(p.myCards)*playerCount),
+	}
+	cards := make([]game.Card, len(p.myCards))
+	for i, myCard := range p.myCards {
+		resp.EncryptedCards[i] = myCard.encryptedCard.Bytes()
+		for playerIndex, decKey := range myCard.decryptionKeys {
+			if playerIndex == int(req.ChallengerIndex) {
+				resp.CardDecryptionKeys = append(resp.CardDecryptionKeys, nil)
+			} else {
+				resp.CardDecryptionKeys = append(resp.CardDecryptionKeys, decKey.Bytes())
+			}
+		}
+		cards[i] = myCard.card
+	}
+	// Challenge succeeds if I had a card of the previous color
+	resp.ChallengeWillSucceed = game.HasColor(cards, p.colorBeforeLastDiscard)
+	return resp, nil
 }
 
 func (p *handler) RevealedCardsForChallenge(
 	ctx context.Context, req *pb.RevealedCardsForChallengeRequest,
 ) (*pb.RevealedCardsForChallengeResponse, error) {
-	panic("TODO")
+	p.dataLock.RLock()
+	myIndex := p.myIndex
+	lastEvent := p.lastEvent
+	colorBeforeLastDiscard := p.colorBeforeLastDiscard
+	cardCipher := p.cardCipher
+	cardEncoding := p.cardEncoding
+	playerCount := 0
+	if p.lastGameStart != nil {
+		playerCount = len(p.lastGameStart.Players)
+	}
+	// Make sure I am the challenger of the one who played it
+	if err := validateWildDrawFourOnTop(lastEvent); err != nil {
+		p.dataLock.RUnlock()
+		return nil, err
+	} else if nextPlayerIndex(lastEvent.Hand) != myIndex {
+		p.dataLock.RUnlock()
+		return nil, fmt.Errorf("Wild draw four not played on me")
+	}
+	challengedIndex := lastEvent.Hand.PlayerIndex
+	// Confirm the cards are the ones given to them and grab my keys for them
+	if len(req.EncryptedCards) != lastEvent.Hand.PlayerCardsRemaining[challengedIndex] {
+		p.dataLock.RUnlock()
+		return nil, fmt.Errorf("Expected %v cards, got %v",
+			lastEvent.Hand.PlayerCardsRemaining[challengedIndex], len(req.EncryptedCards))
+	} else if len(req.CardDecryptionKeys) != len(req.EncryptedCards)*playerCount {
+		p.dataLock.RUnlock()
+		return nil, fmt.Errorf("Invalid decryption key count")
+	}
+	encCards := make([]*big.Int, len(req.EncryptedCards))
+	myPairs := make([]crypto.KeyPair, len(req.EncryptedCards))
+	for i, encCardBytes := range req.EncryptedCards {
+		encCards[i] = new(big.Int).SetBytes(encCardBytes)
+		encCardStr := encCards[i].String()
+		if heldBy, ok := p.encryptedCardsGivenToPlayers[encCardStr]; !ok || heldBy != challengedIndex {
+			p.dataLock.RUnlock()
+			return nil, fmt.Errorf("Card was not given to challenged player")
+		} else if myPairs[i] = p.cardPairs[encCardStr]; myPairs[i] == nil {
+			p.dataLock.RUnlock()
+			return nil, fmt.Errorf("Unable to find card pair")
+		}
+	}
+	p.dataLock.RUnlock()
+	// Decrypt all the cards and see if any of them are the previous color
+	cards := make([]game.Card, len(encCards))
+	resp := &pb.RevealedCardsForChallengeResponse{}
+	for i, encCard := range encCards {
+		for playerIndex, decKey := range req.CardDecryptionKeys[i*playerCount : (i+1)*playerCount] {
+			if playerIndex == myIndex {
+				if len(decKey) != 0 {
+					return nil, fmt.Errorf("A key was given for my index")
+				}
+				encCard = myPairs[i].DecryptInt(encCard)
+			} else if len(decKey) == 0 {
+				return nil, fmt.Errorf("Missing decryption key")
+			} else {
+				encCard = cardCipher.DecryptInt(new(big.Int).SetBytes(decKey), encCard)
+			}
+		}
+		var ok bool
+		if cards[i], ok = crypto.IntToCard(cardEncoding, encCard); !ok {
+			return nil, fmt.Errorf("Invalid card decryption")
+		}
+		keys := req.CardDecryptionKeys[i*playerCount : (i+1)*playerCount]
+		if err := p.writeTranscript(transcript.DecryptionKeysEntry(req.EncryptedCards[i], keys)); err != nil {
+			return nil, err
+		}
+	}
+	resp.ChallengeSucceeded = game.HasColor(cards, colorBeforeLastDiscard)
+	// Send downstream. We give our own result even if it disagrees with the challenged player, the host checks it.
+	ctx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
+	defer cancelFn()
+	if err := p.ui.RevealedCardsForChallenge(ctx, challengedIndex, cards, resp.ChallengeSucceeded); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func validateWildDrawFourOnTop(lastEvent *iface.GameEvent) error {
+	if lastEvent == nil || lastEvent.Hand == nil || lastEvent.Type != game.EventHandPlayerDiscarded ||
+		len(lastEvent.Hand.DiscardStack) == 0 {
+		return fmt.Errorf("Expected last event to be discard")
+	} else if lastEvent.Hand.DiscardStack[len(lastEvent.Hand.DiscardStack)-1].Value() != game.WildDrawFour {
+		return fmt.Errorf("Expected last discard to be wild draw four")
+	}
+	return nil
 }
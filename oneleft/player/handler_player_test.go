@@ -0,0 +1,378 @@
+package player
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/stretchr/testify/require"
+)
+
+// testUI records the cards it receives and fails everything else it's asked
+type testUI struct {
+	iface.Interface
+	received []game.Card
+}
+
+func (t *testUI) ReceiveCard(ctx context.Context, card game.Card) error {
+	t.received = append(t.received, card)
+	return nil
+}
+
+func TestGiveDeckTopCardBlamesBadKey(t *testing.T) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	cardCipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	cardEncoding, err := crypto.NewCardEncoding(cardCipher, make([]byte, 32))
+	require.NoError(t, err)
+	// Every player encrypted the card and committed to their key for it
+	pairs := make([]crypto.KeyPair, 3)
+	commitments := make([]*big.Int, len(pairs))
+	encCard := crypto.CardToInt(cardEncoding, game.Card(42))
+	for i := range pairs {
+		pairs[i], err = cardCipher.GenerateKeyPair()
+		require.NoError(t, err)
+		commitments[i] = sra.KeyCommitment(cardCipher, pairs[i].EncryptionKey())
+		encCard = pairs[i].EncryptInt(encCard)
+	}
+
+	// Each test gives the index of the player whose key is replaced by another's, or -1 for none
+	for _, badIndex := range []int{-1, 0, 2} {
+		ui := &testUI{}
+		p := &handler{
+			ui:                           ui,
+			maxIfaceHandleTime:           time.Minute,
+			myIndex:                      1,
+			cardCipher:                   cardCipher,
+			cardEncoding:                 cardEncoding,
+			cardPairs:                    map[string]crypto.KeyPair{encCard.String(): pairs[1]},
+			decryptionKeyCommitments:     map[string][]*big.Int{encCard.String(): commitments},
+			encryptedDeckCards:           []*big.Int{encCard},
+			encryptedCardsGivenToPlayers: map[string]int{},
+		}
+		req := &pb.GiveDeckTopCardRequest{DecryptionKeys: make([][]byte, len(pairs))}
+		for i, pair := range pairs {
+			if i == badIndex {
+				req.DecryptionKeys[i] = pairs[1].DecryptionKey().Bytes()
+			} else if i != p.myIndex {
+				req.DecryptionKeys[i] = pair.DecryptionKey().Bytes()
+			}
+		}
+		_, err := p.GiveDeckTopCard(context.Background(), req)
+		if badIndex == -1 {
+			require.NoError(t, err)
+			require.Equal(t, []game.Card{42}, ui.received)
+			require.Len(t, p.myCards, 1)
+			continue
+		}
+		require.EqualError(t, err,
+			fmt.Sprintf("Invalid decryption key from player %v: Decryption key doesn't match commitment", badIndex))
+		require.Empty(t, ui.received)
+		require.Empty(t, p.myCards)
+	}
+}
+
+func TestJoinCardCiphers(t *testing.T) {
+	keyPair, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	for _, sraOnly := range []bool{false, true} {
+		p := &handler{player: &player{keyPair: keyPair, name: "me"}, minSharedPrimeBits: 256, sraOnly: sraOnly}
+		resp, err := p.Join(context.Background(), &pb.JoinRequest{})
+		require.NoError(t, err)
+		require.Equal(t, uint32(256), resp.MinSharedCardPrimeBits)
+		// SRA is always accepted so it isn't listed
+		if sraOnly {
+			require.Empty(t, resp.CardCiphers)
+		} else {
+			require.Equal(t, []pb.CardCipher{pb.CardCipher_EC_P256}, resp.CardCiphers)
+		}
+	}
+}
+
+func TestHandStartCardCipher(t *testing.T) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		sraOnly bool
+		req     *pb.HandStartRequest
+		// Empty when the cipher is accepted, which fails later since there was no game start event
+		err string
+	}{
+		{"SRA", false, &pb.HandStartRequest{SharedCardPrime: prime.Bytes()}, ""},
+		{"SRA only", true, &pb.HandStartRequest{SharedCardPrime: prime.Bytes()}, ""},
+		{"SRA without prime", false, &pb.HandStartRequest{}, "Invalid shared prime"},
+		{"EC", false, &pb.HandStartRequest{CardCipher: pb.CardCipher_EC_P256}, ""},
+		{"EC not accepted", true, &pb.HandStartRequest{CardCipher: pb.CardCipher_EC_P256},
+			"Card cipher EC_P256 not accepted"},
+		{"EC with prime", false,
+			&pb.HandStartRequest{CardCipher: pb.CardCipher_EC_P256, SharedCardPrime: prime.Bytes()},
+			"Invalid card cipher: Shared prime only used with SRA"},
+		{"unknown", false, &pb.HandStartRequest{CardCipher: pb.CardCipher(5)},
+			"Invalid card cipher: Unrecognized card cipher 5"},
+	}
+	for _, test := range tests {
+		p := &handler{sraOnly: test.sraOnly, lastGameStart: &pb.GameStartRequest{SharedCardPrimeBits: 128}}
+		test.req.Id, test.req.CardEncodingSeed = make([]byte, 16), make([]byte, 32)
+		_, err := p.HandStart(context.Background(), test.req)
+		if test.err == "" {
+			require.EqualError(t, err, "No previous event", test.name)
+			require.Equal(t, test.req.CardCipher, p.cardCipher.Type(), test.name)
+		} else {
+			require.EqualError(t, err, test.err, test.name)
+			require.Nil(t, p.cardCipher, test.name)
+		}
+	}
+}
+
+func TestHandStartSharedPrime(t *testing.T) {
+	safePrime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	smallPrime, err := sra.GenerateSafePrime(rand.Reader, 127)
+	require.NoError(t, err)
+	// A random prime of this size is almost never safe
+	unsafePrime, err := rand.Prime(rand.Reader, 128)
+	require.NoError(t, err)
+	for sra.IsSafePrime(unsafePrime) {
+		unsafePrime, err = rand.Prime(rand.Reader, 128)
+		require.NoError(t, err)
+	}
+	for name, prime := range map[string]*big.Int{"safe": safePrime, "small": smallPrime, "unsafe": unsafePrime} {
+		p := &handler{lastGameStart: &pb.GameStartRequest{SharedCardPrimeBits: 128}}
+		req := &pb.HandStartRequest{
+			SharedCardPrime:  prime.Bytes(),
+			Id:               make([]byte, 16),
+			CardEncodingSeed: make([]byte, 32),
+		}
+		_, err := p.HandStart(context.Background(), req)
+		// The safe prime fails later since there was no game start event
+		if name == "safe" {
+			require.EqualError(t, err, "No previous event", name)
+		} else {
+			require.EqualError(t, err, "Invalid shared prime", name)
+		}
+	}
+}
+
+// playUI plays the given cards in order
+type playUI struct {
+	testUI
+	plays []game.Card
+}
+
+func (p *playUI) Play(ctx context.Context) (game.Card, game.CardColor, error) {
+	card := p.plays[0]
+	p.plays = p.plays[1:]
+	return card, game.ColorUnknown, nil
+}
+
+func TestPlayAgainRestoresCancelledCard(t *testing.T) {
+	ui := &playUI{plays: []game.Card{3, 5}}
+	p := &handler{
+		ui:                 ui,
+		maxIfaceHandleTime: time.Minute,
+		myCards: []*myCardInfo{
+			{card: 3, encryptedCard: big.NewInt(103)},
+			{card: 5, encryptedCard: big.NewInt(105)},
+		},
+	}
+	resp, err := p.Play(context.Background(), &pb.PlayRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), resp.UnencryptedCard)
+	require.Equal(t, game.Card(3), p.unconfirmedCard.card)
+	require.Len(t, p.myCards, 1)
+	// Asked again after the host cancelled the play for a jump-in that didn't happen
+	resp, err = p.Play(context.Background(), &pb.PlayRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), resp.UnencryptedCard)
+	require.Equal(t, []game.Card{3}, ui.received)
+	require.Equal(t, game.Card(5), p.unconfirmedCard.card)
+	require.Len(t, p.myCards, 1)
+	require.Equal(t, game.Card(3), p.myCards[0].card)
+}
+
+// challengeUI records the hand it's revealed after a challenge
+type challengeUI struct {
+	testUI
+	challengedIndex int
+	cards           []game.Card
+	succeeded       bool
+}
+
+func (c *challengeUI) RevealedCardsForChallenge(
+	ctx context.Context, challengedIndex int, cards []game.Card, succeeded bool,
+) error {
+	c.challengedIndex, c.cards, c.succeeded = challengedIndex, cards, succeeded
+	return nil
+}
+
+func TestChallengeReveal(t *testing.T) {
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	cardCipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	cardEncoding, err := crypto.NewCardEncoding(cardCipher, make([]byte, 32))
+	require.NoError(t, err)
+	// Player 0 played a wild draw four on player 1 holding a red 3 and a yellow 5, every card encrypted by all three
+	hand := []game.Card{3, 30}
+	myCards := make([]*myCardInfo, len(hand))
+	challengerPairs := map[string]crypto.KeyPair{}
+	givenToPlayers := map[string]int{}
+	for i, card := range hand {
+		myCards[i] = &myCardInfo{card: card, encryptedCard: crypto.CardToInt(cardEncoding, card)}
+		pairs := make([]crypto.KeyPair, 3)
+		for playerIndex := range pairs {
+			pairs[playerIndex], err = cardCipher.GenerateKeyPair()
+			require.NoError(t, err)
+			myCards[i].encryptedCard = pairs[playerIndex].EncryptInt(myCards[i].encryptedCard)
+			myCards[i].decryptionKeys = append(myCards[i].decryptionKeys, pairs[playerIndex].DecryptionKey())
+		}
+		challengerPairs[myCards[i].encryptedCard.String()] = pairs[1]
+		givenToPlayers[myCards[i].encryptedCard.String()] = 0
+	}
+	lastEvent := &iface.GameEvent{
+		Type: game.EventHandPlayerDiscarded,
+		Hand: &iface.GameEventHand{
+			PlayerIndex:          0,
+			PlayerCardsRemaining: []int{len(hand), 5, 5},
+			DiscardStack:         []game.Card{30, 104},
+			LastDiscardWildColor: game.ColorGreen,
+			Forward:              true,
+		},
+	}
+	gameStart := &pb.GameStartRequest{Players: make([]*pb.PlayerIdentity, 3)}
+
+	// The challenge succeeds if the hand had the color in play before the wild draw four
+	for _, prevColor := range []game.CardColor{game.ColorRed, game.ColorYellow, game.ColorBlue} {
+		succeeds := prevColor != game.ColorBlue
+		challenged := &handler{
+			myIndex:                0,
+			lastGameStart:          gameStart,
+			lastEvent:              lastEvent,
+			colorBeforeLastDiscard: prevColor,
+			myCards:                myCards,
+		}
+		req := &pb.RevealCardsForChallengeRequest{ChallengerIndex: 1, PrevColor: uint32(prevColor)}
+		resp, err := challenged.RevealCardsForChallenge(context.Background(), req)
+		require.NoError(t, err, prevColor)
+		require.Equal(t, succeeds, resp.ChallengeWillSucceed, prevColor)
+		// Every key but the challenger's is given
+		require.Len(t, resp.CardDecryptionKeys, len(hand)*3)
+		for i, myCard := range myCards {
+			require.Equal(t, myCard.encryptedCard.Bytes(), resp.EncryptedCards[i])
+			require.Equal(t, myCard.decryptionKeys[0].Bytes(), resp.CardDecryptionKeys[i*3])
+			require.Empty(t, resp.CardDecryptionKeys[i*3+1])
+			require.Equal(t, myCard.decryptionKeys[2].Bytes(), resp.CardDecryptionKeys[i*3+2])
+		}
+
+		// The challenger decrypts the hand with its own keys and decides for itself
+		ui := &challengeUI{}
+		challenger := &handler{
+			ui:                           ui,
+			maxIfaceHandleTime:           time.Minute,
+			myIndex:                      1,
+			lastGameStart:                gameStart,
+			lastEvent:                    lastEvent,
+			colorBeforeLastDiscard:       prevColor,
+			cardCipher:                   cardCipher,
+			cardEncoding:                 cardEncoding,
+			cardPairs:                    challengerPairs,
+			encryptedCardsGivenToPlayers: givenToPlayers,
+		}
+		revealedReq := &pb.RevealedCardsForChallengeRequest{
+			EncryptedCards:       resp.EncryptedCards,
+			CardDecryptionKeys:   resp.CardDecryptionKeys,
+			ChallengeWillSucceed: !succeeds,
+		}
+		revealedResp, err := challenger.RevealedCardsForChallenge(context.Background(), revealedReq)
+		require.NoError(t, err, prevColor)
+		require.Equal(t, succeeds, revealedResp.ChallengeSucceeded, prevColor)
+		require.Equal(t, 0, ui.challengedIndex)
+		require.Equal(t, hand, ui.cards)
+		require.Equal(t, succeeds, ui.succeeded)
+	}
+
+	// Only the player that played the wild draw four reveals, and only to the next player
+	refusals := []struct {
+		name   string
+		change func(p *handler, req *pb.RevealCardsForChallengeRequest)
+		err    string
+	}{
+		{"not mine", func(p *handler, req *pb.RevealCardsForChallengeRequest) { p.myIndex = 2 },
+			"Wild draw four not played by me"},
+		{"not next", func(p *handler, req *pb.RevealCardsForChallengeRequest) { req.ChallengerIndex = 2 },
+			"Challenger is not the next player"},
+		{"other color", func(p *handler, req *pb.RevealCardsForChallengeRequest) { req.PrevColor++ },
+			"Invalid color"},
+	}
+	for _, refusal := range refusals {
+		p := &handler{lastGameStart: gameStart, lastEvent: lastEvent, myCards: myCards}
+		req := &pb.RevealCardsForChallengeRequest{ChallengerIndex: 1}
+		refusal.change(p, req)
+		_, err := p.RevealCardsForChallenge(context.Background(), req)
+		require.EqualError(t, err, refusal.err, refusal.name)
+	}
+
+	// The challenger doesn't accept a hand with keys missing, wrong, or for its own index, or with other cards
+	otherPair, err := cardCipher.GenerateKeyPair()
+	require.NoError(t, err)
+	badReveals := []struct {
+		name   string
+		change func(req *pb.RevealedCardsForChallengeRequest)
+		err    string
+	}{
+		{"missing key", func(req *pb.RevealedCardsForChallengeRequest) { req.CardDecryptionKeys[2] = nil },
+			"Missing decryption key"},
+		{"bad key", func(req *pb.RevealedCardsForChallengeRequest) {
+			req.CardDecryptionKeys[2] = otherPair.DecryptionKey().Bytes()
+		}, "Invalid card decryption"},
+		{"my key", func(req *pb.RevealedCardsForChallengeRequest) { req.CardDecryptionKeys[1] = []byte{1} },
+			"A key was given for my index"},
+		{"missing card", func(req *pb.RevealedCardsForChallengeRequest) {
+			req.EncryptedCards, req.CardDecryptionKeys = req.EncryptedCards[:1], req.CardDecryptionKeys[:3]
+		}, "Expected 2 cards, got 1"},
+		{"other card", func(req *pb.RevealedCardsForChallengeRequest) {
+			req.EncryptedCards[0] = big.NewInt(1234).Bytes()
+		}, "Card was not given to challenged player"},
+	}
+	for _, badReveal := range badReveals {
+		ui := &challengeUI{}
+		p := &handler{
+			ui:                           ui,
+			maxIfaceHandleTime:           time.Minute,
+			myIndex:                      1,
+			lastGameStart:                gameStart,
+			lastEvent:                    lastEvent,
+			colorBeforeLastDiscard:       game.ColorRed,
+			cardCipher:                   cardCipher,
+			cardEncoding:                 cardEncoding,
+			cardPairs:                    challengerPairs,
+			encryptedCardsGivenToPlayers: givenToPlayers,
+		}
+		req := &pb.RevealedCardsForChallengeRequest{}
+		for _, myCard := range myCards {
+			req.EncryptedCards = append(req.EncryptedCards, myCard.encryptedCard.Bytes())
+			for playerIndex, decKey := range myCard.decryptionKeys {
+				if playerIndex == 1 {
+					req.CardDecryptionKeys = append(req.CardDecryptionKeys, nil)
+				} else {
+					req.CardDecryptionKeys = append(req.CardDecryptionKeys, decKey.Bytes())
+				}
+			}
+		}
+		badReveal.change(req)
+		_, err := p.RevealedCardsForChallenge(context.Background(), req)
+		require.EqualError(t, err, badReveal.err, badReveal.name)
+		require.Nil(t, ui.cards, badReveal.name)
+	}
+}
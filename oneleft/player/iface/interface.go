@@ -15,8 +15,10 @@ type Interface interface {
 	ChatMessage(context.Context, *ChatMessage) error
 	GameEvent(context.Context, *GameEvent) error
 	Error(context.Context, *Error) error
+	// Called when a player just got one left and can be called on, or with -1 when nobody can be
+	OneLeftCallWindow(ctx context.Context, playerIndex int) error
 
-	GameStart(ctx context.Context, id uuid.UUID, players []*Player) error
+	GameStart(ctx context.Context, id uuid.UUID, players []*Player, rules game.Rules) error
 	GameEnd(ctx context.Context, scores []int) error
 	HandStart(ctx context.Context, dealerIndex int) error
 	HandEnd(
@@ -25,7 +27,15 @@ type Interface interface {
 	ChooseColorSinceFirstCardIsWild(context.Context) (game.CardColor, error)
 	ReceiveCard(ctx context.Context, card game.Card) error
 	Play(ctx context.Context) (card game.Card, wildColor game.CardColor, err error)
-	ShouldChallengeWildDrawFour() (bool, error)
+	ShouldChallengeWildDrawFour(context.Context) (bool, error)
+	// Called after our jump-in claim was accepted, returns the card identical to the top discard or NoCard to withdraw
+	JumpIn(context.Context) (game.Card, error)
+	// Called after we played a 7 when playing Seven-O, returns the index of the player to swap hands with
+	ChooseSwapTarget(context.Context) (int, error)
+	// Called when playing Seven-O and our hand was replaced with the one from the player at fromIndex
+	ReceiveHand(ctx context.Context, fromIndex int, cards []game.Card) error
+	// Called on the challenger with the challenged player's hand after a wild draw four challenge
+	RevealedCardsForChallenge(ctx context.Context, challengedIndex int, cards []game.Card, succeeded bool) error
 }
 
 type Player struct {
@@ -57,6 +67,10 @@ type GameEventHand struct {
 	LastDiscardWildColor game.CardColor
 	Forward              bool
 	OneLeftTarget        int
+	// Only when draw cards are stacked
+	DrawPenalty int
+	// -1 if none
+	SwapTarget int
 }
 
 type GameEventHandComplete struct {
@@ -64,6 +78,7 @@ type GameEventHandComplete struct {
 	Score       int
 	DeckCards   []game.Card
 	PlayerCards [][]game.Card
+	ScoreDeltas []int
 }
 
 type Error struct {
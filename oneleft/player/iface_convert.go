@@ -86,6 +86,8 @@ func convertGameEvent(v *pb.HostMessage_GameEvent) (*iface.GameEvent, error) {
 			LastDiscardWildColor: game.CardColor(v.Hand.LastDiscardWildColor),
 			Forward:              v.Hand.Forward,
 			OneLeftTarget:        int(v.Hand.OneLeftTarget),
+			DrawPenalty:          int(v.Hand.DrawPenalty),
+			SwapTarget:           int(v.Hand.SwapTarget),
 		}
 		if event.Hand.HandID, err = uuid.FromBytes(v.Hand.HandId); err != nil {
 			return nil, err
@@ -97,6 +99,7 @@ func convertGameEvent(v *pb.HostMessage_GameEvent) (*iface.GameEvent, error) {
 			Score:       int(v.HandComplete.Score),
 			DeckCards:   convertUInt32sToCards(v.HandComplete.DeckCards),
 			PlayerCards: make([][]game.Card, len(v.HandComplete.PlayerCards)),
+			ScoreDeltas: convertUInt32sToInts(v.HandComplete.PlayerScoreDeltas),
 		}
 		for i, playerCards := range v.HandComplete.PlayerCards {
 			event.HandComplete.PlayerCards[i] = convertUInt32sToCards(playerCards.PlayerCards)
@@ -105,6 +108,26 @@ func convertGameEvent(v *pb.HostMessage_GameEvent) (*iface.GameEvent, error) {
 	return event, nil
 }
 
+func convertRules(v *pb.Rules) (game.Rules, error) {
+	if v == nil {
+		return game.Rules{}, fmt.Errorf("Missing rules")
+	}
+	return game.Rules{
+		HandSize:          int(v.HandSize),
+		TargetScore:       int(v.TargetScore),
+		SingleHand:        v.SingleHand,
+		DrawUntilPlayable: v.DrawUntilPlayable,
+		PlayDrawnCard:     v.PlayDrawnCard,
+		FirstWildDrawFour: game.FirstWildDrawFourRule(v.FirstWildDrawFour),
+		StackDrawCards:    v.StackDrawCards,
+		JumpIn:            v.JumpIn,
+		SevenO:            v.SevenO,
+		Partners:          v.Partners,
+		Scoring:           game.Scoring(v.Scoring),
+		HandCount:         int(v.HandCount),
+	}, nil
+}
+
 func convertError(v *pb.HostMessage_Error) (*iface.Error, error) {
 	ret := &iface.Error{
 		Message:        v.Message,
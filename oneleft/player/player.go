@@ -1,15 +1,122 @@
 package player
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/pb"
 	"github.com/cretz/one-left/oneleft/player/client"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
 	"github.com/golang/protobuf/proto"
 )
 
+type Player interface {
+	Run() error
+	// Join asks the host to make this client a player in the next game
+	Join() error
+	SendChatMessage(contents string) error
+	// CallOneLeft calls one left on the player at the target index, which can be ourselves. Calling it on someone that
+	// doesn't have one left, or on ourselves when we don't, is penalized.
+	CallOneLeft(targetIndex int) error
+	// JumpIn claims a jump-in on the top discard. If accepted, the UI is asked for the identical card to play.
+	JumpIn() error
+}
+
+// Config is the set of player options. Any zero value is replaced with its default.
+type Config struct {
+	KeyPair ed25519.KeyPair
+	Name    string
+	UI      iface.Interface
+	// If true, join is sent as soon as the host welcomes us
+	JoinOnWelcome bool
+	// How long the UI has to respond to any call
+	MaxIfaceHandleTime time.Duration
+	// If set, every game played in is written to this transcript. It is not closed by the player.
+	Transcript *transcript.Writer
+	// If set, the state of the game being played is kept in this file so it can be resumed after a restart
+	StateFile string
+	// The smallest shared prime in bits we accept cards encrypted with
+	MinSharedPrimeBits int
+	// If true, we only accept cards encrypted with SRA
+	SRAOnly bool
+}
+
+const DefaultMaxIfaceHandleTime = 1 * time.Minute
+const DefaultMinSharedPrimeBits = 256
+
 type player struct {
-	client  client.Client
-	keyPair ed25519.KeyPair
-	name    string
+	client        client.Client
+	keyPair       ed25519.KeyPair
+	name          string
+	joinOnWelcome bool
+
+	chatCounterLock sync.Mutex
+	chatCounter     uint32
+}
+
+func New(stream pb.Host_StreamClient, conf *Config) Player {
+	ret := &player{keyPair: conf.KeyPair, name: conf.Name, joinOnWelcome: conf.JoinOnWelcome}
+	h := &handler{
+		player:             ret,
+		ui:                 conf.UI,
+		maxIfaceHandleTime: conf.MaxIfaceHandleTime,
+		transcript:         conf.Transcript,
+		stateFile:          conf.StateFile,
+		minSharedPrimeBits: conf.MinSharedPrimeBits,
+		sraOnly:            conf.SRAOnly,
+	}
+	if h.maxIfaceHandleTime == 0 {
+		h.maxIfaceHandleTime = DefaultMaxIfaceHandleTime
+	}
+	if h.minSharedPrimeBits == 0 {
+		h.minSharedPrimeBits = DefaultMinSharedPrimeBits
+	}
+	ret.client = client.New(h, stream)
+	return ret
+}
+
+func (p *player) Run() error { return p.client.Run() }
+
+func (p *player) Join() error {
+	return p.client.SendNonBlocking(&pb.ClientMessage{Message: &pb.ClientMessage_StartJoin{StartJoin: true}})
+}
+
+func (p *player) SendChatMessage(contents string) error {
+	// Counter must increment for each message we send
+	p.chatCounterLock.Lock()
+	defer p.chatCounterLock.Unlock()
+	msg := &pb.ChatMessage{
+		PlayerId:   p.keyPair.PublicKey(),
+		PlayerName: p.name,
+		Counter:    p.chatCounter,
+		Contents:   contents,
+	}
+	var err error
+	if msg.Sig, err = p.signProto(msg); err != nil {
+		return fmt.Errorf("Failed signing chat: %v", err)
+	}
+	sendMsg := &pb.ClientMessage{Message: &pb.ClientMessage_ChatMessage{ChatMessage: msg}}
+	if err = p.client.SendNonBlocking(sendMsg); err != nil {
+		return err
+	}
+	p.chatCounter++
+	return nil
+}
+
+func (p *player) CallOneLeft(targetIndex int) error {
+	if targetIndex < 0 {
+		return fmt.Errorf("Invalid target index")
+	}
+	return p.client.SendNonBlocking(&pb.ClientMessage{
+		Message: &pb.ClientMessage_CallOneLeft{CallOneLeft: uint32(targetIndex)},
+	})
+}
+
+func (p *player) JumpIn() error {
+	return p.client.SendNonBlocking(&pb.ClientMessage{Message: &pb.ClientMessage_JumpIn{JumpIn: true}})
 }
 
 func (p *player) sign(contents []byte) []byte {
@@ -17,7 +124,7 @@ func (p *player) sign(contents []byte) []byte {
 }
 
 func (p *player) signProto(msg proto.Message) ([]byte, error) {
-	if byts, err := proto.Marshal(msg); err != nil {
+	if byts, err := pb.MarshalForSig(msg); err != nil {
 		return nil, err
 	} else {
 		return p.sign(byts), nil
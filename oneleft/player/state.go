@@ -0,0 +1,423 @@
+package player
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+)
+
+// How many of the last answered requests are kept to answer again. Only a few requests can be outstanding at once.
+const answeredRequestsKept = 8
+
+// OnResume brings the UI up to date with the game we were playing before we reconnected, then catches up on the
+// events we missed. Requests that weren't answered are sent again after this.
+func (p *handler) OnResume(ctx context.Context, v *pb.HostMessage_Resume) error {
+	p.dataLock.RLock()
+	inGame := p.replay != nil
+	myIndex := p.myIndex
+	rules := p.rules
+	lastGameStart := p.lastGameStart
+	lastHandStart := p.lastHandStart
+	lastEvent := p.lastEvent
+	myCards := make([]game.Card, len(p.myCards))
+	for i, myCard := range p.myCards {
+		myCards[i] = myCard.card
+	}
+	p.dataLock.RUnlock()
+	if !inGame || lastGameStart == nil || !bytes.Equal(lastGameStart.Id, v.GameId) {
+		return fmt.Errorf("Resumed a game we have no state for")
+	} else if int(v.PlayerIndex) != myIndex {
+		return fmt.Errorf("Resumed in another player's seat")
+	}
+	uiCtx, cancelFn := context.WithTimeout(ctx, p.maxIfaceHandleTime)
+	defer cancelFn()
+	if id, err := uuid.FromBytes(lastGameStart.Id); err != nil {
+		return err
+	} else if players, err := convertPlayers(lastGameStart.Players); err != nil {
+		return err
+	} else if err := p.ui.GameStart(uiCtx, id, players, rules); err != nil {
+		return err
+	}
+	// The hand is in progress from the time it's started until its end event
+	if lastHandStart != nil && (lastEvent == nil || lastEvent.Type != game.EventHandEnd) {
+		if err := p.ui.HandStart(uiCtx, int(lastHandStart.DealerIndex)); err != nil {
+			return err
+		}
+	}
+	if lastEvent != nil {
+		if err := p.ui.GameEvent(uiCtx, lastEvent); err != nil {
+			return err
+		}
+	}
+	for _, card := range myCards {
+		if err := p.ui.ReceiveCard(uiCtx, card); err != nil {
+			return err
+		}
+	}
+	for _, event := range v.MissedEvents {
+		if err := p.OnGameEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *handler) AnsweredRequest(req *pb.HostMessage_PlayerRequest) *pb.ClientMessage_PlayerResponse {
+	p.dataLock.RLock()
+	defer p.dataLock.RUnlock()
+	for _, answered := range p.answeredRequests {
+		if proto.Equal(answered.Request, req) {
+			return answered.Response
+		}
+	}
+	return nil
+}
+
+func (p *handler) OnResponse(
+	ctx context.Context, req *pb.HostMessage_PlayerRequest, resp *pb.ClientMessage_PlayerResponse,
+) error {
+	// Only requests in a game are sent again
+	if req.Seq == 0 {
+		return nil
+	}
+	p.dataLock.Lock()
+	p.answeredRequests = append(p.answeredRequests, &pb.PlayerState_AnsweredRequest{Request: req, Response: resp})
+	if len(p.answeredRequests) > answeredRequestsKept {
+		p.answeredRequests = p.answeredRequests[1:]
+	}
+	p.dataLock.Unlock()
+	return p.saveState()
+}
+
+// saveState writes the state of the game we're playing in to the state file if there is one, or removes the file if
+// we're not in a game. A temporary file is written first so the file is never left partially written.
+func (p *handler) saveState() error {
+	if p.stateFile == "" {
+		return nil
+	}
+	p.dataLock.RLock()
+	var byts []byte
+	var err error
+	if p.replay != nil && p.lastGameStart != nil {
+		byts, err = proto.Marshal(p.stateUnsafe())
+	}
+	p.dataLock.RUnlock()
+	if err != nil {
+		return fmt.Errorf("Failed marshalling state: %v", err)
+	} else if byts == nil {
+		if err = os.Remove(p.stateFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Failed removing state: %v", err)
+		}
+		return nil
+	} else if err = ioutil.WriteFile(p.stateFile+".tmp", byts, 0600); err != nil {
+		return fmt.Errorf("Failed writing state: %v", err)
+	} else if err = os.Rename(p.stateFile+".tmp", p.stateFile); err != nil {
+		return fmt.Errorf("Failed writing state: %v", err)
+	}
+	return nil
+}
+
+// loadState restores the state of the game we were playing in from the state file, if there is one
+func (p *handler) loadState() error {
+	if p.stateFile == "" {
+		return nil
+	}
+	byts, err := ioutil.ReadFile(p.stateFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Failed reading state: %v", err)
+	}
+	state := &pb.PlayerState{}
+	if err = proto.Unmarshal(byts, state); err != nil {
+		return fmt.Errorf("Failed unmarshalling state: %v", err)
+	}
+	p.dataLock.Lock()
+	defer p.dataLock.Unlock()
+	if err = p.restoreStateUnsafe(state); err != nil {
+		return fmt.Errorf("Invalid state in %v: %v", p.stateFile, err)
+	}
+	return nil
+}
+
+// Unsafe because it expects callers to lock
+func (p *handler) stateUnsafe() *pb.PlayerState {
+	state := &pb.PlayerState{
+		GameStart:      p.lastGameStart,
+		HandStart:      p.lastHandStart,
+		HandEnd:        p.lastHandEnd,
+		GameEventsSeen: uint32(p.gameEventsSeen),
+		Replay: &pb.PlayerState_Replay{
+			LastEvent:       gameEventToPb(p.replay.lastEvent),
+			BeforeReshuffle: gameEventToPb(p.replay.beforeReshuffle),
+			HandsPlayed:     uint32(p.replay.handsPlayed),
+			TurnIndex:       int32(p.replay.turnIndex),
+			TurnDrewOne:     p.replay.turnDrewOne,
+			TurnTimedOut:    p.replay.turnTimedOut,
+		},
+		ColorBeforeLastDiscard:       int32(p.colorBeforeLastDiscard),
+		ShuffleStage0Pair:            cardKeyPairToPb(p.shuffleStage0Pair),
+		ShuffleStage1Pairs:           make([]*pb.PlayerState_KeyPair, len(p.shuffleStage1Pairs)),
+		CardPairs:                    make(map[string]*pb.PlayerState_KeyPair, len(p.cardPairs)),
+		EncryptedDeckCards:           make([][]byte, len(p.encryptedDeckCards)),
+		EncryptedCardsGivenToPlayers: make(map[string]uint32, len(p.encryptedCardsGivenToPlayers)),
+		MyCards:                      make([]*pb.PlayerState_Card, len(p.myCards)),
+		UnconfirmedCard:              myCardToPb(p.unconfirmedCard),
+		MoveHandGivePair:             keyPairToPb(p.moveHandGivePair),
+		MoveHandTakePair:             keyPairToPb(p.moveHandTakePair),
+		MoveHandFromIndex:            uint32(p.moveHandFromIndex),
+		MoveHandToIndex:              uint32(p.moveHandToIndex),
+		FirstUnencryptedStartCards:   p.firstUnencryptedStartCards,
+		AnsweredRequests:             p.answeredRequests,
+	}
+	for i, pair := range p.shuffleStage1Pairs {
+		state.ShuffleStage1Pairs[i] = cardKeyPairToPb(pair)
+	}
+	for encCardStr, pair := range p.cardPairs {
+		state.CardPairs[encCardStr] = cardKeyPairToPb(pair)
+	}
+	state.DecryptionKeyCommitments =
+		make(map[string]*pb.PlayerState_DecryptionKeyCommitments, len(p.decryptionKeyCommitments))
+	for encCardStr, commitments := range p.decryptionKeyCommitments {
+		pbCommitments := &pb.PlayerState_DecryptionKeyCommitments{Commitments: make([][]byte, len(commitments))}
+		for i, commitment := range commitments {
+			pbCommitments.Commitments[i] = commitment.Bytes()
+		}
+		state.DecryptionKeyCommitments[encCardStr] = pbCommitments
+	}
+	for i, encCard := range p.encryptedDeckCards {
+		state.EncryptedDeckCards[i] = encCard.Bytes()
+	}
+	for encCardStr, playerIndex := range p.encryptedCardsGivenToPlayers {
+		state.EncryptedCardsGivenToPlayers[encCardStr] = uint32(playerIndex)
+	}
+	for i, myCard := range p.myCards {
+		state.MyCards[i] = myCardToPb(myCard)
+	}
+	return state
+}
+
+// Unsafe because it expects callers to lock
+func (p *handler) restoreStateUnsafe(state *pb.PlayerState) error {
+	if state.GameStart == nil || state.Replay == nil {
+		return fmt.Errorf("Missing game start")
+	}
+	// Everything derived from the requests is checked the same way it was when they were received
+	p.myIndex = -1
+	for i, player := range state.GameStart.Players {
+		if bytes.Equal(player.Id, p.player.keyPair.PublicKey()) {
+			p.myIndex = i
+		}
+	}
+	if p.myIndex == -1 {
+		return fmt.Errorf("Unable to find myself")
+	}
+	var err error
+	if p.rules, err = convertRules(state.GameStart.Rules); err != nil {
+		return err
+	}
+	p.cardCipher = nil
+	p.cardEncoding = nil
+	p.lastHandID = uuid.UUID{}
+	if state.HandStart != nil {
+		sharedPrime := new(big.Int).SetBytes(state.HandStart.SharedCardPrime)
+		if p.cardCipher, err = crypto.NewCardCipher(state.HandStart.CardCipher, sharedPrime); err != nil {
+			return err
+		}
+		if p.cardEncoding, err = crypto.NewCardEncoding(p.cardCipher, state.HandStart.CardEncodingSeed); err != nil {
+			return err
+		}
+		if p.lastHandID, err = uuid.FromBytes(state.HandStart.Id); err != nil {
+			return err
+		}
+	}
+	p.replay = newEventReplay(len(state.GameStart.Players), p.rules)
+	if p.replay.lastEvent, err = convertGameEvent(state.Replay.LastEvent); err != nil {
+		return err
+	} else if p.replay.beforeReshuffle, err = convertGameEvent(state.Replay.BeforeReshuffle); err != nil {
+		return err
+	}
+	p.replay.handsPlayed = int(state.Replay.HandsPlayed)
+	p.replay.turnIndex = int(state.Replay.TurnIndex)
+	p.replay.turnDrewOne = state.Replay.TurnDrewOne
+	p.replay.turnTimedOut = state.Replay.TurnTimedOut
+	p.lastEvent = p.replay.lastEvent
+	p.lastGameStart = state.GameStart
+	p.lastHandStart = state.HandStart
+	p.lastHandEnd = state.HandEnd
+	p.gameEventsSeen = int(state.GameEventsSeen)
+	p.turnTimeout = time.Duration(state.GameStart.TurnTimeoutMs) * time.Millisecond
+	p.colorBeforeLastDiscard = game.CardColor(state.ColorBeforeLastDiscard)
+	// Card pairs are only made after a hand starts
+	if p.cardCipher == nil &&
+		(state.ShuffleStage0Pair != nil || len(state.ShuffleStage1Pairs) > 0 || len(state.CardPairs) > 0) {
+		return fmt.Errorf("Card pairs without hand start")
+	}
+	p.shuffleStage0Pair = cardKeyPairFromPb(p.cardCipher, state.ShuffleStage0Pair)
+	p.shuffleStage1Pairs = make([]crypto.KeyPair, len(state.ShuffleStage1Pairs))
+	for i, pair := range state.ShuffleStage1Pairs {
+		p.shuffleStage1Pairs[i] = cardKeyPairFromPb(p.cardCipher, pair)
+	}
+	p.cardPairs = make(map[string]crypto.KeyPair, len(state.CardPairs))
+	for encCardStr, pair := range state.CardPairs {
+		p.cardPairs[encCardStr] = cardKeyPairFromPb(p.cardCipher, pair)
+	}
+	p.decryptionKeyCommitments = make(map[string][]*big.Int, len(state.DecryptionKeyCommitments))
+	for encCardStr, pbCommitments := range state.DecryptionKeyCommitments {
+		commitments := make([]*big.Int, len(pbCommitments.Commitments))
+		for i, commitment := range pbCommitments.Commitments {
+			commitments[i] = new(big.Int).SetBytes(commitment)
+		}
+		p.decryptionKeyCommitments[encCardStr] = commitments
+	}
+	p.encryptedDeckCards = make([]*big.Int, len(state.EncryptedDeckCards))
+	for i, encCard := range state.EncryptedDeckCards {
+		p.encryptedDeckCards[i] = new(big.Int).SetBytes(encCard)
+	}
+	p.encryptedCardsGivenToPlayers = make(map[string]int, len(state.EncryptedCardsGivenToPlayers))
+	for encCardStr, playerIndex := range state.EncryptedCardsGivenToPlayers {
+		p.encryptedCardsGivenToPlayers[encCardStr] = int(playerIndex)
+	}
+	p.myCards = make([]*myCardInfo, len(state.MyCards))
+	for i, myCard := range state.MyCards {
+		p.myCards[i] = myCardFromPb(myCard)
+	}
+	p.unconfirmedCard = myCardFromPb(state.UnconfirmedCard)
+	p.moveHandGivePair = keyPairFromPb(state.MoveHandGivePair)
+	p.moveHandTakePair = keyPairFromPb(state.MoveHandTakePair)
+	p.moveHandFromIndex = int(state.MoveHandFromIndex)
+	p.moveHandToIndex = int(state.MoveHandToIndex)
+	p.firstUnencryptedStartCards = state.FirstUnencryptedStartCards
+	p.answeredRequests = state.AnsweredRequests
+	return nil
+}
+
+// cardKeyPairToPb doesn't keep a prime since the pair is for the hand's card cipher
+func cardKeyPairToPb(pair crypto.KeyPair) *pb.PlayerState_KeyPair {
+	if pair == nil {
+		return nil
+	}
+	return &pb.PlayerState_KeyPair{Enc: pair.EncryptionKey().Bytes(), Dec: pair.DecryptionKey().Bytes()}
+}
+
+func cardKeyPairFromPb(cipher crypto.CardCipher, pair *pb.PlayerState_KeyPair) crypto.KeyPair {
+	if pair == nil {
+		return nil
+	}
+	return cipher.KeyPair(new(big.Int).SetBytes(pair.Enc), new(big.Int).SetBytes(pair.Dec))
+}
+
+func keyPairToPb(pair *sra.KeyPair) *pb.PlayerState_KeyPair {
+	if pair == nil {
+		return nil
+	}
+	return &pb.PlayerState_KeyPair{Prime: pair.Prime.Bytes(), Enc: pair.Enc.Bytes(), Dec: pair.Dec.Bytes()}
+}
+
+func keyPairFromPb(pair *pb.PlayerState_KeyPair) *sra.KeyPair {
+	if pair == nil {
+		return nil
+	}
+	return &sra.KeyPair{
+		Prime: new(big.Int).SetBytes(pair.Prime),
+		Enc:   new(big.Int).SetBytes(pair.Enc),
+		Dec:   new(big.Int).SetBytes(pair.Dec),
+	}
+}
+
+func myCardToPb(myCard *myCardInfo) *pb.PlayerState_Card {
+	if myCard == nil {
+		return nil
+	}
+	return &pb.PlayerState_Card{
+		Card:           uint32(myCard.card),
+		EncryptedCard:  myCard.encryptedCard.Bytes(),
+		DecryptionKeys: myCard.decryptionKeyBytes(),
+		DealtToIndex:   uint32(myCard.dealtToIndex),
+	}
+}
+
+func myCardFromPb(myCard *pb.PlayerState_Card) *myCardInfo {
+	if myCard == nil {
+		return nil
+	}
+	ret := &myCardInfo{
+		card:           game.Card(myCard.Card),
+		encryptedCard:  new(big.Int).SetBytes(myCard.EncryptedCard),
+		decryptionKeys: make([]*big.Int, len(myCard.DecryptionKeys)),
+		dealtToIndex:   int(myCard.DealtToIndex),
+	}
+	for i, decKey := range myCard.DecryptionKeys {
+		ret.decryptionKeys[i] = new(big.Int).SetBytes(decKey)
+	}
+	return ret
+}
+
+func gameEventToPb(event *iface.GameEvent) *pb.HostMessage_GameEvent {
+	if event == nil {
+		return nil
+	}
+	ret := &pb.HostMessage_GameEvent{
+		GameId:       event.GameID[:],
+		Type:         pb.HostMessage_GameEvent_Type(event.Type),
+		PlayerScores: convertIntsToUInt32s(event.PlayerScores),
+		DealerIndex:  uint32(event.DealerIndex),
+	}
+	if event.Hand != nil {
+		ret.Hand = &pb.HostMessage_GameEvent_Hand{
+			HandId:               event.Hand.HandID[:],
+			PlayerIndex:          uint32(event.Hand.PlayerIndex),
+			PlayerCardsRemaining: convertIntsToUInt32s(event.Hand.PlayerCardsRemaining),
+			DeckCardsRemaining:   uint32(event.Hand.DeckCardsRemaining),
+			DiscardStack:         convertCardsToUInt32s(event.Hand.DiscardStack),
+			LastDiscardWildColor: int32(event.Hand.LastDiscardWildColor),
+			Forward:              event.Hand.Forward,
+			OneLeftTarget:        int32(event.Hand.OneLeftTarget),
+			DrawPenalty:          uint32(event.Hand.DrawPenalty),
+			SwapTarget:           int32(event.Hand.SwapTarget),
+		}
+	}
+	if event.HandComplete != nil {
+		ret.HandComplete = &pb.HostMessage_GameEvent_HandComplete{
+			WinnerIndex:       uint32(event.HandComplete.WinnerIndex),
+			Score:             uint32(event.HandComplete.Score),
+			DeckCards:         convertCardsToUInt32s(event.HandComplete.DeckCards),
+			PlayerCards:       make([]*pb.HostMessage_GameEvent_HandComplete_PlayerCards, len(event.HandComplete.PlayerCards)),
+			PlayerScoreDeltas: convertIntsToUInt32s(event.HandComplete.ScoreDeltas),
+		}
+		for i, cards := range event.HandComplete.PlayerCards {
+			ret.HandComplete.PlayerCards[i] = &pb.HostMessage_GameEvent_HandComplete_PlayerCards{
+				PlayerCards: convertCardsToUInt32s(cards),
+			}
+		}
+	}
+	return ret
+}
+
+func convertIntsToUInt32s(v []int) []uint32 {
+	ret := make([]uint32, len(v))
+	for i, s := range v {
+		ret[i] = uint32(s)
+	}
+	return ret
+}
+
+func convertCardsToUInt32s(v []game.Card) []uint32 {
+	ret := make([]uint32, len(v))
+	for i, s := range v {
+		ret[i] = uint32(s)
+	}
+	return ret
+}
@@ -0,0 +1,152 @@
+package player
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	keyPair, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	other, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	prime, err := sra.GenerateSafePrime(rand.Reader, 128)
+	require.NoError(t, err)
+	cardCipher, err := crypto.NewCardCipher(pb.CardCipher_SRA, prime)
+	require.NoError(t, err)
+	rules := game.DefaultRules()
+	rules.JumpIn = true
+	// Midway through the first hand of a played game
+	events := playedEvents(t, 0, 2, rules)
+	replay := newEventReplay(2, rules)
+	for _, event := range events[:10] {
+		require.NoError(t, applyEvent(replay, event))
+	}
+	newPair := func() crypto.KeyPair {
+		pair, err := cardCipher.GenerateKeyPair()
+		require.NoError(t, err)
+		return pair
+	}
+	moveHandPair, err := sra.GenerateKeyPair(rand.Reader, prime)
+	require.NoError(t, err)
+	card := func(c game.Card) *myCardInfo {
+		return &myCardInfo{
+			card:           c,
+			encryptedCard:  big.NewInt(int64(c) + 1000),
+			decryptionKeys: []*big.Int{big.NewInt(7)},
+		}
+	}
+	stateFile := filepath.Join(t.TempDir(), "state")
+	p := &handler{
+		player:    &player{keyPair: keyPair},
+		stateFile: stateFile,
+		myIndex:   1,
+		rules:     rules,
+		lastGameStart: &pb.GameStartRequest{
+			Id:            make([]byte, 16),
+			Players:       []*pb.PlayerIdentity{{Id: other.PublicKey()}, {Id: keyPair.PublicKey()}},
+			Rules:         &pb.Rules{HandSize: 7, TargetScore: 500, PlayDrawnCard: true, JumpIn: true},
+			TurnTimeoutMs: 30000,
+		},
+		lastHandStart: &pb.HandStartRequest{
+			Id:               make([]byte, 16),
+			SharedCardPrime:  prime.Bytes(),
+			CardEncodingSeed: make([]byte, 32),
+		},
+		replay:                       replay,
+		lastEvent:                    replay.lastEvent,
+		gameEventsSeen:               10,
+		cardCipher:                   cardCipher,
+		shuffleStage0Pair:            newPair(),
+		shuffleStage1Pairs:           []crypto.KeyPair{newPair(), newPair()},
+		cardPairs:                    map[string]crypto.KeyPair{"1001": newPair()},
+		decryptionKeyCommitments:     map[string][]*big.Int{"1001": {big.NewInt(1), big.NewInt(2)}},
+		encryptedDeckCards:           []*big.Int{big.NewInt(1002), big.NewInt(1003)},
+		encryptedCardsGivenToPlayers: map[string]int{"1004": 0},
+		myCards:                      []*myCardInfo{card(1), card(2)},
+		unconfirmedCard:              card(3),
+		colorBeforeLastDiscard:       game.ColorRed,
+		moveHandGivePair:             moveHandPair,
+		moveHandFromIndex:            1,
+		firstUnencryptedStartCards:   []uint32{4},
+		answeredRequests: []*pb.PlayerState_AnsweredRequest{{
+			Request:  &pb.HostMessage_PlayerRequest{Seq: 3},
+			Response: &pb.ClientMessage_PlayerResponse{},
+		}},
+	}
+	require.NoError(t, p.saveState())
+
+	// A player started with the state file is back in the game as it was
+	restored := &handler{player: &player{keyPair: keyPair}, stateFile: stateFile}
+	require.NoError(t, restored.loadState())
+	require.True(t, proto.Equal(p.stateUnsafe(), restored.stateUnsafe()))
+	require.Equal(t, p.myIndex, restored.myIndex)
+	require.Equal(t, p.rules, restored.rules)
+	require.Equal(t, p.lastEvent, restored.lastEvent)
+	require.NotNil(t, restored.cardEncoding)
+
+	// Someone not in the game can't restore it
+	stranger, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	err = (&handler{player: &player{keyPair: stranger}, stateFile: stateFile}).loadState()
+	require.EqualError(t, err, "Invalid state in "+stateFile+": Unable to find myself")
+
+	// The file is removed once not in a game, and no file means nothing to restore
+	p.replay = nil
+	require.NoError(t, p.saveState())
+	_, err = os.Stat(stateFile)
+	require.True(t, os.IsNotExist(err))
+	restored = &handler{player: &player{keyPair: keyPair}, stateFile: stateFile}
+	require.NoError(t, restored.loadState())
+	require.Nil(t, restored.replay)
+}
+
+func TestAnsweredRequest(t *testing.T) {
+	p := &handler{}
+	request := func(seq uint64, forPlayerIndex int32) *pb.HostMessage_PlayerRequest {
+		return &pb.HostMessage_PlayerRequest{
+			Seq: seq,
+			Message: &pb.HostMessage_PlayerRequest_GetDeckTopDecryptionKeyRequest{
+				GetDeckTopDecryptionKeyRequest: &pb.GetDeckTopDecryptionKeyRequest{ForPlayerIndex: forPlayerIndex},
+			},
+		}
+	}
+	response := func(key byte) *pb.ClientMessage_PlayerResponse {
+		return &pb.ClientMessage_PlayerResponse{
+			Message: &pb.ClientMessage_PlayerResponse_GetDeckTopDecryptionKeyResponse{
+				GetDeckTopDecryptionKeyResponse: &pb.GetDeckTopDecryptionKeyResponse{DecryptionKey: []byte{key}},
+			},
+		}
+	}
+	// Requests outside of a game aren't kept
+	require.NoError(t, p.OnResponse(context.Background(), request(0, 1), response(1)))
+	require.Nil(t, p.AnsweredRequest(request(0, 1)))
+
+	// The same request gets the same response, but not one with another sequence number or contents
+	require.NoError(t, p.OnResponse(context.Background(), request(1, 1), response(1)))
+	require.True(t, proto.Equal(response(1), p.AnsweredRequest(request(1, 1))))
+	require.Nil(t, p.AnsweredRequest(request(2, 1)))
+	require.Nil(t, p.AnsweredRequest(request(1, 2)))
+
+	// Only the last few are kept
+	for seq := uint64(2); seq <= answeredRequestsKept+1; seq++ {
+		require.NoError(t, p.OnResponse(context.Background(), request(seq, 1), response(byte(seq))))
+	}
+	require.Len(t, p.answeredRequests, answeredRequestsKept)
+	require.Nil(t, p.AnsweredRequest(request(1, 1)))
+	require.True(t, proto.Equal(response(2), p.AnsweredRequest(request(2, 1))))
+	lastSeq := uint64(answeredRequestsKept + 1)
+	require.True(t, proto.Equal(response(byte(lastSeq)), p.AnsweredRequest(request(lastSeq, 1))))
+}
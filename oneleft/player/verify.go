@@ -0,0 +1,680 @@
+package player
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/cretz/one-left/oneleft/crypto"
+	"github.com/cretz/one-left/oneleft/crypto/sra"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/cretz/one-left/oneleft/transcript"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+)
+
+// TranscriptVerification is everything that was verified in a transcript
+type TranscriptVerification struct {
+	// The ID of the player that wrote the transcript, empty if written by the host
+	WriterID []byte
+	Games    []*VerifiedGame
+}
+
+// VerifiedGame is a game in a transcript that was verified as far as the transcript goes
+type VerifiedGame struct {
+	ID      uuid.UUID
+	Players []*iface.Player
+	Rules   game.Rules
+	// False if the transcript stops before the game ended, in which case signatures only seen later aren't required
+	Complete bool
+	Hands    int
+	Events   int
+	// Every valid signature, including the same one seen again in later requests
+	Signatures int
+	// Every card decrypted with the revealed keys, including cards of shuffles that were replaced
+	CardsDecrypted int
+	// The scores as of the last event
+	PlayerScores []int
+}
+
+// VerifyTranscript checks every game in a transcript written by the host or a player. The signatures of every signed
+// request are checked, every event is checked to legally follow the previous one, and every shuffled card is
+// decrypted with the revealed keys to check the deck, every card dealt and drawn, and every discard. It fails on the
+// first problem found.
+func VerifyTranscript(r io.Reader) (*TranscriptVerification, error) {
+	v := &transcriptVerifier{result: &TranscriptVerification{}}
+	reader := transcript.NewReader(r)
+	for entryIndex := 0; ; entryIndex++ {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			return v.result, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed reading entry %v: %v", entryIndex, err)
+		} else if err = v.apply(entry); err != nil {
+			return nil, fmt.Errorf("Invalid entry %v: %v", entryIndex, err)
+		}
+	}
+}
+
+type transcriptVerifier struct {
+	result     *TranscriptVerification
+	headerSeen bool
+	// True when the writer restarted during a game it may have resumed, so its entries are skipped until the next game
+	skippingGame bool
+
+	// Nil when not in a game
+	game              *VerifiedGame
+	gameStart         *pb.GameStartRequest
+	replay            *eventReplay
+	lastEvent         *iface.GameEvent
+	signed            []*signedRequest
+	gameStartSigned   *signedRequest
+	lastHandStart     *pb.HandStartRequest
+	handStartSigned   *signedRequest
+	lastHandEndSigned *signedRequest
+	gameEnd           *pb.GameEndRequest
+
+	// Nil when not in a hand
+	hand *verifyingHand
+}
+
+type verifyingHand struct {
+	id       uuid.UUID
+	cipher   crypto.CardCipher
+	encoding *sra.CardEncoding
+	// Every key revealed in the hand by encrypted card string, by player index, nil if not revealed
+	keys map[string][]*big.Int
+	// The decryption key commitments of every card shuffled in the hand by encrypted card string, by player index
+	commitments map[string][]*big.Int
+	// The final shuffles and the events since the cards were last dealt, in order
+	sinceDeal []interface{}
+	// Nil until the hand ends
+	end *pb.HandEndRequest
+}
+
+type signedRequest struct {
+	desc   string
+	bytes  []byte
+	signed []bool
+}
+
+func (v *transcriptVerifier) apply(entry *pb.TranscriptEntry) error {
+	if header, ok := entry.Entry.(*pb.TranscriptEntry_Header_); ok {
+		return v.applyHeader(header.Header)
+	} else if !v.headerSeen {
+		return fmt.Errorf("Missing header")
+	} else if _, ok := entry.Entry.(*pb.TranscriptEntry_GameStart); v.skippingGame && !ok {
+		return nil
+	}
+	switch e := entry.Entry.(type) {
+	case *pb.TranscriptEntry_GameStart:
+		return v.applyGameStart(e.GameStart)
+	case *pb.TranscriptEntry_HandStart:
+		return v.applyHandStart(e.HandStart)
+	case *pb.TranscriptEntry_Shuffle_:
+		return v.applyShuffle(e.Shuffle)
+	case *pb.TranscriptEntry_DecryptionKeys_:
+		return v.applyDecryptionKeys(e.DecryptionKeys)
+	case *pb.TranscriptEntry_GameEvent:
+		return v.applyGameEvent(e.GameEvent)
+	case *pb.TranscriptEntry_HandEnd:
+		return v.applyHandEnd(e.HandEnd)
+	case *pb.TranscriptEntry_GameEnd:
+		return v.applyGameEnd(e.GameEnd)
+	default:
+		return fmt.Errorf("Unknown entry type %T", e)
+	}
+}
+
+func (v *transcriptVerifier) applyHeader(header *pb.TranscriptEntry_Header) error {
+	if header.Version != transcript.Version {
+		return fmt.Errorf("Unsupported version %v, expected %v", header.Version, transcript.Version)
+	} else if v.headerSeen && !bytes.Equal(header.WriterId, v.result.WriterID) {
+		return fmt.Errorf("Writer changed")
+	}
+	v.headerSeen = true
+	v.result.WriterID = header.WriterId
+	// The writer was restarted, so any game in progress is unfinished here even if the writer resumed it after
+	v.skippingGame = v.game != nil
+	v.game = nil
+	return nil
+}
+
+func (v *transcriptVerifier) applyGameStart(e *pb.TranscriptEntry_SignedGameStart) error {
+	req := e.Request
+	if req == nil {
+		return fmt.Errorf("Missing game start")
+	} else if len(req.Players) < 2 {
+		return fmt.Errorf("Need at least 2 players")
+	}
+	for i, player := range req.Players {
+		if !player.VerifyIdentity() {
+			return fmt.Errorf("Invalid identity for player %v", i)
+		}
+	}
+	rules, err := convertRules(req.Rules)
+	if err != nil {
+		return err
+	} else if err = rules.Validate(len(req.Players)); err != nil {
+		return fmt.Errorf("Invalid rules: %v", err)
+	} else if len(req.PlayerTeams) != len(req.Players) {
+		return fmt.Errorf("Invalid player teams")
+	} else if req.SharedCardPrimeBits < minPrimeBitLen {
+		return fmt.Errorf("Shared prime too small")
+	}
+	for i, team := range rules.PlayerTeams(len(req.Players)) {
+		if req.PlayerTeams[i] != uint32(team) {
+			return fmt.Errorf("Invalid team for player %v", i)
+		}
+	}
+	// A player's transcript only has games they play in
+	if len(v.result.WriterID) > 0 && v.writerIndex(req) == -1 {
+		return fmt.Errorf("Writer not a player in the game")
+	}
+	id, err := uuid.FromBytes(req.Id)
+	if err != nil {
+		return fmt.Errorf("Invalid game ID: %v", err)
+	}
+	players, err := convertPlayers(req.Players)
+	if err != nil {
+		return err
+	}
+	// Any game in progress was never finished
+	*v = transcriptVerifier{
+		result:     v.result,
+		headerSeen: true,
+		game:       &VerifiedGame{ID: id, Players: players, Rules: rules},
+		gameStart:  req,
+		replay:     newEventReplay(len(req.Players), rules),
+	}
+	v.result.Games = append(v.result.Games, v.game)
+	v.gameStartSigned, err = v.newSignedRequest("game start", req, e.PlayerSigs)
+	return err
+}
+
+func (v *transcriptVerifier) applyHandStart(e *pb.TranscriptEntry_SignedHandStart) error {
+	req := e.Request
+	if v.game == nil || v.lastEvent == nil {
+		return fmt.Errorf("Not in a game")
+	} else if req == nil {
+		return fmt.Errorf("Missing hand start")
+	} else if v.lastEvent.Type != game.EventGameStart && v.lastEvent.Type != game.EventHandEnd {
+		return fmt.Errorf("Unexpected hand start")
+	} else if err := validatePlayerScores(v.game.Rules, v.lastEvent.PlayerScores, req.PlayerScores); err != nil {
+		return err
+	}
+	// Same checks the players make
+	prime := new(big.Int).SetBytes(req.SharedCardPrime)
+	if req.CardCipher == pb.CardCipher_SRA &&
+		(prime.BitLen() != int(v.gameStart.SharedCardPrimeBits) || !sra.IsSafePrime(prime)) {
+		return fmt.Errorf("Invalid shared prime")
+	}
+	cipher, err := crypto.NewCardCipher(req.CardCipher, prime)
+	if err != nil {
+		return fmt.Errorf("Invalid card cipher: %v", err)
+	}
+	encoding, err := crypto.NewCardEncoding(cipher, req.CardEncodingSeed)
+	if err != nil {
+		return fmt.Errorf("Invalid card encoding: %v", err)
+	}
+	id, err := uuid.FromBytes(req.Id)
+	if err != nil {
+		return fmt.Errorf("Invalid hand ID: %v", err)
+	}
+	expectedDealerIndex := uint32(0)
+	if v.lastHandStart != nil {
+		expectedDealerIndex = (v.lastHandStart.DealerIndex + 1) % uint32(len(v.gameStart.Players))
+	}
+	if req.DealerIndex != expectedDealerIndex {
+		return fmt.Errorf("Invalid dealer index")
+	}
+	// The signatures of the previous requests can be ones we haven't seen
+	if err := v.addSigs(v.gameStartSigned, req.GameStartPlayerSigs); err != nil {
+		return err
+	} else if v.lastHandEndSigned != nil {
+		if err := v.addSigs(v.lastHandEndSigned, req.LastHandEndPlayerSigs); err != nil {
+			return err
+		}
+	}
+	v.lastHandStart = req
+	v.hand = &verifyingHand{
+		id:          id,
+		cipher:      cipher,
+		encoding:    encoding,
+		keys:        map[string][]*big.Int{},
+		commitments: map[string][]*big.Int{},
+	}
+	v.handStartSigned, err = v.newSignedRequest("hand start", req, e.PlayerSigs)
+	return err
+}
+
+func (v *transcriptVerifier) applyShuffle(e *pb.TranscriptEntry_Shuffle) error {
+	req := e.Request
+	if v.hand == nil {
+		return fmt.Errorf("Not in a hand")
+	} else if req == nil {
+		return fmt.Errorf("Missing shuffle")
+	} else if int(e.PlayerIndex) >= len(v.gameStart.Players) {
+		return fmt.Errorf("Invalid player index")
+	} else if len(req.UnencryptedStartCards) != len(req.WorkingCardSet) {
+		return fmt.Errorf("Working set not same size as orig set")
+	} else if err := v.addSigs(v.handStartSigned, req.HandStartPlayerSigs); err != nil {
+		return err
+	}
+	switch req.Stage {
+	case 0, 1:
+		// Only the final working set can be decrypted with the revealed keys, but the first must be encoded as agreed
+		if req.Stage == 0 && e.PlayerIndex == 0 {
+			for i, workingCard := range req.WorkingCardSet {
+				card := game.Card(req.UnencryptedStartCards[i])
+				if !card.Valid() {
+					return fmt.Errorf("Invalid card to shuffle")
+				} else if new(big.Int).SetBytes(workingCard).Cmp(crypto.CardToInt(v.hand.encoding, card)) != 0 {
+					return fmt.Errorf("Card not encoded as agreed")
+				}
+			}
+		}
+	case 2:
+		// Every shuffle must be proven
+		playerCount := len(v.gameStart.Players)
+		if playerIndex, err := crypto.VerifyShuffleResponses(
+			v.hand.cipher, v.hand.encoding, v.hand.id, playerCount, req,
+		); err != nil && playerIndex >= 0 {
+			return fmt.Errorf("Player %v shuffled wrong: %v", playerIndex, err)
+		} else if err != nil {
+			return err
+		}
+		for encCardStr, commitments := range crypto.DecryptionKeyCommitments(playerCount, req) {
+			v.hand.commitments[encCardStr] = commitments
+		}
+		// A full deck means the cards are dealt again, and anything before is replaced
+		if isFullDeck(req.UnencryptedStartCards) {
+			v.hand.sinceDeal = nil
+		} else if len(v.hand.sinceDeal) == 0 {
+			return fmt.Errorf("Reshuffle before deal")
+		}
+		v.hand.sinceDeal = append(v.hand.sinceDeal, req)
+	default:
+		return fmt.Errorf("Invalid stage")
+	}
+	return nil
+}
+
+func (v *transcriptVerifier) applyDecryptionKeys(e *pb.TranscriptEntry_DecryptionKeys) error {
+	if v.hand == nil {
+		return fmt.Errorf("Not in a hand")
+	} else if len(e.Keys) != len(v.gameStart.Players) {
+		return fmt.Errorf("Invalid decryption key count")
+	}
+	encCardStr := new(big.Int).SetBytes(e.EncryptedCard).String()
+	for i, key := range e.Keys {
+		if err := v.hand.addKey(encCardStr, i, key, len(e.Keys)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *transcriptVerifier) applyGameEvent(e *pb.HostMessage_GameEvent) error {
+	if v.game == nil {
+		return fmt.Errorf("Not in a game")
+	}
+	event, err := convertGameEvent(e)
+	if err != nil {
+		return err
+	} else if event.GameID != v.game.ID {
+		return fmt.Errorf("Invalid game ID")
+	} else if event.Hand != nil && (v.hand == nil || event.Hand.HandID != v.hand.id) {
+		return fmt.Errorf("Not in the event's hand")
+	}
+	// Same as the players' validation, with the dealer and score agreed upon in the signed requests
+	dealerIndex := 0
+	if v.lastHandStart != nil {
+		dealerIndex = int(v.lastHandStart.DealerIndex)
+	}
+	handEndScore := -1
+	if event.Type == game.EventHandEnd {
+		if v.hand.end == nil {
+			return fmt.Errorf("Missing hand end")
+		}
+		handEndScore = int(v.hand.end.Score)
+	}
+	if err = v.replay.apply(event, dealerIndex, handEndScore); err != nil {
+		return err
+	}
+	v.lastEvent = event
+	v.game.Events++
+	v.game.PlayerScores = event.PlayerScores
+	if event.Hand != nil {
+		if len(v.hand.sinceDeal) == 0 {
+			return fmt.Errorf("Hand event before shuffle")
+		}
+		v.hand.sinceDeal = append(v.hand.sinceDeal, event)
+	}
+	switch event.Type {
+	case game.EventHandEnd:
+		if err = v.verifyHandCards(event); err != nil {
+			return err
+		}
+		v.game.Hands++
+		v.hand = nil
+	case game.EventGameEnd:
+		if v.gameEnd == nil {
+			return fmt.Errorf("Missing game end")
+		} else if err = v.verifyAllSigned(); err != nil {
+			return err
+		}
+		v.game.Complete = true
+		v.game = nil
+	}
+	return nil
+}
+
+func (v *transcriptVerifier) applyHandEnd(e *pb.TranscriptEntry_SignedHandEnd) error {
+	req := e.Request
+	if v.hand == nil {
+		return fmt.Errorf("Not in a hand")
+	} else if req == nil || req.Stage != 1 {
+		return fmt.Errorf("Expected final hand end stage")
+	} else if v.hand.end != nil {
+		return fmt.Errorf("Hand already ended")
+	} else if len(req.PlayerInfos) != len(v.gameStart.Players) {
+		return fmt.Errorf("Invalid player info count")
+	}
+	// Every player reveals every key they have for the hand's cards
+	for i, info := range req.PlayerInfos {
+		for encCardStr, key := range info.CardDecryptionKeys {
+			if _, ok := new(big.Int).SetString(encCardStr, 10); !ok {
+				return fmt.Errorf("Invalid encrypted card")
+			} else if err := v.hand.addKey(encCardStr, i, key, len(req.PlayerInfos)); err != nil {
+				return err
+			}
+		}
+	}
+	v.hand.end = req
+	var err error
+	v.lastHandEndSigned, err = v.newSignedRequest("hand end", req, e.PlayerSigs)
+	return err
+}
+
+func (v *transcriptVerifier) applyGameEnd(e *pb.TranscriptEntry_SignedGameEnd) error {
+	req := e.Request
+	if v.game == nil || v.lastEvent == nil {
+		return fmt.Errorf("Not in a game")
+	} else if req == nil {
+		return fmt.Errorf("Missing game end")
+	} else if v.lastEvent.Type != game.EventHandEnd || v.lastHandEndSigned == nil {
+		return fmt.Errorf("Missing hand end")
+	} else if err := validatePlayerScores(v.game.Rules, v.lastEvent.PlayerScores, req.PlayerScores); err != nil {
+		return err
+	} else if err := v.addSigs(v.lastHandEndSigned, req.LastHandEndPlayerSigs); err != nil {
+		return err
+	}
+	v.gameEnd = req
+	_, err := v.newSignedRequest("game end", req, e.PlayerSigs)
+	return err
+}
+
+// verifyHandCards decrypts every card with the revealed keys and follows them from the last deal through every event
+// to the hand end
+func (v *transcriptVerifier) verifyHandCards(event *iface.GameEvent) error {
+	h := v.hand
+	cards, err := h.decryptAll()
+	if err != nil {
+		return err
+	}
+	v.game.CardsDecrypted += len(cards)
+	decrypt := func(encCard []byte) (game.Card, error) {
+		card, ok := cards[new(big.Int).SetBytes(encCard).String()]
+		if !ok {
+			return game.NoCard, fmt.Errorf("Missing decryption keys for card")
+		}
+		return card, nil
+	}
+	// The deck is drawn from the end
+	var deck []game.Card
+	hands := make([][]game.Card, len(v.gameStart.Players))
+	var prev *iface.GameEventHand
+	for _, item := range h.sinceDeal {
+		switch item := item.(type) {
+		case *pb.ShuffleRequest:
+			shuffled := make([]game.Card, len(item.WorkingCardSet))
+			for i, encCard := range item.WorkingCardSet {
+				if shuffled[i], err = decrypt(encCard); err != nil {
+					return err
+				}
+			}
+			startCards := convertUInt32sToCards(item.UnencryptedStartCards)
+			if !sameCards(shuffled, startCards) {
+				return fmt.Errorf("Shuffled cards don't match the cards given to shuffle")
+			}
+			// Reshuffles are the discard other than the top card, with draws before it taking what was left first
+			if prev != nil {
+				discard := prev.DiscardStack
+				if len(discard) == 0 || !cardsEqual(startCards, discard[:len(discard)-1]) {
+					return fmt.Errorf("Reshuffled cards are not the discard")
+				}
+			}
+			deck = append(shuffled, deck...)
+		case *iface.GameEvent:
+			curr := item.Hand
+			if fromIndexes := handMoveFromIndexes(item); fromIndexes != nil {
+				moved := make([][]game.Card, len(hands))
+				for i, fromIndex := range fromIndexes {
+					moved[i] = hands[fromIndex]
+				}
+				hands = moved
+			}
+			if prev != nil && len(curr.DiscardStack) == len(prev.DiscardStack)+1 {
+				card := topCard(curr)
+				if item.Type == game.EventHandStartTopCardAddedToDiscard {
+					if len(deck) == 0 || deck[len(deck)-1] != card {
+						return fmt.Errorf("First discard %v is not the top of the deck", card)
+					}
+					deck = deck[:len(deck)-1]
+				} else if hands[curr.PlayerIndex], err = removeCard(hands[curr.PlayerIndex], card); err != nil {
+					return fmt.Errorf("Player %v discarded %v: %v", curr.PlayerIndex, card, err)
+				}
+			}
+			// Any cards added were drawn from the deck
+			for i, count := range curr.PlayerCardsRemaining {
+				drawn := count - len(hands[i])
+				if drawn < 0 || drawn > len(deck) {
+					return fmt.Errorf("Player %v has %v cards, expected %v", i, count, len(hands[i]))
+				}
+				for ; drawn > 0; drawn-- {
+					hands[i] = append(hands[i], deck[len(deck)-1])
+					deck = deck[:len(deck)-1]
+				}
+			}
+			if len(deck) != curr.DeckCardsRemaining {
+				return fmt.Errorf("Deck has %v cards, expected %v", curr.DeckCardsRemaining, len(deck))
+			}
+			prev = curr
+		}
+	}
+	// What everyone revealed must be what was followed
+	complete := event.HandComplete
+	if !cardsEqual(complete.DeckCards, deck) {
+		return fmt.Errorf("Revealed deck doesn't match")
+	} else if len(h.end.EncryptedDeckCards) != len(deck) {
+		return fmt.Errorf("Hand end deck card count mismatch")
+	} else if int(h.end.WinnerIndex) != complete.WinnerIndex {
+		return fmt.Errorf("Hand end winner mismatch")
+	}
+	for i, encCard := range h.end.EncryptedDeckCards {
+		if card, err := decrypt(encCard); err != nil {
+			return err
+		} else if card != deck[i] {
+			return fmt.Errorf("Hand end deck card mismatch")
+		}
+	}
+	for i, info := range h.end.PlayerInfos {
+		if !sameCards(complete.PlayerCards[i], hands[i]) {
+			return fmt.Errorf("Revealed cards for player %v don't match", i)
+		} else if len(info.EncryptedCardsInHand) != len(hands[i]) ||
+			len(info.UnencryptedCardsInHand) != len(hands[i]) {
+			return fmt.Errorf("Hand end card count mismatch for player %v", i)
+		}
+		for j, encCard := range info.EncryptedCardsInHand {
+			if card, err := decrypt(encCard); err != nil {
+				return err
+			} else if card != game.Card(info.UnencryptedCardsInHand[j]) {
+				return fmt.Errorf("Hand end card mismatch for player %v", i)
+			}
+		}
+		if !sameCards(convertUInt32sToCards(info.UnencryptedCardsInHand), hands[i]) {
+			return fmt.Errorf("Hand end cards for player %v don't match", i)
+		}
+	}
+	for i, delta := range complete.ScoreDeltas {
+		if h.end.PlayerScoreDeltas[i] != uint32(delta) {
+			return fmt.Errorf("Hand end score delta mismatch for player %v", i)
+		}
+	}
+	return nil
+}
+
+// verifyAllSigned makes sure every signed request of the game was signed by every player. Players never see the
+// others' game end signatures, so only the writer's is required in theirs.
+func (v *transcriptVerifier) verifyAllSigned() error {
+	writerIndex := v.writerIndex(v.gameStart)
+	for _, s := range v.signed {
+		for i, signed := range s.signed {
+			if !signed && (s.desc != "game end" || writerIndex == -1 || writerIndex == i) {
+				return fmt.Errorf("Missing %v signature from player %v", s.desc, i)
+			}
+		}
+	}
+	return nil
+}
+
+func (v *transcriptVerifier) newSignedRequest(desc string, req proto.Message, sigs [][]byte) (*signedRequest, error) {
+	reqBytes, err := pb.MarshalForSig(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling: %v", err)
+	}
+	s := &signedRequest{desc: desc, bytes: reqBytes, signed: make([]bool, len(v.gameStart.Players))}
+	v.signed = append(v.signed, s)
+	return s, v.addSigs(s, sigs)
+}
+
+// addSigs checks the signatures by player index, skipping empty ones
+func (v *transcriptVerifier) addSigs(s *signedRequest, sigs [][]byte) error {
+	if len(sigs) != len(s.signed) {
+		return fmt.Errorf("Invalid %v signature count", s.desc)
+	}
+	for i, sig := range sigs {
+		if len(sig) == 0 {
+			continue
+		} else if !v.gameStart.Players[i].VerifySig(s.bytes, sig) {
+			return fmt.Errorf("Invalid %v signature from player %v", s.desc, i)
+		}
+		s.signed[i] = true
+		v.game.Signatures++
+	}
+	return nil
+}
+
+// writerIndex is the index of the transcript writer in the game, or -1 if not a player
+func (v *transcriptVerifier) writerIndex(req *pb.GameStartRequest) int {
+	for i, player := range req.Players {
+		if len(v.result.WriterID) > 0 && bytes.Equal(player.Id, v.result.WriterID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// addKey sets a player's key for the card, failing if it isn't the one committed to when shuffling or it was revealed
+// as something else before
+func (h *verifyingHand) addKey(encCardStr string, playerIndex int, key []byte, playerCount int) error {
+	if len(key) == 0 {
+		return nil
+	}
+	bigKey := new(big.Int).SetBytes(key)
+	if commitments := h.commitments[encCardStr]; commitments == nil {
+		return fmt.Errorf("Player %v revealed a key for a card that wasn't shuffled", playerIndex)
+	} else if err := sra.VerifyDecryptionKey(h.cipher, commitments[playerIndex], bigKey); err != nil {
+		return fmt.Errorf("Player %v revealed an invalid key: %v", playerIndex, err)
+	}
+	keys := h.keys[encCardStr]
+	if keys == nil {
+		keys = make([]*big.Int, playerCount)
+		h.keys[encCardStr] = keys
+	}
+	if keys[playerIndex] != nil && keys[playerIndex].Cmp(bigKey) != 0 {
+		return fmt.Errorf("Player %v revealed different keys for the same card", playerIndex)
+	}
+	keys[playerIndex] = bigKey
+	return nil
+}
+
+// decryptAll decrypts every card that has every player's key, failing if any don't decrypt to a card
+func (h *verifyingHand) decryptAll() (map[string]game.Card, error) {
+	ret := map[string]game.Card{}
+	for encCardStr, keys := range h.keys {
+		cardInt, _ := new(big.Int).SetString(encCardStr, 10)
+		for _, key := range keys {
+			if key == nil {
+				cardInt = nil
+				break
+			}
+			cardInt = h.cipher.DecryptInt(key, cardInt)
+		}
+		if cardInt == nil {
+			continue
+		}
+		card, ok := crypto.IntToCard(h.encoding, cardInt)
+		if !ok {
+			return nil, fmt.Errorf("Invalid decrypted card")
+		}
+		ret[encCardStr] = card
+	}
+	return ret, nil
+}
+
+func isFullDeck(cards []uint32) bool {
+	if len(cards) != 108 {
+		return false
+	}
+	for i, card := range cards {
+		if card != uint32(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func removeCard(cards []game.Card, card game.Card) ([]game.Card, error) {
+	for i, c := range cards {
+		if c == card {
+			return append(append([]game.Card{}, cards[:i]...), cards[i+1:]...), nil
+		}
+	}
+	return nil, fmt.Errorf("Card not held")
+}
+
+func cardsEqual(a []game.Card, b []game.Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, card := range a {
+		if b[i] != card {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCards is true if both have the same cards in any order
+func sameCards(a []game.Card, b []game.Card) bool {
+	sortedA, sortedB := append([]game.Card{}, a...), append([]game.Card{}, b...)
+	sort.Slice(sortedA, func(i, j int) bool { return sortedA[i] < sortedA[j] })
+	sort.Slice(sortedB, func(i, j int) bool { return sortedB[i] < sortedB[j] })
+	return cardsEqual(sortedA, sortedB)
+}
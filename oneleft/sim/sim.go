@@ -0,0 +1,253 @@
+// Package sim plays many games between bots with the plain game engine and collects statistics on how they went. It
+// is meant for balancing rules and tuning bots without the cost of the encrypted deck.
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player/bot"
+)
+
+// Config is the set of simulation options. Any zero value is replaced with its default.
+type Config struct {
+	Rules game.Rules
+	// The difficulty of the bot in each seat, which also sets the player count. Required.
+	Seats []bot.Difficulty
+	// Defaults to DefaultGames
+	Games int
+	// Each game is played with this seed plus the game number, so any game can be played again
+	Seed int64
+	// How many games to play at once. Defaults to the number of CPUs.
+	Parallelism int
+}
+
+const DefaultGames = 1000
+
+// Stats are the totals over every game simulated
+type Stats struct {
+	Seats []bot.Difficulty
+	Games int
+	Hands int
+	// Every discard, jump-in, pass, and absorbed draw penalty
+	Turns int
+	// How many times the discards were shuffled back into the deck during a hand
+	Reshuffles int
+	// Wild draw fours that weren't challenged, that were challenged successfully, and that were challenged and drew six
+	Unchallenged        int
+	ChallengesSucceeded int
+	ChallengesFailed    int
+	// Games won by each seat. Games with more than one winner are split between them.
+	SeatWins []float64
+	// The final score of each seat
+	SeatScores []Distribution
+	// The score of the winner of each hand
+	HandScores Distribution
+}
+
+// Distribution summarizes a set of values
+type Distribution struct {
+	Count  int
+	Min    int
+	Max    int
+	Mean   float64
+	Median int
+	// The value that 90% of values are at or below
+	P90 int
+}
+
+// Run simulates the games, stopping at the first failure
+func Run(conf *Config) (*Stats, error) {
+	if len(conf.Seats) < 2 {
+		return nil, fmt.Errorf("Need at least 2 seats")
+	} else if err := conf.Rules.Validate(len(conf.Seats)); err != nil {
+		return nil, fmt.Errorf("Invalid rules: %v", err)
+	}
+	games, parallelism := conf.Games, conf.Parallelism
+	if games == 0 {
+		games = DefaultGames
+	}
+	if parallelism == 0 {
+		parallelism = runtime.NumCPU()
+	}
+	// Play every game, keeping the results in order so the totals are the same regardless of parallelism
+	results := make([]*gameResult, games)
+	var errLock sync.Mutex
+	var firstErr error
+	gameNums := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for gameNum := range gameNums {
+				seed := conf.Seed + int64(gameNum)
+				result, err := playGame(conf.Seats, conf.Rules, seed, gameNum%len(conf.Seats))
+				errLock.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("Game with seed %v failed: %v", seed, err)
+				}
+				errLock.Unlock()
+				results[gameNum] = result
+			}
+		}()
+	}
+	for gameNum := 0; gameNum < games; gameNum++ {
+		errLock.Lock()
+		failed := firstErr != nil
+		errLock.Unlock()
+		if failed {
+			break
+		}
+		gameNums <- gameNum
+	}
+	close(gameNums)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// Total them up
+	stats := &Stats{Seats: conf.Seats, SeatWins: make([]float64, len(conf.Seats))}
+	seatScores := make([][]int, len(conf.Seats))
+	handScores := []int{}
+	for _, result := range results {
+		stats.Games++
+		stats.Hands += result.hands
+		stats.Turns += result.turns
+		stats.Reshuffles += result.reshuffles
+		stats.Unchallenged += result.unchallenged
+		stats.ChallengesSucceeded += result.challengesSucceeded
+		stats.ChallengesFailed += result.challengesFailed
+		for _, winnerIndex := range result.complete.WinnerIndexes {
+			stats.SeatWins[winnerIndex] += 1 / float64(len(result.complete.WinnerIndexes))
+		}
+		for i, score := range result.complete.PlayerScores {
+			seatScores[i] = append(seatScores[i], score)
+		}
+		handScores = append(handScores, result.handScores...)
+	}
+	for _, scores := range seatScores {
+		stats.SeatScores = append(stats.SeatScores, newDistribution(scores))
+	}
+	stats.HandScores = newDistribution(handScores)
+	return stats, nil
+}
+
+// SeatWinRate is the fraction of games won by the seat
+func (s *Stats) SeatWinRate(seatIndex int) float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return s.SeatWins[seatIndex] / float64(s.Games)
+}
+
+// DifficultyWinRate is the fraction of games won by each seat of the difficulty on average
+func (s *Stats) DifficultyWinRate(difficulty bot.Difficulty) float64 {
+	wins, seats := 0.0, 0
+	for i, seatDifficulty := range s.Seats {
+		if seatDifficulty == difficulty {
+			wins += s.SeatWins[i]
+			seats++
+		}
+	}
+	if seats == 0 || s.Games == 0 {
+		return 0
+	}
+	return wins / float64(seats*s.Games)
+}
+
+// AverageHandTurns is the number of turns in a hand on average
+func (s *Stats) AverageHandTurns() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.Turns) / float64(s.Hands)
+}
+
+// AverageGameHands is the number of hands in a game on average
+func (s *Stats) AverageGameHands() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Hands) / float64(s.Games)
+}
+
+// HandReshuffleRate is the fraction of hands where the deck ran out and the discards were shuffled back in
+func (s *Stats) HandReshuffleRate() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.Reshuffles) / float64(s.Hands)
+}
+
+func newDistribution(values []int) Distribution {
+	ret := Distribution{Count: len(values)}
+	if len(values) == 0 {
+		return ret
+	}
+	sorted := append([]int{}, values...)
+	sort.Ints(sorted)
+	ret.Min, ret.Max = sorted[0], sorted[len(sorted)-1]
+	sum := 0
+	for _, value := range sorted {
+		sum += value
+	}
+	ret.Mean = float64(sum) / float64(len(sorted))
+	ret.Median = sorted[len(sorted)/2]
+	ret.P90 = sorted[(len(sorted)*9)/10]
+	return ret
+}
+
+type gameResult struct {
+	complete            *game.GameComplete
+	hands               int
+	turns               int
+	reshuffles          int
+	unchallenged        int
+	challengesSucceeded int
+	challengesFailed    int
+	handScores          []int
+}
+
+func playGame(seats []bot.Difficulty, rules game.Rules, seed int64, dealerIndex int) (*gameResult, error) {
+	r := rand.New(rand.NewSource(seed))
+	t, err := newTable(seats, rules, r)
+	if err != nil {
+		return nil, err
+	}
+	result := &gameResult{}
+	holders := make([]game.CardHolder, len(t.seats))
+	for i, s := range t.seats {
+		holders[i] = s
+	}
+	newDeck := func() (game.CardDeck, error) { return game.NewMemoryDeck(r, holders), nil }
+	eventCb := func(event *game.Event) error {
+		switch event.Type {
+		case game.EventHandEnd:
+			result.hands++
+			result.handScores = append(result.handScores, event.HandComplete.Score)
+		case game.EventHandPlayerDiscarded, game.EventHandPlayerJumpedIn, game.EventHandPlayerPlayedNothing,
+			game.EventHandPlayerPenaltyAbsorbed:
+			result.turns++
+		case game.EventHandReshuffled:
+			result.reshuffles++
+		case game.EventHandPlayerNoChallengeDrewFour:
+			result.unchallenged++
+		case game.EventHandPlayerChallengeSuccessDrewFour:
+			result.challengesSucceeded++
+		case game.EventHandPlayerChallengeFailedDrewSix:
+			result.challengesFailed++
+		}
+		return t.sendEvent(event)
+	}
+	complete, gameErr := game.New(t.players(), rules, newDeck, eventCb).Play(dealerIndex)
+	if gameErr != nil {
+		return nil, gameErr
+	}
+	result.complete = complete
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package sim_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player/bot"
+	"github.com/cretz/one-left/oneleft/sim"
+)
+
+func TestSimulation(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.JumpIn, rules.SevenO = true, true
+	conf := &sim.Config{Rules: rules, Seats: []bot.Difficulty{bot.Easy, bot.Medium, bot.Hard}, Games: 20, Seed: 1}
+	stats, err := sim.Run(conf)
+	if err != nil {
+		t.Fatal(err)
+	} else if stats.Games != 20 || stats.Hands < 20 {
+		t.Fatalf("Expected 20 games with at least one hand each, got %v games and %v hands", stats.Games, stats.Hands)
+	}
+	wins := 0.0
+	for _, seatWins := range stats.SeatWins {
+		wins += seatWins
+	}
+	if math.Abs(wins-20) > 0.001 {
+		t.Fatalf("Expected 20 wins, got %v", wins)
+	}
+	// The same seed plays the same games regardless of how many are played at once
+	conf.Parallelism = 1
+	if again, err := sim.Run(conf); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(stats, again) {
+		t.Fatalf("Expected same stats from same seed, got %v and %v", stats, again)
+	}
+}
+
+func TestHardBeatsMedium(t *testing.T) {
+	conf := &sim.Config{
+		Rules: game.DefaultRules(),
+		Seats: []bot.Difficulty{bot.Hard, bot.Medium, bot.Hard, bot.Medium},
+		Games: 200,
+		Seed:  1,
+	}
+	stats, err := sim.Run(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hard, medium := stats.DifficultyWinRate(bot.Hard), stats.DifficultyWinRate(bot.Medium)
+	if hard <= medium {
+		t.Fatalf("Expected hard to win more than medium, got %v and %v", hard, medium)
+	}
+	// Medium bots only play wild draw fours without a color match, so hard bots soon stop challenging them
+	challenges := stats.ChallengesSucceeded + stats.ChallengesFailed
+	if wildDrawFours := challenges + stats.Unchallenged; challenges*10 > wildDrawFours {
+		t.Fatalf("Expected few of %v wild draw fours challenged, got %v", wildDrawFours, challenges)
+	}
+}
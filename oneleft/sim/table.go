@@ -0,0 +1,308 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player/bot"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/google/uuid"
+)
+
+// table connects bots to a game. The engine takes one-left calls and jump-in claims in the order they arrive, so the
+// table makes them in a fixed order to keep seeded games the same every time.
+type table struct {
+	seats []*seat
+
+	lock sync.Mutex
+	// The last event sent to the bots
+	lastEvent *iface.GameEvent
+
+	oneLeftSet int
+
+	jumpInSet int
+	// Only claims from the last event, held until every seat has a callback for the new top card
+	jumpInClaims []bool
+}
+
+// seat is the game.Player of a single bot. It holds the real hand since the bot only tracks its own copy.
+type seat struct {
+	table *table
+	index int
+	bot   *bot.Bot
+
+	// Guarded by the table lock
+	cards           []game.Card
+	oneLeftCallback func(target int)
+	jumpInCallback  func()
+	// Whether the last wild draw four played would lose a challenge
+	challengeable bool
+}
+
+func newTable(difficulties []bot.Difficulty, rules game.Rules, r *rand.Rand) (*table, error) {
+	t := &table{jumpInClaims: make([]bool, len(difficulties))}
+	players := make([]*iface.Player, len(difficulties))
+	for i, difficulty := range difficulties {
+		players[i] = &iface.Player{ID: []byte{byte(i)}, Name: fmt.Sprintf("%v %v", difficulty, i)}
+		s := &seat{table: t, index: i}
+		s.bot = bot.New(&bot.Config{
+			ID:         players[i].ID,
+			Difficulty: difficulty,
+			Rand:       rand.New(rand.NewSource(r.Int63())),
+		})
+		s.bot.SetPlayer(&seatPlayer{s})
+		t.seats = append(t.seats, s)
+	}
+	id := uuid.New()
+	for _, s := range t.seats {
+		if err := s.bot.GameStart(context.Background(), id, players, rules); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (t *table) players() []game.Player {
+	ret := make([]game.Player, len(t.seats))
+	for i, s := range t.seats {
+		ret[i] = s
+	}
+	return ret
+}
+
+// seatOrder returns the seat indexes starting after the given one, which comes last
+func (t *table) seatOrder(afterIndex int) []int {
+	ret := make([]int, len(t.seats))
+	for i := range ret {
+		ret[i] = (afterIndex + 1 + i) % len(t.seats)
+	}
+	return ret
+}
+
+// currentPlayerIndexUnsafe is the player of the last hand event. Unsafe because it expects callers to lock.
+func (t *table) currentPlayerIndexUnsafe() int {
+	if t.lastEvent == nil || t.lastEvent.Hand == nil {
+		return -1
+	}
+	return t.lastEvent.Hand.PlayerIndex
+}
+
+func (t *table) sendEvent(event *game.Event) error {
+	ifaceEvent := &iface.GameEvent{Type: event.Type, PlayerScores: event.PlayerScores, DealerIndex: event.DealerIndex}
+	if hand := event.Hand; hand != nil {
+		ifaceEvent.Hand = &iface.GameEventHand{
+			PlayerIndex:          hand.PlayerIndex,
+			PlayerCardsRemaining: hand.PlayerCardsRemaining,
+			DeckCardsRemaining:   hand.DeckCardsRemaining,
+			DiscardStack:         hand.DiscardStack,
+			LastDiscardWildColor: hand.LastDiscardWildColor,
+			Forward:              hand.Forward,
+			OneLeftTarget:        hand.OneLeftTarget,
+			DrawPenalty:          hand.DrawPenalty,
+			SwapTarget:           hand.SwapTarget,
+		}
+	}
+	if complete := event.HandComplete; complete != nil {
+		ifaceEvent.HandComplete = &iface.GameEventHandComplete{
+			WinnerIndex: complete.WinnerIndex,
+			Score:       complete.Score,
+			PlayerCards: complete.DeckReveal.PlayerCards(),
+			ScoreDeltas: complete.ScoreDeltas,
+		}
+	}
+	// Claims are only for the top card of this event
+	t.lock.Lock()
+	t.lastEvent = ifaceEvent
+	for i := range t.jumpInClaims {
+		t.jumpInClaims[i] = false
+	}
+	order := t.seatOrder(t.currentPlayerIndexUnsafe())
+	t.lock.Unlock()
+	for _, i := range order {
+		if err := t.seats[i].bot.GameEvent(context.Background(), ifaceEvent); err != nil {
+			return fmt.Errorf("Bot %v failed handling event: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// setOneLeftCallback stores the seat's callback. Once every seat has one, the bots are asked to call, with the target
+// first so it can call on itself before others catch it.
+func (t *table) setOneLeftCallback(s *seat, target int, callOneLeft func(target int)) {
+	t.lock.Lock()
+	s.oneLeftCallback = callOneLeft
+	t.oneLeftSet++
+	if t.oneLeftSet < len(t.seats) {
+		t.lock.Unlock()
+		return
+	}
+	t.oneLeftSet = 0
+	t.lock.Unlock()
+	if target >= 0 {
+		for _, i := range t.seatOrder(target - 1) {
+			t.seats[i].bot.OneLeftCallWindow(context.Background(), target)
+		}
+	}
+}
+
+func (t *table) callOneLeft(s *seat, target int) {
+	t.lock.Lock()
+	callback := s.oneLeftCallback
+	t.lock.Unlock()
+	if callback != nil {
+		callback(target)
+	}
+}
+
+// setJumpInCallback stores the seat's callback. Once every seat has one, the claims bots made on the event of the new
+// top card are made.
+func (t *table) setJumpInCallback(s *seat, jumpIn func()) {
+	t.lock.Lock()
+	s.jumpInCallback = jumpIn
+	t.jumpInSet++
+	var jumpIns []func()
+	if t.jumpInSet == len(t.seats) {
+		t.jumpInSet = 0
+		jumpIns = t.jumpInCallbacksUnsafe()
+	}
+	t.lock.Unlock()
+	for _, jumpIn := range jumpIns {
+		jumpIn()
+	}
+}
+
+func (t *table) claimJumpIn(s *seat) {
+	t.lock.Lock()
+	t.jumpInClaims[s.index] = true
+	jumpIns := t.jumpInCallbacksUnsafe()
+	t.lock.Unlock()
+	for _, jumpIn := range jumpIns {
+		jumpIn()
+	}
+}
+
+// jumpInCallbacksUnsafe returns the callbacks of the seats with claims in seat order, unless not every seat has one
+// yet. Unsafe because it expects callers to lock.
+func (t *table) jumpInCallbacksUnsafe() []func() {
+	ret := []func(){}
+	if t.jumpInSet > 0 {
+		return ret
+	}
+	for _, i := range t.seatOrder(t.currentPlayerIndexUnsafe()) {
+		if t.jumpInClaims[i] && t.seats[i].jumpInCallback != nil {
+			ret = append(ret, t.seats[i].jumpInCallback)
+		}
+	}
+	return ret
+}
+
+func (s *seat) CardsRemaining() int {
+	s.table.lock.Lock()
+	defer s.table.lock.Unlock()
+	return len(s.cards)
+}
+
+func (s *seat) HeldCards() []game.Card {
+	s.table.lock.Lock()
+	defer s.table.lock.Unlock()
+	return s.cards
+}
+
+func (s *seat) ReceiveCard(card game.Card) error {
+	s.table.lock.Lock()
+	s.cards = append(s.cards, card)
+	s.table.lock.Unlock()
+	return s.bot.ReceiveCard(context.Background(), card)
+}
+
+func (s *seat) ReceiveHand(fromIndex int, cards []game.Card) error {
+	s.table.lock.Lock()
+	s.cards = cards
+	s.table.lock.Unlock()
+	// The bot forgets its cards itself when they are taken back for a shuffle
+	if fromIndex == -1 {
+		return nil
+	}
+	return s.bot.ReceiveHand(context.Background(), fromIndex, append([]game.Card{}, cards...))
+}
+
+func (s *seat) ChooseColorSinceFirstCardIsWild() (game.CardColor, error) {
+	return s.bot.ChooseColorSinceFirstCardIsWild(context.Background())
+}
+
+func (s *seat) Play() (*game.PlayerPlay, error) {
+	card, wildColor, err := s.bot.Play(context.Background())
+	if err != nil || card == game.NoCard {
+		return &game.PlayerPlay{Card: game.NoCard}, err
+	}
+	s.table.lock.Lock()
+	defer s.table.lock.Unlock()
+	if card.Value() == game.WildDrawFour {
+		hand := s.table.lastEvent.Hand
+		top := hand.DiscardStack[len(hand.DiscardStack)-1]
+		s.challengeable = game.WildDrawFourChallengeable(s.cards, top, hand.LastDiscardWildColor)
+	}
+	s.removeCardUnsafe(card)
+	return &game.PlayerPlay{Card: card, WildColor: wildColor}, nil
+}
+
+func (s *seat) ShouldChallengeWildDrawFour() (bool, error) {
+	return s.bot.ShouldChallengeWildDrawFour(context.Background())
+}
+
+func (s *seat) ChallengedWildDrawFour(challengerIndex int) (bool, error) {
+	s.table.lock.Lock()
+	defer s.table.lock.Unlock()
+	return s.challengeable, nil
+}
+
+func (s *seat) SetOneLeftCallback(justGotOneLeftIndex int, callOneLeft func(target int)) {
+	s.table.setOneLeftCallback(s, justGotOneLeftIndex, callOneLeft)
+}
+
+func (s *seat) SetJumpInCallback(jumpIn func()) {
+	s.table.setJumpInCallback(s, jumpIn)
+}
+
+func (s *seat) JumpIn() (*game.PlayerPlay, error) {
+	card, err := s.bot.JumpIn(context.Background())
+	if err != nil || card == game.NoCard {
+		return &game.PlayerPlay{Card: game.NoCard}, err
+	}
+	s.table.lock.Lock()
+	defer s.table.lock.Unlock()
+	s.removeCardUnsafe(card)
+	return &game.PlayerPlay{Card: card}, nil
+}
+
+// CancelPlay does nothing since bots decide their plays right away
+func (s *seat) CancelPlay() {}
+
+func (s *seat) ChooseSwapTarget() (int, error) {
+	return s.bot.ChooseSwapTarget(context.Background())
+}
+
+// Unsafe because it expects callers to lock
+func (s *seat) removeCardUnsafe(card game.Card) {
+	for i, myCard := range s.cards {
+		if myCard == card {
+			s.cards = append(s.cards[:i], s.cards[i+1:]...)
+			return
+		}
+	}
+}
+
+// seatPlayer is the player.Player a bot calls one left and jumps in with
+type seatPlayer struct{ seat *seat }
+
+func (s *seatPlayer) Run() error                   { return nil }
+func (s *seatPlayer) Join() error                  { return nil }
+func (s *seatPlayer) SendChatMessage(string) error { return nil }
+func (s *seatPlayer) CallOneLeft(target int) error {
+	s.seat.table.callOneLeft(s.seat, target)
+	return nil
+}
+func (s *seatPlayer) JumpIn() error { s.seat.table.claimJumpIn(s.seat); return nil }
@@ -0,0 +1,161 @@
+// Package transcript reads and writes game transcripts. A transcript is an append-only file of every signed request,
+// game event, shuffle working set, and revealed decryption key seen while playing, in the order they were seen, so
+// that a game can be proven fair after the fact.
+package transcript
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cretz/one-left/oneleft/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Version is the version written in the header of transcripts by this package
+const Version = 1
+
+// maxEntrySize is the largest entry read, which is well above the size of a hand end with every key
+const maxEntrySize = 16 * 1024 * 1024
+
+// Writer appends entries to a transcript. It is safe for concurrent use.
+type Writer struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+// Create opens the transcript file for appending, creating it if it doesn't exist, and writes a header with the
+// writer ID, which is the player's ID or empty for the host. The writer must be closed.
+func Create(path string, writerID []byte) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	w, err := NewWriter(f, writerID)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// NewWriter writes a header with the writer ID to w and returns a writer that appends entries after it
+func NewWriter(w io.Writer, writerID []byte) (*Writer, error) {
+	ret := &Writer{w: w}
+	err := ret.Write(&pb.TranscriptEntry{Entry: &pb.TranscriptEntry_Header_{Header: &pb.TranscriptEntry_Header{
+		Version:  Version,
+		WriterId: writerID,
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Write appends the entry. The entry is marshalled before returning, so it can be changed afterwards.
+func (w *Writer) Write(entry *pb.TranscriptEntry) error {
+	entryBytes, err := proto.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("Failed marshalling transcript entry: %v", err)
+	}
+	// Write the length and the entry at once so a concurrent reader never sees half an entry's prefix
+	b := append(proto.EncodeVarint(uint64(len(entryBytes))), entryBytes...)
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, err = w.w.Write(b); err != nil {
+		return fmt.Errorf("Failed writing transcript entry: %v", err)
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it is closable
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if closer, ok := w.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Reader reads entries from a transcript in order
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next entry or io.EOF when there are no more. A transcript that ends in the middle of an entry
+// returns io.ErrUnexpectedEOF.
+func (r *Reader) Next() (*pb.TranscriptEntry, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("Failed reading entry size: %v", err)
+	} else if size > maxEntrySize {
+		return nil, fmt.Errorf("Entry size %v too large", size)
+	}
+	entryBytes := make([]byte, size)
+	if _, err = io.ReadFull(r.r, entryBytes); err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+	entry := &pb.TranscriptEntry{}
+	if err = proto.Unmarshal(entryBytes, entry); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling entry: %v", err)
+	}
+	return entry, nil
+}
+
+func GameStartEntry(req *pb.GameStartRequest, playerSigs [][]byte) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_GameStart{GameStart: &pb.TranscriptEntry_SignedGameStart{
+		Request:    req,
+		PlayerSigs: playerSigs,
+	}}}
+}
+
+func HandStartEntry(req *pb.HandStartRequest, playerSigs [][]byte) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_HandStart{HandStart: &pb.TranscriptEntry_SignedHandStart{
+		Request:    req,
+		PlayerSigs: playerSigs,
+	}}}
+}
+
+func ShuffleEntry(playerIndex int, req *pb.ShuffleRequest) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_Shuffle_{Shuffle: &pb.TranscriptEntry_Shuffle{
+		PlayerIndex: uint32(playerIndex),
+		Request:     req,
+	}}}
+}
+
+// DecryptionKeysEntry is the keys revealed for the encrypted card by player index, with empty ones not revealed
+func DecryptionKeysEntry(encryptedCard []byte, keys [][]byte) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_DecryptionKeys_{
+		DecryptionKeys: &pb.TranscriptEntry_DecryptionKeys{EncryptedCard: encryptedCard, Keys: keys},
+	}}
+}
+
+func GameEventEntry(event *pb.HostMessage_GameEvent) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_GameEvent{GameEvent: event}}
+}
+
+func HandEndEntry(req *pb.HandEndRequest, playerSigs [][]byte) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_HandEnd{HandEnd: &pb.TranscriptEntry_SignedHandEnd{
+		Request:    req,
+		PlayerSigs: playerSigs,
+	}}}
+}
+
+func GameEndEntry(req *pb.GameEndRequest, playerSigs [][]byte) *pb.TranscriptEntry {
+	return &pb.TranscriptEntry{Entry: &pb.TranscriptEntry_GameEnd{GameEnd: &pb.TranscriptEntry_SignedGameEnd{
+		Request:    req,
+		PlayerSigs: playerSigs,
+	}}}
+}
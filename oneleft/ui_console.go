@@ -0,0 +1,442 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cretz/bine/torutil/ed25519"
+	"github.com/cretz/one-left/oneleft/game"
+	"github.com/cretz/one-left/oneleft/player"
+	"github.com/cretz/one-left/oneleft/player/iface"
+	"github.com/google/uuid"
+)
+
+const chatPrefix = "/say "
+const callOneLeftCommand = "/one"
+const jumpInCommand = "/jump"
+
+// consoleUI is an iface.Interface for a human at a terminal. Lines prefixed with chatPrefix are sent as chat messages,
+// callOneLeftCommand calls one left, jumpInCommand claims a jump-in, and all others answer the current prompt.
+type consoleUI struct {
+	out   io.Writer
+	myID  ed25519.PublicKey
+	lines chan string
+
+	lock sync.Mutex
+	// Set after the player is created
+	player    player.Player
+	players   []*iface.Player
+	rules     game.Rules
+	cards     []game.Card
+	lastEvent *iface.GameEvent
+	// -1 if nobody just got one left
+	oneLeftIndex int
+}
+
+func newConsoleUI(in io.Reader, out io.Writer, myID ed25519.PublicKey) *consoleUI {
+	ret := &consoleUI{out: out, myID: myID, lines: make(chan string), oneLeftIndex: -1}
+	go ret.readLines(in)
+	return ret
+}
+
+func (c *consoleUI) readLines(in io.Reader) {
+	defer close(c.lines)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		c.lock.Lock()
+		p := c.player
+		oneLeftIndex := c.oneLeftIndex
+		c.lock.Unlock()
+		if !strings.HasPrefix(line, chatPrefix) && line != callOneLeftCommand && line != jumpInCommand {
+			c.lines <- line
+		} else if p == nil {
+			c.printf("Not connected")
+		} else if line == callOneLeftCommand {
+			// Call on whoever just got one left, or on ourselves if nobody did
+			if oneLeftIndex == -1 {
+				oneLeftIndex = c.myIndex()
+			}
+			if err := p.CallOneLeft(oneLeftIndex); err != nil {
+				c.printf("Failed calling one left: %v", err)
+			}
+		} else if line == jumpInCommand {
+			if err := p.JumpIn(); err != nil {
+				c.printf("Failed jumping in: %v", err)
+			}
+		} else if err := p.SendChatMessage(strings.TrimPrefix(line, chatPrefix)); err != nil {
+			c.printf("Failed sending chat: %v", err)
+		}
+	}
+}
+
+func (c *consoleUI) setPlayer(p player.Player) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.player = p
+}
+
+func (c *consoleUI) myIndex() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, p := range c.players {
+		if bytes.Equal(p.ID, c.myID) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *consoleUI) printf(format string, args ...interface{}) {
+	fmt.Fprintf(c.out, format+"\n", args...)
+}
+
+func (c *consoleUI) prompt(ctx context.Context, format string, args ...interface{}) (string, error) {
+	fmt.Fprintf(c.out, format+" ", args...)
+	select {
+	case <-ctx.Done():
+		c.printf("")
+		return "", ctx.Err()
+	case line, ok := <-c.lines:
+		if !ok {
+			return "", io.EOF
+		}
+		return line, nil
+	}
+}
+
+func (c *consoleUI) promptColor(ctx context.Context) (game.CardColor, error) {
+	for {
+		line, err := c.prompt(ctx, "Choose a color (r, y, g, b):")
+		if err != nil {
+			return 0, err
+		}
+		switch strings.ToLower(line) {
+		case "r", "red":
+			return game.ColorRed, nil
+		case "y", "yellow":
+			return game.ColorYellow, nil
+		case "g", "green":
+			return game.ColorGreen, nil
+		case "b", "blue":
+			return game.ColorBlue, nil
+		}
+		c.printf("Invalid color")
+	}
+}
+
+func (c *consoleUI) playerName(index int) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if index < 0 || index >= len(c.players) {
+		return "player " + strconv.Itoa(index)
+	} else if bytes.Equal(c.players[index].ID, c.myID) {
+		return c.players[index].Name + " (you)"
+	}
+	return c.players[index].Name
+}
+
+func (c *consoleUI) Connected(
+	ctx context.Context, players []*iface.Player, chatMessages []*iface.ChatMessage, lastEvent *iface.GameEvent,
+) error {
+	c.lock.Lock()
+	c.players = players
+	c.lastEvent = lastEvent
+	c.lock.Unlock()
+	c.printf("Connected with %v player(s) joined", len(players))
+	for _, msg := range chatMessages {
+		c.ChatMessage(ctx, msg)
+	}
+	return nil
+}
+
+func (c *consoleUI) PlayersUpdated(ctx context.Context, players []*iface.Player) error {
+	c.lock.Lock()
+	c.players = players
+	c.lock.Unlock()
+	names := make([]string, len(players))
+	for i := range players {
+		names[i] = c.playerName(i)
+	}
+	c.printf("Players: %v", strings.Join(names, ", "))
+	return nil
+}
+
+func (c *consoleUI) ChatMessage(ctx context.Context, msg *iface.ChatMessage) error {
+	c.printf("[%v] %v: %v", msg.Time.Format("15:04:05"), msg.Player.Name, msg.Contents)
+	return nil
+}
+
+func (c *consoleUI) GameEvent(ctx context.Context, event *iface.GameEvent) error {
+	c.lock.Lock()
+	c.lastEvent = event
+	// Cards can be dealt again in the same hand
+	if event.Type == game.EventHandStartShuffled {
+		c.cards = nil
+	}
+	c.lock.Unlock()
+	if event.Hand == nil || event.Type == game.EventHandStartCardDealt {
+		return nil
+	}
+	desc := event.Type.String() + " - " + c.playerName(event.Hand.PlayerIndex)
+	if event.Hand.OneLeftTarget >= 0 {
+		desc += " on " + c.playerName(event.Hand.OneLeftTarget)
+	}
+	if event.Hand.SwapTarget >= 0 {
+		desc += " with " + c.playerName(event.Hand.SwapTarget)
+	}
+	if len(event.Hand.DiscardStack) > 0 {
+		desc += ", top card: " + describeCard(event.Hand.DiscardStack[len(event.Hand.DiscardStack)-1],
+			event.Hand.LastDiscardWildColor)
+	}
+	if event.Hand.DrawPenalty > 0 {
+		desc += fmt.Sprintf(", draw penalty: %v", event.Hand.DrawPenalty)
+	}
+	c.printf("%v, cards left: %v", desc, event.Hand.PlayerCardsRemaining)
+	return nil
+}
+
+func (c *consoleUI) Error(ctx context.Context, err *iface.Error) error {
+	c.printf("Error from host: %v", err.Message)
+	return nil
+}
+
+func (c *consoleUI) OneLeftCallWindow(ctx context.Context, playerIndex int) error {
+	c.lock.Lock()
+	c.oneLeftIndex = playerIndex
+	c.lock.Unlock()
+	if playerIndex >= 0 {
+		c.printf("%v has one left, type %v to call it", c.playerName(playerIndex), callOneLeftCommand)
+	}
+	return nil
+}
+
+func (c *consoleUI) GameStart(ctx context.Context, id uuid.UUID, players []*iface.Player, rules game.Rules) error {
+	c.lock.Lock()
+	c.players = players
+	c.rules = rules
+	c.lock.Unlock()
+	c.printf("Game %v started with %v players", id, len(players))
+	c.printf("Rules: %+v", rules)
+	if rules.Partners {
+		for i := 0; i < len(players)/2; i++ {
+			c.printf("Partners: %v and %v", c.playerName(i), c.playerName(i+len(players)/2))
+		}
+	}
+	return nil
+}
+
+func (c *consoleUI) GameEnd(ctx context.Context, scores []int) error {
+	c.lock.Lock()
+	rules := c.rules
+	c.lock.Unlock()
+	c.printf("Game ended")
+	for i, score := range scores {
+		c.printf("  %v: %v", c.playerName(i), score)
+	}
+	for _, winnerIndex := range rules.ScoringPolicy(len(scores)).GameWinners(scores) {
+		c.printf("Game won by %v", c.playerName(winnerIndex))
+	}
+	return nil
+}
+
+func (c *consoleUI) HandStart(ctx context.Context, dealerIndex int) error {
+	c.lock.Lock()
+	c.cards = nil
+	c.lock.Unlock()
+	c.printf("Hand started, dealer is %v", c.playerName(dealerIndex))
+	return nil
+}
+
+func (c *consoleUI) HandEnd(
+	ctx context.Context, winnerIndex int, winnerScore int, deckCards []game.Card, playerCards [][]game.Card,
+) error {
+	c.printf("Hand won by %v for %v points", c.playerName(winnerIndex), winnerScore)
+	return nil
+}
+
+func (c *consoleUI) ChooseColorSinceFirstCardIsWild(ctx context.Context) (game.CardColor, error) {
+	c.printf("The first card is wild")
+	return c.promptColor(ctx)
+}
+
+func (c *consoleUI) ReceiveCard(ctx context.Context, card game.Card) error {
+	c.lock.Lock()
+	c.cards = append(c.cards, card)
+	c.lock.Unlock()
+	c.printf("Received %v", card)
+	return nil
+}
+
+func (c *consoleUI) Play(ctx context.Context) (game.Card, game.CardColor, error) {
+	c.lock.Lock()
+	cards := append([]game.Card{}, c.cards...)
+	lastEvent := c.lastEvent
+	stackDrawCards := c.rules.StackDrawCards
+	c.lock.Unlock()
+	if lastEvent == nil || lastEvent.Hand == nil || len(lastEvent.Hand.DiscardStack) == 0 {
+		return game.NoCard, 0, fmt.Errorf("No discard")
+	}
+	topCard := lastEvent.Hand.DiscardStack[len(lastEvent.Hand.DiscardStack)-1]
+	lastWildColor := lastEvent.Hand.LastDiscardWildColor
+	drawPenalty := lastEvent.Hand.DrawPenalty
+	c.printf("Your turn, top card: %v", describeCard(topCard, lastWildColor))
+	playable := map[game.Card]bool{}
+	for _, play := range game.LegalPlays(cards, topCard, lastWildColor, drawPenalty) {
+		playable[play.Card] = true
+	}
+	for i, card := range cards {
+		if playable[card] {
+			c.printf("  %v: %v (playable)", i+1, card)
+		} else {
+			c.printf("  %v: %v", i+1, card)
+		}
+	}
+	promptText := "Card number to play (blank to draw or pass):"
+	if drawPenalty > 0 {
+		promptText = fmt.Sprintf("Card number to stack (blank to draw the %v card penalty):", drawPenalty)
+	}
+	for {
+		line, err := c.prompt(ctx, promptText)
+		if err != nil {
+			return game.NoCard, 0, err
+		} else if line == "" {
+			return game.NoCard, 0, nil
+		}
+		index, err := strconv.Atoi(line)
+		if err != nil || index < 1 || index > len(cards) {
+			c.printf("Invalid card number")
+			continue
+		}
+		card := cards[index-1]
+		if !card.CanPlayOn(topCard, lastWildColor) {
+			c.printf("Cannot play %v on %v", card, topCard)
+			continue
+		} else if drawPenalty > 0 && !card.CanStackOn(topCard) {
+			c.printf("Cannot stack %v on %v", card, topCard)
+			continue
+		}
+		// Wild draw fours can't be challenged when draw cards are stacked
+		if card.Value() == game.WildDrawFour && !stackDrawCards &&
+			game.WildDrawFourChallengeable(cards, topCard, lastWildColor) {
+			c.printf("You have a %v card, so a challenge would succeed", game.CurrentColor(topCard, lastWildColor))
+		}
+		wildColor := game.CardColor(0)
+		if card.Wild() {
+			if wildColor, err = c.promptColor(ctx); err != nil {
+				return game.NoCard, 0, err
+			}
+		}
+		c.lock.Lock()
+		for i, myCard := range c.cards {
+			if myCard == card {
+				c.cards = append(c.cards[:i], c.cards[i+1:]...)
+				break
+			}
+		}
+		c.lock.Unlock()
+		return card, wildColor, nil
+	}
+}
+
+func (c *consoleUI) ShouldChallengeWildDrawFour(ctx context.Context) (bool, error) {
+	for {
+		line, err := c.prompt(ctx, "A wild draw four was played on you, challenge it? (y/n):")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(line) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+func (c *consoleUI) RevealedCardsForChallenge(
+	ctx context.Context, challengedIndex int, cards []game.Card, succeeded bool,
+) error {
+	cardStrs := make([]string, len(cards))
+	for i, card := range cards {
+		cardStrs[i] = card.String()
+	}
+	c.printf("%v had: %v", c.playerName(challengedIndex), strings.Join(cardStrs, ", "))
+	if succeeded {
+		c.printf("Challenge succeeded")
+	} else {
+		c.printf("Challenge failed")
+	}
+	return nil
+}
+
+func (c *consoleUI) JumpIn(ctx context.Context) (game.Card, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.lastEvent == nil || c.lastEvent.Hand == nil || len(c.lastEvent.Hand.DiscardStack) == 0 {
+		return game.NoCard, fmt.Errorf("No discard")
+	}
+	// Identical cards are interchangeable, so no need to ask which one
+	topCard := c.lastEvent.Hand.DiscardStack[len(c.lastEvent.Hand.DiscardStack)-1]
+	for i, card := range c.cards {
+		if !card.Wild() && card.Identical(topCard) {
+			c.cards = append(c.cards[:i], c.cards[i+1:]...)
+			c.printf("Jumped in with %v", card)
+			return card, nil
+		}
+	}
+	c.printf("No %v to jump in with", topCard)
+	return game.NoCard, nil
+}
+
+func (c *consoleUI) ChooseSwapTarget(ctx context.Context) (int, error) {
+	c.lock.Lock()
+	lastEvent := c.lastEvent
+	c.lock.Unlock()
+	if lastEvent == nil || lastEvent.Hand == nil {
+		return -1, fmt.Errorf("No hand")
+	}
+	myIndex := c.myIndex()
+	for i, count := range lastEvent.Hand.PlayerCardsRemaining {
+		if i != myIndex {
+			c.printf("  %v: %v (%v cards)", i+1, c.playerName(i), count)
+		}
+	}
+	for {
+		line, err := c.prompt(ctx, "Player number to swap hands with:")
+		if err != nil {
+			return -1, err
+		}
+		index, err := strconv.Atoi(line)
+		if err != nil || index < 1 || index > len(lastEvent.Hand.PlayerCardsRemaining) || index-1 == myIndex {
+			c.printf("Invalid player number")
+			continue
+		}
+		return index - 1, nil
+	}
+}
+
+func (c *consoleUI) ReceiveHand(ctx context.Context, fromIndex int, cards []game.Card) error {
+	c.lock.Lock()
+	c.cards = append([]game.Card{}, cards...)
+	c.lock.Unlock()
+	cardStrs := make([]string, len(cards))
+	for i, card := range cards {
+		cardStrs[i] = card.String()
+	}
+	c.printf("Received hand of %v: %v", c.playerName(fromIndex), strings.Join(cardStrs, ", "))
+	return nil
+}
+
+func describeCard(card game.Card, lastWildColor game.CardColor) string {
+	if card.Wild() && lastWildColor.Valid() {
+		return card.String() + " (" + lastWildColor.String() + ")"
+	}
+	return card.String()
+}